@@ -1,15 +1,10 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
-	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/pengelbrecht/ticks/internal/github"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
@@ -46,66 +41,18 @@ func init() {
 }
 
 func runApprove(cmd *cobra.Command, args []string) error {
-	root, err := repoRoot()
+	res, err := applyVerdict(args[0], tick.VerdictApproved, "")
 	if err != nil {
-		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+		return err
 	}
-
-	project, err := github.DetectProject(nil)
-	if err != nil {
-		return NewExitError(ExitGitHub, "failed to detect project: %v", err)
-	}
-
-	id, err := github.NormalizeID(project, args[0])
-	if err != nil {
-		return NewExitError(ExitNotFound, "invalid id: %v", err)
-	}
-
-	store := tick.NewStore(filepath.Join(root, ".tick"))
-	t, err := store.Read(id)
-	if err != nil {
-		return NewExitError(ExitNotFound, "failed to read tick: %v", err)
-	}
-
-	// Verify tick is awaiting human decision
-	if !t.IsAwaitingHuman() {
-		fmt.Fprintf(os.Stderr, "tick %s is not awaiting human decision\n", t.ID)
-		fmt.Fprintf(os.Stderr, "use `tk show %s` to check current status\n", t.ID)
-		return NewExitError(ExitUsage, "tick is not awaiting human decision")
-	}
-
-	// Handle legacy manual flag - normalize to awaiting=work before processing
-	if t.Awaiting == nil && t.Manual {
-		t.SetAwaiting(tick.AwaitingWork)
-	}
-
-	// Set verdict and process
-	verdict := tick.VerdictApproved
-	t.Verdict = &verdict
-	t.UpdatedAt = time.Now().UTC()
-
-	closed, err := tick.ProcessVerdict(&t)
-	if err != nil {
-		return fmt.Errorf("failed to process verdict: %w", err)
-	}
-
-	if err := store.Write(t); err != nil {
-		return fmt.Errorf("failed to save tick: %w", err)
-	}
-
 	if approveJSON {
-		payload := map[string]any{"tick": t, "closed": closed}
-		enc := json.NewEncoder(os.Stdout)
-		if err := enc.Encode(payload); err != nil {
-			return fmt.Errorf("failed to encode json: %w", err)
-		}
-		return nil
+		return encodeVerdictJSON(res)
 	}
 
-	if closed {
-		fmt.Printf("approved %s (closed)\n", t.ID)
+	if res.Closed {
+		fmt.Printf("approved %s (closed)\n", res.Tick.ID)
 	} else {
-		fmt.Printf("approved %s (returned to agent)\n", t.ID)
+		fmt.Printf("approved %s (returned to agent)\n", res.Tick.ID)
 	}
 	return nil
 }
@@ -63,7 +63,7 @@ func runBlock(cmd *cobra.Command, args []string) error {
 	t.BlockedBy = appendUnique(t.BlockedBy, blockerID)
 	t.UpdatedAt = time.Now().UTC()
 
-	if err := store.Write(t); err != nil {
+	if err := store.WriteAs(t, currentActor()); err != nil {
 		return fmt.Errorf("failed to update tick: %w", err)
 	}
 
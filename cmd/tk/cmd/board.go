@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/tickboard/server"
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Serve the board UI locally",
+	Long: `Start the local board server.
+
+The board serves tick state over HTTP (GET /api/ticks, GET /api/ticks/<id>)
+and streams tick changes and live run records to connected browsers
+(GET /api/events, GET /api/run-stream/<epic-id>). Runs started with tk run
+in another terminal show up live. Stop the server with Ctrl+C.
+
+If the port is taken, the next free port is used.
+
+Examples:
+  tk board              # Board UI on :3000
+  tk board --port 8080  # Board UI on a custom port
+  tk board --dev        # Serve the UI from disk for hot reload`,
+	Args: cobra.NoArgs,
+	RunE: runBoard,
+}
+
+var (
+	boardPort int
+	boardDev  bool
+)
+
+func init() {
+	boardCmd.Flags().IntVar(&boardPort, "port", 3000, "board server port")
+	boardCmd.Flags().BoolVar(&boardDev, "dev", false, "serve UI from disk for hot reload")
+
+	rootCmd.AddCommand(boardCmd)
+}
+
+func runBoard(cmd *cobra.Command, args []string) error {
+	root, err := repoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect repo root: %w", err)
+	}
+	tickDir := filepath.Join(root, ".tick")
+	if _, err := os.Stat(tickDir); err != nil {
+		return NewExitError(ExitNotFound, "no .tick directory in %s: run 'tk init' first", root)
+	}
+
+	port, err := findAvailablePort(boardPort)
+	if err != nil {
+		return NewExitError(ExitGeneric, "failed to find available port: %v", err)
+	}
+
+	var serverOpts []server.ServerOption
+	if boardDev {
+		serverOpts = append(serverOpts, server.WithDevMode(true))
+	}
+	boardServer, err := server.New(tickDir, port, serverOpts...)
+	if err != nil {
+		return NewExitError(ExitGeneric, "failed to create board server: %v", err)
+	}
+
+	// Shut down gracefully on Ctrl+C; Run closes the file watchers on exit
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Board: http://localhost:%d\n", port)
+	fmt.Println("Press Ctrl+C to stop")
+
+	if err := boardServer.Run(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("board server: %w", err)
+	}
+	return nil
+}
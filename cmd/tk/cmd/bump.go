@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var bumpCmd = &cobra.Command{
+	Use:   "bump <id>... (--up | --down | --set <priority>)",
+	Short: "Change the priority of one or more ticks",
+	Long: `Change the priority of one or more ticks.
+
+--up makes a tick one step more urgent (P2 -> P1), --down one step less
+urgent (P2 -> P3). Steps stop at P0 and P4. --set takes 0-4, P0-P4, or
+critical|high|medium|low|backlog.
+
+All ids are checked before any tick is changed.
+
+Examples:
+  tk bump abc123 --up             # P2 -> P1
+  tk bump abc123 def456 --down    # Both one step down
+  tk bump abc123 --set P0         # Straight to critical`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runBump,
+}
+
+var (
+	bumpUp   bool
+	bumpDown bool
+	bumpSet  string
+	bumpJSON bool
+)
+
+func init() {
+	bumpCmd.Flags().BoolVar(&bumpUp, "up", false, "one step more urgent (lower number)")
+	bumpCmd.Flags().BoolVar(&bumpDown, "down", false, "one step less urgent (higher number)")
+	bumpCmd.Flags().StringVar(&bumpSet, "set", "", "set priority (0-4, P0-P4, or a name)")
+	bumpCmd.Flags().BoolVar(&bumpJSON, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(bumpCmd)
+}
+
+func runBump(cmd *cobra.Command, args []string) error {
+	setChanged := cmd.Flags().Changed("set")
+	modes := 0
+	for _, set := range []bool{bumpUp, bumpDown, setChanged} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return NewExitError(ExitUsage, "exactly one of --up, --down or --set is required")
+	}
+
+	var priority int
+	if setChanged {
+		p, err := tick.ParsePriority(bumpSet)
+		if err != nil {
+			return NewExitError(ExitUsage, "invalid --set: %v", err)
+		}
+		priority = p
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect repo root: %w", err)
+	}
+
+	project, err := github.DetectProject(nil)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+
+	// Read everything first so a bad id leaves all ticks untouched
+	ticks := make([]tick.Tick, 0, len(args))
+	for _, arg := range args {
+		id, err := github.NormalizeID(project, arg)
+		if err != nil {
+			return fmt.Errorf("invalid id: %w", err)
+		}
+		t, err := store.Read(id)
+		if err != nil {
+			return NewExitError(ExitNotFound, "failed to read tick %s: %v", id, err)
+		}
+		ticks = append(ticks, t)
+	}
+
+	now := time.Now().UTC()
+	for i := range ticks {
+		t := &ticks[i]
+		old := t.Priority
+		switch {
+		case bumpUp:
+			t.Priority = max(t.Priority-1, tick.PriorityMin)
+		case bumpDown:
+			t.Priority = min(t.Priority+1, tick.PriorityMax)
+		default:
+			t.Priority = priority
+		}
+		if t.Priority == old {
+			if !bumpJSON {
+				fmt.Printf("%s: P%d (unchanged)\n", t.ID, old)
+			}
+			continue
+		}
+		t.UpdatedAt = now
+		if err := store.Write(*t); err != nil {
+			return fmt.Errorf("failed to update tick %s: %w", t.ID, err)
+		}
+		if !bumpJSON {
+			fmt.Printf("%s: P%d -> P%d\n", t.ID, old, t.Priority)
+		}
+	}
+
+	if bumpJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(ticks); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/checkpoint"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var checkpointCmd = &cobra.Command{
+	Use:   "checkpoint",
+	Short: "Inspect and restore run checkpoints",
+	Long: `Inspect and restore the checkpoints tk run saves every
+--checkpoint-interval iterations.
+
+Subcommands:
+  list     Show an epic's checkpoints, latest iteration first
+  restore  Roll an epic's code and tasks back to a checkpoint`,
+}
+
+var checkpointListCmd = &cobra.Command{
+	Use:   "list <epic-id>",
+	Short: "List an epic's checkpoints",
+	Long: `List the checkpoints saved for an epic, latest iteration first, with
+their iteration, completed tasks, cost, timestamp and git commit.
+
+Examples:
+  tk checkpoint list abc123         # Table of checkpoints
+  tk checkpoint list abc123 --json  # Output as JSON`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCheckpointList,
+}
+
+var checkpointRestoreCmd = &cobra.Command{
+	Use:   "restore <epic-id> <checkpoint-id>",
+	Short: "Roll an epic back to a checkpoint",
+	Long: `Roll an epic back to the state recorded in a checkpoint.
+
+The run's working directory (its worktree, or the repository for runs
+without --worktree) is reset to the checkpoint's git commit, discarding
+the commits made since. Files under .tick are left alone. The restore is
+refused if tracked files outside .tick have uncommitted changes, or if
+HEAD is no longer on the checkpoint's branch or does not descend from its
+commit. Tasks of the epic closed after the checkpoint was taken, other
+than those it lists as completed, are reopened. Later checkpoints of the
+epic are deleted, so tk resume continues from the restored one.
+
+Asks for confirmation unless --force is given.
+
+Examples:
+  tk checkpoint restore abc123 abc123-10          # Roll back to iteration 10
+  tk checkpoint restore abc123 abc123-10 --force  # Without the prompt`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCheckpointRestore,
+}
+
+var (
+	checkpointListJSON     bool
+	checkpointRestoreForce bool
+	checkpointRestoreJSON  bool
+)
+
+// checkpointRestoreOutput is the JSON output of tk checkpoint restore.
+type checkpointRestoreOutput struct {
+	Checkpoint string   `json:"checkpoint"`
+	EpicID     string   `json:"epic_id"`
+	GitCommit  string   `json:"git_commit"`
+	WorkDir    string   `json:"work_dir"`
+	Reopened   []string `json:"reopened"`
+	Deleted    []string `json:"deleted_checkpoints"`
+}
+
+func init() {
+	checkpointListCmd.Flags().BoolVar(&checkpointListJSON, "json", false, "output as JSON")
+	checkpointRestoreCmd.Flags().BoolVarP(&checkpointRestoreForce, "force", "f", false, "skip the confirmation prompt")
+	checkpointRestoreCmd.Flags().BoolVar(&checkpointRestoreJSON, "json", false, "output as JSON")
+
+	checkpointCmd.AddCommand(checkpointListCmd)
+	checkpointCmd.AddCommand(checkpointRestoreCmd)
+	rootCmd.AddCommand(checkpointCmd)
+}
+
+// repoCheckpointManager returns a checkpoint manager for the repository's
+// .tick/logs/checkpoints, independent of the working directory.
+func repoCheckpointManager(root string) *checkpoint.Manager {
+	return checkpoint.NewManagerWithDir(filepath.Join(root, ".tick", "logs", "checkpoints"))
+}
+
+func runCheckpointList(cmd *cobra.Command, args []string) error {
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "not in a git repository: %v", err)
+	}
+
+	epicID := args[0]
+	checkpoints, err := repoCheckpointManager(root).ListForEpic(epicID)
+	if err != nil {
+		return NewExitError(ExitGeneric, "failed to list checkpoints: %v", err)
+	}
+	return printCheckpoints(checkpoints, epicID, checkpointListJSON)
+}
+
+func runCheckpointRestore(cmd *cobra.Command, args []string) error {
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "not in a git repository: %v", err)
+	}
+
+	epicID := args[0]
+	mgr := repoCheckpointManager(root)
+	cp, err := mgr.Load(args[1])
+	if err != nil {
+		return NewExitError(ExitNotFound, "failed to load checkpoint: %v", err)
+	}
+	if cp.EpicID != epicID {
+		return NewExitError(ExitUsage, "checkpoint %s belongs to epic %s, not %s", cp.ID, cp.EpicID, epicID)
+	}
+	if cp.GitCommit == "" {
+		return NewExitError(ExitGeneric, "checkpoint %s has no git commit to restore", cp.ID)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	reopen, err := closedSinceCheckpoint(store, cp)
+	if err != nil {
+		return err
+	}
+	later, err := mgr.ListForEpic(epicID)
+	if err != nil {
+		return NewExitError(ExitGeneric, "failed to list checkpoints: %v", err)
+	}
+	later = slices.DeleteFunc(later, func(c checkpoint.Checkpoint) bool { return c.Iteration <= cp.Iteration })
+
+	if !checkpointRestoreForce {
+		workDir := cp.WorktreePath
+		if workDir == "" {
+			workDir = root
+		}
+		fmt.Printf("Restore epic %s to checkpoint %s (iteration %d, %s):\n",
+			epicID, cp.ID, cp.Iteration, cp.Timestamp.Format("2006-01-02 15:04:05"))
+		fmt.Printf("  reset %s to %s, discarding later commits (.tick is kept)\n", workDir, shortCommit(cp.GitCommit))
+		fmt.Printf("  reopen %d task(s)\n", len(reopen))
+		for _, t := range reopen {
+			fmt.Printf("    - %s: %s\n", t.ID, t.Title)
+		}
+		fmt.Printf("  delete %d later checkpoint(s)\n", len(later))
+
+		fmt.Print("\nProceed with restore? [y/N] ")
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil && response == "" {
+			fmt.Println("\nRestore cancelled")
+			return nil
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Restore cancelled")
+			return nil
+		}
+	}
+
+	workDir, err := mgr.Rollback(cp, root)
+	if err != nil {
+		if errors.Is(err, checkpoint.ErrWorktreeGone) {
+			return NewExitError(ExitNotFound, "failed to restore checkpoint: %v", err)
+		}
+		return NewExitError(ExitGeneric, "failed to restore checkpoint: %v", err)
+	}
+
+	out := checkpointRestoreOutput{
+		Checkpoint: cp.ID,
+		EpicID:     epicID,
+		GitCommit:  cp.GitCommit,
+		WorkDir:    workDir,
+		Reopened:   []string{},
+	}
+	actor := currentActor()
+	now := time.Now().UTC()
+	for _, t := range reopen {
+		t.Status = tick.StatusOpen
+		t.ClosedAt = nil
+		t.ClosedReason = ""
+		t.UpdatedAt = now
+		line := fmt.Sprintf("%s - Reopened: restored to checkpoint %s", now.Local().Format("2006-01-02 15:04"), cp.ID)
+		if strings.TrimSpace(t.Notes) == "" {
+			t.Notes = line
+		} else {
+			t.Notes = strings.TrimRight(t.Notes, "\n") + "\n" + line
+		}
+		if err := store.WriteAs(t, actor); err != nil {
+			return fmt.Errorf("failed to reopen tick %s: %w", t.ID, err)
+		}
+		out.Reopened = append(out.Reopened, t.ID)
+	}
+
+	out.Deleted, err = mgr.DeleteAfter(epicID, cp.Iteration)
+	if err != nil {
+		return NewExitError(ExitIO, "failed to delete later checkpoints: %v", err)
+	}
+	if out.Deleted == nil {
+		out.Deleted = []string{}
+	}
+
+	if checkpointRestoreJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Printf("Restored epic %s to checkpoint %s (%s at %s)\n", epicID, cp.ID, workDir, shortCommit(cp.GitCommit))
+	if len(out.Reopened) > 0 {
+		fmt.Printf("Reopened: %s\n", strings.Join(out.Reopened, ", "))
+	}
+	if len(out.Deleted) > 0 {
+		fmt.Printf("Deleted checkpoints: %s\n", strings.Join(out.Deleted, ", "))
+	}
+	return nil
+}
+
+// closedSinceCheckpoint returns the epic's tasks that were closed after cp
+// was taken and are not among its completed tasks.
+func closedSinceCheckpoint(store *tick.Store, cp *checkpoint.Checkpoint) ([]tick.Tick, error) {
+	ticks, err := store.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticks: %w", err)
+	}
+	var closed []tick.Tick
+	for _, t := range ticks {
+		if t.Parent != cp.EpicID || t.Status != tick.StatusClosed || t.ClosedAt == nil {
+			continue
+		}
+		if t.ClosedAt.After(cp.Timestamp) && !slices.Contains(cp.CompletedTasks, t.ID) {
+			closed = append(closed, t)
+		}
+	}
+	return closed, nil
+}
+
+// shortCommit abbreviates a commit SHA for display.
+func shortCommit(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
@@ -76,8 +76,13 @@ func runCheckpoints(cmd *cobra.Command, args []string) error {
 		return NewExitError(ExitGeneric, "failed to list checkpoints: %v", err)
 	}
 
+	return printCheckpoints(checkpoints, epicFilter, checkpointsJSON)
+}
+
+// printCheckpoints prints checkpoints as a table, or as JSON if asJSON is set.
+func printCheckpoints(checkpoints []checkpoint.Checkpoint, epicFilter string, asJSON bool) error {
 	if len(checkpoints) == 0 {
-		if checkpointsJSON {
+		if asJSON {
 			output := checkpointsOutput{
 				Checkpoints: []checkpointInfo{},
 				EpicFilter:  epicFilter,
@@ -110,7 +115,7 @@ func runCheckpoints(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if checkpointsJSON {
+	if asJSON {
 		output := checkpointsOutput{
 			Checkpoints: infos,
 			EpicFilter:  epicFilter,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/pengelbrecht/ticks/internal/config"
 	"github.com/pengelbrecht/ticks/internal/github"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
@@ -23,26 +24,36 @@ Examples:
   tk close abc123                      # Close tick
   tk close abc123 --reason "done"      # Close with reason
   tk close abc123 --force              # Close epic with all children, or bypass requires gate
-  tk close abc123 --json               # Output closed tick as JSON`,
+  tk close abc123 --cascade            # Close epic and its open tasks, routing gated tasks to awaiting
+  tk close abc123 --json               # Output closed tick as JSON
+  tk close abc123 --discover "Fix X"   # Close and file a follow-up discovered from abc123`,
 	Args: cobra.ExactArgs(1),
 	RunE: runClose,
 }
 
 var (
-	closeReason string
-	closeForce  bool
-	closeJSON   bool
+	closeReason   string
+	closeForce    bool
+	closeCascade  bool
+	closeDiscover string
+	closeJSON     bool
 )
 
 func init() {
 	closeCmd.Flags().StringVar(&closeReason, "reason", "", "close reason")
 	closeCmd.Flags().BoolVar(&closeForce, "force", false, "close epic and all open children, or bypass requires gate")
+	closeCmd.Flags().BoolVar(&closeCascade, "cascade", false, "close epic and its open tasks, respecting each task's requires gate")
+	closeCmd.Flags().StringVar(&closeDiscover, "discover", "", "create a follow-up tick with this title, discovered from the closed tick")
 	closeCmd.Flags().BoolVar(&closeJSON, "json", false, "output as JSON")
 
 	rootCmd.AddCommand(closeCmd)
 }
 
 func runClose(cmd *cobra.Command, args []string) error {
+	if closeCascade && closeForce {
+		return NewExitError(ExitUsage, "--cascade cannot be combined with --force")
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to detect repo root: %w", err)
@@ -53,20 +64,35 @@ func runClose(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
 
-	id, err := github.NormalizeID(project, args[0])
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	id, err := resolveTickID(store, project, args[0])
 	if err != nil {
-		return fmt.Errorf("invalid id: %w", err)
+		return err
 	}
 
-	store := tick.NewStore(filepath.Join(root, ".tick"))
+	actor := currentActor()
 	t, err := store.Read(id)
 	if err != nil {
 		return fmt.Errorf("failed to read tick: %w", err)
 	}
+	if closeCascade && t.Type != tick.TypeEpic {
+		return NewExitError(ExitUsage, "--cascade requires an epic, %s is a %s", t.ID, t.Type)
+	}
 
 	now := time.Now().UTC()
 
+	// Build the follow-up tick up front so an invalid one aborts before anything is closed
+	var discovered *tick.Tick
+	if title := strings.TrimSpace(closeDiscover); title != "" {
+		d, err := newDiscoveredTick(root, t, title, now)
+		if err != nil {
+			return err
+		}
+		discovered = &d
+	}
+
 	// Check for open children if closing an epic
+	var cascade *cascadeResult
 	if t.Type == tick.TypeEpic {
 		all, err := store.List()
 		if err != nil {
@@ -80,13 +106,18 @@ func runClose(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		if len(openChildren) > 0 {
+		if closeCascade {
+			cascade, err = cascadeClose(store, openChildren, closeReason, actor)
+			if err != nil {
+				return err
+			}
+		} else if len(openChildren) > 0 {
 			if !closeForce {
 				fmt.Fprintf(os.Stderr, "cannot close epic %s: has %d open children\n", t.ID, len(openChildren))
 				for _, c := range openChildren {
 					fmt.Fprintf(os.Stderr, "  - %s: %s\n", c.ID, c.Title)
 				}
-				fmt.Fprintln(os.Stderr, "use --force to close epic and all children")
+				fmt.Fprintln(os.Stderr, "use --cascade to close its tasks, or --force to close epic and all children")
 				return fmt.Errorf("epic has open children")
 			}
 
@@ -98,7 +129,7 @@ func runClose(cmd *cobra.Command, args []string) error {
 				c.ClearAwaiting()
 				c.Verdict = nil
 				c.UpdatedAt = now
-				if err := store.Write(c); err != nil {
+				if err := store.WriteAs(c, actor); err != nil {
 					return fmt.Errorf("failed to close child %s: %w", c.ID, err)
 				}
 			}
@@ -119,26 +150,142 @@ func runClose(cmd *cobra.Command, args []string) error {
 		routed := tick.HandleClose(&t, closeReason)
 		if routed {
 			// Save the routed state, but return error
-			if err := store.Write(t); err != nil {
+			if err := writeWithDiscovered(store, t, discovered, actor); err != nil {
 				return fmt.Errorf("failed to save tick: %w", err)
 			}
-			fmt.Fprintf(os.Stderr, "tick %s requires %s before closing\n", t.ID, *t.Requires)
+			if discovered != nil {
+				fmt.Println(discovered.ID)
+			}
+			fmt.Fprintf(os.Stderr, "tick %s awaiting %s: requires %s before closing\n", t.ID, t.GetAwaitingType(), *t.Requires)
 			fmt.Fprintf(os.Stderr, "use 'tk approve %s' to approve and close\n", t.ID)
 			fmt.Fprintf(os.Stderr, "use 'tk close %s --force' to bypass and close immediately\n", t.ID)
-			return fmt.Errorf("tick requires approval before closing")
+			return fmt.Errorf("tick requires %s before closing", *t.Requires)
 		}
 	}
 
-	if err := store.Write(t); err != nil {
+	if err := writeWithDiscovered(store, t, discovered, actor); err != nil {
 		return fmt.Errorf("failed to close tick: %w", err)
 	}
 
 	if closeJSON {
+		var payload any = t
+		if discovered != nil || cascade != nil {
+			fields := map[string]any{"tick": t}
+			if discovered != nil {
+				fields["discovered"] = discovered
+			}
+			if cascade != nil {
+				fields["cascade"] = cascade
+			}
+			payload = fields
+		}
 		enc := json.NewEncoder(os.Stdout)
-		if err := enc.Encode(t); err != nil {
+		if err := enc.Encode(payload); err != nil {
 			return fmt.Errorf("failed to encode json: %w", err)
 		}
+		return nil
+	}
+
+	if cascade != nil {
+		fmt.Printf("closed %d tasks, %d awaiting\n", len(cascade.Closed), len(cascade.Awaiting))
+		for _, c := range cascade.Awaiting {
+			fmt.Printf("  - %s: awaiting %s\n", c.ID, c.Awaiting)
+		}
+	}
+	if discovered != nil {
+		fmt.Println(discovered.ID)
 	}
 
 	return nil
 }
+
+// cascadeResult records what closing an epic with --cascade did to its tasks.
+type cascadeResult struct {
+	Closed   []string          `json:"closed"`
+	Awaiting []cascadeAwaiting `json:"awaiting"`
+}
+
+// cascadeAwaiting is a gated task that was routed to a human instead of closed.
+type cascadeAwaiting struct {
+	ID       string `json:"id"`
+	Awaiting string `json:"awaiting"`
+}
+
+// cascadeClose closes each child through tick.HandleClose so tasks with a
+// requires gate are routed to awaiting rather than force-closed. Tasks that
+// are already awaiting their gate are left untouched. Writes are attributed
+// to actor.
+func cascadeClose(store *tick.Store, children []tick.Tick, reason, actor string) (*cascadeResult, error) {
+	result := &cascadeResult{Closed: []string{}, Awaiting: []cascadeAwaiting{}}
+	for _, c := range children {
+		if c.HasRequiredGate() && c.IsAwaitingHuman() {
+			result.Awaiting = append(result.Awaiting, cascadeAwaiting{ID: c.ID, Awaiting: c.GetAwaitingType()})
+			continue
+		}
+		if tick.HandleClose(&c, reason) {
+			result.Awaiting = append(result.Awaiting, cascadeAwaiting{ID: c.ID, Awaiting: c.GetAwaitingType()})
+		} else {
+			result.Closed = append(result.Closed, c.ID)
+		}
+		if err := store.WriteAs(c, actor); err != nil {
+			return nil, fmt.Errorf("failed to close child %s: %w", c.ID, err)
+		}
+	}
+	return result, nil
+}
+
+// newDiscoveredTick builds a follow-up tick discovered while working on source.
+// Priority and type come from the repo defaults, as with tk create, and it
+// inherits the source's parent so the follow-up lands in the same epic.
+func newDiscoveredTick(root string, source tick.Tick, title string, now time.Time) (tick.Tick, error) {
+	cfg, err := config.Load(filepath.Join(root, ".tick", "config.json"))
+	if err != nil {
+		return tick.Tick{}, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	creator, err := github.DetectOwner(nil)
+	if err != nil {
+		return tick.Tick{}, fmt.Errorf("failed to detect owner: %w", err)
+	}
+
+	tickType := cfg.Defaults.GetType()
+	id, err := generateTickID(root, &cfg, tickType)
+	if err != nil {
+		return tick.Tick{}, err
+	}
+
+	d := tick.Tick{
+		ID:             id,
+		Title:          title,
+		Status:         tick.StatusOpen,
+		Priority:       cfg.Defaults.GetPriority(),
+		Type:           tickType,
+		Owner:          creator,
+		Parent:         source.Parent,
+		DiscoveredFrom: source.ID,
+		CreatedBy:      creator,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := d.Validate(); err != nil {
+		return tick.Tick{}, fmt.Errorf("invalid follow-up tick: %w", err)
+	}
+	return d, nil
+}
+
+// writeWithDiscovered writes t together with an optional follow-up tick.
+// The follow-up is written first and removed again if writing t fails,
+// so either both changes land or neither does. Writes are attributed to actor.
+func writeWithDiscovered(store *tick.Store, t tick.Tick, discovered *tick.Tick, actor string) error {
+	if discovered == nil {
+		return store.WriteAs(t, actor)
+	}
+	if err := store.WriteAs(*discovered, actor); err != nil {
+		return fmt.Errorf("write follow-up %s: %w", discovered.ID, err)
+	}
+	if err := store.WriteAs(t, actor); err != nil {
+		_ = store.Delete(discovered.ID)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Get or set project configuration",
+	Long: `Get or set project configuration in .tick/config.json.
+
+Keys are dotted JSON paths, e.g. id_length, context.max_tokens,
+verification.enabled, or type_prefixes.bug.
+
+Subcommands:
+  get    Print a config value
+  set    Set a config value`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a config value",
+	Long: `Print a config value.
+
+Exits 1 if the key is not set, in which case the built-in default applies.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a config value",
+	Long: `Set a config value.
+
+Values are parsed as JSON (numbers, true/false); string keys also take
+plain text. The new config is validated before it is saved.
+
+Examples:
+  tk config set id_length 4
+  tk config set context.max_tokens 8000
+  tk config set context.generation_timeout 10m`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	cfg, _, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	value, ok, err := cfg.Get(args[0])
+	if err != nil {
+		return NewExitError(ExitUsage, "%v", err)
+	}
+	if !ok {
+		return NewExitError(ExitGeneric, "%s is not set (using default)", args[0])
+	}
+
+	if s, isString := value.(string); isString {
+		fmt.Println(s)
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	cfg, path, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Set(args[0], args[1]); err != nil {
+		return NewExitError(ExitUsage, "%v", err)
+	}
+	if err := config.Save(path, cfg); err != nil {
+		return NewExitError(ExitIO, "failed to save config: %v", err)
+	}
+	return nil
+}
+
+// loadProjectConfig loads .tick/config.json for the current repo.
+func loadProjectConfig() (config.Config, string, error) {
+	root, err := repoRoot()
+	if err != nil {
+		return config.Config{}, "", NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+	path := filepath.Join(root, ".tick", "config.json")
+	cfg, err := config.Load(path)
+	if err != nil {
+		return config.Config{}, "", fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg, path, nil
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/agent"
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/runrecord"
+	"github.com/pengelbrecht/ticks/internal/styles"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var costsCmd = &cobra.Command{
+	Use:   "costs",
+	Short: "Total agent token and cost usage from run records",
+	Long: `Total the tokens, cost and time spent by agent runs, from the run
+records tk run keeps for each tick.
+
+Costs are broken down per tick by default; --by groups them by epic (the
+tick's parent) or owner instead. Records for ticks that no longer exist
+are counted under "-".
+
+Examples:
+  tk costs                     # Per-tick breakdown for the whole project
+  tk costs --by epic           # Totals per epic
+  tk costs --epic abc          # Only tasks under epic abc
+  tk costs --since 7d --owner @me
+  tk costs --json`,
+	Args: cobra.NoArgs,
+	RunE: runCosts,
+}
+
+var (
+	costsSince string
+	costsEpic  string
+	costsOwner string
+	costsBy    string
+	costsJSON  bool
+)
+
+// costTotals sums the metrics of one or more run records.
+type costTotals struct {
+	Runs                int     `json:"runs"`
+	InputTokens         int     `json:"input_tokens"`
+	OutputTokens        int     `json:"output_tokens"`
+	CacheReadTokens     int     `json:"cache_read_tokens"`
+	CacheCreationTokens int     `json:"cache_creation_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+	DurationMS          int     `json:"duration_ms"`
+}
+
+func (c *costTotals) add(m agent.MetricsRecord) {
+	c.Runs++
+	c.InputTokens += m.InputTokens
+	c.OutputTokens += m.OutputTokens
+	c.CacheReadTokens += m.CacheReadTokens
+	c.CacheCreationTokens += m.CacheCreationTokens
+	c.CostUSD += m.CostUSD
+	c.DurationMS += m.DurationMS
+}
+
+// costGroup is the totals for one tick, epic or owner.
+type costGroup struct {
+	Key   string `json:"key"`
+	Title string `json:"title,omitempty"`
+	costTotals
+}
+
+// costsOutput is the JSON output of tk costs.
+type costsOutput struct {
+	By     string      `json:"by"`
+	Total  costTotals  `json:"total"`
+	Groups []costGroup `json:"groups"`
+}
+
+func init() {
+	costsCmd.Flags().StringVar(&costsSince, "since", "", "only runs started at or after this RFC3339 time or within a window (e.g. 7d, 2w)")
+	costsCmd.Flags().StringVar(&costsEpic, "epic", "", "only runs for this epic and its tasks")
+	costsCmd.Flags().StringVarP(&costsOwner, "owner", "o", "", "only runs for ticks with this owner (@me for yourself)")
+	costsCmd.Flags().StringVar(&costsBy, "by", "tick", "group by tick, epic, or owner")
+	costsCmd.Flags().BoolVar(&costsJSON, "json", false, "output as JSON")
+	rootCmd.AddCommand(costsCmd)
+}
+
+func runCosts(cmd *cobra.Command, args []string) error {
+	switch costsBy {
+	case "tick", "epic", "owner":
+	default:
+		return NewExitError(ExitUsage, "invalid --by %q (use tick, epic, or owner)", costsBy)
+	}
+
+	var since time.Time
+	if value := strings.TrimSpace(costsSince); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			d, durErr := parseDuration(value)
+			if durErr != nil {
+				return NewExitError(ExitUsage, "invalid --since (use RFC3339 or a window like 7d): %v", err)
+			}
+			parsed = time.Now().UTC().Add(-d)
+		}
+		since = parsed
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	epicID := ""
+	if costsEpic != "" {
+		project, err := github.DetectProject(nil)
+		if err != nil {
+			return fmt.Errorf("failed to detect project: %w", err)
+		}
+		if epicID, err = github.NormalizeID(project, costsEpic); err != nil {
+			return NewExitError(ExitUsage, "invalid epic id: %v", err)
+		}
+	}
+
+	owner := ""
+	if costsOwner != "" {
+		if owner, err = resolveOwner(false, costsOwner); err != nil {
+			return fmt.Errorf("failed to detect owner: %w", err)
+		}
+	}
+
+	ticks, err := tick.NewStore(filepath.Join(root, ".tick")).List()
+	if err != nil {
+		return NewExitError(ExitIO, "failed to list ticks: %v", err)
+	}
+	byID := make(map[string]tick.Tick, len(ticks))
+	for _, t := range ticks {
+		byID[t.ID] = t
+	}
+
+	records := runrecord.NewStore(root)
+	ids, err := records.List()
+	if err != nil {
+		return NewExitError(ExitIO, "failed to list run records: %v", err)
+	}
+
+	out := costsOutput{By: costsBy, Groups: []costGroup{}}
+	groups := make(map[string]*costGroup)
+	for _, id := range ids {
+		record, err := records.Read(id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping run record %s: %v\n", id, err)
+			continue
+		}
+		if !since.IsZero() && record.StartedAt.Before(since) {
+			continue
+		}
+
+		t, known := byID[id]
+		epic := t.Parent
+		if t.Type == tick.TypeEpic {
+			epic = t.ID
+		}
+		if epicID != "" && epic != epicID {
+			continue
+		}
+		if owner != "" && t.Owner != owner {
+			continue
+		}
+
+		key, title := id, t.Title
+		switch costsBy {
+		case "epic":
+			key, title = epic, byID[epic].Title
+		case "owner":
+			key, title = t.Owner, ""
+		}
+		if !known || key == "" {
+			key = "-"
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &costGroup{Key: key, Title: title}
+			groups[key] = g
+		}
+		g.add(record.Metrics)
+		out.Total.add(record.Metrics)
+	}
+
+	for _, g := range groups {
+		out.Groups = append(out.Groups, *g)
+	}
+	sort.Slice(out.Groups, func(i, j int) bool {
+		if out.Groups[i].CostUSD != out.Groups[j].CostUSD {
+			return out.Groups[i].CostUSD > out.Groups[j].CostUSD
+		}
+		return out.Groups[i].Key < out.Groups[j].Key
+	})
+
+	if costsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	if out.Total.Runs == 0 {
+		fmt.Println("No run records found")
+		return nil
+	}
+
+	header := fmt.Sprintf(" %-12s  %4s  %9s  %9s  %9s  %9s  %8s  %s", strings.ToUpper(costsBy), "RUNS", "IN", "OUT", "CACHE", "COST", "TIME", "TITLE")
+	fmt.Println(styles.DimStyle.Render(header))
+	for _, g := range out.Groups {
+		fmt.Println(formatCostRow(g.Key, g.costTotals, g.Title))
+	}
+	fmt.Println(styles.BoldStyle.Render(formatCostRow("total", out.Total, "")))
+	return nil
+}
+
+func formatCostRow(key string, c costTotals, title string) string {
+	return fmt.Sprintf(" %-12s  %4d  %9d  %9d  %9d  %9s  %8s  %s",
+		key,
+		c.Runs,
+		c.InputTokens,
+		c.OutputTokens,
+		c.CacheReadTokens+c.CacheCreationTokens,
+		fmt.Sprintf("$%.4f", c.CostUSD),
+		(time.Duration(c.DurationMS) * time.Millisecond).Round(time.Second).String(),
+		title,
+	)
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +17,7 @@ import (
 
 	"github.com/pengelbrecht/ticks/internal/config"
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
@@ -45,8 +51,25 @@ Examples:
   tk create "Configure AWS credentials" --awaiting work
 
   # Task under an epic with PR review required
-  tk create "Implement payment API" --parent abc123 --requires review`,
-	Args: cobra.MinimumNArgs(1),
+  tk create "Implement payment API" --parent abc123 --requires review
+
+  # Refuse to create if an open tick has a near-identical title
+  tk create "Fix login bug" --check-dup
+
+  # Read the tick from a JSON object on stdin; flags still override it
+  echo '{"title": "Fix login bug", "labels": ["auth"]}' | tk create --from-stdin -p 1
+
+  # One task per "- [ ] title" line, with optional (P1) and #label annotations
+  tk create --from-file tasks.md --parent abc123
+
+  # Prompt for title, type, priority, owner, labels and parent
+  tk create --interactive`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if createFromStdin || createFromFile != "" || createInteractive {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: runCreate,
 }
 
@@ -66,8 +89,24 @@ var (
 	createRequires       string
 	createAwaiting       string
 	createJSON           bool
+	createCheckDup       bool
+	createForce          bool
+	createFromStdin      bool
+	createFromFile       string
+	createInteractive    bool
 )
 
+// createInput is the subset of tick fields accepted by tk create --from-stdin.
+type createInput struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Priority    *int     `json:"priority"`
+	Labels      []string `json:"labels"`
+	BlockedBy   []string `json:"blocked_by"`
+	Parent      string   `json:"parent"`
+}
+
 func init() {
 	createCmd.Flags().StringVarP(&createDescription, "description", "d", "", "detailed description")
 	createCmd.Flags().IntVarP(&createPriority, "priority", "p", 2, "priority 0-4")
@@ -84,36 +123,52 @@ func init() {
 	createCmd.Flags().StringVarP(&createRequires, "requires", "r", "", "approval gate (approval|review|content)")
 	createCmd.Flags().StringVarP(&createAwaiting, "awaiting", "a", "", "wait state (work|approval|input|review|content|escalation|checkpoint)")
 	createCmd.Flags().BoolVar(&createJSON, "json", false, "output as JSON")
+	createCmd.Flags().BoolVar(&createCheckDup, "check-dup", false, "fail if an open tick has a very similar title")
+	createCmd.Flags().BoolVar(&createForce, "force", false, "create even if --check-dup finds similar titles")
+	createCmd.Flags().BoolVar(&createFromStdin, "from-stdin", false, "read the tick as a JSON object from stdin (flags override it)")
+	createCmd.Flags().StringVar(&createFromFile, "from-file", "", "create one task per '- [ ] title' line of a markdown file")
+	createCmd.Flags().BoolVarP(&createInteractive, "interactive", "i", false, "prompt for the tick's fields (needs a terminal)")
 
 	rootCmd.AddCommand(createCmd)
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	if createInteractive && (createFromStdin || createFromFile != "") {
+		return NewExitError(ExitUsage, "--interactive cannot be combined with --from-stdin or --from-file")
+	}
+	if createFromFile != "" {
+		return runCreateFromFile(cmd, args)
+	}
+
+	// Without a terminal to prompt on, --interactive falls back to flags
+	interactive := createInteractive && stdinIsTerminal()
+	if createInteractive && !interactive {
+		fmt.Fprintln(os.Stderr, "warning: stdin is not a terminal, ignoring --interactive")
+	}
+
+	var input createInput
+	if createFromStdin {
+		var err error
+		if input, err = readCreateInput(os.Stdin); err != nil {
+			return err
+		}
+	}
+
 	title := strings.TrimSpace(strings.Join(args, " "))
 	if title == "" {
+		title = strings.TrimSpace(input.Title)
+	}
+	if title == "" && !interactive {
 		return fmt.Errorf("title is required")
 	}
 
-	// Validate requires flag if provided
-	requiresVal := strings.TrimSpace(createRequires)
-	if requiresVal != "" {
-		switch requiresVal {
-		case tick.RequiresApproval, tick.RequiresReview, tick.RequiresContent:
-			// valid
-		default:
-			return NewExitError(ExitUsage, "invalid requires value: %s (must be approval, review, or content)", requiresVal)
-		}
+	requires, awaiting, err := createGates()
+	if err != nil {
+		return err
 	}
-
-	// Validate awaiting flag if provided
-	awaitingVal := strings.TrimSpace(createAwaiting)
-	if awaitingVal != "" {
-		switch awaitingVal {
-		case tick.AwaitingWork, tick.AwaitingApproval, tick.AwaitingInput, tick.AwaitingReview, tick.AwaitingContent, tick.AwaitingEscalation, tick.AwaitingCheckpoint:
-			// valid
-		default:
-			return NewExitError(ExitUsage, "invalid awaiting value: %s (must be work, approval, input, review, content, escalation, or checkpoint)", awaitingVal)
-		}
+	deferUntil, err := createDeferUntil()
+	if err != nil {
+		return err
 	}
 
 	root, err := repoRoot()
@@ -131,64 +186,80 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to detect owner: %w", err)
 	}
 
-	owner := creator
-	if strings.TrimSpace(createOwner) != "" {
-		owner = strings.TrimSpace(createOwner)
+	owner, priority, tickType, labels := createDefaults(cmd, cfg, creator, input)
+	description := strings.TrimSpace(createDescription)
+	if !cmd.Flags().Changed("description") && input.Description != "" {
+		description = strings.TrimSpace(input.Description)
+	}
+	blockedBy := splitCSV(createBlockedBy)
+	if !cmd.Flags().Changed("blocked-by") && input.BlockedBy != nil {
+		blockedBy = input.BlockedBy
+	}
+	parent := strings.TrimSpace(createParent)
+	if !cmd.Flags().Changed("parent") && input.Parent != "" {
+		parent = strings.TrimSpace(input.Parent)
 	}
 
 	store := tick.NewStore(filepath.Join(root, ".tick"))
-	gen := tick.NewIDGenerator(nil)
-	id, newLen, err := gen.Generate(func(candidate string) bool {
-		_, err := os.Stat(filepath.Join(root, ".tick", "issues", candidate+".json"))
-		return err == nil
-	}, cfg.IDLength)
-	if err != nil {
-		return fmt.Errorf("failed to generate id: %w", err)
-	}
 
-	now := time.Now().UTC()
-	var deferUntil *time.Time
-	if createDefer != "" {
-		parsed, err := time.Parse("2006-01-02", createDefer)
+	var wizard *createWizard
+	if interactive {
+		project, err := github.DetectProject(nil)
 		if err != nil {
-			return fmt.Errorf("invalid defer date (use YYYY-MM-DD): %w", err)
+			return fmt.Errorf("failed to detect project: %w", err)
 		}
-		deferUntil = &parsed
-	}
-
-	// Set requires pointer only if value provided
-	var requires *string
-	if requiresVal != "" {
-		requires = &requiresVal
+		wizard = &createWizard{in: bufio.NewReader(os.Stdin), out: os.Stderr}
+		answers, err := wizard.run(createAnswers{
+			Title:    title,
+			Type:     tickType,
+			Priority: priority,
+			Owner:    owner,
+			Labels:   labels,
+			Parent:   parent,
+		}, func(arg string) (string, error) {
+			id, err := resolveTickID(store, project, arg)
+			if err != nil {
+				return "", err
+			}
+			p, err := store.Read(id)
+			if err != nil {
+				return "", err
+			}
+			if p.Type != tick.TypeEpic {
+				return "", fmt.Errorf("%s is a %s, not an epic", id, p.Type)
+			}
+			return id, nil
+		})
+		if err != nil {
+			return err
+		}
+		title, tickType, priority = answers.Title, answers.Type, answers.Priority
+		owner, labels, parent = answers.Owner, answers.Labels, answers.Parent
 	}
 
-	// Set awaiting pointer only if value provided
-	var awaiting *string
-	if awaitingVal != "" {
-		awaiting = &awaitingVal
+	if createCheckDup && !createForce {
+		if err := checkDuplicateTitle(store, title); err != nil {
+			return err
+		}
 	}
 
-	// Handle deprecated --manual flag
-	if createManual {
-		fmt.Fprintln(os.Stderr, "Warning: --manual is deprecated, use --awaiting work instead")
-		// Map manual to awaiting=work if awaiting not already set
-		if awaiting == nil {
-			awaitingWork := tick.AwaitingWork
-			awaiting = &awaitingWork
-		}
+	id, err := generateTickID(root, &cfg, tickType)
+	if err != nil {
+		return err
 	}
 
+	now := time.Now().UTC()
 	t := tick.Tick{
 		ID:                 id,
 		Title:              title,
-		Description:        strings.TrimSpace(createDescription),
+		Description:        description,
 		Status:             tick.StatusOpen,
-		Priority:           createPriority,
-		Type:               strings.TrimSpace(createType),
+		Priority:           priority,
+		Type:               tickType,
 		Owner:              owner,
-		Labels:             splitCSV(createLabels),
-		BlockedBy:          splitCSV(createBlockedBy),
-		Parent:             strings.TrimSpace(createParent),
+		Labels:             labels,
+		BlockedBy:          blockedBy,
+		Parent:             parent,
 		DiscoveredFrom:     strings.TrimSpace(createDiscoveredFrom),
 		AcceptanceCriteria: strings.TrimSpace(createAcceptance),
 		DeferUntil:         deferUntil,
@@ -201,15 +272,17 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		UpdatedAt:          now,
 	}
 
-	if err := store.Write(t); err != nil {
-		return fmt.Errorf("failed to write tick: %w", err)
+	if err := t.Validate(); err != nil {
+		return NewExitError(ExitUsage, "invalid tick: %v", err)
 	}
 
-	if newLen != cfg.IDLength {
-		cfg.IDLength = newLen
-		if err := config.Save(filepath.Join(root, ".tick", "config.json"), cfg); err != nil {
-			return fmt.Errorf("failed to update config: %w", err)
-		}
+	if wizard != nil && !wizard.confirm(t) {
+		fmt.Fprintln(os.Stderr, "Aborted.")
+		return nil
+	}
+
+	if err := store.Write(t); err != nil {
+		return fmt.Errorf("failed to write tick: %w", err)
 	}
 
 	if createJSON {
@@ -230,6 +303,501 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// createDefaults resolves the owner, priority, type and labels of a new tick.
+// Flags win over fields read with --from-stdin, which win over repo defaults
+// from .tick/config.json.
+func createDefaults(cmd *cobra.Command, cfg config.Config, creator string, input createInput) (owner string, priority int, tickType string, labels []string) {
+	owner = creator
+	if defaultOwner := strings.TrimSpace(cfg.Defaults.GetOwner()); defaultOwner != "" {
+		owner = defaultOwner
+	}
+	if strings.TrimSpace(createOwner) != "" {
+		owner = strings.TrimSpace(createOwner)
+	}
+	priority = createPriority
+	if !cmd.Flags().Changed("priority") {
+		priority = cfg.Defaults.GetPriority()
+		if input.Priority != nil {
+			priority = *input.Priority
+		}
+	}
+	tickType = strings.TrimSpace(createType)
+	if !cmd.Flags().Changed("type") {
+		tickType = cfg.Defaults.GetType()
+		if v := strings.TrimSpace(input.Type); v != "" {
+			tickType = v
+		}
+	}
+	labels = splitCSV(createLabels)
+	if !cmd.Flags().Changed("labels") {
+		labels = cfg.Defaults.GetLabels()
+		if input.Labels != nil {
+			labels = input.Labels
+		}
+	}
+	return owner, priority, tickType, labels
+}
+
+// createGates validates --requires and --awaiting, returning nil for unset
+// values. The deprecated --manual flag maps to awaiting=work.
+func createGates() (requires, awaiting *string, err error) {
+	if v := strings.TrimSpace(createRequires); v != "" {
+		switch v {
+		case tick.RequiresApproval, tick.RequiresReview, tick.RequiresContent:
+			requires = &v
+		default:
+			return nil, nil, NewExitError(ExitUsage, "invalid requires value: %s (must be approval, review, or content)", v)
+		}
+	}
+	if v := strings.TrimSpace(createAwaiting); v != "" {
+		switch v {
+		case tick.AwaitingWork, tick.AwaitingApproval, tick.AwaitingInput, tick.AwaitingReview, tick.AwaitingContent, tick.AwaitingEscalation, tick.AwaitingCheckpoint:
+			awaiting = &v
+		default:
+			return nil, nil, NewExitError(ExitUsage, "invalid awaiting value: %s (must be work, approval, input, review, content, escalation, or checkpoint)", v)
+		}
+	}
+	if createManual {
+		fmt.Fprintln(os.Stderr, "Warning: --manual is deprecated, use --awaiting work instead")
+		if awaiting == nil {
+			awaitingWork := tick.AwaitingWork
+			awaiting = &awaitingWork
+		}
+	}
+	return requires, awaiting, nil
+}
+
+// createDeferUntil parses --defer, returning nil when it is unset.
+func createDeferUntil() (*time.Time, error) {
+	if createDefer == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", createDefer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid defer date (use YYYY-MM-DD): %w", err)
+	}
+	return &parsed, nil
+}
+
+// createAnswers holds the fields asked for by tk create --interactive.
+type createAnswers struct {
+	Title    string
+	Type     string
+	Priority int
+	Owner    string
+	Labels   []string
+	Parent   string
+}
+
+// createWizard asks for tick fields on out and reads the answers from in.
+// Prompts go to stderr so stdout stays clean for the id or --json output.
+type createWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// run asks for each field, offering the values in defaults. An empty answer
+// keeps the default and "-" clears the labels or parent. resolveParent turns
+// the parent answer into an epic id or explains why it is not one.
+func (w *createWizard) run(defaults createAnswers, resolveParent func(string) (string, error)) (createAnswers, error) {
+	a := defaults
+	var err error
+
+	if a.Title, err = w.ask("Title", defaults.Title, func(v string) error {
+		if v == "" {
+			return fmt.Errorf("title is required")
+		}
+		return nil
+	}); err != nil {
+		return a, err
+	}
+
+	types := []string{tick.TypeTask, tick.TypeEpic, tick.TypeBug, tick.TypeFeature, tick.TypeChore}
+	if a.Type, err = w.ask("Type ("+strings.Join(types, "|")+")", defaults.Type, func(v string) error {
+		if !slices.Contains(types, v) {
+			return fmt.Errorf("invalid type: %s", v)
+		}
+		return nil
+	}); err != nil {
+		return a, err
+	}
+
+	priority, err := w.ask("Priority (0-4)", strconv.Itoa(defaults.Priority), func(v string) error {
+		_, err := tick.ParsePriority(v)
+		return err
+	})
+	if err != nil {
+		return a, err
+	}
+	a.Priority, _ = tick.ParsePriority(priority)
+
+	if a.Owner, err = w.ask("Owner", defaults.Owner, nil); err != nil {
+		return a, err
+	}
+
+	labels, err := w.ask("Labels, comma-separated (- for none)", strings.Join(defaults.Labels, ","), nil)
+	if err != nil {
+		return a, err
+	}
+	a.Labels = nil
+	if labels != "-" {
+		a.Labels = splitCSV(labels)
+	}
+
+	parent, err := w.ask("Parent epic (- for none)", defaults.Parent, func(v string) error {
+		if v == "" || v == "-" {
+			return nil
+		}
+		_, err := resolveParent(v)
+		return err
+	})
+	if err != nil {
+		return a, err
+	}
+	a.Parent = ""
+	if parent != "" && parent != "-" {
+		a.Parent, _ = resolveParent(parent)
+	}
+
+	return a, nil
+}
+
+// ask prompts for one value, showing def as the default. Answers rejected by
+// check are reported and asked for again. Running out of input aborts.
+func (w *createWizard) ask(label, def string, check func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(w.out, "%s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(w.out, "%s: ", label)
+		}
+		line, err := w.in.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Fprintln(w.out)
+			return "", NewExitError(ExitUsage, "aborted: no answer for %s", strings.ToLower(strings.Fields(label)[0]))
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+		if check != nil {
+			if err := check(answer); err != nil {
+				fmt.Fprintf(w.out, "  %v\n", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// confirm shows the tick about to be created and asks whether to write it.
+func (w *createWizard) confirm(t tick.Tick) bool {
+	fmt.Fprintln(w.out)
+	fmt.Fprintf(w.out, "  Title:    %s\n", t.Title)
+	fmt.Fprintf(w.out, "  Type:     %s\n", t.Type)
+	fmt.Fprintf(w.out, "  Priority: P%d\n", t.Priority)
+	fmt.Fprintf(w.out, "  Owner:    %s\n", t.Owner)
+	if len(t.Labels) > 0 {
+		fmt.Fprintf(w.out, "  Labels:   %s\n", strings.Join(t.Labels, ", "))
+	}
+	if t.Parent != "" {
+		fmt.Fprintf(w.out, "  Parent:   %s\n", t.Parent)
+	}
+	fmt.Fprint(w.out, "\nCreate this tick? [Y/n] ")
+
+	line, err := w.in.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(w.out)
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "", "y", "yes":
+		return true
+	}
+	return false
+}
+
+// readCreateInput decodes a single JSON object for tk create --from-stdin.
+// Unknown fields are rejected so typos do not silently drop data.
+func readCreateInput(r io.Reader) (createInput, error) {
+	var input createInput
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&input); err != nil {
+		return createInput{}, NewExitError(ExitUsage, "invalid tick JSON on stdin: %v", err)
+	}
+	if dec.More() {
+		return createInput{}, NewExitError(ExitUsage, "invalid tick JSON on stdin: expected a single object")
+	}
+	return input, nil
+}
+
+// checklistItem is an unchecked "- [ ] title" line from a markdown file.
+type checklistItem struct {
+	Title    string
+	Priority *int
+	Labels   []string
+	// Parent is the index of the enclosing item, or -1 at the top level.
+	Parent int
+	// HasChildren is set when a more indented item follows within this one.
+	HasChildren bool
+}
+
+var (
+	checklistLine     = regexp.MustCompile(`^(\s*)[-*+] \[ \]\s+(.*)$`)
+	checklistPriority = regexp.MustCompile(`(?i)\(P([0-4])\)`)
+	checklistLabel    = regexp.MustCompile(`(^|\s)#([A-Za-z][\w-]*)`)
+)
+
+// parseChecklist returns the unchecked checklist items of a markdown document
+// in order. Inline "(P1)" sets the priority and "#label" adds a label; both
+// are removed from the title. Other lines, including checked "- [x]" items,
+// are ignored. Indentation links an item to the closest less indented item
+// above it.
+func parseChecklist(r io.Reader) ([]checklistItem, error) {
+	type open struct{ indent, index int }
+	var items []checklistItem
+	var stack []open
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := checklistLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+		item := checklistItem{Parent: -1}
+
+		text := m[2]
+		if pm := checklistPriority.FindStringSubmatch(text); pm != nil {
+			p, _ := strconv.Atoi(pm[1])
+			item.Priority = &p
+			text = checklistPriority.ReplaceAllString(text, "")
+		}
+		for _, lm := range checklistLabel.FindAllStringSubmatch(text, -1) {
+			item.Labels = append(item.Labels, lm[2])
+		}
+		text = checklistLabel.ReplaceAllString(text, "$1")
+		item.Title = strings.Join(strings.Fields(text), " ")
+		if item.Title == "" {
+			return nil, fmt.Errorf("line %q has no title", scanner.Text())
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) > 0 {
+			item.Parent = stack[len(stack)-1].index
+			items[item.Parent].HasChildren = true
+		}
+		stack = append(stack, open{indent: indent, index: len(items)})
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// runCreateFromFile creates one tick per checklist item in --from-file and
+// prints the new ids in order. With --parent, top-level items go under that
+// epic and nested items go under the item above them, which becomes an epic.
+// Without --parent, indentation is ignored and every item is a standalone task.
+func runCreateFromFile(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return NewExitError(ExitUsage, "--from-file cannot be combined with a title argument")
+	}
+	if createFromStdin {
+		return NewExitError(ExitUsage, "--from-file cannot be combined with --from-stdin")
+	}
+	// These describe a single tick and would be copied onto every item
+	for _, name := range []string{"description", "acceptance", "external-ref", "check-dup"} {
+		if cmd.Flags().Changed(name) {
+			return NewExitError(ExitUsage, "--%s cannot be combined with --from-file", name)
+		}
+	}
+
+	requires, awaiting, err := createGates()
+	if err != nil {
+		return err
+	}
+	deferUntil, err := createDeferUntil()
+	if err != nil {
+		return err
+	}
+	blockedBy := splitCSV(createBlockedBy)
+	discoveredFrom := strings.TrimSpace(createDiscoveredFrom)
+
+	f, err := os.Open(createFromFile)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", createFromFile, err)
+	}
+	items, err := parseChecklist(f)
+	_ = f.Close()
+	if err != nil {
+		return NewExitError(ExitUsage, "failed to parse %s: %v", createFromFile, err)
+	}
+	if len(items) == 0 {
+		return NewExitError(ExitUsage, "no \"- [ ] title\" items found in %s", createFromFile)
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect repo root: %w", err)
+	}
+
+	cfg, err := config.Load(filepath.Join(root, ".tick", "config.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	creator, err := github.DetectOwner(nil)
+	if err != nil {
+		return fmt.Errorf("failed to detect owner: %w", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+
+	parentID := strings.TrimSpace(createParent)
+	if parentID != "" {
+		project, err := github.DetectProject(nil)
+		if err != nil {
+			return fmt.Errorf("failed to detect project: %w", err)
+		}
+		if parentID, err = github.NormalizeID(project, parentID); err != nil {
+			return fmt.Errorf("invalid parent id: %w", err)
+		}
+		parent, err := store.Read(parentID)
+		if err != nil {
+			return fmt.Errorf("failed to read parent: %w", err)
+		}
+		if parent.Type != tick.TypeEpic {
+			return NewExitError(ExitUsage, "parent %s is a %s, not an epic", parentID, parent.Type)
+		}
+	}
+
+	// Annotations on a line win over flags and repo defaults
+	owner, priority, tickType, labels := createDefaults(cmd, cfg, creator, createInput{})
+
+	ids := make([]string, len(items))
+	created := make([]tick.Tick, 0, len(items))
+	for i, item := range items {
+		itemType := tickType
+		itemParent := parentID
+		if parentID != "" {
+			if item.HasChildren {
+				itemType = tick.TypeEpic
+			}
+			if item.Parent >= 0 {
+				itemParent = ids[item.Parent]
+			}
+		}
+
+		id, err := generateTickID(root, &cfg, itemType)
+		if err != nil {
+			return err
+		}
+
+		itemPriority := priority
+		if item.Priority != nil {
+			itemPriority = *item.Priority
+		}
+		itemLabels := append(append([]string{}, labels...), item.Labels...)
+		if len(itemLabels) == 0 {
+			itemLabels = nil
+		}
+
+		now := time.Now().UTC()
+		t := tick.Tick{
+			ID:             id,
+			Title:          item.Title,
+			Status:         tick.StatusOpen,
+			Priority:       itemPriority,
+			Type:           itemType,
+			Owner:          owner,
+			Labels:         itemLabels,
+			BlockedBy:      blockedBy,
+			Parent:         itemParent,
+			DiscoveredFrom: discoveredFrom,
+			DeferUntil:     deferUntil,
+			Requires:       requires,
+			Awaiting:       awaiting,
+			CreatedBy:      creator,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := t.Validate(); err != nil {
+			return NewExitError(ExitUsage, "invalid tick %q: %v", item.Title, err)
+		}
+		if err := store.Write(t); err != nil {
+			return fmt.Errorf("failed to write tick: %w", err)
+		}
+		ids[i] = id
+		created = append(created, t)
+		if !createJSON {
+			fmt.Println(id)
+		}
+	}
+
+	if createJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(created); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkDuplicateTitle fails if any open tick has a title similar to title,
+// listing the candidates on stderr.
+func checkDuplicateTitle(store *tick.Store, title string) error {
+	all, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	var open []tick.Tick
+	for _, t := range all {
+		if t.Status != tick.StatusClosed {
+			open = append(open, t)
+		}
+	}
+
+	matches := query.SimilarTitles(title, open, query.DefaultSimilarityThreshold)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Possible duplicates:")
+	for _, m := range matches {
+		fmt.Fprintf(os.Stderr, "  %s  %s (%.0f%% similar)\n", m.Tick.ID, m.Tick.Title, m.Score*100)
+	}
+	return NewExitError(ExitGeneric, "found %d similar open tick(s); use --force to create anyway", len(matches))
+}
+
+// generateTickID returns a fresh, unused tick id, prefixed if the config sets a
+// prefix for tickType. If collisions force a longer id, the new length is saved
+// to config so later ids use it too.
+func generateTickID(root string, cfg *config.Config, tickType string) (string, error) {
+	gen := tick.NewIDGenerator(nil)
+	id, newLen, err := gen.GenerateWithPrefix(func(candidate string) bool {
+		_, err := os.Stat(filepath.Join(root, ".tick", "issues", candidate+".json"))
+		return err == nil
+	}, cfg.IDLength, cfg.IDPrefix(tickType))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate id: %w", err)
+	}
+
+	if newLen != cfg.IDLength {
+		cfg.IDLength = newLen
+		if err := config.Save(filepath.Join(root, ".tick", "config.json"), *cfg); err != nil {
+			return "", fmt.Errorf("failed to update config: %w", err)
+		}
+	}
+
+	return id, nil
+}
+
 // splitCSV splits a comma-separated string into a slice of trimmed non-empty strings.
 func splitCSV(value string) []string {
 	value = strings.TrimSpace(value)
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/runrecord"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
@@ -19,19 +22,42 @@ var deleteCmd = &cobra.Command{
 	Long: `Delete a tick permanently.
 
 By default, a confirmation prompt is shown before deleting.
-Use --force to skip the confirmation.
+Use --yes to skip the confirmation.
+
+The deleted id is removed from every other tick's blocked_by list.
+Epics with open children are not deleted unless --force is given,
+so tasks are never orphaned silently. Deleting an epic clears the
+parent of its children.
 
 Examples:
-  tk delete abc123         # Delete tick (with confirmation)
-  tk delete abc123 --force # Delete without confirmation`,
+  tk delete abc123               # Delete tick (with confirmation)
+  tk delete abc123 --yes         # Delete without confirmation
+  tk delete epic12 --force --yes # Delete an epic that has open children
+  tk delete abc123 --dry-run     # Show what would change`,
 	Args: cobra.ExactArgs(1),
 	RunE: runDelete,
 }
 
-var deleteForce bool
+var (
+	deleteYes    bool
+	deleteForce  bool
+	deleteDryRun bool
+	deleteJSON   bool
+)
+
+// deleteOutput is the JSON output of tk delete.
+type deleteOutput struct {
+	ID               string   `json:"id"`
+	CleanedBlockers  []string `json:"cleaned_blockers"`
+	DetachedChildren []string `json:"detached_children"`
+	DryRun           bool     `json:"dry_run,omitempty"`
+}
 
 func init() {
-	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "skip confirmation")
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "skip confirmation prompt")
+	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "allow deleting an epic that has open children")
+	deleteCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "report what would be deleted without writing")
+	deleteCmd.Flags().BoolVar(&deleteJSON, "json", false, "output as JSON")
 
 	rootCmd.AddCommand(deleteCmd)
 }
@@ -52,38 +78,117 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid id: %w", err)
 	}
 
-	if !deleteForce {
-		fmt.Printf("Delete %s? (y/N): ", id)
-		var response string
-		if _, err := fmt.Fscanln(os.Stdin, &response); err != nil || strings.ToLower(strings.TrimSpace(response)) != "y" {
-			fmt.Println("Aborted.")
-			return nil
-		}
-	}
-
 	store := tick.NewStore(filepath.Join(root, ".tick"))
-	if err := store.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete tick: %w", err)
+	target, err := store.Read(id)
+	if err != nil {
+		return fmt.Errorf("failed to read tick: %w", err)
 	}
 
-	// Cleanup references in other ticks
 	ticks, err := store.List()
 	if err != nil {
 		return fmt.Errorf("failed to list ticks: %w", err)
 	}
 
+	if target.Type == tick.TypeEpic && !deleteForce {
+		var openChildren []string
+		for _, t := range ticks {
+			if t.Parent == id && t.Status != tick.StatusClosed {
+				openChildren = append(openChildren, t.ID)
+			}
+		}
+		if len(openChildren) > 0 {
+			return NewExitError(ExitGeneric, "epic %s has %d open children (%s); use --force to delete anyway",
+				id, len(openChildren), strings.Join(openChildren, ", "))
+		}
+	}
+
+	// Find ticks that reference the deleted id as a blocker or parent
+	var affected []tick.Tick
+	cleaned := []string{}
+	detached := []string{}
 	for _, t := range ticks {
+		if t.ID == id {
+			continue
+		}
 		updated := removeString(t.BlockedBy, id)
-		if len(updated) == len(t.BlockedBy) {
+		blocked := len(updated) != len(t.BlockedBy)
+		child := t.Parent == id
+		if !blocked && !child {
 			continue
 		}
-		t.BlockedBy = updated
+		if blocked {
+			t.BlockedBy = updated
+			cleaned = append(cleaned, t.ID)
+		}
+		if child {
+			t.Parent = ""
+			detached = append(detached, t.ID)
+		}
+		affected = append(affected, t)
+	}
+
+	if deleteDryRun {
+		return printDeleteResult(deleteOutput{ID: id, CleanedBlockers: cleaned, DetachedChildren: detached, DryRun: true})
+	}
+
+	if !deleteYes {
+		// Keep stdout clean for JSON consumers
+		var prompt io.Writer = os.Stdout
+		if deleteJSON {
+			prompt = os.Stderr
+		}
+		fmt.Fprintf(prompt, "Delete %s? (y/N): ", id)
+		var response string
+		if _, err := fmt.Fscanln(os.Stdin, &response); err != nil || strings.ToLower(strings.TrimSpace(response)) != "y" {
+			fmt.Fprintln(prompt, "Aborted.")
+			return nil
+		}
+	}
+
+	if err := store.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete tick: %w", err)
+	}
+
+	// Drop the tick's run records so the board doesn't show a ghost run
+	records := runrecord.NewStore(root)
+	if err := records.DeleteLive(id); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err := records.Delete(id); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// Cleanup references in other ticks
+	for _, t := range affected {
 		t.UpdatedAt = time.Now().UTC()
 		if err := store.Write(t); err != nil {
 			return fmt.Errorf("failed to update tick: %w", err)
 		}
 	}
 
-	fmt.Printf("Deleted %s\n", id)
+	return printDeleteResult(deleteOutput{ID: id, CleanedBlockers: cleaned, DetachedChildren: detached})
+}
+
+// printDeleteResult reports a delete (or planned delete) as text or JSON.
+func printDeleteResult(out deleteOutput) error {
+	if deleteJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	verb := "Deleted"
+	if out.DryRun {
+		verb = "Would delete"
+	}
+	fmt.Printf("%s %s\n", verb, out.ID)
+	if len(out.CleanedBlockers) > 0 {
+		fmt.Printf("  blocker removed from: %s\n", strings.Join(out.CleanedBlockers, ", "))
+	}
+	if len(out.DetachedChildren) > 0 {
+		fmt.Printf("  parent cleared on: %s\n", strings.Join(out.DetachedChildren, ", "))
+	}
 	return nil
 }
@@ -1,16 +1,26 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/pengelbrecht/ticks/internal/agent"
+	epiccontext "github.com/pengelbrecht/ticks/internal/context"
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/styles"
 	"github.com/pengelbrecht/ticks/internal/tick"
+	"github.com/pengelbrecht/ticks/internal/ticks"
 )
 
 var depsCmd = &cobra.Command{
@@ -19,15 +29,53 @@ var depsCmd = &cobra.Command{
 	Long: `Show what a tick is blocked by and what it blocks.
 
 Displays the dependency relationships for the specified tick,
-showing both upstream blockers and downstream dependents.`,
+showing both upstream blockers and downstream dependents.
+Check this before closing a blocker to see what will become ready.
+
+Use --recursive to walk the full downstream chain. Each tick is listed
+once, and dependency cycles are reported instead of followed.
+
+Use --analyze on an epic to have the agent predict which files each open
+task will touch. Tasks that would edit the same files are reported, and
+blocked-by edges are added so they don't run in parallel (the same
+analysis tk run does for pools). Add --dry-run to review the proposed
+edges without writing them.
+
+Examples:
+  tk deps abc123
+  tk deps abc123 --recursive
+  tk deps abc123 --recursive --json
+  tk deps epic1 --analyze --dry-run
+  tk deps epic1 --analyze --timeout 5m --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runDeps,
 }
 
-var depsJSON bool
+var (
+	depsJSON      bool
+	depsRecursive bool
+	depsAnalyze   bool
+	depsDryRun    bool
+	depsTimeout   time.Duration
+)
+
+// downstreamTick is a tick reached by walking dependents from the target.
+type downstreamTick struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	// Depth is 1 for direct dependents, 2 for their dependents, and so on.
+	Depth int `json:"depth"`
+	// Via is the tick this one was reached through.
+	Via string `json:"via"`
+}
 
 func init() {
 	depsCmd.Flags().BoolVar(&depsJSON, "json", false, "output as JSON")
+	depsCmd.Flags().BoolVar(&depsRecursive, "recursive", false, "walk the full downstream chain")
+	depsCmd.Flags().BoolVar(&depsAnalyze, "analyze", false, "predict file conflicts between an epic's tasks and add blockers")
+	depsCmd.Flags().BoolVar(&depsDryRun, "dry-run", false, "with --analyze, show proposed blockers without writing them")
+	depsCmd.Flags().DurationVar(&depsTimeout, "timeout", 3*time.Minute, "with --analyze, timeout for the agent")
 	rootCmd.AddCommand(depsCmd)
 }
 
@@ -47,6 +95,16 @@ func runDeps(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid id: %w", err)
 	}
 
+	if depsAnalyze {
+		if depsRecursive {
+			return NewExitError(ExitUsage, "--analyze cannot be combined with --recursive")
+		}
+		return runDepsAnalyze(cmd.Context(), filepath.Join(root, ".tick"), id)
+	}
+	if depsDryRun || cmd.Flags().Changed("timeout") {
+		return NewExitError(ExitUsage, "--dry-run and --timeout require --analyze")
+	}
+
 	store := tick.NewStore(filepath.Join(root, ".tick"))
 	ticks, err := store.List()
 	if err != nil {
@@ -68,8 +126,23 @@ func runDeps(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	var downstream []downstreamTick
+	var cycles []string
+	if depsRecursive {
+		downstream, cycles = walkDownstream(target.ID, ticks)
+	}
+
 	if depsJSON {
 		payload := map[string]any{"blocked_by": target.BlockedBy, "blocks": dependents}
+		if depsRecursive {
+			if downstream == nil {
+				downstream = []downstreamTick{}
+			}
+			payload["downstream"] = downstream
+			if len(cycles) > 0 {
+				payload["cycles"] = cycles
+			}
+		}
 		enc := json.NewEncoder(os.Stdout)
 		if err := enc.Encode(payload); err != nil {
 			return fmt.Errorf("failed to encode json: %w", err)
@@ -82,9 +155,155 @@ func runDeps(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s blocks: none\n", target.ID)
 		return nil
 	}
+	if depsRecursive {
+		fmt.Printf("%s blocks (downstream):\n", target.ID)
+		for _, d := range downstream {
+			fmt.Printf("%s- %s %s (%s)\n", strings.Repeat("  ", d.Depth-1), d.ID, d.Title, d.Status)
+		}
+		for _, c := range cycles {
+			fmt.Printf("cycle: %s\n", c)
+		}
+		return nil
+	}
 	fmt.Printf("%s blocks:\n", target.ID)
 	for _, t := range dependents {
 		fmt.Printf("- %s %s (%s)\n", t.ID, t.Title, t.Status)
 	}
 	return nil
 }
+
+// runDepsAnalyze runs the dependency analyzer on an epic's open tasks and
+// reports conflicting tasks and the blockers added (or proposed, with --dry-run).
+func runDepsAnalyze(ctx context.Context, tickDir, epicID string) error {
+	client := ticks.NewClient(tickDir)
+	epic, err := client.GetEpic(epicID)
+	if err != nil {
+		return NewExitError(ExitNotFound, "%v", err)
+	}
+	if epic.Type != tick.TypeEpic {
+		return NewExitError(ExitUsage, "%s is a %s, --analyze needs an epic", epicID, epic.Type)
+	}
+
+	allTasks, err := client.ListTasks(epicID)
+	if err != nil {
+		return NewExitError(ExitIO, "failed to list tasks: %v", err)
+	}
+	var tasks []ticks.Task
+	for _, t := range allTasks {
+		if t.Status != tick.StatusClosed {
+			tasks = append(tasks, t)
+		}
+	}
+
+	result := &epiccontext.AnalysisResult{}
+	// No conflicts are possible with fewer than two tasks; skip the agent
+	if len(tasks) > 1 {
+		claudeAgent := agent.NewClaudeAgent()
+		if !claudeAgent.Available() {
+			return NewExitError(ExitGeneric, "claude CLI not found - install from https://claude.ai/code")
+		}
+
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+
+		analyzer := epiccontext.NewDependencyAnalyzer(claudeAgent, tick.NewStore(tickDir),
+			epiccontext.WithDepTimeout(depsTimeout),
+			epiccontext.WithDepDryRun(depsDryRun),
+			epiccontext.WithDepLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		)
+		if result, err = analyzer.Analyze(ctx, epic, tasks); err != nil {
+			return NewExitError(ExitGeneric, "dependency analysis failed: %v", err)
+		}
+	}
+
+	if depsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	if len(result.ConflictingPairs) == 0 {
+		fmt.Printf("No conflicts found among %d open tasks of %s\n", len(tasks), epicID)
+		return nil
+	}
+
+	conflicts := result.ConflictingPairs
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Task1 != conflicts[j].Task1 {
+			return conflicts[i].Task1 < conflicts[j].Task1
+		}
+		return conflicts[i].Task2 < conflicts[j].Task2
+	})
+	fmt.Println(styles.RenderHeader("Conflicts:"))
+	for _, c := range conflicts {
+		files := append([]string(nil), c.SharedFiles...)
+		sort.Strings(files)
+		fmt.Printf("  %s ↔ %s  %s\n", styles.RenderID(c.Task1), styles.RenderID(c.Task2), styles.RenderDim(strings.Join(files, ", ")))
+	}
+
+	header := "Added blockers:"
+	if depsDryRun {
+		header = "Proposed blockers (dry run):"
+	}
+	fmt.Println()
+	fmt.Println(styles.RenderHeader(header))
+	if len(result.AddedDeps) == 0 {
+		fmt.Println("  none (conflicting tasks are already ordered)")
+		return nil
+	}
+	blocked := make([]string, 0, len(result.AddedDeps))
+	for id := range result.AddedDeps {
+		blocked = append(blocked, id)
+	}
+	sort.Strings(blocked)
+	for _, id := range blocked {
+		fmt.Printf("  %s blocked by %s\n", styles.RenderID(id), strings.Join(result.AddedDeps[id], ", "))
+	}
+	return nil
+}
+
+// walkDownstream returns every tick transitively blocked by rootID in
+// depth-first order, each listed once. Edges that lead back into the current
+// chain are reported as cycles ("a -> b") rather than followed.
+func walkDownstream(rootID string, ticks []tick.Tick) ([]downstreamTick, []string) {
+	byID := make(map[string]tick.Tick, len(ticks))
+	dependents := make(map[string][]string)
+	for _, t := range ticks {
+		byID[t.ID] = t
+		for _, blocker := range t.BlockedBy {
+			dependents[blocker] = append(dependents[blocker], t.ID)
+		}
+	}
+	for _, ids := range dependents {
+		sort.Strings(ids)
+	}
+
+	var out []downstreamTick
+	var cycles []string
+	visited := map[string]bool{rootID: true}
+	onPath := map[string]bool{rootID: true}
+
+	var walk func(id string, depth int)
+	walk = func(id string, depth int) {
+		for _, dep := range dependents[id] {
+			if onPath[dep] {
+				cycles = append(cycles, id+" -> "+dep)
+				continue
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			t := byID[dep]
+			out = append(out, downstreamTick{ID: dep, Title: t.Title, Status: t.Status, Depth: depth, Via: id})
+			onPath[dep] = true
+			walk(dep, depth+1)
+			onPath[dep] = false
+		}
+	}
+	walk(rootID, 1)
+
+	return out, cycles
+}
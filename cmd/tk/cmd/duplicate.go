@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/config"
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var duplicateCmd = &cobra.Command{
+	Use:   "duplicate <id>",
+	Short: "Copy a tick as a template for new work",
+	Long: `Copy a tick as a template for new work.
+
+The copy keeps the title, description, type, priority, labels, parent,
+and acceptance criteria. It starts open and owned by you, with no
+blockers, notes, or awaiting/verdict state.
+
+Examples:
+  tk duplicate abc123                        # One copy, same title
+  tk duplicate abc123 --title "Rotate keys (staging)"
+  tk duplicate abc123 --count 3              # Three copies`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDuplicate,
+}
+
+var (
+	duplicateTitle string
+	duplicateCount int
+	duplicateJSON  bool
+)
+
+func init() {
+	duplicateCmd.Flags().StringVar(&duplicateTitle, "title", "", "title for the copies (default: source title)")
+	duplicateCmd.Flags().IntVar(&duplicateCount, "count", 1, "number of copies to create")
+	duplicateCmd.Flags().BoolVar(&duplicateJSON, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(duplicateCmd)
+}
+
+func runDuplicate(cmd *cobra.Command, args []string) error {
+	if duplicateCount < 1 {
+		return NewExitError(ExitUsage, "--count must be at least 1")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	project, err := github.DetectProject(nil)
+	if err != nil {
+		return NewExitError(ExitGitHub, "failed to detect project: %v", err)
+	}
+
+	id, err := github.NormalizeID(project, args[0])
+	if err != nil {
+		return NewExitError(ExitNotFound, "invalid id: %v", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	src, err := store.Read(id)
+	if err != nil {
+		return NewExitError(ExitNotFound, "failed to read tick: %v", err)
+	}
+
+	cfg, err := config.Load(filepath.Join(root, ".tick", "config.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	actor, err := github.DetectOwner(nil)
+	if err != nil {
+		return fmt.Errorf("failed to detect owner: %w", err)
+	}
+
+	copies := make([]tick.Tick, 0, duplicateCount)
+	for i := 0; i < duplicateCount; i++ {
+		newID, err := generateTickID(root, &cfg, src.Type)
+		if err != nil {
+			return err
+		}
+		c := src.Clone(newID, actor, time.Now().UTC())
+		if title := strings.TrimSpace(duplicateTitle); title != "" {
+			c.Title = title
+		}
+		if err := store.Write(c); err != nil {
+			return fmt.Errorf("failed to write tick: %w", err)
+		}
+		copies = append(copies, c)
+	}
+
+	if duplicateJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(copies); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	for _, c := range copies {
+		fmt.Println(c.ID)
+	}
+	return nil
+}
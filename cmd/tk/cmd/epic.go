@@ -0,0 +1,378 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/config"
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var epicCmd = &cobra.Command{
+	Use:   "epic",
+	Short: "Reorganize epics",
+	Long: `Reorganize epics.
+
+Subcommands:
+  split  Move some of an epic's tasks into a new epic
+  merge  Move all of an epic's tasks into another epic`,
+}
+
+var epicSplitCmd = &cobra.Command{
+	Use:   "split <epic-id> --title <title> <task-id>...",
+	Short: "Move some of an epic's tasks into a new epic",
+	Long: `Create a new epic and move the listed tasks of an existing epic into it.
+
+The new epic takes the source epic's priority, labels and parent. Moved
+tasks keep their blocked_by lists, so dependencies on tasks left in the
+source epic now cross epics; each one is reported as a warning. Closed
+blockers are not reported.
+
+Examples:
+  tk epic split abc123 --title "Payments: refunds" def456 ghi789
+  tk epic split abc123 --title "Payments: refunds" def456 --dry-run`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runEpicSplit,
+}
+
+var epicMergeCmd = &cobra.Command{
+	Use:   "merge <source-epic-id> <target-epic-id>",
+	Short: "Move all of an epic's tasks into another epic",
+	Long: `Move every child of the source epic, open or closed, under the target
+epic. Blockers are left unchanged, so dependencies between the two epics'
+tasks keep working. Use --close-source to close the emptied source epic.
+
+Examples:
+  tk epic merge abc123 def456                 # Move abc123's tasks to def456
+  tk epic merge abc123 def456 --close-source  # Also close abc123
+  tk epic merge abc123 def456 --dry-run       # Show what would move`,
+	Args: cobra.ExactArgs(2),
+	RunE: runEpicMerge,
+}
+
+var (
+	epicSplitTitle  string
+	epicSplitDryRun bool
+	epicSplitJSON   bool
+
+	epicMergeCloseSource bool
+	epicMergeDryRun      bool
+	epicMergeJSON        bool
+)
+
+// epicSplitOutput is the JSON output of tk epic split.
+type epicSplitOutput struct {
+	Source            string             `json:"source"`
+	Epic              *tick.Tick         `json:"epic,omitempty"`
+	Title             string             `json:"title"`
+	Moved             []string           `json:"moved"`
+	CrossEpicBlockers []crossEpicBlocker `json:"cross_epic_blockers"`
+	DryRun            bool               `json:"dry_run,omitempty"`
+}
+
+// crossEpicBlocker is a blocked_by edge that a split leaves spanning the
+// source and the new epic.
+type crossEpicBlocker struct {
+	TaskID    string `json:"task_id"`
+	BlockedBy string `json:"blocked_by"`
+}
+
+// epicMergeOutput is the JSON output of tk epic merge.
+type epicMergeOutput struct {
+	Source       string   `json:"source"`
+	Target       string   `json:"target"`
+	Moved        []string `json:"moved"`
+	SourceClosed bool     `json:"source_closed"`
+	DryRun       bool     `json:"dry_run,omitempty"`
+}
+
+func init() {
+	epicSplitCmd.Flags().StringVar(&epicSplitTitle, "title", "", "title of the new epic (required)")
+	epicSplitCmd.Flags().BoolVar(&epicSplitDryRun, "dry-run", false, "report what would change without writing")
+	epicSplitCmd.Flags().BoolVar(&epicSplitJSON, "json", false, "output as JSON")
+
+	epicMergeCmd.Flags().BoolVar(&epicMergeCloseSource, "close-source", false, "close the source epic after moving its tasks")
+	epicMergeCmd.Flags().BoolVar(&epicMergeDryRun, "dry-run", false, "report what would change without writing")
+	epicMergeCmd.Flags().BoolVar(&epicMergeJSON, "json", false, "output as JSON")
+
+	epicCmd.AddCommand(epicSplitCmd)
+	epicCmd.AddCommand(epicMergeCmd)
+	rootCmd.AddCommand(epicCmd)
+}
+
+func runEpicSplit(cmd *cobra.Command, args []string) error {
+	title := strings.TrimSpace(epicSplitTitle)
+	if title == "" {
+		return NewExitError(ExitUsage, "--title is required")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	project, err := github.DetectProject(nil)
+	if err != nil {
+		return NewExitError(ExitGitHub, "failed to detect project: %v", err)
+	}
+
+	sourceID, err := github.NormalizeID(project, args[0])
+	if err != nil {
+		return NewExitError(ExitNotFound, "invalid id: %v", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	source, err := store.Read(sourceID)
+	if err != nil {
+		return NewExitError(ExitNotFound, "failed to read tick: %v", err)
+	}
+	if source.Type != tick.TypeEpic {
+		return NewExitError(ExitUsage, "%s is a %s, not an epic", sourceID, source.Type)
+	}
+
+	var moveIDs []string
+	for _, arg := range args[1:] {
+		id, err := github.NormalizeID(project, arg)
+		if err != nil {
+			return NewExitError(ExitNotFound, "invalid id: %v", err)
+		}
+		if !slices.Contains(moveIDs, id) {
+			moveIDs = append(moveIDs, id)
+		}
+	}
+
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+	byID := make(map[string]tick.Tick, len(ticks))
+	for _, t := range ticks {
+		byID[t.ID] = t
+	}
+
+	moving := make(map[string]bool, len(moveIDs))
+	for _, id := range moveIDs {
+		t, ok := byID[id]
+		if !ok {
+			return NewExitError(ExitNotFound, "tick not found: %s", id)
+		}
+		if t.Parent != sourceID {
+			return NewExitError(ExitUsage, "%s is not in epic %s", id, sourceID)
+		}
+		moving[id] = true
+	}
+
+	// Open blockers between moved tasks and tasks staying behind
+	cross := []crossEpicBlocker{}
+	for _, t := range ticks {
+		if t.Parent != sourceID || t.Status == tick.StatusClosed {
+			continue
+		}
+		for _, id := range t.BlockedBy {
+			b, ok := byID[id]
+			if !ok || b.Parent != sourceID || b.Status == tick.StatusClosed {
+				continue
+			}
+			if moving[t.ID] != moving[id] {
+				cross = append(cross, crossEpicBlocker{TaskID: t.ID, BlockedBy: id})
+			}
+		}
+	}
+
+	out := epicSplitOutput{
+		Source:            sourceID,
+		Title:             title,
+		Moved:             moveIDs,
+		CrossEpicBlockers: cross,
+		DryRun:            epicSplitDryRun,
+	}
+	if epicSplitDryRun {
+		return printEpicSplit(out)
+	}
+
+	cfg, err := config.Load(filepath.Join(root, ".tick", "config.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	actor, err := github.DetectOwner(nil)
+	if err != nil {
+		return fmt.Errorf("failed to detect owner: %w", err)
+	}
+	newID, err := generateTickID(root, &cfg, tick.TypeEpic)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	epic := tick.Tick{
+		ID:        newID,
+		Title:     title,
+		Status:    tick.StatusOpen,
+		Priority:  source.Priority,
+		Type:      tick.TypeEpic,
+		Owner:     actor,
+		Labels:    slices.Clone(source.Labels),
+		Parent:    source.Parent,
+		CreatedBy: actor,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := store.Write(epic); err != nil {
+		return fmt.Errorf("failed to write tick: %w", err)
+	}
+
+	for _, id := range moveIDs {
+		t := byID[id]
+		t.Parent = newID
+		t.UpdatedAt = now
+		if err := store.WriteAs(t, actor); err != nil {
+			return fmt.Errorf("failed to update tick %s: %w", id, err)
+		}
+	}
+
+	out.Epic = &epic
+	return printEpicSplit(out)
+}
+
+// printEpicSplit reports a split (or planned split) as text or JSON.
+// Cross-epic blockers are warned about on stderr.
+func printEpicSplit(out epicSplitOutput) error {
+	for _, c := range out.CrossEpicBlockers {
+		fmt.Fprintf(os.Stderr, "warning: %s is blocked by %s, which is in the other epic after the split\n", c.TaskID, c.BlockedBy)
+	}
+
+	if epicSplitJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	if out.DryRun {
+		fmt.Printf("Would create epic %q from %s\n", out.Title, out.Source)
+		fmt.Printf("  would move: %s\n", strings.Join(out.Moved, ", "))
+		return nil
+	}
+	fmt.Printf("Created epic %s from %s\n", out.Epic.ID, out.Source)
+	fmt.Printf("  moved: %s\n", strings.Join(out.Moved, ", "))
+	return nil
+}
+
+func runEpicMerge(cmd *cobra.Command, args []string) error {
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	project, err := github.DetectProject(nil)
+	if err != nil {
+		return NewExitError(ExitGitHub, "failed to detect project: %v", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	var epics [2]tick.Tick
+	for i, arg := range args {
+		id, err := github.NormalizeID(project, arg)
+		if err != nil {
+			return NewExitError(ExitNotFound, "invalid id: %v", err)
+		}
+		epics[i], err = store.Read(id)
+		if err != nil {
+			return NewExitError(ExitNotFound, "failed to read tick: %v", err)
+		}
+		if epics[i].Type != tick.TypeEpic {
+			return NewExitError(ExitUsage, "%s is a %s, not an epic", id, epics[i].Type)
+		}
+	}
+	source, target := epics[0], epics[1]
+	if source.ID == target.ID {
+		return NewExitError(ExitUsage, "cannot merge epic %s into itself", source.ID)
+	}
+
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+	var children []tick.Tick
+	for _, t := range ticks {
+		if t.Parent != source.ID {
+			continue
+		}
+		// A nested epic cannot move under its own descendant
+		if err := checkParentCycle(store, t.ID, target); err != nil {
+			return err
+		}
+		children = append(children, t)
+	}
+
+	out := epicMergeOutput{
+		Source:       source.ID,
+		Target:       target.ID,
+		Moved:        []string{},
+		SourceClosed: epicMergeCloseSource && source.Status != tick.StatusClosed,
+		DryRun:       epicMergeDryRun,
+	}
+	for _, c := range children {
+		out.Moved = append(out.Moved, c.ID)
+	}
+	if epicMergeDryRun {
+		return printEpicMerge(out)
+	}
+
+	actor := currentActor()
+	now := time.Now().UTC()
+	for _, c := range children {
+		c.Parent = target.ID
+		c.UpdatedAt = now
+		if err := store.WriteAs(c, actor); err != nil {
+			return fmt.Errorf("failed to update tick %s: %w", c.ID, err)
+		}
+	}
+
+	if out.SourceClosed {
+		source.Status = tick.StatusClosed
+		source.ClosedAt = &now
+		source.ClosedReason = fmt.Sprintf("merged into %s", target.ID)
+		source.ClearAwaiting()
+		source.Verdict = nil
+		source.UpdatedAt = now
+		if err := store.WriteAs(source, actor); err != nil {
+			return fmt.Errorf("failed to close epic %s: %w", source.ID, err)
+		}
+	}
+
+	return printEpicMerge(out)
+}
+
+// printEpicMerge reports a merge (or planned merge) as text or JSON.
+func printEpicMerge(out epicMergeOutput) error {
+	if epicMergeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	moved, closed := "Moved", "Closed"
+	if out.DryRun {
+		moved, closed = "Would move", "Would close"
+	}
+	fmt.Printf("%s %d task(s) from %s to %s\n", moved, len(out.Moved), out.Source, out.Target)
+	if len(out.Moved) > 0 {
+		fmt.Printf("  %s\n", strings.Join(out.Moved, ", "))
+	}
+	if out.SourceClosed {
+		fmt.Printf("%s %s\n", closed, out.Source)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+	"github.com/pengelbrecht/ticks/internal/tickboard/cloud"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export ticks as JSON lines",
+	Long: `Export ticks to stdout as JSON lines, one tick per line.
+
+By default exports the same ticks cloud sync does: all open ticks plus
+ticks closed within the sync window (closed_sync_window in ~/.ticksrc,
+default 24h). Use --all to include every tick.
+
+Re-import with: tk import ticks.jsonl
+
+Examples:
+  tk export > ticks.jsonl
+  tk export --all > backup.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+var exportAll bool
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportAll, "all", false, "export all ticks, including long-closed ones")
+
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	if !exportAll {
+		window := cloud.ClosedSyncWindow()
+		now := time.Now()
+		kept := ticks[:0]
+		for _, t := range ticks {
+			if cloud.InSyncWindow(t, window, now) {
+				kept = append(kept, t)
+			}
+		}
+		ticks = kept
+	}
+
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i].ID < ticks[j].ID })
+
+	if err := tick.WriteJSONL(os.Stdout, ticks); err != nil {
+		return NewExitError(ExitIO, "failed to write ticks: %v", err)
+	}
+	return nil
+}
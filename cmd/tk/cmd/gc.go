@@ -6,13 +6,17 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/pengelbrecht/ticks/internal/config"
 	"github.com/pengelbrecht/ticks/internal/gc"
 	"github.com/spf13/cobra"
 )
 
 var (
-	gcDryRun bool
-	gcMaxAge string
+	gcDryRun         bool
+	gcMaxAge         string
+	gcSuccessMaxAge  string
+	gcFailureMaxAge  string
+	gcKeepSuccessful int
 )
 
 var gcCmd = &cobra.Command{
@@ -30,14 +34,29 @@ Targets:
 Live files (.live.json) are never deleted.
 
 Use --dry-run to preview what would be deleted without making changes.
-Use --max-age to specify how old files must be to be deleted (default: 30d).`,
+Use --max-age to specify how old files must be to be deleted (default:
+gc.max_age_days from .tick/config.json, or 30d).
+
+Run records can be pruned by outcome, so failures stay around for
+investigation while routine successes go sooner. Set defaults in
+.tick/config.json under "run_record_retention" or override per run:
+  --success-max-age   keep successful records this long
+  --failure-max-age   keep failed records this long
+  --keep-successful   always keep the N most recent successful records
+
+Set "gc": {"keep_last": N} to always keep the N most recent run records
+of any outcome. tk run and tk resume clean up in the background with the
+configured retention.`,
 	Args: cobra.NoArgs,
 	RunE: runGC,
 }
 
 func init() {
 	gcCmd.Flags().BoolVar(&gcDryRun, "dry-run", false, "preview changes without deleting files")
-	gcCmd.Flags().StringVar(&gcMaxAge, "max-age", "30d", "maximum age of files to keep (e.g., 7d, 2w, 1m)")
+	gcCmd.Flags().StringVar(&gcMaxAge, "max-age", "30d", "maximum age of files to keep (e.g., 7d, 2w, 1m; default from config)")
+	gcCmd.Flags().StringVar(&gcSuccessMaxAge, "success-max-age", "", "maximum age of successful run records (default: max-age)")
+	gcCmd.Flags().StringVar(&gcFailureMaxAge, "failure-max-age", "", "maximum age of failed run records (default: max-age)")
+	gcCmd.Flags().IntVar(&gcKeepSuccessful, "keep-successful", -1, "always keep the N most recent successful run records")
 	rootCmd.AddCommand(gcCmd)
 }
 
@@ -52,16 +71,31 @@ func runGC(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no .tick directory found - run 'tk init' first")
 	}
 
-	// Parse max-age duration
-	maxAge, err := parseDuration(gcMaxAge)
+	cfg, err := config.LoadOrDefault(filepath.Join(tickDir, "config.json"))
 	if err != nil {
-		return fmt.Errorf("invalid --max-age: %w", err)
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Parse max-age duration, defaulting to gc.max_age_days from config
+	maxAge := configMaxAge(cfg)
+	if cmd.Flags().Changed("max-age") {
+		if maxAge, err = parseDuration(gcMaxAge); err != nil {
+			return fmt.Errorf("invalid --max-age: %w", err)
+		}
+	}
+
+	retention, err := gcRecordRetention(cfg)
+	if err != nil {
+		return err
 	}
 
 	// Run cleanup
 	cleaner := gc.NewCleaner(root).
 		WithMaxAge(maxAge).
 		WithDryRun(gcDryRun)
+	if retention != nil {
+		cleaner = cleaner.WithRecordRetention(*retention)
+	}
 
 	if gcDryRun {
 		fmt.Println("Dry run - no files will be deleted")
@@ -107,8 +141,66 @@ func runGC(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// gcRecordRetention builds the run record retention policy from config, with
+// flag overrides. Returns nil when no record retention is configured.
+func gcRecordRetention(cfg config.Config) (*gc.RecordRetention, error) {
+	var err error
+	r := configRecordRetention(cfg)
+
+	if gcSuccessMaxAge != "" {
+		if r.SuccessMaxAge, err = parseDuration(gcSuccessMaxAge); err != nil {
+			return nil, NewExitError(ExitUsage, "invalid --success-max-age: %v", err)
+		}
+	}
+	if gcFailureMaxAge != "" {
+		if r.FailureMaxAge, err = parseDuration(gcFailureMaxAge); err != nil {
+			return nil, NewExitError(ExitUsage, "invalid --failure-max-age: %v", err)
+		}
+	}
+	if gcKeepSuccessful >= 0 {
+		r.KeepSuccessful = gcKeepSuccessful
+	}
+
+	if r == (gc.RecordRetention{}) {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// configMaxAge returns the log retention set by gc.max_age_days.
+func configMaxAge(cfg config.Config) time.Duration {
+	return time.Duration(cfg.GC.GetMaxAgeDays()) * 24 * time.Hour
+}
+
+// configRecordRetention returns the run record retention set in config.
+func configRecordRetention(cfg config.Config) gc.RecordRetention {
+	day := 24 * time.Hour
+	return gc.RecordRetention{
+		SuccessMaxAge:  time.Duration(cfg.RunRecordRetention.GetSuccessMaxAgeDays()) * day,
+		FailureMaxAge:  time.Duration(cfg.RunRecordRetention.GetFailureMaxAgeDays()) * day,
+		KeepSuccessful: cfg.RunRecordRetention.GetKeepSuccessful(),
+		KeepLast:       cfg.GC.GetKeepLast(),
+	}
+}
+
+// autoCleanup runs the background cleanup of root started by tk run and
+// tk resume, using the retention from .tick/config.json. Errors are ignored.
+// The caller resolves root, as the working directory may change while the
+// cleanup runs.
+func autoCleanup(root string) {
+	cfg, err := config.LoadOrDefault(filepath.Join(root, ".tick", "config.json"))
+	if err != nil {
+		return
+	}
+	cleaner := gc.NewCleaner(root).WithMaxAge(configMaxAge(cfg))
+	if r := configRecordRetention(cfg); r != (gc.RecordRetention{}) {
+		cleaner = cleaner.WithRecordRetention(r)
+	}
+	_, _ = cleaner.Cleanup()
+}
+
 // parseDuration parses a human-friendly duration string like "7d", "2w", "1m".
-// Supports: d (days), w (weeks), m (months, 30 days).
+// Supports: h (hours), d (days), w (weeks), m (months, 30 days).
 func parseDuration(s string) (time.Duration, error) {
 	if len(s) < 2 {
 		return 0, fmt.Errorf("duration too short: %q", s)
@@ -127,6 +219,8 @@ func parseDuration(s string) (time.Duration, error) {
 	}
 
 	switch unit {
+	case 'h':
+		return time.Duration(value) * time.Hour, nil
 	case 'd':
 		return time.Duration(value) * 24 * time.Hour, nil
 	case 'w':
@@ -134,7 +228,7 @@ func parseDuration(s string) (time.Duration, error) {
 	case 'm':
 		return time.Duration(value) * 30 * 24 * time.Hour, nil
 	default:
-		return 0, fmt.Errorf("unknown unit %q (use d, w, or m)", string(unit))
+		return 0, fmt.Errorf("unknown unit %q (use h, d, w, or m)", string(unit))
 	}
 }
 
@@ -5,13 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/styles"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
@@ -146,82 +146,33 @@ func runGraph(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Build dependency graph
-	// For each task, find which tasks in this epic block it
-	blockedBy := make(map[string][]string)   // task -> tasks that block it
-	blocks := make(map[string][]string)      // task -> tasks it blocks
-	inDegree := make(map[string]int)         // number of open blockers
-
+	// Record which tasks in this epic block each other, for the output
+	blockedBy := make(map[string][]string) // task -> tasks that block it
+	blocks := make(map[string][]string)    // task -> tasks it blocks
 	taskSet := make(map[string]bool)
 	for _, t := range tasks {
 		taskSet[t.ID] = true
-		inDegree[t.ID] = 0
 	}
-
 	for _, t := range tasks {
 		for _, blockerID := range t.BlockedBy {
 			// Only count blockers that are in this epic and not closed
-			if taskSet[blockerID] {
-				blocker, exists := tickMap[blockerID]
-				if exists && blocker.Status != tick.StatusClosed {
-					blockedBy[t.ID] = append(blockedBy[t.ID], blockerID)
-					blocks[blockerID] = append(blocks[blockerID], t.ID)
-					inDegree[t.ID]++
-				}
+			if taskSet[blockerID] && tickMap[blockerID].Status != tick.StatusClosed {
+				blockedBy[t.ID] = append(blockedBy[t.ID], blockerID)
+				blocks[blockerID] = append(blocks[blockerID], t.ID)
 			}
 		}
 	}
 
-	// Compute waves using Kahn's algorithm (topological sort by levels)
-	var waves []wave
-	remaining := make(map[string]bool)
-	for _, t := range tasks {
-		remaining[t.ID] = true
+	// Same wave computation as tk run --parallel
+	taskWaves, cycleIDs := query.Waves(tasks)
+	if len(cycleIDs) > 0 {
+		fmt.Printf("\n%s Circular dependency detected among: %s\n",
+			styles.StatusBlockedStyle.Render("!"),
+			strings.Join(cycleIDs, ", "))
 	}
-
-	waveNum := 1
-	for len(remaining) > 0 {
-		// Find all tasks with no remaining blockers
-		var ready []tick.Tick
-		for _, t := range tasks {
-			if remaining[t.ID] && inDegree[t.ID] == 0 {
-				ready = append(ready, t)
-			}
-		}
-
-		if len(ready) == 0 {
-			// Cycle detected - remaining tasks have circular dependencies
-			var cycleIDs []string
-			for id := range remaining {
-				cycleIDs = append(cycleIDs, id)
-			}
-			sort.Strings(cycleIDs)
-			fmt.Printf("\n%s Circular dependency detected among: %s\n",
-				styles.StatusBlockedStyle.Render("!"),
-				strings.Join(cycleIDs, ", "))
-			break
-		}
-
-		// Sort by priority within wave
-		sort.Slice(ready, func(i, j int) bool {
-			if ready[i].Priority != ready[j].Priority {
-				return ready[i].Priority < ready[j].Priority
-			}
-			return ready[i].ID < ready[j].ID
-		})
-
-		waves = append(waves, wave{level: waveNum, ticks: ready})
-
-		// Remove ready tasks and update inDegree
-		for _, t := range ready {
-			delete(remaining, t.ID)
-			for _, dependentID := range blocks[t.ID] {
-				if remaining[dependentID] {
-					inDegree[dependentID]--
-				}
-			}
-		}
-		waveNum++
+	waves := make([]wave, len(taskWaves))
+	for i, ticks := range taskWaves {
+		waves[i] = wave{level: i + 1, ticks: ticks}
 	}
 
 	// Calculate stats
@@ -237,18 +188,15 @@ func runGraph(cmd *cobra.Command, args []string) error {
 	awaitingHuman := 0
 	deferred := 0
 	now := time.Now()
+	// Same predicate as tk list --agent-ready, so blockers outside the epic count too
+	agentReady := query.AgentReady(tasks, allTicks)
 
 	for _, t := range tasks {
-		isDeferred := t.DeferUntil != nil && t.DeferUntil.After(now)
-		isAwaiting := t.IsAwaitingHuman()
-		isBlocked := inDegree[t.ID] > 0
-		isClosed := t.Status == tick.StatusClosed
-
-		if isDeferred {
+		if t.DeferUntil != nil && t.DeferUntil.After(now) {
 			deferred++
-		} else if isAwaiting {
+		} else if t.IsAwaitingHuman() {
 			awaitingHuman++
-		} else if !isBlocked && !isClosed {
+		} else if agentReady[t.ID] {
 			readyForAgent++
 		}
 	}
@@ -278,12 +226,6 @@ func runGraph(cmd *cobra.Command, args []string) error {
 				Ready:    w.level == 1,
 			}
 			for _, t := range w.ticks {
-				isDeferred := t.DeferUntil != nil && t.DeferUntil.After(now)
-				isAwaiting := t.IsAwaitingHuman()
-				isBlocked := inDegree[t.ID] > 0
-				isClosed := t.Status == tick.StatusClosed
-				agentReady := !isDeferred && !isAwaiting && !isBlocked && !isClosed
-
 				gt := graphTask{
 					ID:         t.ID,
 					Title:      t.Title,
@@ -291,7 +233,7 @@ func runGraph(cmd *cobra.Command, args []string) error {
 					Status:     t.Status,
 					BlockedBy:  blockedBy[t.ID],
 					Blocks:     blocks[t.ID],
-					AgentReady: agentReady,
+					AgentReady: agentReady[t.ID],
 				}
 				if t.Awaiting != nil {
 					gt.Awaiting = *t.Awaiting
@@ -367,11 +309,9 @@ func runGraph(cmd *cobra.Command, args []string) error {
 			if t.DeferUntil != nil && t.DeferUntil.After(now) {
 				blockerInfo += styles.DimStyle.Render(fmt.Sprintf(" [deferred until %s]", t.DeferUntil.Format("Jan 2")))
 			}
-			fmt.Printf("  %s %s %s %s%s\n",
+			fmt.Printf("  %s %s%s\n",
 				statusIcon,
-				t.ID,
-				styles.RenderPriority(t.Priority),
-				t.Title,
+				t.SummaryStyled(styles.Summary, blockedInEpic(t, tickMap, taskSet)),
 				blockerInfo)
 		}
 		fmt.Println()
@@ -396,15 +336,22 @@ func renderTaskStatus(t tick.Tick, tickMap map[string]tick.Tick, taskSet map[str
 		return styles.StatusAwaitingStyle.Render(styles.IconAwaiting)
 	}
 
-	// Check if blocked by any open task in the epic
+	if blockedInEpic(t, tickMap, taskSet) {
+		return styles.StatusBlockedStyle.Render(styles.IconBlocked)
+	}
+
+	return styles.RenderStatus(t.Status)
+}
+
+// blockedInEpic reports whether t is blocked by an open task in the epic.
+func blockedInEpic(t tick.Tick, tickMap map[string]tick.Tick, taskSet map[string]bool) bool {
 	for _, blockerID := range t.BlockedBy {
 		if taskSet[blockerID] {
 			blocker, exists := tickMap[blockerID]
 			if exists && blocker.Status != tick.StatusClosed {
-				return styles.StatusBlockedStyle.Render(styles.IconBlocked)
+				return true
 			}
 		}
 	}
-
-	return styles.RenderStatus(t.Status)
+	return false
 }
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -16,27 +18,52 @@ import (
 var importCmd = &cobra.Command{
 	Use:   "import [file]",
 	Short: "Import ticks from external sources",
-	Long: `Import ticks from external sources (beads format).
+	Long: `Import ticks from a tk export file or from beads.
 
 If no file is specified, auto-detects .beads/issues.jsonl in the repo root.
 The special argument "beads" also triggers auto-detection.
 
+Files written by tk export are detected automatically (or force the format
+with --format). Each tick is validated before anything is written. When
+a tick ID already exists, --on-conflict decides:
+  skip       keep the existing tick (default)
+  overwrite  replace it
+  newer      replace it only if the imported tick's updated_at is later
+
 Examples:
   tk import                    # Auto-detect beads file
   tk import beads              # Explicit auto-detect
-  tk import path/to/file.jsonl # Import from specific file`,
+  tk import path/to/file.jsonl # Import from specific file
+  tk import ticks.jsonl --on-conflict newer`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runImport,
 }
 
-var importJSON bool
+var (
+	importJSON       bool
+	importFormat     string
+	importOnConflict string
+)
 
 func init() {
 	importCmd.Flags().BoolVar(&importJSON, "json", false, "output as JSON")
+	importCmd.Flags().StringVar(&importFormat, "format", "auto", "input format (auto|ticks|beads)")
+	importCmd.Flags().StringVar(&importOnConflict, "on-conflict", tick.ConflictSkip, "for tk export files: skip|overwrite|newer when a tick id exists")
 	rootCmd.AddCommand(importCmd)
 }
 
 func runImport(cmd *cobra.Command, args []string) error {
+	switch importFormat {
+	case "auto", "ticks", "beads":
+	default:
+		return NewExitError(ExitUsage, "invalid --format %q (expected auto, ticks, or beads)", importFormat)
+	}
+	switch importOnConflict {
+	case tick.ConflictSkip, tick.ConflictOverwrite, tick.ConflictNewer:
+	default:
+		return NewExitError(ExitUsage, "invalid --on-conflict %q (expected skip, overwrite, or newer)", importOnConflict)
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to detect repo root: %w", err)
@@ -60,6 +87,10 @@ func runImport(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if importFormat == "ticks" || (importFormat == "auto" && len(args) > 0 && isTicksFile(sourcePath)) {
+		return importTicksFile(root, sourcePath)
+	}
+
 	// Parse beads file
 	issues, err := beads.ParseFile(sourcePath)
 	if err != nil {
@@ -90,3 +121,56 @@ func runImport(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Imported %d issues (%d skipped)\n", result.Imported, result.Skipped)
 	return nil
 }
+
+// importTicksFile imports a JSON-lines file written by tk export.
+func importTicksFile(root, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return NewExitError(ExitIO, "failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	ticks, err := tick.ReadJSONL(f)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	result, err := store.Import(ticks, importOnConflict)
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	if importJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Printf("Imported %d ticks (%d overwritten, %d skipped)\n", result.Imported, result.Overwritten, result.Skipped)
+	return nil
+}
+
+// isTicksFile reports whether the first record in path is a valid tick,
+// i.e. the file was written by tk export rather than beads.
+func isTicksFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var t tick.Tick
+		return json.Unmarshal([]byte(line), &t) == nil && t.Validate() == nil
+	}
+	return false
+}
@@ -1,15 +1,18 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/pengelbrecht/ticks/internal/beads"
 	"github.com/pengelbrecht/ticks/internal/config"
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/migrate"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
@@ -21,14 +24,22 @@ var initCmd = &cobra.Command{
 This command creates the .tick directory structure, detects the GitHub
 repository and owner, and sets up the merge driver for conflict-free merging.
 
+If .tick/config.json already exists, init refuses to run unless --force is
+given. With --force, settings in the existing config are kept and only
+missing fields are filled in with defaults.
+
 Use --import-beads to also import any existing beads issues after initialization.`,
 	RunE: runInit,
 }
 
-var importBeads bool
+var (
+	importBeads bool
+	initForce   bool
+)
 
 func init() {
 	initCmd.Flags().BoolVar(&importBeads, "import-beads", false, "import beads issues after init")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "reinitialize an existing .tick directory, keeping its config")
 	rootCmd.AddCommand(initCmd)
 }
 
@@ -48,15 +59,32 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	tickDir := filepath.Join(root, ".tick")
+	configPath := filepath.Join(tickDir, "config.json")
+	_, statErr := os.Stat(configPath)
+	reinit := statErr == nil
+	if reinit && !initForce {
+		return NewExitError(ExitGeneric, ".tick is already initialized in %s; use --force to reinitialize", root)
+	}
+
 	if err := os.MkdirAll(filepath.Join(tickDir, "issues"), 0o755); err != nil {
 		return fmt.Errorf("failed to create .tick directory: %w", err)
 	}
 
-	if err := config.Save(filepath.Join(tickDir, "config.json"), config.Default()); err != nil {
+	cfg := config.Default()
+	cfg.SchemaVersion = migrate.LatestVersion()
+	if reinit {
+		// Keep the existing config; Load fills in defaults for unset fields.
+		// The schema version stays as recorded since nothing is migrated here.
+		cfg, err = config.Load(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to read existing config: %w", err)
+		}
+	}
+	if err := config.Save(configPath, cfg); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
-	if err := os.WriteFile(filepath.Join(tickDir, ".gitignore"), []byte(".index.json\nlogs/\n"), 0o644); err != nil {
+	if err := ensureTickGitignore(filepath.Join(tickDir, ".gitignore")); err != nil {
 		return fmt.Errorf("failed to write .gitignore: %w", err)
 	}
 
@@ -69,7 +97,11 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Detected GitHub repo: %s\n", project)
 	fmt.Printf("Detected user: %s\n\n", owner)
-	fmt.Println("Initialized .tick/")
+	if reinit {
+		fmt.Println("Reinitialized .tick/ (existing config kept)")
+	} else {
+		fmt.Println("Initialized .tick/")
+	}
 
 	// Check if .tick/ is gitignored (it shouldn't be)
 	if IsTickDirGitignored(root) {
@@ -106,7 +138,44 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// repoRoot returns the root directory of the git repository.
+// tickGitignoreEntries are the paths .tick/.gitignore must exclude.
+var tickGitignoreEntries = []string{".index.json", "logs/"}
+
+// ensureTickGitignore adds any missing tickGitignoreEntries to path, keeping
+// lines the user has added.
+func ensureTickGitignore(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	contents := string(data)
+	present := make(map[string]bool)
+	for _, line := range strings.Split(contents, "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+	changed := data == nil
+	for _, entry := range tickGitignoreEntries {
+		if present[entry] {
+			continue
+		}
+		if contents != "" && !strings.HasSuffix(contents, "\n") {
+			contents += "\n"
+		}
+		contents += entry + "\n"
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return os.WriteFile(path, []byte(contents), 0o644)
+}
+
+// ErrNotRepo is returned by repoRoot when no enclosing git repository is found.
+var ErrNotRepo = errors.New("not in a git repository")
+
+// repoRoot returns the root directory of the git repository containing the
+// working directory, or ErrNotRepo.
 func repoRoot() (string, error) {
 	dir, err := os.Getwd()
 	if err != nil {
@@ -119,7 +188,7 @@ func repoRoot() (string, error) {
 		}
 		parent := filepath.Dir(dir)
 		if parent == dir {
-			return "", fmt.Errorf("not in a git repository")
+			return "", ErrNotRepo
 		}
 		dir = parent
 	}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -17,36 +19,59 @@ var labelCmd = &cobra.Command{
 	Long: `Manage labels on a tick.
 
 Subcommands:
-  add    Add a label to a tick
-  rm     Remove a label from a tick
-  list   List labels on a tick`,
+  add     Add labels to a tick
+  remove  Remove labels from a tick (alias: rm)
+  list    List all labels with counts, or the labels on one tick
+  rename  Rename a label on every tick
+
+Examples:
+  tk label add abc123 backend urgent
+  tk label remove abc123 urgent
+  tk label list
+  tk label rename ui frontend`,
 }
 
 var labelAddCmd = &cobra.Command{
-	Use:   "add <id> <label>",
-	Short: "Add a label to a tick",
-	Args:  cobra.ExactArgs(2),
+	Use:   "add <id> <label>...",
+	Short: "Add labels to a tick",
+	Args:  cobra.MinimumNArgs(2),
 	RunE:  runLabelAdd,
 }
 
 var labelRmCmd = &cobra.Command{
-	Use:   "rm <id> <label>",
-	Short: "Remove a label from a tick",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runLabelRm,
+	Use:     "remove <id> <label>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove labels from a tick",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    runLabelRm,
 }
 
 var labelListCmd = &cobra.Command{
-	Use:   "list <id>",
-	Short: "List labels on a tick",
-	Args:  cobra.ExactArgs(1),
+	Use:   "list [id]",
+	Short: "List all labels with counts, or the labels on one tick",
+	Args:  cobra.MaximumNArgs(1),
 	RunE:  runLabelList,
 }
 
+var labelRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a label on every tick",
+	Long: `Rename a label on every tick that has it.
+
+Ticks that already have the new label just lose the old one.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runLabelRename,
+}
+
+var labelListJSON bool
+
 func init() {
+	labelListCmd.Flags().BoolVar(&labelListJSON, "json", false, "output as JSON")
+
 	labelCmd.AddCommand(labelAddCmd)
 	labelCmd.AddCommand(labelRmCmd)
 	labelCmd.AddCommand(labelListCmd)
+	labelCmd.AddCommand(labelRenameCmd)
 	rootCmd.AddCommand(labelCmd)
 }
 
@@ -72,7 +97,13 @@ func runLabelAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read tick: %w", err)
 	}
 
-	t.Labels = appendUnique(t.Labels, args[1])
+	before := len(t.Labels)
+	for _, label := range args[1:] {
+		t.Labels = appendUnique(t.Labels, label)
+	}
+	if len(t.Labels) == before {
+		return nil
+	}
 	t.UpdatedAt = time.Now().UTC()
 
 	if err := store.Write(t); err != nil {
@@ -104,7 +135,13 @@ func runLabelRm(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read tick: %w", err)
 	}
 
-	t.Labels = removeString(t.Labels, args[1])
+	before := len(t.Labels)
+	for _, label := range args[1:] {
+		t.Labels = removeString(t.Labels, label)
+	}
+	if len(t.Labels) == before {
+		return nil
+	}
 	t.UpdatedAt = time.Now().UTC()
 
 	if err := store.Write(t); err != nil {
@@ -115,6 +152,10 @@ func runLabelRm(cmd *cobra.Command, args []string) error {
 }
 
 func runLabelList(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return printLabelCounts(labelListJSON)
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to detect repo root: %w", err)
@@ -142,3 +183,47 @@ func runLabelList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runLabelRename(cmd *cobra.Command, args []string) error {
+	oldLabel, newLabel := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+	if oldLabel == "" || newLabel == "" {
+		return NewExitError(ExitUsage, "labels must not be empty")
+	}
+	if oldLabel == newLabel {
+		return NewExitError(ExitUsage, "old and new label are the same")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect repo root: %w", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	now := time.Now().UTC()
+	renamed := 0
+	for _, t := range ticks {
+		i := slices.Index(t.Labels, oldLabel)
+		if i == -1 {
+			continue
+		}
+		// Keep the label's position unless the tick already has the new one
+		if slices.Contains(t.Labels, newLabel) {
+			t.Labels = removeString(t.Labels, oldLabel)
+		} else {
+			t.Labels[i] = newLabel
+		}
+		t.UpdatedAt = now
+		if err := store.Write(t); err != nil {
+			return fmt.Errorf("failed to update tick %s: %w", t.ID, err)
+		}
+		renamed++
+	}
+
+	fmt.Printf("Renamed label %s to %s on %d ticks\n", oldLabel, newLabel, renamed)
+	return nil
+}
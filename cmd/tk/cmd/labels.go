@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/spf13/cobra"
 
@@ -29,6 +30,12 @@ func init() {
 }
 
 func runLabels(cmd *cobra.Command, args []string) error {
+	return printLabelCounts(labelsJSON)
+}
+
+// printLabelCounts prints every label in use with the number of ticks that
+// have it, for tk labels and tk label list.
+func printLabelCounts(asJSON bool) error {
 	root, err := repoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to detect repo root: %w", err)
@@ -47,7 +54,7 @@ func runLabels(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if labelsJSON {
+	if asJSON {
 		enc := json.NewEncoder(os.Stdout)
 		if err := enc.Encode(counts); err != nil {
 			return fmt.Errorf("failed to encode json: %w", err)
@@ -55,8 +62,13 @@ func runLabels(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	for label, count := range counts {
-		fmt.Printf("%s: %d\n", label, count)
+	labels := make([]string, 0, len(counts))
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		fmt.Printf("%s: %d\n", label, counts[label])
 	}
 
 	return nil
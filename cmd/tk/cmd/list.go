@@ -1,15 +1,22 @@
 package cmd
 
 import (
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 
-	"github.com/pengelbrecht/ticks/internal/github"
 	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/styles"
 	"github.com/pengelbrecht/ticks/internal/tick"
@@ -17,16 +24,38 @@ import (
 
 // listOutput wraps the output for JSON formatting.
 type listOutput struct {
-	Ticks   []tick.Tick `json:"ticks"`
+	Ticks   []listTick  `json:"ticks"`
 	Filters *listFilter `json:"filters,omitempty"`
 }
 
+// listTick is a tick in JSON list output, marked with whether an agent can
+// pick it up now (see query.AgentReady).
+type listTick struct {
+	tick.Tick
+	AgentReady bool `json:"agent_ready"`
+}
+
+// newListTicks marks ticks with their agent readiness. allTicks resolves
+// blockers outside ticks.
+func newListTicks(ticks, allTicks []tick.Tick) []listTick {
+	agentReady := query.AgentReady(ticks, allTicks)
+	out := make([]listTick, 0, len(ticks))
+	for _, t := range ticks {
+		out = append(out, listTick{Tick: t, AgentReady: agentReady[t.ID]})
+	}
+	return out
+}
+
 // listFilter captures the search/filter options applied to list output.
 type listFilter struct {
 	TitleContains string   `json:"title_contains,omitempty"`
 	DescContains  string   `json:"desc_contains,omitempty"`
 	NotesContains string   `json:"notes_contains,omitempty"`
 	LabelAny      []string `json:"label_any,omitempty"`
+	LabelsAll     []string `json:"labels_all,omitempty"`
+	UpdatedBy     string   `json:"updated_by,omitempty"`
+	UpdatedSince  string   `json:"updated_since,omitempty"`
+	Since         string   `json:"since,omitempty"`
 }
 
 var listCmd = &cobra.Command{
@@ -36,7 +65,8 @@ var listCmd = &cobra.Command{
 	Long: `List ticks with optional filters.
 
 By default, only shows ticks owned by the current user.
-Use --all to show all owners.
+Use --all to show all owners, or --owner to pick them
+(e.g. --owner @me --owner alice).
 
 Awaiting Filter Examples:
   # All ticks awaiting human action
@@ -48,27 +78,96 @@ Awaiting Filter Examples:
   # Ticks awaiting approval or review
   tk list --awaiting approval,review
 
+  # Open or in-progress ticks awaiting approval or review (flags repeat)
+  tk list --status open --status in_progress --awaiting approval --awaiting review
+
   # Show what needs your attention (JSON)
-  tk list --awaiting= --json | jq '.ticks[] | {id, title, awaiting}'`,
+  tk list --awaiting= --json | jq '.ticks[] | {id, title, awaiting}'
+
+Priority Range Examples:
+  Lower numbers are more urgent (P0 = critical, P4 = backlog). Both bounds
+  are inclusive and accept 0-4, P0-P4, or critical|high|medium|low|backlog.
+
+  # Only P0 and P1
+  tk list --max-priority 1
+
+  # Medium through low
+  tk list --min-priority medium --max-priority low
+
+Epic Examples:
+  # Just the roadmap
+  tk list --all --epics-only
+
+  # Just the work items
+  tk list --all --no-epics
+
+Author Examples:
+  Uses the activity log to find who last changed each tick.
+
+  # Ticks an agent touched most recently
+  tk list --all --updated-by dependency-analyzer
+
+  # A teammate's changes from the last two days
+  tk list --all --status all --updated-by alice --updated-within 2d
+
+Incremental Examples:
+  Filters on each tick's updated_at and lists the oldest change first,
+  so the last tick's updated_at can be passed to the next poll.
+
+  tk list --all --status all --since 2025-01-08T10:30:00Z --json
+
+Agent Examples:
+  The AGENT column (agent_ready in JSON) marks ticks an agent can pick up
+  now: not deferred, awaiting a human, blocked, or closed. Blockers count
+  even when the filters hide them.
+
+  # What an agent could start on right now
+  tk list --all --agent-ready
+
+Watch Examples:
+  Reprints the list whenever a tick changes until Ctrl+C. With --json,
+  each change prints one JSON snapshot per line.
+
+  # Live view of what an agent could start on
+  tk list --all --agent-ready --watch
+
+  # Snapshot stream for a script
+  tk list --all --watch --json | while read -r snapshot; do ...; done
+
+Export Examples:
+  # Spreadsheet-friendly CSV of all open ticks
+  tk list --all --format csv > ticks.csv`,
 	Args: cobra.NoArgs,
 	RunE: runList,
 }
 
 var (
 	listAll           bool
-	listOwner         string
-	listStatus        string
+	listOwner         []string
+	listStatus        []string
 	listPriority      int
+	listMinPriority   string
+	listMaxPriority   string
 	listType          string
 	listLabel         string
 	listLabelAny      string
+	listLabelsAll     []string
+	listLabelsAny     []string
 	listParent        string
 	listTitleContains string
 	listDescContains  string
 	listNotesContains string
 	listManual        bool
-	listAwaiting      string
+	listAwaiting      []string
 	listJSON          bool
+	listFormat        string
+	listEpicsOnly     bool
+	listNoEpics       bool
+	listUpdatedBy     string
+	listUpdatedWithin string
+	listSince         string
+	listAgentReady    bool
+	listWatch         bool
 )
 
 // listAwaitingSet tracks whether --awaiting flag was explicitly provided
@@ -76,19 +175,31 @@ var listAwaitingSet bool
 
 func init() {
 	listCmd.Flags().BoolVarP(&listAll, "all", "a", false, "all owners")
-	listCmd.Flags().StringVarP(&listOwner, "owner", "o", "", "owner")
-	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "status (open|closed|all)")
+	listCmd.Flags().StringArrayVarP(&listOwner, "owner", "o", nil, "owner (@me for yourself); repeatable or comma-separated")
+	listCmd.Flags().StringArrayVarP(&listStatus, "status", "s", nil, "status (open|in_progress|closed|all); repeatable or comma-separated")
 	listCmd.Flags().IntVarP(&listPriority, "priority", "p", -1, "priority (0-4)")
+	listCmd.Flags().StringVar(&listMinPriority, "min-priority", "", "lowest priority number to include, inclusive (e.g. 0, P0, critical)")
+	listCmd.Flags().StringVar(&listMaxPriority, "max-priority", "", "highest priority number to include, inclusive (e.g. 1, P1, high)")
 	listCmd.Flags().StringVarP(&listType, "type", "t", "", "type (task|epic|bug|feature|chore)")
+	listCmd.Flags().BoolVar(&listEpicsOnly, "epics-only", false, "show only epics")
+	listCmd.Flags().BoolVar(&listNoEpics, "no-epics", false, "exclude epics")
 	listCmd.Flags().StringVarP(&listLabel, "label", "l", "", "label")
-	listCmd.Flags().StringVar(&listLabelAny, "label-any", "", "label-any (comma-separated)")
+	listCmd.Flags().StringVar(&listLabelAny, "label-any", "", "label-any (comma-separated); same as --labels-any")
+	listCmd.Flags().StringArrayVar(&listLabelsAll, "labels-all", nil, "ticks must have every listed label; repeatable or comma-separated")
+	listCmd.Flags().StringArrayVar(&listLabelsAny, "labels-any", nil, "ticks must have at least one listed label; repeatable or comma-separated")
 	listCmd.Flags().StringVar(&listParent, "parent", "", "parent epic id")
 	listCmd.Flags().StringVar(&listTitleContains, "title-contains", "", "title contains (case-insensitive)")
 	listCmd.Flags().StringVar(&listDescContains, "desc-contains", "", "description contains (case-insensitive)")
 	listCmd.Flags().StringVar(&listNotesContains, "notes-contains", "", "notes contains (case-insensitive)")
+	listCmd.Flags().StringVar(&listUpdatedBy, "updated-by", "", "only ticks last changed by this actor (from the activity log)")
+	listCmd.Flags().StringVar(&listSince, "since", "", "only ticks with updated_at at or after this RFC3339 time, oldest first")
+	listCmd.Flags().StringVar(&listUpdatedWithin, "updated-within", "", "only ticks last changed within this window (e.g. 12h, 7d, 2w)")
 	listCmd.Flags().BoolVar(&listManual, "manual", false, "show only manual tasks (requires human intervention)")
-	listCmd.Flags().StringVar(&listAwaiting, "awaiting", "", "filter by awaiting status (empty = all awaiting, or specific type(s) comma-separated)")
+	listCmd.Flags().StringArrayVar(&listAwaiting, "awaiting", nil, "filter by awaiting status (empty = all awaiting, or specific type(s); repeatable or comma-separated)")
+	listCmd.Flags().BoolVar(&listAgentReady, "agent-ready", false, "show only ticks an agent can pick up now (not deferred, awaiting, blocked, or closed)")
 	listCmd.Flags().BoolVar(&listJSON, "json", false, "output as JSON")
+	listCmd.Flags().StringVar(&listFormat, "format", "", "output format (table|json|csv)")
+	listCmd.Flags().BoolVarP(&listWatch, "watch", "w", false, "keep running and reprint the list whenever ticks change")
 
 	rootCmd.AddCommand(listCmd)
 }
@@ -97,44 +208,162 @@ func runList(cmd *cobra.Command, args []string) error {
 	// Track whether --awaiting was explicitly set (even if empty)
 	listAwaitingSet = cmd.Flags().Changed("awaiting")
 
+	format := strings.TrimSpace(listFormat)
+	switch format {
+	case "":
+		format = "table"
+		if listJSON {
+			format = "json"
+		}
+	case "table", "json", "csv":
+		if listJSON && format != "json" {
+			return NewExitError(ExitUsage, "--json cannot be combined with --format %s", format)
+		}
+	default:
+		return NewExitError(ExitUsage, "invalid --format %q (expected table, json, or csv)", format)
+	}
+
+	tickType := strings.TrimSpace(listType)
+	var excludeType string
+	switch {
+	case listEpicsOnly && listNoEpics:
+		return NewExitError(ExitUsage, "--epics-only and --no-epics are mutually exclusive")
+	case listEpicsOnly:
+		if tickType != "" && tickType != tick.TypeEpic {
+			return NewExitError(ExitUsage, "--epics-only cannot be combined with --type %s", tickType)
+		}
+		tickType = tick.TypeEpic
+	case listNoEpics:
+		if tickType == tick.TypeEpic {
+			return NewExitError(ExitUsage, "--no-epics cannot be combined with --type epic")
+		}
+		excludeType = tick.TypeEpic
+	}
+
+	var since *time.Time
+	if value := strings.TrimSpace(listSince); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return NewExitError(ExitUsage, "invalid --since (use RFC3339, e.g. 2025-01-08T10:30:00Z): %v", err)
+		}
+		since = &parsed
+	}
+
+	var updatedWithin *time.Duration
+	if within := strings.TrimSpace(listUpdatedWithin); within != "" {
+		d, err := parseDuration(within)
+		if err != nil {
+			return NewExitError(ExitUsage, "invalid --updated-within: %v", err)
+		}
+		updatedWithin = &d
+	}
+
+	if listWatch && format == "csv" {
+		return NewExitError(ExitUsage, "--watch cannot be combined with --format csv")
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to detect repo root: %w", err)
 	}
 
-	owner, err := resolveOwner(listAll, listOwner)
+	owners, err := resolveOwners(listAll, splitCSV(strings.Join(listOwner, ",")))
 	if err != nil {
 		return fmt.Errorf("failed to detect owner: %w", err)
 	}
 
-	store := tick.NewStore(filepath.Join(root, ".tick"))
-	ticks, err := store.List()
-	if err != nil {
-		return fmt.Errorf("failed to list ticks: %w", err)
-	}
-
 	var priority *int
 	if listPriority >= 0 {
 		p := listPriority
 		priority = &p
 	}
 
-	status := strings.TrimSpace(listStatus)
-	if status == "all" {
-		status = ""
+	minPriority, maxPriority, err := parsePriorityRange(listMinPriority, listMaxPriority)
+	if err != nil {
+		return err
+	}
+
+	var statuses []string
+	for _, status := range splitCSV(strings.Join(listStatus, ",")) {
+		if status == "all" {
+			statuses = nil
+			break
+		}
+		statuses = append(statuses, status)
+	}
+
+	// --awaiting= (empty) means any awaiting type; otherwise match the listed types
+	var awaitingAny []string
+	anyAwaiting := false
+	for _, value := range listAwaiting {
+		types := splitCSV(value)
+		if len(types) == 0 {
+			anyAwaiting = true
+		}
+		awaitingAny = append(awaitingAny, types...)
+	}
+	if anyAwaiting {
+		awaitingAny = nil
 	}
 
 	filter := query.Filter{
-		Owner:         owner,
-		Status:        status,
+		Owners:        owners,
+		StatusAny:     statuses,
+		AwaitingAny:   awaitingAny,
 		Priority:      priority,
-		Type:          strings.TrimSpace(listType),
+		MinPriority:   minPriority,
+		MaxPriority:   maxPriority,
+		Type:          tickType,
+		ExcludeType:   excludeType,
 		Label:         strings.TrimSpace(listLabel),
-		LabelAny:      splitCSV(listLabelAny),
+		LabelsAll:     splitCSV(strings.Join(listLabelsAll, ",")),
+		LabelsAny:     splitCSV(strings.Join(listLabelsAny, ",") + "," + listLabelAny),
 		Parent:        strings.TrimSpace(listParent),
 		TitleContains: strings.TrimSpace(listTitleContains),
 		DescContains:  strings.TrimSpace(listDescContains),
 		NotesContains: strings.TrimSpace(listNotesContains),
+		Since:         since,
+	}
+
+	q := listQuery{
+		format:        format,
+		filter:        filter,
+		anyAwaiting:   listAwaitingSet && anyAwaiting,
+		updatedBy:     strings.TrimSpace(listUpdatedBy),
+		updatedWithin: updatedWithin,
+	}
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	if listWatch {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		return watchList(ctx, store, q)
+	}
+	return printList(store, q)
+}
+
+// listQuery is a parsed tk list invocation. --watch prints it again on
+// every change.
+type listQuery struct {
+	format      string
+	filter      query.Filter
+	anyAwaiting bool // empty --awaiting: only ticks awaiting a human
+	updatedBy   string
+	// updatedWithin is the --updated-within window, measured back from
+	// each print
+	updatedWithin *time.Duration
+}
+
+// printList loads the ticks and prints those matching q.
+func printList(store *tick.Store, q listQuery) error {
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	filter, format, since, updatedBy := q.filter, q.format, q.filter.Since, q.updatedBy
+	var updatedSince time.Time
+	if q.updatedWithin != nil {
+		updatedSince = time.Now().UTC().Add(-*q.updatedWithin)
 	}
 
 	filtered := query.Apply(ticks, filter)
@@ -150,44 +379,67 @@ func runList(cmd *cobra.Command, args []string) error {
 		filtered = manualTicks
 	}
 
-	// Filter by awaiting status if requested
-	if listAwaitingSet {
-		awaitingVal := strings.TrimSpace(listAwaiting)
+	// Empty --awaiting means all awaiting ticks (specific types are handled by AwaitingAny)
+	if q.anyAwaiting {
 		var awaitingTicks []tick.Tick
-		if awaitingVal == "" {
-			// Empty string means all awaiting ticks
-			for _, t := range filtered {
-				if t.IsAwaitingHuman() {
-					awaitingTicks = append(awaitingTicks, t)
-				}
-			}
-		} else {
-			// Filter by specific awaiting type(s)
-			types := splitCSV(awaitingVal)
-			typeSet := make(map[string]bool)
-			for _, typ := range types {
-				typeSet[typ] = true
-			}
-			for _, t := range filtered {
-				if t.IsAwaitingHuman() && typeSet[t.GetAwaitingType()] {
-					awaitingTicks = append(awaitingTicks, t)
-				}
+		for _, t := range filtered {
+			if t.IsAwaitingHuman() {
+				awaitingTicks = append(awaitingTicks, t)
 			}
 		}
 		filtered = awaitingTicks
 	}
 
-	query.SortByPriorityCreatedAt(filtered)
+	// Filter by last modifier if requested
+	if updatedBy != "" || !updatedSince.IsZero() {
+		activities, err := store.ReadActivity(0)
+		if err != nil {
+			return fmt.Errorf("failed to read activity log: %w", err)
+		}
+		filtered = filterLastUpdated(filtered, activities, updatedBy, updatedSince)
+	}
 
-	if listJSON {
-		output := listOutput{Ticks: filtered}
+	// Blockers are looked up in all ticks, so ones filtered out still count
+	agentReady := query.AgentReady(filtered, ticks)
+	if listAgentReady {
+		var readyTicks []tick.Tick
+		for _, t := range filtered {
+			if agentReady[t.ID] {
+				readyTicks = append(readyTicks, t)
+			}
+		}
+		filtered = readyTicks
+	}
+
+	if since != nil {
+		// Oldest change first so pollers can keep the last updated_at as a cursor
+		query.SortByUpdatedAt(filtered)
+	} else {
+		query.SortByPriorityCreatedAt(filtered)
+	}
+
+	if format == "csv" {
+		return writeTicksCSV(os.Stdout, filtered)
+	}
+
+	if format == "json" {
+		output := listOutput{Ticks: newListTicks(filtered, ticks)}
 		// Include filter metadata if any search filters are present
-		if filter.TitleContains != "" || filter.DescContains != "" || filter.NotesContains != "" || len(filter.LabelAny) > 0 {
+		if filter.TitleContains != "" || filter.DescContains != "" || filter.NotesContains != "" || len(filter.LabelsAny) > 0 || len(filter.LabelsAll) > 0 ||
+			updatedBy != "" || !updatedSince.IsZero() || since != nil {
 			output.Filters = &listFilter{
 				TitleContains: filter.TitleContains,
 				DescContains:  filter.DescContains,
 				NotesContains: filter.NotesContains,
-				LabelAny:      filter.LabelAny,
+				LabelAny:      filter.LabelsAny,
+				LabelsAll:     filter.LabelsAll,
+				UpdatedBy:     updatedBy,
+			}
+			if !updatedSince.IsZero() {
+				output.Filters.UpdatedSince = updatedSince.Format(time.RFC3339)
+			}
+			if since != nil {
+				output.Filters.Since = since.Format(time.RFC3339Nano)
 			}
 		}
 		enc := json.NewEncoder(os.Stdout)
@@ -205,8 +457,17 @@ func runList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Size columns to their widest plain value; styled cells are padded by
+	// visible width so color codes don't break the alignment
+	idWidth, typeWidth := len("ID"), len("TYPE")
+	for _, t := range filtered {
+		idWidth = max(idWidth, len(t.ID))
+		typeWidth = max(typeWidth, len(t.Type))
+	}
+	const priWidth, statusWidth, agentWidth = len("PRI"), len("ST"), len("AGENT")
+
 	// Print header
-	header := fmt.Sprintf(" %-4s  %s  %-7s  %s  %s", "ID", "PRI", "TYPE", "ST", "TITLE")
+	header := fmt.Sprintf(" %-*s  %-*s  %-*s  %-*s  %-*s  %s", idWidth, "ID", priWidth, "PRI", typeWidth, "TYPE", statusWidth, "ST", agentWidth, "AGENT", "TITLE")
 	fmt.Println(styles.DimStyle.Render(header))
 
 	for _, t := range filtered {
@@ -222,11 +483,16 @@ func runList(cmd *cobra.Command, args []string) error {
 		}
 
 		statusIcon := styles.RenderTickStatusWithBlocked(t, isBlocked)
-		fmt.Printf(" %-4s  %s  %-7s  %s   %s\n",
-			t.ID,
-			styles.RenderPriority(t.Priority),
-			styles.RenderType(t.Type),
-			statusIcon,
+		agentIcon := ""
+		if agentReady[t.ID] {
+			agentIcon = styles.StatusInProgressStyle.Render(styles.IconClosed)
+		}
+		fmt.Printf(" %-*s  %s  %s  %s  %s  %s\n",
+			idWidth, t.ID,
+			styles.PadRight(styles.RenderPriority(t.Priority), priWidth),
+			styles.PadRight(styles.RenderType(t.Type), typeWidth),
+			styles.PadRight(statusIcon, statusWidth),
+			styles.PadRight(agentIcon, agentWidth),
 			t.Title,
 		)
 	}
@@ -234,14 +500,145 @@ func runList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// resolveOwner resolves the owner to use based on flags.
-func resolveOwner(allOwners bool, ownerFlag string) (string, error) {
-	if allOwners {
-		return "", nil
+// listWatchDebounce is how long tk list --watch waits for changes to settle
+// before reprinting, so a burst of writes produces a single frame.
+const listWatchDebounce = 100 * time.Millisecond
+
+// watchList prints q, then reprints it after every change to the issues
+// directory until ctx is cancelled. Table output clears the terminal between
+// frames; JSON output is one snapshot per line.
+func watchList(ctx context.Context, store *tick.Store, q listQuery) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+	issuesDir := filepath.Join(store.Root, "issues")
+	if err := watcher.Add(issuesDir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", issuesDir, err)
+	}
+
+	frame := func() error {
+		if q.format == "table" {
+			fmt.Print("\033[H\033[2J")
+		}
+		return printList(store, q)
+	}
+	if err := frame(); err != nil {
+		return err
+	}
+
+	var settled <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if strings.HasSuffix(event.Name, ".json") {
+				settled = time.After(listWatchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "watch: file watcher error: %v\n", err)
+		case <-settled:
+			settled = nil
+			if err := frame(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// filterLastUpdated keeps ticks whose most recent activity entry was made by
+// actor (if set) at or after since (if set). Ticks with no activity are dropped.
+func filterLastUpdated(ticks []tick.Tick, activities []tick.Activity, actor string, since time.Time) []tick.Tick {
+	last := make(map[string]tick.Activity)
+	for _, a := range activities {
+		if prev, ok := last[a.TickID]; !ok || !a.Timestamp.Before(prev.Timestamp) {
+			last[a.TickID] = a
+		}
+	}
+
+	var out []tick.Tick
+	for _, t := range ticks {
+		a, ok := last[t.ID]
+		if !ok {
+			continue
+		}
+		if actor != "" && a.Actor != actor {
+			continue
+		}
+		if !since.IsZero() && a.Timestamp.Before(since) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// csvHeader lists the columns written by writeTicksCSV.
+var csvHeader = []string{"id", "title", "status", "type", "priority", "owner", "parent", "labels", "created_at", "closed_at"}
+
+// writeTicksCSV writes ticks as CSV with a header row.
+// Labels are joined with commas; timestamps use RFC 3339 and closed_at is empty for open ticks.
+func writeTicksCSV(w io.Writer, ticks []tick.Tick) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	for _, t := range ticks {
+		closedAt := ""
+		if t.ClosedAt != nil {
+			closedAt = t.ClosedAt.UTC().Format(time.RFC3339)
+		}
+		record := []string{
+			t.ID,
+			t.Title,
+			t.Status,
+			t.Type,
+			strconv.Itoa(t.Priority),
+			t.Owner,
+			t.Parent,
+			strings.Join(t.Labels, ","),
+			t.CreatedAt.UTC().Format(time.RFC3339),
+			closedAt,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// parsePriorityRange parses --min-priority/--max-priority flag values.
+// An empty value leaves that side of the range open.
+func parsePriorityRange(minVal, maxVal string) (*int, *int, error) {
+	var minPriority, maxPriority *int
+	if strings.TrimSpace(minVal) != "" {
+		p, err := tick.ParsePriority(minVal)
+		if err != nil {
+			return nil, nil, NewExitError(ExitUsage, "invalid --min-priority: %v", err)
+		}
+		minPriority = &p
+	}
+	if strings.TrimSpace(maxVal) != "" {
+		p, err := tick.ParsePriority(maxVal)
+		if err != nil {
+			return nil, nil, NewExitError(ExitUsage, "invalid --max-priority: %v", err)
+		}
+		maxPriority = &p
 	}
-	owner := strings.TrimSpace(ownerFlag)
-	if owner != "" {
-		return owner, nil
+	if minPriority != nil && maxPriority != nil && *minPriority > *maxPriority {
+		return nil, nil, NewExitError(ExitUsage, "--min-priority (%d) must not be greater than --max-priority (%d)", *minPriority, *maxPriority)
 	}
-	return github.DetectOwner(nil)
+	return minPriority, maxPriority, nil
 }
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/agent"
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/runrecord"
+	"github.com/pengelbrecht/ticks/internal/styles"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log <id>",
+	Short: "Show the agent run record for a tick",
+	Long: `Show the agent run recorded for a tick by tk run.
+
+Displays the session, model, start and end times, outcome, turns, tools
+used, and token, cost and duration metrics. While the tick is still being
+worked on, the in-progress snapshot is shown instead.
+
+With --json, prints the raw run record (or the live record while running).
+
+Examples:
+  tk log abc
+  tk log abc --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLog,
+}
+
+var logJSON bool
+
+func init() {
+	logCmd.Flags().BoolVar(&logJSON, "json", false, "output as JSON")
+	rootCmd.AddCommand(logCmd)
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	project, err := github.DetectProject(nil)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+
+	id, err := github.NormalizeID(project, args[0])
+	if err != nil {
+		return NewExitError(ExitUsage, "invalid id: %v", err)
+	}
+
+	store := runrecord.NewStore(root)
+
+	// A live record means the run is still in progress
+	if store.LiveExists(id) {
+		live, err := store.ReadLive(id)
+		if err == nil {
+			if logJSON {
+				return encodeLogJSON(live)
+			}
+			printLiveRecord(id, live)
+			return nil
+		}
+		// The live file can vanish as the run finalizes; fall back to the record
+	}
+
+	record, err := store.Read(id)
+	if errors.Is(err, runrecord.ErrNotFound) {
+		return NewExitError(ExitNotFound, "no run recorded for %s", id)
+	}
+	if err != nil {
+		return NewExitError(ExitIO, "failed to read run record: %v", err)
+	}
+
+	if logJSON {
+		return encodeLogJSON(record)
+	}
+	printRunRecord(id, record)
+	return nil
+}
+
+func encodeLogJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode json: %w", err)
+	}
+	return nil
+}
+
+func printRunRecord(id string, r *agent.RunRecord) {
+	outcome := styles.StatusClosedStyle.Render("success")
+	if !r.Success {
+		outcome = styles.StatusBlockedStyle.Render("failed")
+	}
+
+	lines := []string{styles.RenderID(id) + "  " + outcome, ""}
+	lines = append(lines, styles.RenderLabel("Session:")+"  "+r.SessionID)
+	lines = append(lines, styles.RenderLabel("Model:")+"  "+r.Model)
+	lines = append(lines, styles.RenderLabel("Started:")+"  "+formatTime(r.StartedAt))
+	lines = append(lines, styles.RenderLabel("Ended:")+"  "+formatTime(r.EndedAt))
+	lines = append(lines, styles.RenderLabel("Turns:")+"  "+fmt.Sprintf("%d", r.NumTurns))
+	lines = append(lines, logMetricLines(r.Tools, r.Metrics)...)
+	if r.ErrorMsg != "" {
+		lines = append(lines, styles.RenderLabel("Error:")+"  "+r.ErrorMsg)
+	}
+
+	fmt.Println(styles.BoxStyle.Render(strings.Join(lines, "\n")))
+}
+
+func printLiveRecord(id string, r *runrecord.LiveRecord) {
+	banner := styles.StatusInProgressStyle.Render("running")
+	if r.Status != "" {
+		banner += styles.RenderDim(" (" + r.Status + ")")
+	}
+
+	lines := []string{styles.RenderID(id) + "  " + banner, ""}
+	lines = append(lines, styles.RenderLabel("Session:")+"  "+r.SessionID)
+	lines = append(lines, styles.RenderLabel("Model:")+"  "+r.Model)
+	lines = append(lines, styles.RenderLabel("Started:")+"  "+formatTime(r.StartedAt))
+	lines = append(lines, styles.RenderLabel("Updated:")+"  "+formatTime(r.LastUpdated))
+	lines = append(lines, styles.RenderLabel("Turns:")+"  "+fmt.Sprintf("%d", r.NumTurns))
+	lines = append(lines, logMetricLines(r.Tools, r.Metrics)...)
+	if r.ActiveTool != nil {
+		lines = append(lines, styles.RenderLabel("Active tool:")+"  "+r.ActiveTool.Name)
+	}
+	if r.ErrorMsg != "" {
+		lines = append(lines, styles.RenderLabel("Error:")+"  "+r.ErrorMsg)
+	}
+
+	fmt.Println(styles.BoxStyle.Render(strings.Join(lines, "\n")))
+}
+
+// logMetricLines renders tool usage and metrics shared by finished and live records.
+func logMetricLines(tools []agent.ToolRecord, m agent.MetricsRecord) []string {
+	lines := []string{
+		styles.RenderLabel("Tools:") + "  " + formatToolCounts(tools),
+		styles.RenderLabel("Tokens:") + "  " + fmt.Sprintf("%d in, %d out (cache %d read, %d write)",
+			m.InputTokens, m.OutputTokens, m.CacheReadTokens, m.CacheCreationTokens),
+		styles.RenderLabel("Cost:") + "  " + fmt.Sprintf("$%.4f", m.CostUSD),
+		styles.RenderLabel("Duration:") + "  " + (time.Duration(m.DurationMS) * time.Millisecond).Round(time.Second).String(),
+	}
+	return lines
+}
+
+// formatToolCounts summarizes tool calls by name in order of first use,
+// e.g. "Read ×3, Edit ×1".
+func formatToolCounts(tools []agent.ToolRecord) string {
+	if len(tools) == 0 {
+		return "none"
+	}
+	var names []string
+	counts := make(map[string]int)
+	for _, t := range tools {
+		if counts[t.Name] == 0 {
+			names = append(names, t.Name)
+		}
+		counts[t.Name]++
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s ×%d", name, counts[name])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -29,8 +30,62 @@ Arguments:
 	RunE: runMergeFile,
 }
 
+var mergeDriverCmd = &cobra.Command{
+	Use:   "merge-driver <base> <ours> <theirs> [path]",
+	Short: "Git merge driver for tick files",
+	Long: `Three-way merge of a tick file, run by git as the "tick" merge driver
+that tk init registers. The merged tick is written over <ours>, as git
+expects; [path] is only used in error messages.
+
+Fields changed on one side take that side's value; fields changed on both
+take the value from the side with the newer updated_at. Labels and
+blocked_by are merged as sets, and notes keep the unique lines of both
+sides.`,
+	Args:   cobra.RangeArgs(3, 4),
+	Hidden: true,
+	RunE:   runMergeDriver,
+}
+
 func init() {
 	rootCmd.AddCommand(mergeFileCmd)
+	rootCmd.AddCommand(mergeDriverCmd)
+}
+
+func runMergeDriver(cmd *cobra.Command, args []string) error {
+	name := args[1]
+	if len(args) == 4 {
+		name = args[3]
+	}
+
+	// git passes an empty base when both sides added the file
+	var base tick.Tick
+	if data, err := os.ReadFile(args[0]); err != nil {
+		return fmt.Errorf("%s: failed to read base: %w", name, err)
+	} else if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &base); err != nil {
+			return fmt.Errorf("%s: failed to parse base: %w", name, err)
+		}
+	}
+	ours, err := tickFromPath(args[1])
+	if err != nil {
+		return fmt.Errorf("%s: failed to read ours: %w", name, err)
+	}
+	theirs, err := tickFromPath(args[2])
+	if err != nil {
+		return fmt.Errorf("%s: failed to read theirs: %w", name, err)
+	}
+
+	merged, err := merge.ThreeWay(base, ours, theirs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if err := merged.Validate(); err != nil {
+		return fmt.Errorf("%s: merged tick is invalid: %w", name, err)
+	}
+	if err := writeTickPath(args[1], merged); err != nil {
+		return fmt.Errorf("%s: failed to write merged: %w", name, err)
+	}
+	return nil
 }
 
 func runMergeFile(cmd *cobra.Command, args []string) error {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/pengelbrecht/ticks/internal/config"
 	"github.com/pengelbrecht/ticks/internal/migrate"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,9 @@ var migrateCmd = &cobra.Command{
 	Short: "Run data migrations",
 	Long: `Run data migrations to upgrade .tick data to the latest format.
 
+Every known migration is checked in order and the pending ones are
+applied, with a migrated/skipped count for each.
+
 Currently supports:
   - run-records: Migrate run records from tick JSON files to .tick/logs/records/
 
@@ -41,46 +45,63 @@ func runMigrate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no .tick directory found - run 'tk init' first")
 	}
 
-	// Check if migration is needed
-	needsMigration, err := migrate.NeedsMigration(tickDir)
+	// A repo recording an older schema version is upgraded even when no
+	// migration finds anything to do
+	fromVersion, latest := 0, migrate.LatestVersion()
+	if cfg, err := config.Load(filepath.Join(tickDir, "config.json")); err == nil && cfg.GetSchemaVersion() < latest {
+		fromVersion = cfg.GetSchemaVersion()
+	}
+
+	var reports []migrate.Report
+	if migrateDryRun {
+		reports, err = migrate.RunAll(tickDir, true)
+	} else {
+		reports, err = migrate.Upgrade(tickDir)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to check migration status: %w", err)
+		return fmt.Errorf("migration failed: %w", err)
 	}
 
-	if !needsMigration {
+	pending := 0
+	for _, r := range reports {
+		if r.Needed {
+			pending++
+		}
+	}
+	if pending == 0 && fromVersion == 0 {
 		fmt.Println("No migrations needed - all data is up to date.")
 		return nil
 	}
 
-	// Run the run record migration
-	fmt.Println("Migrating run records from tick JSON to .tick/logs/records/...")
 	if migrateDryRun {
 		fmt.Println("(dry-run mode - no files will be modified)")
 	}
-
-	m := migrate.NewRunRecordMigration(tickDir)
-	m.SetDryRun(migrateDryRun)
-
-	result, err := m.Run()
-	if err != nil {
-		return fmt.Errorf("migration failed: %w", err)
-	}
-
-	// Report results
-	if migrateDryRun {
-		fmt.Printf("\nDry run complete:\n")
-		fmt.Printf("  Would migrate: %d run records\n", result.Migrated)
-		fmt.Printf("  Would skip: %d ticks (no run record)\n", result.Skipped)
-	} else {
-		fmt.Printf("\nMigration complete:\n")
-		fmt.Printf("  Migrated: %d run records\n", result.Migrated)
-		fmt.Printf("  Skipped: %d ticks (no run record)\n", result.Skipped)
+	for _, r := range reports {
+		if !r.Needed {
+			fmt.Printf("\n%s: up to date\n", r.Name)
+			continue
+		}
+		fmt.Printf("\n%s: %s\n", r.Name, r.Description)
+		if migrateDryRun {
+			fmt.Printf("  Would migrate: %d\n", r.Result.Migrated)
+			fmt.Printf("  Would skip: %d\n", r.Result.Skipped)
+		} else {
+			fmt.Printf("  Migrated: %d\n", r.Result.Migrated)
+			fmt.Printf("  Skipped: %d\n", r.Result.Skipped)
+		}
+		if len(r.Result.Errors) > 0 {
+			fmt.Printf("  Errors: %d\n", len(r.Result.Errors))
+			for _, e := range r.Result.Errors {
+				fmt.Printf("    - %s\n", e)
+			}
+		}
 	}
 
-	if len(result.Errors) > 0 {
-		fmt.Printf("  Errors: %d\n", len(result.Errors))
-		for _, e := range result.Errors {
-			fmt.Printf("    - %s\n", e)
+	if fromVersion != 0 {
+		if migrateDryRun {
+			fmt.Printf("\nWould upgrade schema version %d -> %d\n", fromVersion, latest)
+		} else if cfg, err := config.Load(filepath.Join(tickDir, "config.json")); err == nil && cfg.GetSchemaVersion() == latest {
+			fmt.Printf("\nUpgraded schema version %d -> %d\n", fromVersion, latest)
 		}
 	}
 
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var moveCmd = &cobra.Command{
+	Use:   "move <id> --parent <epic-id>",
+	Short: "Move a tick under a different epic",
+	Long: `Move a tick under a different epic, or detach it.
+
+The new parent must exist and be an epic. Moves that would make a tick
+its own ancestor are rejected. Use --parent "" to detach the tick into
+a standalone task.
+
+Examples:
+  tk move abc123 --parent def456   # Move under epic def456
+  tk move abc123 --parent ""       # Detach into a standalone task`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMove,
+}
+
+var (
+	moveParent string
+	moveJSON   bool
+)
+
+func init() {
+	moveCmd.Flags().StringVar(&moveParent, "parent", "", "new parent epic id (empty to detach)")
+	moveCmd.Flags().BoolVar(&moveJSON, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(moveCmd)
+}
+
+func runMove(cmd *cobra.Command, args []string) error {
+	if !cmd.Flags().Changed("parent") {
+		return NewExitError(ExitUsage, "--parent is required (use --parent \"\" to detach)")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	project, err := github.DetectProject(nil)
+	if err != nil {
+		return NewExitError(ExitGitHub, "failed to detect project: %v", err)
+	}
+
+	id, err := github.NormalizeID(project, args[0])
+	if err != nil {
+		return NewExitError(ExitNotFound, "invalid id: %v", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	t, err := store.Read(id)
+	if err != nil {
+		return NewExitError(ExitNotFound, "failed to read tick: %v", err)
+	}
+
+	parentID := ""
+	if strings.TrimSpace(moveParent) != "" {
+		parentID, err = github.NormalizeID(project, strings.TrimSpace(moveParent))
+		if err != nil {
+			return NewExitError(ExitNotFound, "invalid parent id: %v", err)
+		}
+		parent, err := store.Read(parentID)
+		if err != nil {
+			return NewExitError(ExitNotFound, "failed to read parent: %v", err)
+		}
+		if parent.Type != tick.TypeEpic {
+			return NewExitError(ExitUsage, "parent %s is a %s, not an epic", parentID, parent.Type)
+		}
+		if err := checkParentCycle(store, id, parent); err != nil {
+			return err
+		}
+	}
+
+	t.Parent = parentID
+	t.UpdatedAt = time.Now().UTC()
+	if err := store.Write(t); err != nil {
+		return fmt.Errorf("failed to update tick: %w", err)
+	}
+
+	if moveJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(t); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	if parentID == "" {
+		fmt.Printf("Detached %s\n", t.ID)
+	} else {
+		fmt.Printf("Moved %s under %s\n", t.ID, parentID)
+	}
+	return nil
+}
+
+// checkParentCycle fails if id is parent or one of parent's ancestors.
+func checkParentCycle(store *tick.Store, id string, parent tick.Tick) error {
+	seen := map[string]bool{}
+	for cur := parent; ; {
+		if cur.ID == id {
+			return NewExitError(ExitUsage, "cannot move %s under %s: would create a parent cycle", id, parent.ID)
+		}
+		if cur.Parent == "" || seen[cur.ID] {
+			return nil
+		}
+		seen[cur.ID] = true
+
+		next, err := store.Read(cur.Parent)
+		if err != nil {
+			// Dangling parent: the chain ends here
+			return nil
+		}
+		cur = next
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	epiccontext "github.com/pengelbrecht/ticks/internal/context"
 	"github.com/pengelbrecht/ticks/internal/github"
 	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/tick"
@@ -45,7 +47,30 @@ Examples:
   tk next --awaiting=
 
   # Next ready epic
-  tk next --epic`,
+  tk next --epic
+
+  # Next P0/P1 task only (lower number = more urgent, bounds inclusive)
+  tk next --max-priority high
+
+  # Next ready bug
+  tk next --type bug
+
+Picks the highest-priority ready tick, oldest first within a priority.
+When nothing is ready, stdout is empty (or null with --json) and the
+exit code is 0.
+
+Scheduling (--json --all):
+  Instead of a single tick, emits every open workable task with its
+  dependency wave, whether it is ready, whether it can be dispatched
+  alongside the other dispatchable tasks (no predicted file conflict, from
+  the cached dependency analysis), and whether it needs its own worktree.
+  Not used with --epic or --awaiting.
+
+  # Dispatch plan for an epic
+  tk next epic-123 --json --all
+
+  # Dispatch plan across all owners and epics
+  tk next --json --all`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runNext,
 }
@@ -54,7 +79,10 @@ var (
 	nextAll           bool
 	nextOwner         string
 	nextEpic          bool
+	nextType          string
 	nextIncludeManual bool
+	nextMinPriority   string
+	nextMaxPriority   string
 	nextAwaiting      string
 	nextJSON          bool
 )
@@ -66,7 +94,10 @@ func init() {
 	nextCmd.Flags().BoolVarP(&nextAll, "all", "a", false, "all owners")
 	nextCmd.Flags().StringVarP(&nextOwner, "owner", "o", "", "owner")
 	nextCmd.Flags().BoolVarP(&nextEpic, "epic", "e", false, "show next ready epic")
+	nextCmd.Flags().StringVarP(&nextType, "type", "t", "", "type (task|epic|bug|feature|chore)")
 	nextCmd.Flags().BoolVar(&nextIncludeManual, "include-manual", false, "include tasks marked as manual (excluded by default)")
+	nextCmd.Flags().StringVar(&nextMinPriority, "min-priority", "", "lowest priority number to include, inclusive (e.g. 0, P0, critical)")
+	nextCmd.Flags().StringVar(&nextMaxPriority, "max-priority", "", "highest priority number to include, inclusive (e.g. 1, P1, high)")
 	nextCmd.Flags().StringVar(&nextAwaiting, "awaiting", "", "get next task awaiting human (empty = any type, or specific type(s) comma-separated)")
 	nextCmd.Flags().BoolVar(&nextJSON, "json", false, "output as JSON")
 
@@ -77,6 +108,11 @@ func runNext(cmd *cobra.Command, args []string) error {
 	// Track whether --awaiting was explicitly set (even if empty)
 	nextAwaitingSet = cmd.Flags().Changed("awaiting")
 
+	nextTypeVal := strings.TrimSpace(nextType)
+	if nextEpic && nextTypeVal != "" && nextTypeVal != tick.TypeEpic {
+		return NewExitError(ExitUsage, "--epic cannot be combined with --type %s", nextTypeVal)
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to detect repo root: %w", err)
@@ -98,8 +134,13 @@ func runNext(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list ticks: %w", err)
 	}
 
+	minPriority, maxPriority, err := parsePriorityRange(nextMinPriority, nextMaxPriority)
+	if err != nil {
+		return err
+	}
+
 	// Determine filter based on flags and positional args
-	filter := query.Filter{Owner: owner}
+	filter := query.Filter{Owner: owner, Type: nextTypeVal, MinPriority: minPriority, MaxPriority: maxPriority}
 
 	if nextEpic {
 		// Next ready epic
@@ -149,7 +190,8 @@ func runNext(cmd *cobra.Command, args []string) error {
 				fmt.Println("null")
 				return nil
 			}
-			fmt.Println("No awaiting ticks")
+			// Keep stdout empty so scripts can test for a result
+			fmt.Fprintln(os.Stderr, "No awaiting ticks")
 			return nil
 		}
 
@@ -165,6 +207,19 @@ func runNext(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Orchestrator mode: the whole wave dispatch plan
+	if nextJSON && nextAll && !nextEpic {
+		plan, err := buildWavePlan(filepath.Join(root, ".tick"), filtered, ticks, nextIncludeManual)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(plan); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
 	// Agent mode: return next ready task (not awaiting)
 	ready := query.Ready(filtered, ticks)
 
@@ -195,7 +250,7 @@ func runNext(cmd *cobra.Command, args []string) error {
 			fmt.Println("null")
 			return nil
 		}
-		fmt.Println("No ready ticks")
+		fmt.Fprintln(os.Stderr, "No ready ticks")
 		return nil
 	}
 
@@ -212,3 +267,153 @@ func runNext(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s  P%d %s  %s\n", next.ID, next.Priority, next.Type, next.Title)
 	return nil
 }
+
+// waveTask is one task in a wave dispatch plan.
+type waveTask struct {
+	ID            string   `json:"id"`
+	Title         string   `json:"title"`
+	Priority      int      `json:"priority"`
+	Parent        string   `json:"parent,omitempty"`
+	Wave          int      `json:"wave"`
+	Ready         bool     `json:"ready"`
+	Dispatchable  bool     `json:"dispatchable"`
+	NeedsWorktree bool     `json:"needs_worktree"`
+	ConflictsWith []string `json:"conflicts_with,omitempty"`
+}
+
+// wavePlan is the JSON output of tk next --json --all.
+type wavePlan struct {
+	Tasks []waveTask `json:"tasks"`
+	// Cycle lists tasks caught in a dependency cycle (reported with wave 0).
+	Cycle []string `json:"cycle,omitempty"`
+}
+
+// buildWavePlan groups the open workable tasks in scope into dependency waves
+// and marks which ready tasks can be dispatched together. A ready task is not
+// dispatchable if the cached dependency analysis predicts a file conflict with
+// a higher-priority dispatchable task. When more than one task is dispatchable,
+// each needs its own worktree.
+func buildWavePlan(tickDir string, scope, all []tick.Tick, includeManual bool) (wavePlan, error) {
+	var tasks []tick.Tick
+	for _, t := range scope {
+		if t.Status != tick.StatusOpen || t.Type == tick.TypeEpic || t.IsAwaitingHuman() {
+			continue
+		}
+		if t.Manual && !includeManual {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+
+	readySet := make(map[string]bool)
+	for _, t := range query.Ready(tasks, all) {
+		readySet[t.ID] = true
+	}
+
+	conflicts, err := loadConflicts(tickDir, tasks)
+	if err != nil {
+		return wavePlan{}, err
+	}
+
+	waves, cycle := query.Waves(tasks)
+	plan := wavePlan{Tasks: []waveTask{}, Cycle: cycle}
+	dispatched := make(map[string]bool)
+
+	for i, wave := range waves {
+		for _, t := range wave {
+			wt := waveTask{
+				ID:            t.ID,
+				Title:         t.Title,
+				Priority:      t.Priority,
+				Parent:        t.Parent,
+				Wave:          i + 1,
+				Ready:         readySet[t.ID],
+				ConflictsWith: conflicts[t.ID],
+			}
+			if wt.Ready {
+				wt.Dispatchable = true
+				for _, other := range wt.ConflictsWith {
+					if dispatched[other] {
+						wt.Dispatchable = false
+						break
+					}
+				}
+				if wt.Dispatchable {
+					dispatched[t.ID] = true
+				}
+			}
+			plan.Tasks = append(plan.Tasks, wt)
+		}
+	}
+
+	cycleSet := make(map[string]bool, len(cycle))
+	for _, id := range cycle {
+		cycleSet[id] = true
+	}
+	for _, t := range tasks {
+		if cycleSet[t.ID] {
+			plan.Tasks = append(plan.Tasks, waveTask{
+				ID:            t.ID,
+				Title:         t.Title,
+				Priority:      t.Priority,
+				Parent:        t.Parent,
+				ConflictsWith: conflicts[t.ID],
+			})
+		}
+	}
+
+	if len(dispatched) > 1 {
+		for i := range plan.Tasks {
+			plan.Tasks[i].NeedsWorktree = plan.Tasks[i].Dispatchable
+		}
+	}
+
+	return plan, nil
+}
+
+// loadConflicts returns predicted file conflicts between tasks, read from the
+// cached dependency analysis of each task's epic. Only conflicts between tasks
+// in the given list are returned, as sorted ID lists.
+func loadConflicts(tickDir string, tasks []tick.Tick) (map[string][]string, error) {
+	inScope := make(map[string]bool, len(tasks))
+	parents := make(map[string]bool)
+	for _, t := range tasks {
+		inScope[t.ID] = true
+		if t.Parent != "" {
+			parents[t.Parent] = true
+		}
+	}
+
+	sets := make(map[string]map[string]bool)
+	add := func(a, b string) {
+		if sets[a] == nil {
+			sets[a] = make(map[string]bool)
+		}
+		sets[a][b] = true
+	}
+
+	for parent := range parents {
+		analysis, err := epiccontext.LoadAnalysis(tickDir, parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dependency analysis for %s: %w", parent, err)
+		}
+		if analysis == nil {
+			continue
+		}
+		for _, pair := range analysis.ConflictingPairs {
+			if inScope[pair.Task1] && inScope[pair.Task2] {
+				add(pair.Task1, pair.Task2)
+				add(pair.Task2, pair.Task1)
+			}
+		}
+	}
+
+	conflicts := make(map[string][]string, len(sets))
+	for id, set := range sets {
+		for other := range set {
+			conflicts[id] = append(conflicts[id], other)
+		}
+		sort.Strings(conflicts[id])
+	}
+	return conflicts, nil
+}
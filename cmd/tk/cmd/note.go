@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,6 +21,10 @@ var noteCmd = &cobra.Command{
 	Short: "Add a timestamped note to a tick",
 	Long: `Add a timestamped note to a tick.
 
+Notes are appended as "<timestamp> - (from: <owner>) <message>", the same
+format the cloud uses, so local and remote notes interleave cleanly.
+Pass "-" as the message to read it from stdin.
+
 The --from flag marks the source of the note for agent-human handoffs:
   - agent: Context about work, questions, PR links (default)
   - human: Feedback, answers, direction for the agent
@@ -33,6 +39,9 @@ Examples:
   # Human answering a question
   tk note abc123 "Use Stripe for payment processing" --from human
 
+  # Read a longer note from stdin
+  git log -1 --format=%B | tk note abc123 -
+
   # Edit notes in $EDITOR
   tk note abc123 --edit
 
@@ -44,11 +53,13 @@ Note: tk reject <id> "feedback" automatically adds a human-marked note.`,
 var (
 	noteEdit bool
 	noteFrom string
+	noteJSON bool
 )
 
 func init() {
 	noteCmd.Flags().BoolVar(&noteEdit, "edit", false, "edit notes in $EDITOR")
 	noteCmd.Flags().StringVar(&noteFrom, "from", "agent", "note author: agent or human")
+	noteCmd.Flags().BoolVar(&noteJSON, "json", false, "output the updated tick as JSON")
 	rootCmd.AddCommand(noteCmd)
 }
 
@@ -111,13 +122,21 @@ func runNote(cmd *cobra.Command, args []string) error {
 		if err := store.Write(t); err != nil {
 			return fmt.Errorf("failed to update tick: %w", err)
 		}
-		return nil
+		return printNoteResult(t)
 	}
 
 	if len(args) < 2 {
 		return fmt.Errorf("note text is required")
 	}
-	note := strings.TrimSpace(strings.Join(args[1:], " "))
+	note := strings.Join(args[1:], " ")
+	if len(args) == 2 && args[1] == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read note from stdin: %w", err)
+		}
+		note = string(data)
+	}
+	note = strings.TrimSpace(note)
 	if note == "" {
 		return fmt.Errorf("note text is required")
 	}
@@ -132,7 +151,11 @@ func runNote(cmd *cobra.Command, args []string) error {
 	if noteFrom == "human" {
 		line = fmt.Sprintf("%s - [human] %s", timestamp, note)
 	} else {
-		line = fmt.Sprintf("%s - %s", timestamp, note)
+		owner, err := github.DetectOwner(nil)
+		if err != nil {
+			return fmt.Errorf("failed to detect owner: %w", err)
+		}
+		line = fmt.Sprintf("%s - (from: %s) %s", timestamp, owner, note)
 	}
 	if strings.TrimSpace(t.Notes) == "" {
 		t.Notes = line
@@ -143,5 +166,17 @@ func runNote(cmd *cobra.Command, args []string) error {
 	if err := store.Write(t); err != nil {
 		return fmt.Errorf("failed to update tick: %w", err)
 	}
+	return printNoteResult(t)
+}
+
+// printNoteResult emits the updated tick as JSON when --json is set.
+func printNoteResult(t tick.Tick) error {
+	if !noteJSON {
+		return nil
+	}
+	enc := json.NewEncoder(os.Stdout)
+	if err := enc.Encode(t); err != nil {
+		return fmt.Errorf("failed to encode json: %w", err)
+	}
 	return nil
 }
@@ -94,7 +94,7 @@ func runReady(cmd *cobra.Command, args []string) error {
 	filter := query.Filter{
 		Owner:         owner,
 		Label:         strings.TrimSpace(readyLabel),
-		LabelAny:      splitCSV(readyLabelAny),
+		LabelsAny:     splitCSV(readyLabelAny),
 		TitleContains: strings.TrimSpace(readyTitleContains),
 		DescContains:  strings.TrimSpace(readyDescContains),
 		NotesContains: strings.TrimSpace(readyNotesContains),
@@ -116,14 +116,14 @@ func runReady(cmd *cobra.Command, args []string) error {
 	}
 
 	if readyJSON {
-		output := listOutput{Ticks: ready}
+		output := listOutput{Ticks: newListTicks(ready, ticks)}
 		// Include filter metadata if any search filters are present
-		if filter.TitleContains != "" || filter.DescContains != "" || filter.NotesContains != "" || len(filter.LabelAny) > 0 {
+		if filter.TitleContains != "" || filter.DescContains != "" || filter.NotesContains != "" || len(filter.LabelsAny) > 0 {
 			output.Filters = &listFilter{
 				TitleContains: filter.TitleContains,
 				DescContains:  filter.DescContains,
 				NotesContains: filter.NotesContains,
-				LabelAny:      filter.LabelAny,
+				LabelAny:      filter.LabelsAny,
 			}
 		}
 		enc := json.NewEncoder(os.Stdout)
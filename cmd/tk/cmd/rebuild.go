@@ -8,17 +8,18 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
 var rebuildCmd = &cobra.Command{
-	Use:   "rebuild",
-	Short: "Rebuild the .tick index",
+	Use:     "rebuild",
+	Aliases: []string{"reindex"},
+	Short:   "Rebuild the .tick index",
 	Long: `Rebuild the .tick index file.
 
 Regenerates the .index.json file from all tick files in the .tick/issues directory.
-Use this if the index becomes out of sync with the actual tick files.`,
+Once the index exists, listing re-reads only tick files whose mtime or size
+changed and keeps the index up to date. Delete .tick/.index.json to disable it.`,
 	Args: cobra.NoArgs,
 	RunE: runRebuild,
 }
@@ -37,18 +38,13 @@ func runRebuild(cmd *cobra.Command, args []string) error {
 	}
 
 	store := tick.NewStore(filepath.Join(root, ".tick"))
-	ticks, err := store.List()
+	count, err := store.Reindex()
 	if err != nil {
-		return fmt.Errorf("failed to list ticks: %w", err)
-	}
-
-	indexPath := filepath.Join(root, ".tick", ".index.json")
-	if err := query.SaveIndex(indexPath, ticks); err != nil {
-		return fmt.Errorf("failed to write index: %w", err)
+		return fmt.Errorf("failed to rebuild index: %w", err)
 	}
 
 	if rebuildJSON {
-		payload := map[string]any{"count": len(ticks)}
+		payload := map[string]any{"count": count}
 		enc := json.NewEncoder(os.Stdout)
 		if err := enc.Encode(payload); err != nil {
 			return fmt.Errorf("failed to encode json: %w", err)
@@ -56,6 +52,6 @@ func runRebuild(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Printf("Rebuilt index with %d ticks\n", len(ticks))
+	fmt.Printf("Rebuilt index with %d ticks\n", count)
 	return nil
 }
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/runrecord"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var renameIDCmd = &cobra.Command{
+	Use:   "rename-id <old> <new>",
+	Short: "Change a tick's id and update references to it",
+	Long: `Change a tick's id.
+
+The tick is written under the new id and the old file is removed. Every
+other tick that refers to the old id as a blocker, parent or discovered-from
+source is rewritten to use the new id, and the tick's run records under
+.tick/logs/records are moved with it. The new id must be unused and made of
+lowercase letters and digits, optionally with a type prefix (e.g. bug-a1b).
+
+Examples:
+  tk rename-id a1b x9z            # Rename a1b to x9z
+  tk rename-id a1b x9z --dry-run  # Show the reference updates only`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRenameID,
+}
+
+var (
+	renameIDDryRun bool
+	renameIDJSON   bool
+)
+
+// renameIDOutput is the JSON output of tk rename-id.
+type renameIDOutput struct {
+	From    string        `json:"from"`
+	To      string        `json:"to"`
+	Updated []renameIDRef `json:"updated"`
+	DryRun  bool          `json:"dry_run,omitempty"`
+}
+
+// renameIDRef is a tick whose references to the old id were rewritten.
+type renameIDRef struct {
+	ID     string   `json:"id"`
+	Fields []string `json:"fields"`
+}
+
+func init() {
+	renameIDCmd.Flags().BoolVar(&renameIDDryRun, "dry-run", false, "show the reference updates without writing")
+	renameIDCmd.Flags().BoolVar(&renameIDJSON, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(renameIDCmd)
+}
+
+func runRenameID(cmd *cobra.Command, args []string) error {
+	root, err := repoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect repo root: %w", err)
+	}
+
+	project, err := github.DetectProject(nil)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+
+	oldID, err := github.NormalizeID(project, args[0])
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+	newID, err := github.NormalizeID(project, args[1])
+	if err != nil {
+		return NewExitError(ExitUsage, "invalid new id: %v", err)
+	}
+	if !tick.ValidID(newID) {
+		return NewExitError(ExitUsage, "invalid new id %q: use lowercase letters and digits, optionally with a prefix (e.g. bug-a1b)", newID)
+	}
+	if newID == oldID {
+		return NewExitError(ExitUsage, "new id is the same as the old id")
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	target, err := store.Read(oldID)
+	if err != nil {
+		return fmt.Errorf("failed to read tick: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".tick", "issues", newID+".json")); err == nil {
+		return NewExitError(ExitUsage, "id %s is already in use", newID)
+	}
+
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	var affected []tick.Tick
+	out := renameIDOutput{From: oldID, To: newID, Updated: []renameIDRef{}, DryRun: renameIDDryRun}
+	for _, t := range ticks {
+		if t.ID == oldID {
+			continue
+		}
+		fields := rewriteTickRefs(&t, oldID, newID)
+		if len(fields) == 0 {
+			continue
+		}
+		affected = append(affected, t)
+		out.Updated = append(out.Updated, renameIDRef{ID: t.ID, Fields: fields})
+	}
+
+	if renameIDDryRun {
+		return printRenameIDResult(out)
+	}
+
+	// Write the new tick first so a failure never loses the original
+	now := time.Now().UTC()
+	target.ID = newID
+	target.UpdatedAt = now
+	if err := store.Write(target); err != nil {
+		return fmt.Errorf("failed to write tick: %w", err)
+	}
+	for _, t := range affected {
+		t.UpdatedAt = now
+		if err := store.Write(t); err != nil {
+			return fmt.Errorf("failed to update %s: %w", t.ID, err)
+		}
+	}
+	if err := store.Delete(oldID); err != nil {
+		return fmt.Errorf("failed to remove old tick: %w", err)
+	}
+
+	// Move the run records so the board and tk log follow the tick
+	if err := runrecord.NewStore(root).Rename(oldID, newID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	return printRenameIDResult(out)
+}
+
+// rewriteTickRefs replaces references to oldID in t and returns the names of
+// the fields it changed.
+func rewriteTickRefs(t *tick.Tick, oldID, newID string) []string {
+	var fields []string
+	if t.Parent == oldID {
+		t.Parent = newID
+		fields = append(fields, "parent")
+	}
+	if t.DiscoveredFrom == oldID {
+		t.DiscoveredFrom = newID
+		fields = append(fields, "discovered_from")
+	}
+	changed := false
+	for i, b := range t.BlockedBy {
+		if b == oldID {
+			t.BlockedBy[i] = newID
+			changed = true
+		}
+	}
+	if changed {
+		fields = append(fields, "blocked_by")
+	}
+	return fields
+}
+
+// printRenameIDResult reports a rename (or planned rename) as text or JSON.
+func printRenameIDResult(out renameIDOutput) error {
+	if renameIDJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	verb := "Renamed"
+	if out.DryRun {
+		verb = "Would rename"
+	}
+	fmt.Printf("%s %s to %s\n", verb, out.From, out.To)
+	for _, ref := range out.Updated {
+		fmt.Printf("  %s: %s\n", ref.ID, strings.Join(ref.Fields, ", "))
+	}
+	return nil
+}
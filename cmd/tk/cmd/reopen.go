@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -18,18 +19,29 @@ var reopenCmd = &cobra.Command{
 	Short: "Reopen a closed tick",
 	Long: `Reopen a closed tick.
 
+A "Reopened" note is appended to the tick with the acting user, including
+the reason if given.
+Any requires gate is kept, so the next close routes to a human again.
+Use --reset-gates to also clear requires, awaiting and verdict.
+
 Examples:
-  tk reopen abc123          # Reopen tick
-  tk reopen abc123 --json   # Output reopened tick as JSON`,
+  tk reopen abc123                           # Reopen tick
+  tk reopen abc123 --reason "still failing"  # Record why it was reopened
+  tk reopen abc123 --reset-gates             # Reopen without the requires gate
+  tk reopen abc123 --json                    # Output reopened tick as JSON`,
 	Args: cobra.ExactArgs(1),
 	RunE: runReopen,
 }
 
 var (
-	reopenJSON bool
+	reopenReason     string
+	reopenResetGates bool
+	reopenJSON       bool
 )
 
 func init() {
+	reopenCmd.Flags().StringVar(&reopenReason, "reason", "", "why the tick is being reopened (added as a note)")
+	reopenCmd.Flags().BoolVar(&reopenResetGates, "reset-gates", false, "also clear requires, awaiting and verdict")
 	reopenCmd.Flags().BoolVar(&reopenJSON, "json", false, "output as JSON")
 
 	rootCmd.AddCommand(reopenCmd)
@@ -60,9 +72,25 @@ func runReopen(cmd *cobra.Command, args []string) error {
 	t.Status = tick.StatusOpen
 	t.ClosedAt = nil
 	t.ClosedReason = ""
+	if reopenResetGates {
+		t.Requires = nil
+		t.ClearAwaiting()
+		t.Verdict = nil
+	}
 	t.UpdatedAt = time.Now().UTC()
 
-	if err := store.Write(t); err != nil {
+	note := "Reopened"
+	if reason := strings.TrimSpace(reopenReason); reason != "" {
+		note = "Reopened: " + reason
+	}
+	line := fmt.Sprintf("%s - (from: %s) %s", time.Now().Format("2006-01-02 15:04"), currentActor(), note)
+	if strings.TrimSpace(t.Notes) == "" {
+		t.Notes = line
+	} else {
+		t.Notes = strings.TrimRight(t.Notes, "\n") + "\n" + line
+	}
+
+	if err := store.WriteAs(t, currentActor()); err != nil {
 		return fmt.Errorf("failed to reopen tick: %w", err)
 	}
 
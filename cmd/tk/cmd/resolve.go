@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+// resolveOwner resolves the owner to use based on flags.
+// The literal "@me" resolves to the current user.
+func resolveOwner(allOwners bool, ownerFlag string) (string, error) {
+	if allOwners {
+		return "", nil
+	}
+	owner := strings.TrimSpace(ownerFlag)
+	if owner != "" && owner != "@me" {
+		return owner, nil
+	}
+	return github.DetectOwner(nil)
+}
+
+// resolveOwners resolves a list of owner flags, defaulting to the current
+// user. Returns nil (no owner filter) when allOwners is set.
+func resolveOwners(allOwners bool, ownerFlags []string) ([]string, error) {
+	if allOwners {
+		return nil, nil
+	}
+	if len(ownerFlags) == 0 {
+		ownerFlags = []string{""}
+	}
+	owners := make([]string, 0, len(ownerFlags))
+	for _, flag := range ownerFlags {
+		owner, err := resolveOwner(false, flag)
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, owner)
+	}
+	return owners, nil
+}
+
+// currentActor returns the user that writes are attributed to in the
+// activity log. It returns "" when the user can't be detected, in which
+// case the store falls back to the tick's owner.
+func currentActor() string {
+	owner, err := github.DetectOwner(nil)
+	if err != nil {
+		return ""
+	}
+	return owner
+}
+
+// resolveTickID turns an id argument into a tick ID. Global ids are
+// normalized first, then a unique prefix of an ID (e.g. "a1" for "a1b") is
+// expanded. An ambiguous prefix is a usage error listing the candidates.
+func resolveTickID(store *tick.Store, project, arg string) (string, error) {
+	id, err := github.NormalizeID(project, arg)
+	if err != nil {
+		return "", fmt.Errorf("invalid id: %w", err)
+	}
+	resolved, err := store.ResolvePrefix(id)
+	if errors.Is(err, tick.ErrAmbiguousPrefix) {
+		return "", NewExitError(ExitUsage, "%v", err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read tick: %w", err)
+	}
+	return resolved, nil
+}
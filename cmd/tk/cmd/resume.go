@@ -16,7 +16,6 @@ import (
 	"github.com/pengelbrecht/ticks/internal/budget"
 	"github.com/pengelbrecht/ticks/internal/checkpoint"
 	"github.com/pengelbrecht/ticks/internal/engine"
-	"github.com/pengelbrecht/ticks/internal/gc"
 	"github.com/pengelbrecht/ticks/internal/ticks"
 )
 
@@ -68,20 +67,14 @@ type resumeOutput struct {
 }
 
 func runResume(cmd *cobra.Command, args []string) error {
-	// Start async garbage collection
-	go func() {
-		root, err := repoRoot()
-		if err != nil {
-			return
-		}
-		_, _ = gc.Cleanup(root, gc.DefaultMaxAge)
-	}()
-
 	root, err := repoRoot()
 	if err != nil {
 		return NewExitError(ExitNoRepo, "not in a git repository: %v", err)
 	}
 
+	// Start async garbage collection
+	go autoCleanup(root)
+
 	checkpointID := args[0]
 
 	// Load the checkpoint
@@ -130,7 +123,7 @@ func runResume(cmd *cobra.Command, args []string) error {
 
 	// Enable verification unless skipped
 	if !resumeSkipVerify {
-		eng.EnableVerification()
+		enableVerification(eng, ticksClient, filepath.Join(root, ".tick"))
 	}
 
 	// Set up output streaming for non-JSONL mode
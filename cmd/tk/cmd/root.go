@@ -1,27 +1,42 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/styles"
 )
 
 // Version is set at build time via ldflags
 var Version = "dev"
 
-// Exit codes matching the legacy CLI behavior
+// Exit codes returned by every command. Scripts can rely on these.
 const (
-	ExitSuccess  = 0
-	ExitGeneric  = 1
-	ExitUsage    = 2
-	ExitNoRepo   = 3
+	// ExitSuccess means the command completed.
+	ExitSuccess = 0
+	// ExitGeneric is any failure that has no more specific code.
+	ExitGeneric = 1
+	// ExitUsage means bad arguments or flags: unknown flags, missing
+	// arguments, invalid values or conflicting options.
+	ExitUsage = 2
+	// ExitNoRepo means the working directory is not inside a git repository.
+	ExitNoRepo = 3
+	// ExitNotFound means a tick or file named on the command line does not exist.
 	ExitNotFound = 4
-	ExitGitHub   = 5
-	ExitIO       = 6
+	// ExitGitHub means the project or owner could not be detected from git.
+	ExitGitHub = 5
+	// ExitIO means reading, parsing or writing tick files or command output
+	// failed, including encoding --json output.
+	ExitIO = 6
 )
 
 // ExitError is an error that carries a specific exit code.
@@ -44,16 +59,35 @@ func NewExitError(code int, format string, args ...interface{}) error {
 }
 
 // GetExitCode returns the exit code from an error.
-// If the error is an ExitError, it returns that code.
-// For Cobra argument/flag validation errors, returns ExitUsage (2).
-// Otherwise, it returns ExitGeneric (1).
+// An ExitError anywhere in the chain decides the code. Otherwise wrapped
+// errors are classified: ErrNotRepo is ExitNoRepo, a github.DetectError
+// is ExitGitHub, a missing file is
+// ExitNotFound, and filesystem or JSON failures are ExitIO. JSON marshal and
+// encode errors share ExitIO with file errors on purpose; see the exit-code
+// table in SPEC.md.
+// Cobra argument/flag validation errors return ExitUsage (2).
+// Anything else returns ExitGeneric (1).
 func GetExitCode(err error) int {
 	if err == nil {
 		return ExitSuccess
 	}
-	if exitErr, ok := err.(ExitError); ok {
+	var exitErr ExitError
+	if errors.As(err, &exitErr) {
 		return exitErr.Code
 	}
+	if errors.Is(err, ErrNotRepo) {
+		return ExitNoRepo
+	}
+	var detectErr *github.DetectError
+	if errors.As(err, &detectErr) {
+		return ExitGitHub
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return ExitNotFound
+	}
+	if isIOError(err) {
+		return ExitIO
+	}
 	// Check for Cobra argument validation errors
 	errMsg := err.Error()
 	if strings.Contains(errMsg, "accepts ") && strings.Contains(errMsg, "arg(s)") {
@@ -62,12 +96,29 @@ func GetExitCode(err error) int {
 	if strings.Contains(errMsg, "requires at least") && strings.Contains(errMsg, "arg(s)") {
 		return ExitUsage
 	}
-	if strings.Contains(errMsg, "unknown flag") || strings.Contains(errMsg, "invalid argument") {
+	if strings.Contains(errMsg, "unknown flag") || strings.Contains(errMsg, "unknown shorthand flag") ||
+		strings.Contains(errMsg, "invalid argument") || strings.Contains(errMsg, "flag needs an argument") {
 		return ExitUsage
 	}
 	return ExitGeneric
 }
 
+// isIOError reports whether err came from the filesystem or from encoding or
+// parsing JSON.
+func isIOError(err error) bool {
+	var pathErr *fs.PathError
+	var linkErr *os.LinkError
+	var syscallErr *os.SyscallError
+	var unsupportedType *json.UnsupportedTypeError
+	var unsupportedValue *json.UnsupportedValueError
+	var marshalerErr *json.MarshalerError
+	var syntaxErr *json.SyntaxError
+	var unmarshalType *json.UnmarshalTypeError
+	return errors.As(err, &pathErr) || errors.As(err, &linkErr) || errors.As(err, &syscallErr) ||
+		errors.As(err, &unsupportedType) || errors.As(err, &unsupportedValue) || errors.As(err, &marshalerErr) ||
+		errors.As(err, &syntaxErr) || errors.As(err, &unmarshalType)
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "tk",
@@ -101,8 +152,15 @@ Human-Only Tasks (awaiting=work):
     tk list --awaiting work             # List human-only tasks`,
 	Version: Version,
 	// Run is intentionally not set - this allows subcommands or help to be shown
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		styles.SetPlain(noColor || styles.NoColorEnv())
+		return checkSchemaVersion(cmd)
+	},
 }
 
+// noColor disables colored output for every command (see also NO_COLOR).
+var noColor bool
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -130,6 +188,33 @@ func ExecuteArgs(args []string) error {
 	return rootCmd.Execute()
 }
 
+// SplitGlobalFlags splits the persistent flags (such as --no-color or
+// --migrate) that lead args from the command and its own args, so the caller
+// can route on the command name wherever the global flags are placed.
+func SplitGlobalFlags(args []string) (flags, rest []string) {
+	i := 0
+	for i < len(args) {
+		arg := args[i]
+		var f *pflag.Flag
+		switch {
+		case strings.HasPrefix(arg, "--") && len(arg) > 2:
+			name, _, _ := strings.Cut(arg[2:], "=")
+			f = rootCmd.PersistentFlags().Lookup(name)
+		case strings.HasPrefix(arg, "-") && len(arg) == 2:
+			f = rootCmd.PersistentFlags().ShorthandLookup(arg[1:])
+		}
+		if f == nil {
+			break
+		}
+		i++
+		// Non-boolean flags take the next arg as their value unless given inline
+		if f.Value.Type() != "bool" && !strings.Contains(arg, "=") && i < len(args) {
+			i++
+		}
+	}
+	return args[:i], args[i:]
+}
+
 // resetCobraFlags resets the Cobra flag tracking for a command and all its subcommands.
 // This is necessary because Cobra's Changed() tracking persists across multiple
 // Execute() calls in the same process.
@@ -146,21 +231,37 @@ func resetCobraFlags(cmd *cobra.Command) {
 // This must be called before each command execution to prevent flag
 // values from persisting across multiple executions in the same process.
 func ResetFlags() {
+	// Reset global flags
+	noColor = false
+	autoMigrate = false
+
 	// Reset list flags
 	listAll = false
-	listOwner = ""
-	listStatus = ""
+	listOwner = nil
+	listStatus = nil
 	listPriority = -1
+	listMinPriority = ""
+	listMaxPriority = ""
 	listType = ""
 	listLabel = ""
 	listLabelAny = ""
+	listLabelsAll = nil
+	listLabelsAny = nil
 	listParent = ""
 	listTitleContains = ""
 	listDescContains = ""
 	listNotesContains = ""
 	listManual = false
-	listAwaiting = ""
+	listAwaiting = nil
 	listJSON = false
+	listFormat = ""
+	listEpicsOnly = false
+	listNoEpics = false
+	listUpdatedBy = ""
+	listUpdatedWithin = ""
+	listSince = ""
+	listAgentReady = false
+	listWatch = false
 	listAwaitingSet = false
 
 	// Reset create flags
@@ -179,6 +280,11 @@ func ResetFlags() {
 	createRequires = ""
 	createAwaiting = ""
 	createJSON = false
+	createCheckDup = false
+	createForce = false
+	createFromStdin = false
+	createFromFile = ""
+	createInteractive = false
 
 	// Reset update flags
 	updateTitle = ""
@@ -199,6 +305,13 @@ func ResetFlags() {
 	updateAwaiting = ""
 	updateVerdict = ""
 	updateJSON = false
+	updateFilterStatus = nil
+	updateFilterType = ""
+	updateFilterPriority = -1
+	updateFilterLabel = ""
+	updateFilterParent = ""
+	updateFilterOwner = ""
+	updateDryRun = false
 	updateTitleSet = false
 	updateDescriptionSet = false
 	updateNotesSet = false
@@ -236,7 +349,10 @@ func ResetFlags() {
 	nextAwaiting = ""
 	nextAwaitingSet = false
 	nextEpic = false
+	nextType = ""
 	nextIncludeManual = false
+	nextMinPriority = ""
+	nextMaxPriority = ""
 	nextJSON = false
 
 	// Reset blocked flags
@@ -247,23 +363,87 @@ func ResetFlags() {
 	// Reset note flags
 	noteEdit = false
 	noteFrom = "agent"
+	noteJSON = false
 
 	// Reset close flags
 	closeReason = ""
 	closeForce = false
+	closeCascade = false
+	closeDiscover = ""
 	closeJSON = false
 
 	// Reset show flags
 	showJSON = false
+	showChildren = false
+	showBlockersTree = false
+	showHistory = false
+	showRelated = false
+
+	// Reset worktree flags
+	worktreeListJSON = false
+	worktreePruneDry = false
+	worktreePruneJSON = false
+
+	// Reset rename-id flags
+	renameIDDryRun = false
+	renameIDJSON = false
 
 	// Reset reopen flags
+	reopenReason = ""
+	reopenResetGates = false
 	reopenJSON = false
 
 	// Reset delete flags
+	deleteYes = false
 	deleteForce = false
+	deleteDryRun = false
+	deleteJSON = false
+
+	// Reset export flags
+	exportAll = false
+
+	// Reset duplicate flags
+	duplicateTitle = ""
+	duplicateCount = 1
+	duplicateJSON = false
+
+	// Reset search flags
+	searchRegex = false
+	searchType = ""
+	searchStatus = nil
+	searchJSON = false
+
+	// Reset validate flags
+	validateJSON = false
+	validateFix = false
+	validateDryRun = false
+
+	// Reset log flags
+	logJSON = false
+
+	// Reset costs flags
+	costsSince = ""
+	costsEpic = ""
+	costsOwner = ""
+	costsBy = "tick"
+	costsJSON = false
+
+	// Reset move flags
+	moveParent = ""
+	moveJSON = false
+	epicSplitTitle = ""
+	epicSplitDryRun = false
+	epicSplitJSON = false
+	epicMergeCloseSource = false
+	epicMergeDryRun = false
+	epicMergeJSON = false
 
 	// Reset deps flags
 	depsJSON = false
+	depsRecursive = false
+	depsAnalyze = false
+	depsDryRun = false
+	depsTimeout = 3 * time.Minute
 
 	// Reset graph flags
 	graphAll = false
@@ -275,9 +455,25 @@ func ResetFlags() {
 	// Reset stats flags
 	statsAll = false
 	statsJSON = false
+	statsByOwner = false
+
+	// Reset bump flags
+	bumpUp = false
+	bumpDown = false
+	bumpSet = ""
+	bumpJSON = false
+
+	// Reset stale flags
+	staleDays = 14
+	staleStatus = ""
+	staleAwaitingOnly = false
+	staleAll = false
+	staleOwner = ""
+	staleJSON = false
 
 	// Reset labels flags
 	labelsJSON = false
+	labelListJSON = false
 
 	// Reset view flags
 	viewAll = false
@@ -290,6 +486,8 @@ func ResetFlags() {
 
 	// Reset import flags
 	importJSON = false
+	importFormat = "auto"
+	importOnConflict = "skip"
 
 	// Reset approve flags
 	approveJSON = false
@@ -297,29 +495,49 @@ func ResetFlags() {
 	// Reset reject flags
 	rejectJSON = false
 
+	// Reset verdict flags
+	verdictApprove = false
+	verdictReject = false
+	verdictReason = ""
+	verdictJSON = false
+
 	// Reset rebuild flags
 	rebuildJSON = false
 
 	// Reset whoami flags
 	whoamiJSON = false
+	whoamiRepo = false
 
 	// Reset init flags
 	importBeads = false
+	initForce = false
+
+	// Reset migrate flags
+	migrateDryRun = false
 
 	// Reset gc flags
 	gcDryRun = false
 	gcMaxAge = "30d"
+	gcSuccessMaxAge = ""
+	gcFailureMaxAge = ""
+	gcKeepSuccessful = -1
 
 	// Reset run flags
 	runMaxIterations = 50
 	runMaxCost = 0
+	runTotalMaxCost = 0
+	runMaxDuration = 0
 	runCheckpointEvery = 5
 	runMaxTaskRetries = 3
 	runAuto = false
 	runJSONL = false
+	runQuiet = false
+	runVerbose = false
+	runBudgetReport = false
 	runSkipVerify = false
 	runVerifyOnly = false
 	runWorktree = false
+	runResumeEpic = ""
 	runParallel = 1
 	runWatch = false
 	runTimeout = 30 * time.Minute
@@ -328,6 +546,23 @@ func ResetFlags() {
 	runIncludeStandalone = false
 	runIncludeOrphans = false
 	runAll = false
+	runBoardEnabled = false
+	runBoardPort = 3000
+	runCloudEnabled = false
+	runDevMode = false
+	runSwarmMode = false
+	runRalphMode = false
+	runMaxAgents = 5
+	runPoolMode = ""
+	runStaleTimeout = time.Hour
+	runSkipDepAnalysis = false
+	runEstimateCost = false
+	runYes = false
+	runAgentName = "claude"
+
+	// Reset board flags
+	boardPort = 3000
+	boardDev = false
 
 	// Reset resume flags
 	resumeMaxIterations = 50
@@ -337,6 +572,15 @@ func ResetFlags() {
 
 	// Reset checkpoints flags
 	checkpointsJSON = false
+	checkpointListJSON = false
+	checkpointRestoreForce = false
+	checkpointRestoreJSON = false
+
+	// Reset sync flags
+	syncDaemon = false
+	syncStatus = false
+	syncStop = false
+	syncJSON = false
 
 	// Reset merge flags
 	mergeForce = false
@@ -352,9 +596,8 @@ func SetVersion(v string) {
 }
 
 func init() {
-	// Global flags can be added here in the future
-	// For example:
-	// rootCmd.PersistentFlags().BoolP("json", "j", false, "Output as JSON")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&autoMigrate, "migrate", false, "apply pending data migrations without asking")
 
 	// Disable the default completion command (can be re-enabled later if needed)
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
@@ -11,6 +12,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -20,11 +22,12 @@ import (
 	"github.com/pengelbrecht/ticks/internal/agent"
 	"github.com/pengelbrecht/ticks/internal/budget"
 	"github.com/pengelbrecht/ticks/internal/checkpoint"
+	"github.com/pengelbrecht/ticks/internal/config"
 	epiccontext "github.com/pengelbrecht/ticks/internal/context"
 	"github.com/pengelbrecht/ticks/internal/engine"
-	"github.com/pengelbrecht/ticks/internal/gc"
 	"github.com/pengelbrecht/ticks/internal/parallel"
 	"github.com/pengelbrecht/ticks/internal/pool"
+	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/runrecord"
 	"github.com/pengelbrecht/ticks/internal/swarm"
 	"github.com/pengelbrecht/ticks/internal/taskrunner"
@@ -32,6 +35,7 @@ import (
 	"github.com/pengelbrecht/ticks/internal/tickboard/cloud"
 	"github.com/pengelbrecht/ticks/internal/tickboard/server"
 	"github.com/pengelbrecht/ticks/internal/ticks"
+	"github.com/pengelbrecht/ticks/internal/verify"
 	"github.com/pengelbrecht/ticks/internal/worktree"
 )
 
@@ -60,10 +64,19 @@ Examples:
   tk run abc def --parallel 2 --pool  # 2 epics with auto pool workers each
   tk run --auto                     # Auto-select next ready epic
   tk run abc123 --max-iterations 10 # Limit to 10 iterations per task
+  tk run abc123 --agent echo        # Exercise the pipeline without calling a model
   tk run abc123 --max-cost 5.00     # Stop if cost exceeds $5.00
+  tk run abc def --total-max-cost 10  # Stop the whole run at $10 across all epics
+  tk run abc123 --ralph --max-duration 2h  # Stop the run after two hours
+  tk run abc123 --estimate-cost     # Print a cost estimate before running
+  tk run abc123 --estimate-cost --max-cost 5 --yes  # Proceed even if estimate exceeds $5
   tk run abc123 --worktree          # Run in isolated git worktree
+  tk run --resume abc123            # Resume an interrupted --worktree run in its worktree
   tk run abc123 --watch             # Watch mode - restart when tasks ready
   tk run abc123 --jsonl             # Output JSONL format for parsing
+  tk run abc123 --quiet             # Only print the final summary
+  tk run abc123 --verbose           # Also print tool calls and thinking
+  tk run abc123 --ralph --budget-report  # Break down iterations and cost by task
   tk run abc123 --board             # Run agent with board UI on :3000
   tk run --board                    # Board UI only, no agent
   tk run --board --port 8080        # Board UI on custom port
@@ -76,13 +89,19 @@ Examples:
 var (
 	runMaxIterations     int
 	runMaxCost           float64
+	runTotalMaxCost      float64
+	runMaxDuration       time.Duration
 	runCheckpointEvery   int
 	runMaxTaskRetries    int
 	runAuto              bool
 	runJSONL             bool
+	runQuiet             bool
+	runVerbose           bool
+	runBudgetReport      bool
 	runSkipVerify        bool
 	runVerifyOnly        bool
 	runWorktree          bool
+	runResumeEpic        string
 	runParallel          int
 	runWatch             bool
 	runTimeout           time.Duration
@@ -101,18 +120,27 @@ var (
 	runPoolMode          string // "auto", number, or "" (disabled)
 	runStaleTimeout      time.Duration
 	runSkipDepAnalysis   bool
+	runEstimateCost      bool
+	runYes               bool
+	runAgentName         string
 )
 
 func init() {
 	runCmd.Flags().IntVar(&runMaxIterations, "max-iterations", 50, "maximum iterations per task")
 	runCmd.Flags().Float64Var(&runMaxCost, "max-cost", 0, "maximum cost in USD (0=unlimited)")
+	runCmd.Flags().Float64Var(&runTotalMaxCost, "total-max-cost", 0, "maximum cost in USD across all epics in the run (0=unlimited)")
+	runCmd.Flags().DurationVar(&runMaxDuration, "max-duration", 0, "stop the whole run after this wall-clock time, like Ctrl+C (ralph mode, 0=unlimited)")
 	runCmd.Flags().IntVar(&runCheckpointEvery, "checkpoint-interval", 5, "checkpoint every N iterations")
 	runCmd.Flags().IntVar(&runMaxTaskRetries, "max-task-retries", 3, "max retries for failed tasks")
 	runCmd.Flags().BoolVar(&runAuto, "auto", false, "auto-select next ready epic if none specified")
 	runCmd.Flags().BoolVar(&runJSONL, "jsonl", false, "output JSONL format for parsing")
+	runCmd.Flags().BoolVar(&runQuiet, "quiet", false, "only print the final summary, not agent output")
+	runCmd.Flags().BoolVar(&runVerbose, "verbose", false, "also print the agent's tool calls and thinking")
+	runCmd.Flags().BoolVar(&runBudgetReport, "budget-report", false, "report iterations and cost per task and the share of the budget used (ralph mode)")
 	runCmd.Flags().BoolVar(&runSkipVerify, "skip-verify", false, "skip verification after task completion")
 	runCmd.Flags().BoolVar(&runVerifyOnly, "verify-only", false, "only run verification, no agent")
 	runCmd.Flags().BoolVar(&runWorktree, "worktree", false, "run in isolated git worktree")
+	runCmd.Flags().StringVar(&runResumeEpic, "resume", "", "resume an interrupted --worktree run of this epic in its existing worktree (ralph mode)")
 	runCmd.Flags().IntVar(&runParallel, "parallel", 1, "run N epics in parallel (uses worktrees)")
 	runCmd.Flags().BoolVar(&runWatch, "watch", false, "watch mode - restart when tasks become ready")
 	runCmd.Flags().DurationVar(&runTimeout, "timeout", 30*time.Minute, "task timeout duration")
@@ -132,6 +160,9 @@ func init() {
 	runCmd.Flags().Lookup("pool").NoOptDefVal = "auto" // --pool without value means auto
 	runCmd.Flags().DurationVar(&runStaleTimeout, "stale-timeout", time.Hour, "timeout for stale task recovery in pool mode")
 	runCmd.Flags().BoolVar(&runSkipDepAnalysis, "skip-dep-analysis", false, "skip dependency analysis for file conflicts (pool mode)")
+	runCmd.Flags().BoolVar(&runEstimateCost, "estimate-cost", false, "print a cost estimate first; confirm if it exceeds --max-cost")
+	runCmd.Flags().BoolVarP(&runYes, "yes", "y", false, "skip the --estimate-cost confirmation prompt")
+	runCmd.Flags().StringVar(&runAgentName, "agent", agent.DefaultName, "agent backend: "+strings.Join(agent.Names(), ", ")+" (echo/noop never call a model)")
 
 	rootCmd.AddCommand(runCmd)
 }
@@ -147,6 +178,55 @@ type runOutput struct {
 	ExitReason     string   `json:"exit_reason"`
 	Signal         string   `json:"signal,omitempty"`
 	SignalReason   string   `json:"signal_reason,omitempty"`
+
+	Verifications []verificationOutput `json:"verifications,omitempty"`
+
+	Budget *budgetReportOutput `json:"budget_report,omitempty"`
+}
+
+// budgetReportOutput is the JSONL output for --budget-report. Percentages
+// are of the configured limit and omitted when the limit is unlimited.
+type budgetReportOutput struct {
+	MaxIterations int                `json:"max_iterations,omitempty"`
+	MaxCost       float64            `json:"max_cost,omitempty"`
+	IterationsPct *float64           `json:"iterations_pct,omitempty"`
+	CostPct       *float64           `json:"cost_pct,omitempty"`
+	Tasks         []taskBudgetOutput `json:"tasks"`
+}
+
+// taskBudgetOutput is the budget consumed by one task in --budget-report.
+type taskBudgetOutput struct {
+	TaskID        string   `json:"task_id"`
+	Iterations    int      `json:"iterations"`
+	TokensIn      int      `json:"tokens_in"`
+	TokensOut     int      `json:"tokens_out"`
+	Cost          float64  `json:"cost"`
+	IterationsPct *float64 `json:"iterations_pct,omitempty"`
+	CostPct       *float64 `json:"cost_pct,omitempty"`
+}
+
+// verificationOutput is the JSONL output for one task in --verify-only mode.
+type verificationOutput struct {
+	TaskID          string   `json:"task_id"`
+	Passed          bool     `json:"passed"`
+	FailedVerifiers []string `json:"failed_verifiers,omitempty"`
+}
+
+// costEstimateOutput is the JSONL output format for --estimate-cost.
+type costEstimateOutput struct {
+	EpicIDs            []string `json:"epic_ids"`
+	Tasks              int      `json:"tasks"`
+	IterationsLow      int      `json:"iterations_low"`
+	IterationsExpected int      `json:"iterations_expected"`
+	IterationsHigh     int      `json:"iterations_high"`
+	TokensLow          int      `json:"tokens_low"`
+	TokensExpected     int      `json:"tokens_expected"`
+	TokensHigh         int      `json:"tokens_high"`
+	CostLow            float64  `json:"cost_low"`
+	CostExpected       float64  `json:"cost_expected"`
+	CostHigh           float64  `json:"cost_high"`
+	MaxCost            float64  `json:"max_cost,omitempty"`
+	ExceedsMaxCost     bool     `json:"exceeds_max_cost"`
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
@@ -164,36 +244,83 @@ func runRun(cmd *cobra.Command, args []string) error {
 	if modeCount > 1 {
 		return NewExitError(ExitUsage, "cannot combine --swarm, --ralph, and --pool flags")
 	}
+	if runQuiet && runVerbose {
+		return NewExitError(ExitUsage, "--quiet cannot be combined with --verbose")
+	}
+	if runJSONL && (runQuiet || runVerbose) {
+		return NewExitError(ExitUsage, "--jsonl cannot be combined with --quiet or --verbose")
+	}
+	if _, err := agent.New(runAgentName); err != nil {
+		return NewExitError(ExitUsage, "invalid --agent: %v", err)
+	}
+	if runSwarmMode && runAgentName != agent.DefaultName {
+		return NewExitError(ExitUsage, "--swarm only supports --agent %s", agent.DefaultName)
+	}
+
+	// --resume continues an interrupted ralph --worktree run
+	if runResumeEpic != "" {
+		if len(args) > 0 || runAuto {
+			return NewExitError(ExitUsage, "--resume takes the epic id and cannot be combined with epic-id args or --auto")
+		}
+		if runSwarmMode || runPoolMode != "" {
+			return NewExitError(ExitUsage, "--resume cannot be combined with --swarm or --pool")
+		}
+		if runParallel > 1 {
+			return NewExitError(ExitUsage, "--resume cannot be combined with --parallel")
+		}
+		runRalphMode = true
+		runWorktree = true
+		modeCount = 1
+	}
 
 	// Default to pool mode if no mode explicitly specified
 	if modeCount == 0 {
 		runPoolMode = "auto"
 	}
+	if runBudgetReport && (!runRalphMode || runParallel > 1) {
+		return NewExitError(ExitUsage, "--budget-report requires --ralph and cannot be combined with --parallel")
+	}
+	if runMaxDuration < 0 {
+		return NewExitError(ExitUsage, "--max-duration must not be negative")
+	}
+	if runMaxDuration > 0 && !runRalphMode {
+		return NewExitError(ExitUsage, "--max-duration requires --ralph")
+	}
 
 	// --cloud implies --board
 	if runCloudEnabled {
 		runBoardEnabled = true
 	}
 
-	// Start async garbage collection
-	go func() {
-		root, err := repoRoot()
-		if err != nil {
-			return
-		}
-		_, _ = gc.Cleanup(root, gc.DefaultMaxAge)
-	}()
-
 	root, err := repoRoot()
 	if err != nil {
 		return NewExitError(ExitNoRepo, "not in a git repository: %v", err)
 	}
 
+	// Start async garbage collection
+	go autoCleanup(root)
+
 	tickDir := filepath.Join(root, ".tick")
 
+	// Run-wide budget shared by every epic (--total-max-cost)
+	totalBudget := budget.NewTracker(budget.Limits{MaxCost: runTotalMaxCost})
+
 	// Determine epic IDs to run
 	epicIDs := args
 	runningAgent := true
+	if runResumeEpic != "" {
+		state, err := worktree.LoadRunState(tickDir, runResumeEpic)
+		if err != nil {
+			return NewExitError(ExitIO, "failed to load run state: %v", err)
+		}
+		if state == nil {
+			return NewExitError(ExitNotFound, "no interrupted worktree run for epic %s", runResumeEpic)
+		}
+		if !runJSONL {
+			fmt.Printf("Resuming epic %s in worktree %s\n", runResumeEpic, state.Path)
+		}
+		epicIDs = []string{runResumeEpic}
+	}
 	if len(epicIDs) == 0 {
 		if runAuto {
 			// Auto-select next ready epic
@@ -222,9 +349,26 @@ func runRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Verify-only mode not implemented yet
+	// Verify-only mode: re-run verification for completed tasks, no agent
 	if runVerifyOnly {
-		return NewExitError(ExitUsage, "--verify-only is not yet implemented")
+		if !runningAgent {
+			return NewExitError(ExitUsage, "--verify-only requires epic-id(s) or --auto")
+		}
+		if runSkipVerify {
+			return NewExitError(ExitUsage, "cannot combine --verify-only and --skip-verify")
+		}
+		return runVerifyOnlyEpics(root, epicIDs)
+	}
+
+	// Estimate cost and check the --max-cost guard before spending anything
+	if runEstimateCost && runningAgent {
+		proceed, err := confirmCostEstimate(tickDir, epicIDs)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
 	}
 
 	// Parallel mode requires worktree
@@ -232,6 +376,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 		runWorktree = true
 	}
 
+	// The --max-duration clock starts once setup and prompts are done
+	runDeadline = time.Time{}
+	if runMaxDuration > 0 {
+		runDeadline = time.Now().Add(runMaxDuration)
+	}
+
 	// Set up signal handling for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -272,19 +422,19 @@ func runRun(cmd *cobra.Command, args []string) error {
 		if runCloudEnabled {
 			cloudCfg := cloud.LoadConfig(tickDir)
 			if cloudCfg == nil {
-				return NewExitError(ExitGeneric, `cloud sync requires authentication.
-Add token to ~/.ticksrc:
-  token=your-token-here
-
-Get a token at https://ticks.sh/settings`)
+				return NewExitError(ExitGeneric, cloudAuthHelp)
 			}
 			cloudClient, err = cloud.NewClient(*cloudCfg)
 			if err != nil {
 				return NewExitError(ExitGeneric, "failed to create cloud client: %v", err)
 			}
 
-			// Connect server to cloud for event broadcasting
-			boardServer.SetCloudClient(cloudClient)
+			// Connect server to cloud for event broadcasting. Ralph runs
+			// stream their iterations to the cloud directly (see runEpic), so
+			// the server only forwards live records for the other modes.
+			if !runRalphMode || !runningAgent {
+				boardServer.SetCloudClient(cloudClient)
+			}
 
 			// Start cloud client in background
 			wg.Add(1)
@@ -321,13 +471,15 @@ Get a token at https://ticks.sh/settings`)
 			}
 
 			// Set up output streaming
-			if !runJSONL {
+			if !runJSONL && !runQuiet {
 				swarmRunner.OnOutput = func(chunk string) {
 					fmt.Print(chunk)
 				}
 				swarmRunner.OnStart = func(epicID string) {
 					fmt.Printf("\n🐝 Starting swarm for epic %s (max %d agents)...\n", epicID, runMaxAgents)
 				}
+			}
+			if !runJSONL {
 				swarmRunner.OnEnd = func(epicID string, result *swarm.Result) {
 					if result.Success {
 						fmt.Printf("\n✅ Swarm completed for epic %s (duration: %v)\n", epicID, result.Duration.Round(time.Second))
@@ -345,11 +497,21 @@ Get a token at https://ticks.sh/settings`)
 
 			// Run each epic with swarm
 			for _, epicID := range epicIDs {
-				// Set up OnState callback to write epic live records
-				if runRecordStore != nil {
+				// Set up OnState callback to write epic live records and,
+				// with --verbose, print tool calls and thinking
+				var console *engine.ConsoleOutput
+				if runVerbose {
+					console = engine.NewConsoleOutput(engine.VerbosityVerbose, "")
+				}
+				if runRecordStore != nil || console != nil {
 					currentEpicID := epicID // Capture for closure
 					swarmRunner.OnState = func(snap agent.AgentStateSnapshot) {
-						_ = runRecordStore.WriteEpicLive(currentEpicID, snap)
+						if runRecordStore != nil {
+							_ = runRecordStore.WriteEpicLive(currentEpicID, snap)
+						}
+						if console != nil {
+							console.AgentState(snap)
+						}
 					}
 				}
 				var workDir string
@@ -440,11 +602,11 @@ Get a token at https://ticks.sh/settings`)
 			}
 		} else if runPoolMode != "" {
 			// Pool mode: parallel workers processing tasks within each epic
-			claudeAgent := agent.NewClaudeAgent()
-			if !claudeAgent.Available() {
-				cancel()
+			agentImpl, _ := agent.New(runAgentName) // validated above
+			if !agentImpl.Available() {
+				cancel() // Stop board server too
 				wg.Wait()
-				return NewExitError(ExitGeneric, "claude CLI not found - install from https://claude.ai/code")
+				return NewExitError(ExitGeneric, "%s CLI not found - install from https://claude.ai/code", agentImpl.Name())
 			}
 
 			// Parallel execution with worktrees (combined with pool)
@@ -456,7 +618,7 @@ Get a token at https://ticks.sh/settings`)
 					wg.Wait()
 					return NewExitError(ExitGeneric, "failed to determine pool size: %v", err)
 				}
-				parallelResult, err := runParallelEpicsWithPool(ctx, root, epicIDs, claudeAgent, poolSize, runStaleTimeout)
+				parallelResult, err := runParallelEpicsWithPool(ctx, root, epicIDs, agentImpl, poolSize, runStaleTimeout, totalBudget)
 				if err != nil {
 					cancel()
 					wg.Wait()
@@ -474,7 +636,7 @@ Get a token at https://ticks.sh/settings`)
 						return NewExitError(ExitGeneric, "failed to determine pool size for %s: %v", epicID, err)
 					}
 
-					result, err := runEpicWithPool(ctx, root, epicID, claudeAgent, poolSize, runStaleTimeout)
+					result, err := runEpicWithPool(ctx, root, epicID, agentImpl, poolSize, runStaleTimeout, totalBudget)
 					if err != nil {
 						if ctx.Err() != nil {
 							if result != nil {
@@ -489,6 +651,10 @@ Get a token at https://ticks.sh/settings`)
 
 					outputPoolResult(result, epicID)
 
+					if totalBudgetExhausted(totalBudget, epicID) {
+						break
+					}
+
 					if ctx.Err() != nil {
 						break
 					}
@@ -496,16 +662,16 @@ Get a token at https://ticks.sh/settings`)
 			}
 		} else {
 			// Ralph mode: use Go engine iteration loop
-			claudeAgent := agent.NewClaudeAgent()
-			if !claudeAgent.Available() {
+			agentImpl, _ := agent.New(runAgentName) // validated above
+			if !agentImpl.Available() {
 				cancel() // Stop board server too
 				wg.Wait()
-				return NewExitError(ExitGeneric, "claude CLI not found - install from https://claude.ai/code")
+				return NewExitError(ExitGeneric, "%s CLI not found - install from https://claude.ai/code", agentImpl.Name())
 			}
 
 			// Parallel execution with worktrees
 			if runParallel > 1 && len(epicIDs) > 1 {
-				parallelResult, err := runParallelEpics(ctx, root, epicIDs, claudeAgent)
+				parallelResult, err := runParallelEpics(ctx, root, epicIDs, agentImpl, totalBudget, cloudClient)
 				if err != nil {
 					cancel()
 					wg.Wait()
@@ -513,9 +679,9 @@ Get a token at https://ticks.sh/settings`)
 				}
 				outputParallelResult(parallelResult)
 			} else {
-				// Run each epic sequentially
+				// Run each epic sequentially, sharing the total budget
 				for _, epicID := range epicIDs {
-					result, err := runEpic(ctx, root, epicID, claudeAgent)
+					result, err := runEpic(ctx, root, epicID, agentImpl, totalBudget, cloudClient)
 					if err != nil {
 						if ctx.Err() != nil {
 							// Context cancelled - output partial result if we have one
@@ -531,6 +697,13 @@ Get a token at https://ticks.sh/settings`)
 
 					outputResult(result)
 
+					if result.ExitReason == engine.ExitReasonMaxDuration {
+						break
+					}
+					if totalBudgetExhausted(totalBudget, epicID) {
+						break
+					}
+
 					// Stop if context cancelled
 					if ctx.Err() != nil {
 						break
@@ -553,13 +726,30 @@ Get a token at https://ticks.sh/settings`)
 	return nil
 }
 
-func runEpic(ctx context.Context, root, epicID string, agentImpl agent.Agent) (*engine.RunResult, error) {
+// runDeadline is when --max-duration ends the current tk run (zero = none).
+var runDeadline time.Time
+
+// maxDurationLeft returns the RunConfig.MaxDuration for an engine started
+// now: the time left until runDeadline, so every epic of the run shares one
+// wall-clock limit. 0 means no limit.
+func maxDurationLeft() time.Duration {
+	if runDeadline.IsZero() {
+		return 0
+	}
+	// Past the deadline the engine must still stop at once, not run unbounded
+	return max(time.Until(runDeadline), time.Nanosecond)
+}
+
+// runEpic runs the ralph engine loop on one epic. Usage is also charged to
+// total, whose limits stop the epic alongside the per-epic ones. With a
+// cloud client, iterations are streamed to the cloud board as run events.
+func runEpic(ctx context.Context, root, epicID string, agentImpl agent.Agent, total *budget.Tracker, cloudClient *cloud.Client) (*engine.RunResult, error) {
 	// Create dependencies
 	ticksClient := ticks.NewClient(filepath.Join(root, ".tick"))
 	budgetTracker := budget.NewTracker(budget.Limits{
 		MaxIterations: runMaxIterations,
 		MaxCost:       runMaxCost,
-	})
+	}).WithParent(total, epicID)
 	checkpointMgr := checkpoint.NewManager()
 
 	// Create engine
@@ -571,28 +761,19 @@ func runEpic(ctx context.Context, root, epicID string, agentImpl agent.Agent) (*
 
 	// Enable verification unless skipped
 	if !runSkipVerify {
-		eng.EnableVerification()
+		enableVerification(eng, ticksClient, filepath.Join(root, ".tick"))
 	}
 
 	// Enable context generation for epics
-	contextStore := epiccontext.NewStoreWithDir(filepath.Join(root, ".tick", "logs", "context"))
-	contextGenerator, err := epiccontext.NewGenerator(agentImpl)
-	if err == nil {
-		eng.SetContextComponents(contextStore, contextGenerator)
+	if !isStubAgent(agentImpl) {
+		enableEpicContext(eng, agentImpl, filepath.Join(root, ".tick"))
 	}
 
 	// Set up output streaming for non-JSONL mode
 	if !runJSONL {
-		eng.OnOutput = func(chunk string) {
-			fmt.Print(chunk)
-		}
-		eng.OnIterationStart = func(ctx engine.IterationContext) {
-			fmt.Printf("\n=== Iteration %d: %s (%s) ===\n", ctx.Iteration, ctx.Task.ID, ctx.Task.Title)
-		}
-		eng.OnIterationEnd = func(result *engine.IterationResult) {
-			fmt.Printf("\n--- Iteration %d complete (tokens: %d in, %d out, cost: $%.4f) ---\n",
-				result.Iteration, result.TokensIn, result.TokensOut, result.Cost)
-		}
+		engine.NewConsoleOutput(runVerbosity(), "").Attach(eng)
+	}
+	if !runJSONL && !runQuiet {
 		// Context generation status callbacks
 		eng.OnContextGenerating = func(epicID string, taskCount int) {
 			fmt.Printf("\n📚 Generating epic context for %s (%d tasks)...\n", epicID, taskCount)
@@ -611,26 +792,217 @@ func runEpic(ctx context.Context, root, epicID string, agentImpl agent.Agent) (*
 		}
 	}
 
+	// Stream iterations to the cloud board (--cloud)
+	if cloudClient != nil {
+		attachCloudRunEvents(eng, cloud.NewRunEventForwarder(cloudClient, epicID, "ralph"))
+	}
+
 	// Build run config
 	config := engine.RunConfig{
 		EpicID:            epicID,
 		MaxIterations:     runMaxIterations,
 		MaxCost:           runMaxCost,
+		MaxDuration:       maxDurationLeft(),
 		CheckpointEvery:   runCheckpointEvery,
 		MaxTaskRetries:    runMaxTaskRetries,
 		AgentTimeout:      runTimeout,
 		SkipVerify:        runSkipVerify,
 		UseWorktree:       runWorktree,
+		ResumeWorktree:    runResumeEpic != "",
 		RepoRoot:          root,
 		Watch:             runWatch,
 		WatchPollInterval: runPoll,
 		DebounceInterval:  runDebounce,
+		BudgetReport:      runBudgetReport,
 	}
 
 	// Run the engine
 	return eng.Run(ctx, config)
 }
 
+// attachCloudRunEvents feeds the engine's iteration callbacks into fwd,
+// keeping any callbacks already set for terminal output.
+func attachCloudRunEvents(eng *engine.Engine, fwd *cloud.RunEventForwarder) {
+	onStart, onOutput, onEnd := eng.OnIterationStart, eng.OnOutput, eng.OnIterationEnd
+	eng.OnIterationStart = func(ctx engine.IterationContext) {
+		if onStart != nil {
+			onStart(ctx)
+		}
+		if ctx.Task != nil {
+			fwd.TaskStarted(ctx.Task.ID, ctx.Task.Title, ctx.Iteration)
+		}
+	}
+	eng.OnOutput = func(chunk string) {
+		if onOutput != nil {
+			onOutput(chunk)
+		}
+		fwd.Output(chunk)
+	}
+	eng.OnIterationEnd = func(result *engine.IterationResult) {
+		if onEnd != nil {
+			onEnd(result)
+		}
+		fwd.IterationEnded(result.TaskID, result.Iteration, result.Output, cloud.RunEventMetrics{
+			InputTokens:  result.TokensIn,
+			OutputTokens: result.TokensOut,
+			CostUSD:      result.Cost,
+			DurationMS:   result.Duration.Milliseconds(),
+		}, result.Error == nil && !result.IsTimeout, result.TaskClosed)
+	}
+}
+
+// totalBudgetExhausted reports whether the run-wide budget is used up,
+// printing which epic hit the cap.
+func totalBudgetExhausted(total *budget.Tracker, epicID string) bool {
+	stop, reason := total.ShouldStop()
+	if !stop {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "Stopping run: epic %s hit the total budget: %s\n", epicID, reason)
+	return true
+}
+
+// isStubAgent reports whether a is an echo/noop agent, which never calls a
+// model. Context generation and dependency analysis are skipped for stubs.
+func isStubAgent(a agent.Agent) bool {
+	_, ok := a.(*agent.StubAgent)
+	return ok
+}
+
+// estimateRunCost estimates the agent cost of running epicIDs using the
+// project's cost model. Each epic's open, agent-workable tasks are counted,
+// capped by --max-iterations per epic.
+func estimateRunCost(tickDir string, epicIDs []string) (budget.RunEstimate, error) {
+	cfg, err := config.LoadOrDefault(filepath.Join(tickDir, "config.json"))
+	if err != nil {
+		return budget.RunEstimate{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	model := budget.CostModel{
+		Pricing:               budget.GetPricing(cfg.CostModel.GetModel()),
+		TokensInPerIteration:  cfg.CostModel.GetTokensInPerIteration(),
+		TokensOutPerIteration: cfg.CostModel.GetTokensOutPerIteration(),
+		IterationsPerTask:     cfg.CostModel.GetIterationsPerTask(),
+	}
+
+	allTicks, err := tick.NewStore(tickDir).List()
+	if err != nil {
+		return budget.RunEstimate{}, fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	var total budget.RunEstimate
+	for _, epicID := range epicIDs {
+		var sizes []int
+		for _, t := range allTicks {
+			if t.Parent != epicID || t.Type == tick.TypeEpic || t.Status == tick.StatusClosed || t.IsAwaitingHuman() {
+				continue
+			}
+			sizes = append(sizes, len(t.Title)+len(t.Description)+len(t.AcceptanceCriteria))
+		}
+		total = total.Add(budget.EstimateRun(sizes, runMaxIterations, model))
+	}
+	return total, nil
+}
+
+// confirmCostEstimate prints the cost estimate and, if the expected cost exceeds
+// --max-cost, asks for confirmation unless --yes is set. Returns whether to proceed.
+func confirmCostEstimate(tickDir string, epicIDs []string) (bool, error) {
+	est, err := estimateRunCost(tickDir, epicIDs)
+	if err != nil {
+		return false, err
+	}
+	exceeds := runMaxCost > 0 && est.CostExpected > runMaxCost
+
+	if runJSONL {
+		enc := json.NewEncoder(os.Stdout)
+		_ = enc.Encode(costEstimateOutput{
+			EpicIDs:            epicIDs,
+			Tasks:              est.Tasks,
+			IterationsLow:      est.IterationsLow,
+			IterationsExpected: est.IterationsExpected,
+			IterationsHigh:     est.IterationsHigh,
+			TokensLow:          est.TokensLow,
+			TokensExpected:     est.TokensExpected,
+			TokensHigh:         est.TokensHigh,
+			CostLow:            est.CostLow,
+			CostExpected:       est.CostExpected,
+			CostHigh:           est.CostHigh,
+			MaxCost:            runMaxCost,
+			ExceedsMaxCost:     exceeds,
+		})
+	} else {
+		fmt.Printf("Cost estimate: %d task(s) in %d epic(s), max %d iterations per epic\n", est.Tasks, len(epicIDs), runMaxIterations)
+		fmt.Printf("  Iterations: %d-%d (expected %d)\n", est.IterationsLow, est.IterationsHigh, est.IterationsExpected)
+		fmt.Printf("  Tokens:     %d-%d (expected %d)\n", est.TokensLow, est.TokensHigh, est.TokensExpected)
+		fmt.Printf("  Cost:       $%.2f-$%.2f (expected $%.2f)\n", est.CostLow, est.CostHigh, est.CostExpected)
+	}
+
+	if !exceeds {
+		return true, nil
+	}
+	if runYes {
+		fmt.Fprintf(os.Stderr, "Warning: expected cost $%.2f exceeds --max-cost $%.2f, continuing (--yes)\n", est.CostExpected, runMaxCost)
+		return true, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Expected cost $%.2f exceeds --max-cost $%.2f. Proceed? [y/N] ", est.CostExpected, runMaxCost)
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil && response == "" {
+		fmt.Fprintln(os.Stderr, "\nRun cancelled")
+		return false, nil
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Fprintln(os.Stderr, "Run cancelled")
+		return false, nil
+	}
+	return true, nil
+}
+
+// enableVerification turns on post-close verification, also filing bug ticks
+// for failures when verification.create_bug_on_failure is set in config.
+func enableVerification(eng *engine.Engine, ticksClient *ticks.Client, tickDir string) {
+	eng.EnableVerification()
+	cfg, err := config.LoadOrDefault(filepath.Join(tickDir, "config.json"))
+	if err == nil && cfg.Verification.ShouldCreateBugOnFailure() {
+		eng.EnableBugOnFailure(ticksClient)
+	}
+}
+
+// enableEpicContext sets up epic context generation with the settings from
+// the context section of .tick/config.json.
+func enableEpicContext(eng *engine.Engine, agentImpl agent.Agent, tickDir string) {
+	cfg := loadContextConfig(tickDir)
+	eng.SetContextConfig(cfg)
+	contextStore := epiccontext.NewStoreWithDir(filepath.Join(tickDir, "logs", "context"))
+	contextGenerator, err := epiccontext.NewGenerator(agentImpl, epiccontext.OptionsFromConfig(cfg)...)
+	if err == nil {
+		eng.SetContextComponents(contextStore, contextGenerator)
+	}
+}
+
+// loadContextConfig returns the context config for tickDir, or nil (all
+// defaults) if the config can't be loaded.
+func loadContextConfig(tickDir string) *config.ContextConfig {
+	cfg, err := config.LoadOrDefault(filepath.Join(tickDir, "config.json"))
+	if err != nil {
+		return nil
+	}
+	return cfg.Context
+}
+
+// runVerbosity returns the console verbosity selected by --quiet and --verbose.
+func runVerbosity() engine.Verbosity {
+	switch {
+	case runQuiet:
+		return engine.VerbosityQuiet
+	case runVerbose:
+		return engine.VerbosityVerbose
+	default:
+		return engine.VerbosityNormal
+	}
+}
+
 func outputResult(result *engine.RunResult) {
 	if runJSONL {
 		output := runOutput{
@@ -646,6 +1018,16 @@ func outputResult(result *engine.RunResult) {
 			output.Signal = result.Signal.String()
 			output.SignalReason = result.SignalReason
 		}
+		for _, v := range result.Verifications {
+			output.Verifications = append(output.Verifications, verificationOutput{
+				TaskID:          v.TaskID,
+				Passed:          v.Passed,
+				FailedVerifiers: failedVerifiers(v.Results),
+			})
+		}
+		if result.Budget != nil {
+			output.Budget = newBudgetReportOutput(result.Budget)
+		}
 		enc := json.NewEncoder(os.Stdout)
 		_ = enc.Encode(output)
 	} else {
@@ -663,10 +1045,137 @@ func outputResult(result *engine.RunResult) {
 				fmt.Printf("Signal reason: %s\n", result.SignalReason)
 			}
 		}
+		for _, v := range result.Verifications {
+			status := "PASS"
+			if !v.Passed {
+				status = "FAIL"
+			}
+			fmt.Printf("  [%s] %s", status, v.TaskID)
+			if failed := failedVerifiers(v.Results); len(failed) > 0 {
+				fmt.Printf(" (%s)", strings.Join(failed, ", "))
+			} else if v.Results == nil {
+				fmt.Printf(" (verification unavailable)")
+			}
+			fmt.Println()
+		}
+		if result.Budget != nil {
+			printBudgetReport(result.Budget)
+		}
+	}
+}
+
+// newBudgetReportOutput converts a budget report to its JSONL form.
+func newBudgetReportOutput(r *budget.Report) *budgetReportOutput {
+	out := &budgetReportOutput{
+		MaxIterations: r.Limits.MaxIterations,
+		MaxCost:       r.Limits.MaxCost,
+		IterationsPct: budgetPercent(float64(r.Usage.Iterations), float64(r.Limits.MaxIterations)),
+		CostPct:       budgetPercent(r.Usage.Cost, r.Limits.MaxCost),
+		Tasks:         []taskBudgetOutput{},
+	}
+	for _, t := range r.Tasks {
+		out.Tasks = append(out.Tasks, taskBudgetOutput{
+			TaskID:        t.TaskID,
+			Iterations:    t.Iterations,
+			TokensIn:      t.TokensIn,
+			TokensOut:     t.TokensOut,
+			Cost:          t.Cost,
+			IterationsPct: budgetPercent(float64(t.Iterations), float64(r.Limits.MaxIterations)),
+			CostPct:       budgetPercent(t.Cost, r.Limits.MaxCost),
+		})
+	}
+	return out
+}
+
+// budgetPercent returns used as a percentage of limit, or nil if unlimited.
+func budgetPercent(used, limit float64) *float64 {
+	pct, ok := budget.Percent(used, limit)
+	if !ok {
+		return nil
+	}
+	return &pct
+}
+
+// printBudgetReport prints the per-task budget breakdown of a run.
+func printBudgetReport(r *budget.Report) {
+	fmt.Printf("\nBudget by task:\n")
+	if len(r.Tasks) == 0 {
+		fmt.Println("  (no iterations)")
+	}
+	for _, t := range r.Tasks {
+		fmt.Printf("  %-12s %3d iterations  %8d tokens  $%.4f", t.TaskID, t.Iterations, t.TokensIn+t.TokensOut, t.Cost)
+		if pct, ok := budget.Percent(t.Cost, r.Limits.MaxCost); ok {
+			fmt.Printf(" (%.1f%% of max cost)", pct)
+		}
+		fmt.Println()
+	}
+
+	iterations := fmt.Sprintf("%d iterations", r.Usage.Iterations)
+	if pct, ok := budget.Percent(float64(r.Usage.Iterations), float64(r.Limits.MaxIterations)); ok {
+		iterations = fmt.Sprintf("%d/%d iterations (%.1f%%)", r.Usage.Iterations, r.Limits.MaxIterations, pct)
+	}
+	cost := fmt.Sprintf("$%.4f (no limit)", r.Usage.Cost)
+	if pct, ok := budget.Percent(r.Usage.Cost, r.Limits.MaxCost); ok {
+		cost = fmt.Sprintf("$%.4f/$%.2f (%.1f%%)", r.Usage.Cost, r.Limits.MaxCost, pct)
+	}
+	fmt.Printf("Budget used: %s, %s\n", iterations, cost)
+}
+
+// failedVerifiers returns the names of the verifiers that failed.
+func failedVerifiers(results *verify.Results) []string {
+	if results == nil {
+		return nil
+	}
+	var failed []string
+	for _, r := range results.Results {
+		if !r.Passed {
+			failed = append(failed, r.Verifier)
+		}
+	}
+	return failed
+}
+
+// runVerifyOnlyEpics runs the verification phase for each epic's closed tasks
+// without invoking an agent. Returns an error if any task fails verification.
+func runVerifyOnlyEpics(root string, epicIDs []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	failed := 0
+	for _, epicID := range epicIDs {
+		// The agent is never invoked; the stub only satisfies the engine
+		ticksClient := ticks.NewClient(filepath.Join(root, ".tick"))
+		eng := engine.NewEngine(agent.NewNoopAgent(), ticksClient, budget.NewTracker(budget.Limits{}), checkpoint.NewManager())
+		eng.EnableVerification()
+
+		result, err := eng.VerifyOnly(ctx, engine.RunConfig{EpicID: epicID, RepoRoot: root})
+		if err != nil {
+			if ctx.Err() != nil && result != nil {
+				outputResult(result)
+				break
+			}
+			return NewExitError(ExitGeneric, "verify-only failed for epic %s: %v", epicID, err)
+		}
+
+		if len(result.Verifications) == 0 && !runJSONL {
+			fmt.Printf("No completed tasks to verify in %s\n", epicID)
+		}
+		outputResult(result)
+
+		for _, v := range result.Verifications {
+			if !v.Passed {
+				failed++
+			}
+		}
 	}
+
+	if failed > 0 {
+		return NewExitError(ExitGeneric, "verification failed for %d task(s)", failed)
+	}
+	return nil
 }
 
-func runParallelEpics(ctx context.Context, root string, epicIDs []string, agentImpl agent.Agent) (*parallel.ParallelResult, error) {
+func runParallelEpics(ctx context.Context, root string, epicIDs []string, agentImpl agent.Agent, total *budget.Tracker, cloudClient *cloud.Client) (*parallel.ParallelResult, error) {
 	tickDir := filepath.Join(root, ".tick")
 
 	// Create worktree manager
@@ -697,35 +1206,28 @@ func runParallelEpics(ctx context.Context, root string, epicIDs []string, agentI
 		epicBudget := budget.NewTracker(budget.Limits{
 			MaxIterations: runMaxIterations,
 			MaxCost:       runMaxCost / float64(len(epicIDs)), // Divide cost budget
-		})
+		}).WithParent(total, epicID)
 		checkpointMgr := checkpoint.NewManager()
 
 		eng := engine.NewEngine(agentImpl, ticksClient, epicBudget, checkpointMgr)
 		eng.SetRunRecordStore(runRecordStore)
 
 		if !runSkipVerify {
-			eng.EnableVerification()
+			enableVerification(eng, ticksClient, tickDir)
 		}
 
 		// Context generation for epics
-		contextStore := epiccontext.NewStoreWithDir(filepath.Join(tickDir, "logs", "context"))
-		contextGenerator, err := epiccontext.NewGenerator(agentImpl)
-		if err == nil {
-			eng.SetContextComponents(contextStore, contextGenerator)
+		if !isStubAgent(agentImpl) {
+			enableEpicContext(eng, agentImpl, tickDir)
 		}
 
 		// Set up output streaming for non-JSONL mode
 		if !runJSONL {
-			eng.OnOutput = func(chunk string) {
-				fmt.Printf("[%s] %s", epicID, chunk)
-			}
-			eng.OnIterationStart = func(ctx engine.IterationContext) {
-				fmt.Printf("\n=== [%s] Iteration %d: %s (%s) ===\n", epicID, ctx.Iteration, ctx.Task.ID, ctx.Task.Title)
-			}
-			eng.OnIterationEnd = func(result *engine.IterationResult) {
-				fmt.Printf("\n--- [%s] Iteration %d complete (tokens: %d, cost: $%.4f) ---\n",
-					epicID, result.Iteration, result.TokensIn+result.TokensOut, result.Cost)
-			}
+			engine.NewConsoleOutput(runVerbosity(), epicID).Attach(eng)
+		}
+
+		if cloudClient != nil {
+			attachCloudRunEvents(eng, cloud.NewRunEventForwarder(cloudClient, epicID, "ralph"))
 		}
 
 		return eng
@@ -742,6 +1244,7 @@ func runParallelEpics(ctx context.Context, root string, epicIDs []string, agentI
 		EngineConfig: engine.RunConfig{
 			MaxIterations:     runMaxIterations,
 			MaxCost:           runMaxCost / float64(len(epicIDs)),
+			MaxDuration:       maxDurationLeft(),
 			CheckpointEvery:   runCheckpointEvery,
 			MaxTaskRetries:    runMaxTaskRetries,
 			AgentTimeout:      runTimeout,
@@ -893,7 +1396,9 @@ func isPortAvailable(port int) bool {
 }
 
 // runEpicWithPool runs a single epic using pool mode with N parallel workers.
-func runEpicWithPool(ctx context.Context, root, epicID string, agentImpl agent.Agent, poolSize int, staleTimeout time.Duration) (*pool.Result, error) {
+// Each task's usage counts against total, and workers stop claiming tasks
+// once it is used up.
+func runEpicWithPool(ctx context.Context, root, epicID string, agentImpl agent.Agent, poolSize int, staleTimeout time.Duration, total *budget.Tracker) (*pool.Result, error) {
 	tickDir := filepath.Join(root, ".tick")
 
 	if !runJSONL {
@@ -905,7 +1410,7 @@ func runEpicWithPool(ctx context.Context, root, epicID string, agentImpl agent.A
 
 	// Run dependency analysis to detect file conflicts and get predictions
 	filePredictions := make(map[string][]string)
-	if !runSkipDepAnalysis && poolSize > 1 {
+	if !runSkipDepAnalysis && poolSize > 1 && !isStubAgent(agentImpl) {
 		predictions := runDependencyAnalysis(ctx, tickDir, epicID, agentImpl)
 		if predictions != nil {
 			filePredictions = predictions
@@ -919,11 +1424,12 @@ func runEpicWithPool(ctx context.Context, root, epicID string, agentImpl agent.A
 		EpicID:       epicID,
 		TickDir:      tickDir,
 		EpicContext:  epicContextContent,
-		RunTask:      createPoolTaskRunner(ctx, root, agentImpl, epicContextContent, filePredictions),
+		RunTask:      budgetedPoolTask(createPoolTaskRunner(ctx, root, agentImpl, epicContextContent, filePredictions), total, epicID),
+		ShouldStop:   budgetSpent(total),
 	}
 
-	// Set up minimal status output (unless JSONL mode)
-	if !runJSONL {
+	// Set up minimal status output (unless JSONL or quiet mode)
+	if !runJSONL && !runQuiet {
 		cfg.OnStatus = func(event pool.TaskEvent) {
 			switch event.Status {
 			case "starting":
@@ -943,6 +1449,25 @@ func runEpicWithPool(ctx context.Context, root, epicID string, agentImpl agent.A
 	return pool.RunPool(ctx, cfg)
 }
 
+// budgetedPoolTask wraps runTask so each task's usage is added to total
+// under epicID.
+func budgetedPoolTask(runTask pool.RunTaskFunc, total *budget.Tracker, epicID string) pool.RunTaskFunc {
+	return func(ctx context.Context, task *tick.Tick) (bool, float64, int) {
+		success, cost, tokens := runTask(ctx, task)
+		total.AddForEpic(epicID, tokens, 0, cost)
+		return success, cost, tokens
+	}
+}
+
+// budgetSpent returns a pool ShouldStop func that reports whether total is
+// used up.
+func budgetSpent(total *budget.Tracker) func() bool {
+	return func() bool {
+		stop, _ := total.ShouldStop()
+		return stop
+	}
+}
+
 // createPoolTaskRunner creates a RunTask function for pool workers.
 // This wraps the agent execution logic to work with pool mode.
 // Uses TaskRunner for consistent run record and live streaming support.
@@ -961,12 +1486,16 @@ func createPoolTaskRunner(ctx context.Context, root string, agentImpl agent.Agen
 		prompt := buildPoolTaskPrompt(task, epicContext, predictedFiles)
 
 		// Create runner with run record support
-		runner := taskrunner.New(taskrunner.Config{
+		runnerCfg := taskrunner.Config{
 			Agent:       agentImpl,
 			TickClient:  tickClient,
 			RecordStore: recordStore,
 			Timeout:     runTimeout,
-		})
+		}
+		if runVerbose {
+			runnerCfg.OnAgentState = engine.NewConsoleOutput(engine.VerbosityVerbose, task.ID).AgentState
+		}
+		runner := taskrunner.New(runnerCfg)
 
 		// Run the task with full run record tracking
 		result := runner.Run(ctx, task.ID, prompt)
@@ -1066,11 +1595,9 @@ func computeMaxWaveWidth(tickDir, epicID string) int {
 
 	// Filter to open tasks under this epic
 	var tasks []tick.Tick
-	taskSet := make(map[string]bool)
 	for _, t := range allTicks {
 		if t.Parent == epicID && t.Type != tick.TypeEpic && t.Status != tick.StatusClosed {
 			tasks = append(tasks, t)
-			taskSet[t.ID] = true
 		}
 	}
 
@@ -1078,53 +1605,11 @@ func computeMaxWaveWidth(tickDir, epicID string) int {
 		return 1
 	}
 
-	// Build in-degree map (count of open blockers within epic)
-	inDegree := make(map[string]int)
-	blocks := make(map[string][]string)
-	for _, t := range tasks {
-		inDegree[t.ID] = 0
-	}
-	for _, t := range tasks {
-		for _, blockerID := range t.BlockedBy {
-			if taskSet[blockerID] {
-				inDegree[t.ID]++
-				blocks[blockerID] = append(blocks[blockerID], t.ID)
-			}
-		}
-	}
-
-	// Compute waves using Kahn's algorithm
-	remaining := make(map[string]bool)
-	for _, t := range tasks {
-		remaining[t.ID] = true
-	}
-
+	waves, _ := query.Waves(tasks)
 	maxParallel := 0
-	for len(remaining) > 0 {
-		// Find all tasks with no remaining blockers
-		var ready []string
-		for _, t := range tasks {
-			if remaining[t.ID] && inDegree[t.ID] == 0 {
-				ready = append(ready, t.ID)
-			}
-		}
-
-		if len(ready) == 0 {
-			break // cycle detected
-		}
-
-		if len(ready) > maxParallel {
-			maxParallel = len(ready)
-		}
-
-		// Remove ready tasks and update inDegree
-		for _, id := range ready {
-			delete(remaining, id)
-			for _, dependentID := range blocks[id] {
-				if remaining[dependentID] {
-					inDegree[dependentID]--
-				}
-			}
+	for _, w := range waves {
+		if len(w) > maxParallel {
+			maxParallel = len(w)
 		}
 	}
 
@@ -1153,6 +1638,19 @@ func ensurePoolEpicContext(ctx context.Context, tickDir, epicID string, agentImp
 		}
 	}
 
+	// Stub agents can't write context; their output would be stored as junk
+	if isStubAgent(agentImpl) {
+		return ""
+	}
+
+	cfg := loadContextConfig(tickDir)
+	if !cfg.IsEnabled() {
+		if !runJSONL {
+			fmt.Printf("⏭ Context skipped: disabled in config\n")
+		}
+		return ""
+	}
+
 	// Get epic and tasks to generate context
 	ticksClient := ticks.NewClient(tickDir)
 	epic, err := ticksClient.GetEpic(epicID)
@@ -1184,7 +1682,7 @@ func ensurePoolEpicContext(ctx context.Context, tickDir, epicID string, agentImp
 		fmt.Printf("📚 Generating epic context for %s (%d tasks)...\n", epicID, len(tasks))
 	}
 
-	contextGenerator, err := epiccontext.NewGenerator(agentImpl)
+	contextGenerator, err := epiccontext.NewGenerator(agentImpl, epiccontext.OptionsFromConfig(cfg)...)
 	if err != nil {
 		if !runJSONL {
 			fmt.Printf("⚠ Context generation failed: %v\n", err)
@@ -1330,7 +1828,9 @@ type poolOutput struct {
 }
 
 // runParallelEpicsWithPool runs multiple epics in parallel worktrees, each with pool mode.
-func runParallelEpicsWithPool(ctx context.Context, root string, epicIDs []string, agentImpl agent.Agent, poolSize int, staleTimeout time.Duration) (*parallel.ParallelResult, error) {
+// Every pool task's usage counts against total, and workers in all epics
+// stop claiming tasks once it is used up.
+func runParallelEpicsWithPool(ctx context.Context, root string, epicIDs []string, agentImpl agent.Agent, poolSize int, staleTimeout time.Duration, total *budget.Tracker) (*parallel.ParallelResult, error) {
 	tickDir := filepath.Join(root, ".tick")
 
 	// Create worktree manager
@@ -1367,27 +1867,16 @@ func runParallelEpicsWithPool(ctx context.Context, root string, epicIDs []string
 		eng.SetRunRecordStore(runRecordStore)
 
 		if !runSkipVerify {
-			eng.EnableVerification()
+			enableVerification(eng, ticksClient, tickDir)
 		}
 
 		// Context generation for epics
-		contextStore := epiccontext.NewStoreWithDir(filepath.Join(tickDir, "logs", "context"))
-		contextGenerator, err := epiccontext.NewGenerator(agentImpl)
-		if err == nil {
-			eng.SetContextComponents(contextStore, contextGenerator)
+		if !isStubAgent(agentImpl) {
+			enableEpicContext(eng, agentImpl, tickDir)
 		}
 
 		if !runJSONL {
-			eng.OnOutput = func(chunk string) {
-				fmt.Printf("[%s] %s", epicID, chunk)
-			}
-			eng.OnIterationStart = func(ctx engine.IterationContext) {
-				fmt.Printf("\n=== [%s] Iteration %d: %s (%s) ===\n", epicID, ctx.Iteration, ctx.Task.ID, ctx.Task.Title)
-			}
-			eng.OnIterationEnd = func(result *engine.IterationResult) {
-				fmt.Printf("\n--- [%s] Iteration %d complete (tokens: %d, cost: $%.4f) ---\n",
-					epicID, result.Iteration, result.TokensIn+result.TokensOut, result.Cost)
-			}
+			engine.NewConsoleOutput(runVerbosity(), epicID).Attach(eng)
 		}
 
 		return eng
@@ -1406,6 +1895,7 @@ func runParallelEpicsWithPool(ctx context.Context, root string, epicIDs []string
 		EngineConfig: engine.RunConfig{
 			MaxIterations:     runMaxIterations,
 			MaxCost:           runMaxCost / float64(len(epicIDs)),
+			MaxDuration:       maxDurationLeft(),
 			CheckpointEvery:   runCheckpointEvery,
 			MaxTaskRetries:    runMaxTaskRetries,
 			AgentTimeout:      runTimeout,
@@ -1418,6 +1908,10 @@ func runParallelEpicsWithPool(ctx context.Context, root string, epicIDs []string
 		// Pass pool config to runner
 		PoolSize:     poolSize,
 		StaleTimeout: staleTimeout,
+		PoolRunTaskFactory: func(epicID, workDir string) pool.RunTaskFunc {
+			return budgetedPoolTask(createPoolTaskRunner(ctx, root, agentImpl, "", nil), total, epicID)
+		},
+		PoolShouldStop: budgetSpent(total),
 	}
 
 	runner := parallel.NewRunner(runnerConfig)
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/config"
+	"github.com/pengelbrecht/ticks/internal/migrate"
+)
+
+// autoMigrate applies pending migrations without asking (--migrate).
+var autoMigrate bool
+
+// schemaExempt lists the commands that run whatever the schema version of
+// the .tick data, because they create, upgrade or never read it.
+var schemaExempt = map[string]bool{
+	"init":         true,
+	"migrate":      true,
+	"version":      true,
+	"upgrade":      true,
+	"help":         true,
+	"snippet":      true,
+	"merge-file":   true,
+	"merge-driver": true,
+}
+
+// checkSchemaVersion stops a command from running on .tick data older than
+// this tk supports. On a terminal the user is asked whether to migrate;
+// with --migrate the migrations are applied without asking. Repos without
+// a readable config are left for the command itself to report.
+func checkSchemaVersion(cmd *cobra.Command) error {
+	top := cmd
+	for top.HasParent() && top.Parent().HasParent() {
+		top = top.Parent()
+	}
+	if schemaExempt[top.Name()] {
+		return nil
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return nil
+	}
+	tickDir := filepath.Join(root, ".tick")
+	cfg, err := config.Load(filepath.Join(tickDir, "config.json"))
+	if err != nil {
+		return nil
+	}
+	current, latest := cfg.GetSchemaVersion(), migrate.LatestVersion()
+	if current >= latest {
+		return nil
+	}
+
+	if !autoMigrate {
+		if !stdinIsTerminal() {
+			return NewExitError(ExitGeneric, ".tick data is schema version %d but tk uses version %d; run tk migrate or pass --migrate", current, latest)
+		}
+		fmt.Fprintf(os.Stderr, ".tick data is schema version %d but tk uses version %d.\nRun the pending migrations now? [y/N] ", current, latest)
+		response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			return NewExitError(ExitGeneric, "migration declined; run tk migrate to upgrade .tick data")
+		}
+	}
+
+	reports, err := migrate.Upgrade(tickDir)
+	if err != nil {
+		return NewExitError(ExitIO, "migration failed: %v", err)
+	}
+	for _, r := range reports {
+		if !r.Needed {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Migrated %s: %d migrated, %d skipped\n", r.Name, r.Result.Migrated, r.Result.Skipped)
+		if len(r.Result.Errors) > 0 {
+			return NewExitError(ExitIO, "%s migration had %d error(s); run tk migrate for details", r.Name, len(r.Result.Errors))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Upgraded .tick data to schema version %d\n", latest)
+	return nil
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal.
+var stdinIsTerminal = func() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// SetTerminalCheck replaces the check for an interactive stdin, so tests can
+// drive prompts with scripted input. It returns a function that restores the
+// previous check.
+func SetTerminalCheck(fn func() bool) (restore func()) {
+	prev := stdinIsTerminal
+	stdinIsTerminal = fn
+	return func() { stdinIsTerminal = prev }
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/query"
+	"github.com/pengelbrecht/ticks/internal/styles"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search ticks by title, description, and notes",
+	Long: `Search ticks by title, description, and notes.
+
+Matching is case-insensitive. Ticks whose title matches are listed
+before ticks that only match in their description, then notes.
+Searches all owners.
+
+Examples:
+  tk search stripe                  # Find ticks mentioning stripe
+  tk search "webhook|callback" --regex
+  tk search oauth --type bug --status open
+  tk search oauth --json            # Include which fields matched`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
+}
+
+var (
+	searchRegex  bool
+	searchType   string
+	searchStatus []string
+	searchJSON   bool
+)
+
+// searchResult is the JSON output for one search match.
+type searchResult struct {
+	Tick   tick.Tick `json:"tick"`
+	Fields []string  `json:"fields"`
+}
+
+func init() {
+	searchCmd.Flags().BoolVar(&searchRegex, "regex", false, "treat the query as a regular expression")
+	searchCmd.Flags().StringVarP(&searchType, "type", "t", "", "type (task|epic|bug|feature|chore)")
+	searchCmd.Flags().StringArrayVarP(&searchStatus, "status", "s", nil, "status (open|in_progress|closed); repeatable or comma-separated")
+	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(args[0]) == "" {
+		return NewExitError(ExitUsage, "search query is empty")
+	}
+	re, err := query.CompileSearch(args[0], searchRegex)
+	if err != nil {
+		return NewExitError(ExitUsage, "invalid --regex pattern: %v", err)
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect repo root: %w", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	filtered := query.Apply(ticks, query.Filter{
+		StatusAny: splitCSV(strings.Join(searchStatus, ",")),
+		Type:      strings.TrimSpace(searchType),
+	})
+	matches := query.Search(filtered, re)
+
+	if searchJSON {
+		out := make([]searchResult, 0, len(matches))
+		for _, m := range matches {
+			out = append(out, searchResult{Tick: m.Tick, Fields: m.Fields})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	// Same column layout as tk list
+	idWidth, typeWidth := len("ID"), len("TYPE")
+	for _, m := range matches {
+		idWidth = max(idWidth, len(m.Tick.ID))
+		typeWidth = max(typeWidth, len(m.Tick.Type))
+	}
+	const priWidth, statusWidth = len("PRI"), len("ST")
+	indent := strings.Repeat(" ", idWidth+3)
+
+	header := fmt.Sprintf(" %-*s  %-*s  %-*s  %-*s  %s", idWidth, "ID", priWidth, "PRI", typeWidth, "TYPE", statusWidth, "ST", "TITLE")
+	fmt.Println(styles.DimStyle.Render(header))
+
+	for _, m := range matches {
+		t := m.Tick
+		fmt.Printf(" %-*s  %s  %s  %s  %s\n",
+			idWidth, t.ID,
+			styles.PadRight(styles.RenderPriority(t.Priority), priWidth),
+			styles.PadRight(styles.RenderType(t.Type), typeWidth),
+			styles.PadRight(styles.RenderTickStatus(t), statusWidth),
+			styles.RenderMatches(t.Title, re),
+		)
+		// Show where body-only matches were found
+		if m.Fields[0] != query.FieldTitle {
+			text := t.Description
+			if m.Fields[0] == query.FieldNotes {
+				text = t.Notes
+			}
+			fmt.Printf("%s%s %s\n", indent, styles.DimStyle.Render(m.Fields[0]+":"), styles.RenderMatches(matchLine(text, re), re))
+		}
+	}
+	fmt.Printf("\n%d ticks\n", len(matches))
+	return nil
+}
+
+// matchLine returns the first line of text that matches re, trimmed and
+// shortened to about 80 characters around the match.
+func matchLine(text string, re *regexp.Regexp) string {
+	const width = 80
+	for _, line := range strings.Split(text, "\n") {
+		loc := re.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		line = strings.TrimSpace(line)
+		if len(line) <= width {
+			return line
+		}
+		loc = re.FindStringIndex(line)
+		start := loc[0] - width/2
+		if start < 0 {
+			start = 0
+		}
+		end := start + width
+		if end > len(line) {
+			end = len(line)
+			start = max(0, end-width)
+		}
+		// Avoid cutting through a multi-byte rune
+		for start > 0 && !utf8.RuneStart(line[start]) {
+			start--
+		}
+		for end < len(line) && !utf8.RuneStart(line[end]) {
+			end++
+		}
+		snippet := line[start:end]
+		if start > 0 {
+			snippet = "…" + snippet
+		}
+		if end < len(line) {
+			snippet += "…"
+		}
+		return snippet
+	}
+	return ""
+}
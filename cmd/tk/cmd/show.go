@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/styles"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
@@ -22,19 +23,132 @@ var showCmd = &cobra.Command{
 	Long: `Show the full details of a tick by its ID.
 
 Displays all tick metadata including title, description, notes, labels,
-blockers, and timestamps. Use --json for machine-readable output.`,
+blockers, and timestamps. Use --json for machine-readable output.
+
+With --children, an epic is followed by its open child tasks in wave order
+(the same waves as tk graph); any other tick is followed by the ticks it blocks.
+
+With --blockers-tree, the tick is followed by every upstream blocker: its
+blockers, their blockers, and so on. Closed blockers end a branch since they
+no longer block, and blockers that lead back into the chain are marked as a
+cycle. The longest chain of open blockers is marked as the critical path, the
+one that decides when the tick becomes ready.
+
+With --history, the tick is followed by its audit trail from the activity
+log: who changed it, when, and which fields.
+
+With --related, the tick is followed by how its work branched: the tick it
+was discovered from, the ticks discovered from it, and its siblings under the
+same parent.
+
+Examples:
+  tk show abc                    # Tick details
+  tk show abc --children         # Epic plus its task plan
+  tk show abc --children --json  # Same, machine-readable
+  tk show abc --blockers-tree    # Everything abc is waiting on
+  tk show abc --history          # Who changed abc and what
+  tk show abc --related          # Discovered-from source, follow-ups, siblings`,
 	Args: cobra.ExactArgs(1),
 	RunE: runShow,
 }
 
-var showJSON bool
+var (
+	showJSON         bool
+	showChildren     bool
+	showBlockersTree bool
+	showHistory      bool
+	showRelated      bool
+)
+
+// showChildrenOutput is the JSON shape for show --children.
+type showChildrenOutput struct {
+	Tick tick.Tick `json:"tick"`
+	// Relation is "children" for an epic's tasks or "blocks" for ticks blocked by this one.
+	Relation string      `json:"relation"`
+	Children []showChild `json:"children"`
+}
+
+// showChild is a compact summary of a related tick.
+type showChild struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Priority int    `json:"priority"`
+	Status   string `json:"status"`
+	Awaiting string `json:"awaiting,omitempty"`
+	Wave     int    `json:"wave,omitempty"`
+	// Missing is set when the id does not resolve to a tick.
+	Missing bool `json:"missing,omitempty"`
+
+	tick tick.Tick
+}
+
+// showBlockersOutput is the JSON shape for show --blockers-tree.
+type showBlockersOutput struct {
+	Tick     tick.Tick     `json:"tick"`
+	Blockers []blockerNode `json:"blockers"`
+}
+
+// showHistoryOutput is the JSON shape for show --history.
+type showHistoryOutput struct {
+	Tick    tick.Tick       `json:"tick"`
+	History []tick.Activity `json:"history"`
+}
+
+// showRelatedOutput is the JSON shape for show --related.
+type showRelatedOutput struct {
+	Tick tick.Tick `json:"tick"`
+	// DiscoveredFrom is the tick named in the tick's discovered_from field.
+	DiscoveredFrom *showChild `json:"discovered_from"`
+	// Discovered lists the ticks whose discovered_from is this tick.
+	Discovered []showChild `json:"discovered"`
+	// Siblings lists the other ticks under the same parent.
+	Siblings []showChild `json:"siblings"`
+}
+
+// blockerNode is one upstream blocker in show --blockers-tree.
+type blockerNode struct {
+	ID       string `json:"id"`
+	Title    string `json:"title,omitempty"`
+	Priority int    `json:"priority"`
+	Status   string `json:"status"`
+	Awaiting string `json:"awaiting,omitempty"`
+	// Missing is set when the blocker id does not resolve to a tick.
+	Missing bool `json:"missing,omitempty"`
+	// Cycle is set when the blocker already appears higher up the branch;
+	// it is not expanded again.
+	Cycle bool `json:"cycle,omitempty"`
+	// Repeat is set when the blocker was already expanded elsewhere in the
+	// tree; it is not expanded again.
+	Repeat bool `json:"repeat,omitempty"`
+	// Critical marks the longest chain of open blockers.
+	Critical  bool          `json:"critical,omitempty"`
+	BlockedBy []blockerNode `json:"blocked_by,omitempty"`
+
+	// depth is the length of the longest chain of open blockers starting
+	// here, including below a repeat.
+	depth int
+}
 
 func init() {
 	showCmd.Flags().BoolVar(&showJSON, "json", false, "output as JSON")
+	showCmd.Flags().BoolVar(&showChildren, "children", false, "list child tasks in wave order (epics) or ticks this one blocks")
+	showCmd.Flags().BoolVar(&showBlockersTree, "blockers-tree", false, "show all upstream blockers as a tree")
+	showCmd.Flags().BoolVar(&showHistory, "history", false, "show who changed the tick and which fields")
+	showCmd.Flags().BoolVar(&showRelated, "related", false, "show the discovered-from source, ticks discovered from this one, and siblings")
 	rootCmd.AddCommand(showCmd)
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
+	if showChildren && showBlockersTree {
+		return NewExitError(ExitUsage, "--children cannot be combined with --blockers-tree")
+	}
+	if showHistory && (showChildren || showBlockersTree) {
+		return NewExitError(ExitUsage, "--history cannot be combined with --children or --blockers-tree")
+	}
+	if showRelated && (showChildren || showBlockersTree || showHistory) {
+		return NewExitError(ExitUsage, "--related cannot be combined with --children, --blockers-tree or --history")
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to detect repo root: %w", err)
@@ -45,20 +159,72 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
 
-	id, err := github.NormalizeID(project, args[0])
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	id, err := resolveTickID(store, project, args[0])
 	if err != nil {
-		return fmt.Errorf("invalid id: %w", err)
+		return err
 	}
 
-	store := tick.NewStore(filepath.Join(root, ".tick"))
 	t, err := store.Read(id)
 	if err != nil {
 		return fmt.Errorf("failed to read tick: %w", err)
 	}
 
+	var relation string
+	var children []showChild
+	if showChildren {
+		allTicks, err := store.List()
+		if err != nil {
+			return fmt.Errorf("failed to list ticks: %w", err)
+		}
+		relation, children = collectShowChildren(t, allTicks)
+	}
+
+	var blockers []blockerNode
+	if showBlockersTree {
+		allTicks, err := store.List()
+		if err != nil {
+			return fmt.Errorf("failed to list ticks: %w", err)
+		}
+		blockers = buildBlockerTree(t, allTicks)
+	}
+
+	var related showRelatedOutput
+	if showRelated {
+		allTicks, err := store.List()
+		if err != nil {
+			return fmt.Errorf("failed to list ticks: %w", err)
+		}
+		related = collectShowRelated(t, allTicks)
+	}
+
+	var history []tick.Activity
+	if showHistory {
+		history, err = store.History(t.ID)
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+		if history == nil {
+			history = []tick.Activity{}
+		}
+	}
+
 	if showJSON {
+		var payload any = t
+		if showChildren {
+			payload = showChildrenOutput{Tick: t, Relation: relation, Children: children}
+		}
+		if showBlockersTree {
+			payload = showBlockersOutput{Tick: t, Blockers: blockers}
+		}
+		if showHistory {
+			payload = showHistoryOutput{Tick: t, History: history}
+		}
+		if showRelated {
+			payload = related
+		}
 		enc := json.NewEncoder(os.Stdout)
-		if err := enc.Encode(t); err != nil {
+		if err := enc.Encode(payload); err != nil {
 			return fmt.Errorf("failed to encode json: %w", err)
 		}
 		return nil
@@ -131,9 +297,10 @@ func runShow(cmd *cobra.Command, args []string) error {
 	}
 	if len(t.BlockedBy) > 0 {
 		var blocked []string
+		blockers, _ := store.ReadMany(t.BlockedBy)
 		for _, blocker := range t.BlockedBy {
-			blk, err := store.Read(blocker)
-			if err != nil {
+			blk, ok := blockers[blocker]
+			if !ok {
 				blocked = append(blocked, fmt.Sprintf("%s (unknown)", blocker))
 				continue
 			}
@@ -155,7 +322,7 @@ func runShow(cmd *cobra.Command, args []string) error {
 	lines = append(lines, "")
 	lines = append(lines, styles.RenderDim(fmt.Sprintf("Created: %s by %s", formatTime(t.CreatedAt), t.CreatedBy)))
 	lines = append(lines, styles.RenderDim(fmt.Sprintf("Updated: %s", formatTime(t.UpdatedAt))))
-	lines = append(lines, styles.RenderDim(fmt.Sprintf("Global:  %s:%s", project, t.ID)))
+	lines = append(lines, styles.RenderDim("Global:  "+github.GlobalID(project, t.ID)))
 
 	// Render in box
 	content := strings.Join(lines, "\n")
@@ -166,9 +333,316 @@ func runShow(cmd *cobra.Command, args []string) error {
 		Render(content)
 
 	fmt.Println(box)
+
+	if showChildren {
+		printShowChildren(relation, children)
+	}
+	if showBlockersTree {
+		printBlockerTree(blockers)
+	}
+	if showHistory {
+		printShowHistory(history)
+	}
+	if showRelated {
+		printShowRelated(related)
+	}
 	return nil
 }
 
+// collectShowChildren returns the ticks related to t for show --children.
+// For epics these are the open direct children, ordered and numbered by wave.
+// For other ticks these are the ticks listing t as a blocker.
+func collectShowChildren(t tick.Tick, allTicks []tick.Tick) (string, []showChild) {
+	children := []showChild{}
+
+	if t.Type == tick.TypeEpic {
+		var tasks []tick.Tick
+		for _, c := range allTicks {
+			if c.Parent == t.ID && c.Type != tick.TypeEpic && c.Status != tick.StatusClosed {
+				tasks = append(tasks, c)
+			}
+		}
+		waves, cycle := query.Waves(tasks)
+		for i, w := range waves {
+			for _, c := range w {
+				children = append(children, newShowChild(c, i+1))
+			}
+		}
+		// Tasks stuck in a dependency cycle still belong to the epic; list them last without a wave.
+		byID := make(map[string]tick.Tick, len(tasks))
+		for _, c := range tasks {
+			byID[c.ID] = c
+		}
+		for _, id := range cycle {
+			children = append(children, newShowChild(byID[id], 0))
+		}
+		return "children", children
+	}
+
+	var blocked []tick.Tick
+	for _, c := range allTicks {
+		for _, blockerID := range c.BlockedBy {
+			if blockerID == t.ID {
+				blocked = append(blocked, c)
+				break
+			}
+		}
+	}
+	query.SortByPriorityCreatedAt(blocked)
+	for _, c := range blocked {
+		children = append(children, newShowChild(c, 0))
+	}
+	return "blocks", children
+}
+
+// collectShowRelated returns the discovered-from source of t, the ticks
+// discovered from t, and t's siblings under the same parent. The lists are
+// sorted by priority, then creation time; closed ticks are included since
+// they are part of how the work branched.
+func collectShowRelated(t tick.Tick, allTicks []tick.Tick) showRelatedOutput {
+	out := showRelatedOutput{Tick: t, Discovered: []showChild{}, Siblings: []showChild{}}
+
+	var discovered, siblings []tick.Tick
+	for _, c := range allTicks {
+		if c.ID == t.ID {
+			continue
+		}
+		if t.DiscoveredFrom != "" && c.ID == t.DiscoveredFrom {
+			source := newShowChild(c, 0)
+			out.DiscoveredFrom = &source
+		}
+		if c.DiscoveredFrom == t.ID {
+			discovered = append(discovered, c)
+		}
+		if t.Parent != "" && c.Parent == t.Parent {
+			siblings = append(siblings, c)
+		}
+	}
+	if t.DiscoveredFrom != "" && out.DiscoveredFrom == nil {
+		out.DiscoveredFrom = &showChild{ID: t.DiscoveredFrom, Missing: true}
+	}
+
+	query.SortByPriorityCreatedAt(discovered)
+	for _, c := range discovered {
+		out.Discovered = append(out.Discovered, newShowChild(c, 0))
+	}
+	query.SortByPriorityCreatedAt(siblings)
+	for _, c := range siblings {
+		out.Siblings = append(out.Siblings, newShowChild(c, 0))
+	}
+	return out
+}
+
+func newShowChild(t tick.Tick, wave int) showChild {
+	return showChild{
+		ID:       t.ID,
+		Title:    t.Title,
+		Priority: t.Priority,
+		Status:   t.Status,
+		Awaiting: t.GetAwaitingType(),
+		Wave:     wave,
+		tick:     t,
+	}
+}
+
+// printShowChildren prints the related ticks below the detail box.
+func printShowChildren(relation string, children []showChild) {
+	heading := "Children"
+	if relation == "blocks" {
+		heading = "Blocks"
+	}
+	fmt.Printf("\n%s (%d)\n", styles.RenderHeader(heading+":"), len(children))
+
+	lastWave := -1
+	for _, c := range children {
+		if relation == "children" && c.Wave != lastWave {
+			label := fmt.Sprintf("Wave %d", c.Wave)
+			if c.Wave == 0 {
+				label = "Cycle"
+			}
+			fmt.Println(styles.DimStyle.Render(label))
+			lastWave = c.Wave
+		}
+		printShowChild(c)
+	}
+}
+
+// printShowChild prints one related tick as an indented summary line.
+func printShowChild(c showChild) {
+	if c.Missing {
+		fmt.Printf("  %s  %s\n", styles.RenderID(c.ID), styles.RenderDim("(not found)"))
+		return
+	}
+	fmt.Printf("  %s\n", c.tick.SummaryStyled(styles.Summary, false))
+}
+
+// printShowRelated prints the related ticks for show --related below the
+// detail box. Empty groups are shown with a count of zero.
+func printShowRelated(related showRelatedOutput) {
+	fmt.Printf("\n%s\n", styles.RenderHeader("Discovered from:"))
+	if related.DiscoveredFrom != nil {
+		printShowChild(*related.DiscoveredFrom)
+	} else {
+		fmt.Println(styles.RenderDim("  (none)"))
+	}
+
+	fmt.Printf("\n%s (%d)\n", styles.RenderHeader("Discovered:"), len(related.Discovered))
+	for _, c := range related.Discovered {
+		printShowChild(c)
+	}
+
+	fmt.Printf("\n%s (%d)\n", styles.RenderHeader("Siblings:"), len(related.Siblings))
+	for _, c := range related.Siblings {
+		printShowChild(c)
+	}
+}
+
+// printShowHistory prints a tick's activity entries below the detail box,
+// oldest first.
+func printShowHistory(history []tick.Activity) {
+	fmt.Printf("\n%s (%d)\n", styles.RenderHeader("History:"), len(history))
+	for _, a := range history {
+		actor := a.Actor
+		if actor == "" {
+			actor = "unknown"
+		}
+		line := fmt.Sprintf("  %s  %-16s  %-8s", formatTime(a.Timestamp.Local()), actor, a.Action)
+		if fields := activityFields(a); len(fields) > 0 {
+			line += "  " + strings.Join(fields, ", ")
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+}
+
+// activityFields returns the changed field names recorded on an activity
+// entry. Entries written before fields were recorded have none.
+func activityFields(a tick.Activity) []string {
+	raw, _ := a.Data["fields"].([]interface{})
+	fields := make([]string, 0, len(raw))
+	for _, f := range raw {
+		if name, ok := f.(string); ok {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// buildBlockerTree walks t's blockers recursively. Closed and missing
+// blockers are leaves, and a blocker already on the current branch is
+// recorded as a cycle instead of being expanded again. Each blocker is
+// expanded once; later occurrences are recorded as repeats, so shared
+// blockers don't multiply the tree.
+func buildBlockerTree(t tick.Tick, allTicks []tick.Tick) []blockerNode {
+	byID := make(map[string]tick.Tick, len(allTicks))
+	for _, tk := range allTicks {
+		byID[tk.ID] = tk
+	}
+
+	branch := map[string]bool{t.ID: true}
+	depths := make(map[string]int) // ids already expanded
+	var walk func(ids []string) []blockerNode
+	walk = func(ids []string) []blockerNode {
+		nodes := []blockerNode{}
+		for _, id := range ids {
+			blk, ok := byID[id]
+			if !ok {
+				nodes = append(nodes, blockerNode{ID: id, Status: "unknown", Missing: true})
+				continue
+			}
+			node := blockerNode{
+				ID:       blk.ID,
+				Title:    blk.Title,
+				Priority: blk.Priority,
+				Status:   blk.Status,
+				Awaiting: blk.GetAwaitingType(),
+			}
+			if blk.Status != tick.StatusClosed {
+				node.depth = 1
+			}
+			switch {
+			case branch[id]:
+				node.Cycle = true
+			case blk.Status == tick.StatusClosed || len(blk.BlockedBy) == 0:
+				// Leaf: nothing to expand
+			case depths[id] > 0:
+				node.Repeat = true
+				node.depth = depths[id]
+			default:
+				branch[id] = true
+				node.BlockedBy = walk(blk.BlockedBy)
+				delete(branch, id)
+				for _, c := range node.BlockedBy {
+					node.depth = max(node.depth, c.depth+1)
+				}
+				depths[id] = node.depth
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	nodes := walk(t.BlockedBy)
+	markCriticalPath(nodes)
+	return nodes
+}
+
+// markCriticalPath marks the first of the longest chains of open blockers.
+// Closed and missing blockers don't block.
+func markCriticalPath(nodes []blockerNode) {
+	best, bestDepth := -1, 0
+	for i := range nodes {
+		if d := nodes[i].depth; d > bestDepth {
+			best, bestDepth = i, d
+		}
+	}
+	if best == -1 {
+		return
+	}
+	nodes[best].Critical = true
+	markCriticalPath(nodes[best].BlockedBy)
+}
+
+// printBlockerTree prints the upstream blockers below the detail box.
+func printBlockerTree(nodes []blockerNode) {
+	fmt.Printf("\n%s\n", styles.RenderHeader("Blocker tree:"))
+	if len(nodes) == 0 {
+		fmt.Println(styles.RenderDim("  none"))
+		return
+	}
+	printBlockerNodes(nodes, "  ")
+}
+
+func printBlockerNodes(nodes []blockerNode, prefix string) {
+	for i, n := range nodes {
+		connector, childPrefix := "├─ ", "│  "
+		if i == len(nodes)-1 {
+			connector, childPrefix = "└─ ", "   "
+		}
+
+		status := n.Status
+		if n.Awaiting != "" {
+			status += " (awaiting " + n.Awaiting + ")"
+		}
+		line := fmt.Sprintf("%s  %s  %s", styles.RenderID(n.ID), styles.RenderPriority(n.Priority), status)
+		switch {
+		case n.Missing:
+			line = fmt.Sprintf("%s  %s", styles.RenderID(n.ID), styles.RenderDim("unknown tick"))
+		case n.Cycle:
+			line += "  " + n.Title + "  " + styles.StatusBlockedStyle.Render("(cycle)")
+		case n.Repeat:
+			line += "  " + n.Title + "  " + styles.RenderDim("(already shown)")
+		default:
+			line += "  " + n.Title
+		}
+		if n.Critical {
+			line += "  " + styles.HeaderStyle.Render("← critical path")
+		}
+
+		fmt.Println(styles.RenderDim(prefix+connector) + line)
+		printBlockerNodes(n.BlockedBy, prefix+childPrefix)
+	}
+}
+
 // formatTime formats a time value for display.
 func formatTime(t time.Time) string {
 	if t.IsZero() {
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/query"
+	"github.com/pengelbrecht/ticks/internal/styles"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "List ticks that haven't been updated in a while",
+	Long: `List stale ticks.
+
+Stale ticks are open or in-progress ticks whose last update is older than
+--days days (default 14), a sign of abandoned work. They are listed
+oldest update first. By default, only shows ticks owned by the current user.
+
+Examples:
+  # List ticks untouched for two weeks
+  tk stale
+
+  # In-progress ticks untouched for a week, all owners
+  tk stale --days 7 --status in_progress --all
+
+  # Only ticks stuck waiting on a human
+  tk stale --awaiting-only
+
+  # Output as JSON
+  tk stale --json`,
+	Args: cobra.NoArgs,
+	RunE: runStale,
+}
+
+var (
+	staleDays         int
+	staleStatus       string
+	staleAwaitingOnly bool
+	staleAll          bool
+	staleOwner        string
+	staleJSON         bool
+)
+
+func init() {
+	staleCmd.Flags().IntVar(&staleDays, "days", 14, "days without updates before a tick is stale")
+	staleCmd.Flags().StringVarP(&staleStatus, "status", "s", "", "status (open|in_progress); default both")
+	staleCmd.Flags().BoolVar(&staleAwaitingOnly, "awaiting-only", false, "only ticks awaiting human action")
+	staleCmd.Flags().BoolVarP(&staleAll, "all", "a", false, "all owners")
+	staleCmd.Flags().StringVarP(&staleOwner, "owner", "o", "", "owner")
+	staleCmd.Flags().BoolVar(&staleJSON, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(staleCmd)
+}
+
+func runStale(cmd *cobra.Command, args []string) error {
+	if staleDays < 0 {
+		return NewExitError(ExitUsage, "--days must not be negative")
+	}
+	switch staleStatus {
+	case "", tick.StatusOpen, tick.StatusInProgress:
+	default:
+		return NewExitError(ExitUsage, "invalid --status %q: must be open or in_progress", staleStatus)
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect repo root: %w", err)
+	}
+
+	owner, err := resolveOwner(staleAll, staleOwner)
+	if err != nil {
+		return fmt.Errorf("failed to detect owner: %w", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	now := time.Now()
+	stale := findStale(query.Apply(ticks, query.Filter{Owner: owner}), now,
+		time.Duration(staleDays)*24*time.Hour, staleStatus, staleAwaitingOnly)
+
+	if staleJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(listOutput{Ticks: newListTicks(stale, ticks)}); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	// Same column layout as tk list, plus the age
+	idWidth, typeWidth, ageWidth := len("ID"), len("TYPE"), len("STALE")
+	ages := make([]string, len(stale))
+	for i, t := range stale {
+		idWidth = max(idWidth, len(t.ID))
+		typeWidth = max(typeWidth, len(t.Type))
+		ages[i] = formatAge(t.StaleSince(now).Hours())
+		ageWidth = max(ageWidth, len(ages[i]))
+	}
+	const priWidth, statusWidth = len("PRI"), len("ST")
+
+	header := fmt.Sprintf(" %-*s  %-*s  %-*s  %-*s  %-*s  %s", idWidth, "ID", priWidth, "PRI", typeWidth, "TYPE", statusWidth, "ST", ageWidth, "STALE", "TITLE")
+	fmt.Println(styles.DimStyle.Render(header))
+
+	for i, t := range stale {
+		fmt.Printf(" %-*s  %s  %s  %s  %-*s  %s\n",
+			idWidth, t.ID,
+			styles.PadRight(styles.RenderPriority(t.Priority), priWidth),
+			styles.PadRight(styles.RenderType(t.Type), typeWidth),
+			styles.PadRight(styles.RenderTickStatus(t), statusWidth),
+			ageWidth, ages[i],
+			t.Title,
+		)
+	}
+	fmt.Printf("\n%d ticks (stale > %dd)\n", len(stale), staleDays)
+	return nil
+}
+
+// findStale returns the non-closed ticks last updated more than maxAge
+// before now, oldest update first. A non-empty status keeps only ticks in
+// that status; awaitingOnly keeps only ticks awaiting human action.
+func findStale(ticks []tick.Tick, now time.Time, maxAge time.Duration, status string, awaitingOnly bool) []tick.Tick {
+	var stale []tick.Tick
+	for _, t := range ticks {
+		if t.Status == tick.StatusClosed {
+			continue
+		}
+		if status != "" && t.Status != status {
+			continue
+		}
+		if awaitingOnly && !t.IsAwaitingHuman() {
+			continue
+		}
+		if t.StaleSince(now) > maxAge {
+			stale = append(stale, t)
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		if !stale[i].UpdatedAt.Equal(stale[j].UpdatedAt) {
+			return stale[i].UpdatedAt.Before(stale[j].UpdatedAt)
+		}
+		return stale[i].ID < stale[j].ID
+	})
+	return stale
+}
@@ -3,9 +3,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -22,7 +25,8 @@ var statsCmd = &cobra.Command{
 	Long: `Show repository statistics.
 
 Displays summary statistics about ticks in the repository including
-counts by status, priority, and type, as well as ready and blocked counts.
+counts by status, priority, and type, ready, blocked, awaiting-human and
+deferred counts, and the median age of open ticks.
 
 Examples:
   # Show stats for current user
@@ -31,6 +35,9 @@ Examples:
   # Show stats for all owners
   tk stats --all
 
+  # Break counts down per owner (implies --all)
+  tk stats --by-owner
+
   # Output as JSON
   tk stats --json`,
 	Args: cobra.NoArgs,
@@ -38,13 +45,38 @@ Examples:
 }
 
 var (
-	statsAll  bool
-	statsJSON bool
+	statsAll     bool
+	statsJSON    bool
+	statsByOwner bool
 )
 
+// boardStats holds aggregate counts for a set of ticks.
+type boardStats struct {
+	Total    int            `json:"total"`
+	Open     int            `json:"open"`
+	Closed   int            `json:"closed"`
+	Status   map[string]int `json:"status"`
+	Priority map[int]int    `json:"priority"`
+	Type     map[string]int `json:"type"`
+	Ready    int            `json:"ready"`
+	Blocked  int            `json:"blocked"`
+	// AwaitingHuman and Deferred count only ticks that are not closed.
+	AwaitingHuman int `json:"awaiting_human"`
+	Deferred      int `json:"deferred"`
+	// MedianOpenAgeHours is the median time since creation of ticks that are not closed.
+	MedianOpenAgeHours float64 `json:"median_open_age_hours"`
+}
+
+// statsOutput is the JSON output of tk stats.
+type statsOutput struct {
+	boardStats
+	Owners map[string]boardStats `json:"owners,omitempty"`
+}
+
 func init() {
 	statsCmd.Flags().BoolVarP(&statsAll, "all", "a", false, "all owners")
 	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "output as JSON")
+	statsCmd.Flags().BoolVar(&statsByOwner, "by-owner", false, "group counts per owner (implies --all)")
 
 	rootCmd.AddCommand(statsCmd)
 }
@@ -55,7 +87,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to detect repo root: %w", err)
 	}
 
-	owner, err := resolveOwner(statsAll, "")
+	owner, err := resolveOwner(statsAll || statsByOwner, "")
 	if err != nil {
 		return fmt.Errorf("failed to detect owner: %w", err)
 	}
@@ -67,31 +99,24 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 
 	filtered := query.Apply(ticks, query.Filter{Owner: owner})
+	now := time.Now()
+	stats := computeStats(filtered, ticks, now)
 
-	statusCounts := make(map[string]int)
-	priorityCounts := make(map[int]int)
-	typeCounts := make(map[string]int)
-
-	for _, t := range filtered {
-		statusCounts[t.Status]++
-		priorityCounts[t.Priority]++
-		typeCounts[t.Type]++
+	var owners map[string]boardStats
+	if statsByOwner {
+		byOwner := make(map[string][]tick.Tick)
+		for _, t := range filtered {
+			byOwner[t.Owner] = append(byOwner[t.Owner], t)
+		}
+		owners = make(map[string]boardStats, len(byOwner))
+		for o, group := range byOwner {
+			owners[o] = computeStats(group, ticks, now)
+		}
 	}
 
-	ready := query.Ready(filtered, ticks)
-	blocked := query.Blocked(filtered, ticks)
-
 	if statsJSON {
-		payload := map[string]any{
-			"total":    len(filtered),
-			"status":   statusCounts,
-			"priority": priorityCounts,
-			"type":     typeCounts,
-			"ready":    len(ready),
-			"blocked":  len(blocked),
-		}
 		enc := json.NewEncoder(os.Stdout)
-		if err := enc.Encode(payload); err != nil {
+		if err := enc.Encode(statsOutput{boardStats: stats, Owners: owners}); err != nil {
 			return fmt.Errorf("failed to encode json: %w", err)
 		}
 		return nil
@@ -106,18 +131,38 @@ func runStats(cmd *cobra.Command, args []string) error {
 	var lines []string
 	lines = append(lines, styles.HeaderStyle.Render(project))
 	lines = append(lines, "")
-	lines = append(lines, fmt.Sprintf("%s %d ticks", styles.RenderLabel("Total:"), len(filtered)))
+	lines = append(lines, fmt.Sprintf("%s %d ticks (%d open, %d closed)", styles.RenderLabel("Total:"), stats.Total, stats.Open, stats.Closed))
 	lines = append(lines, "")
-	lines = append(lines, styles.RenderLabel("Status:")+"  "+formatStatusCounts(statusCounts))
-	lines = append(lines, styles.RenderLabel("Priority:")+"  "+formatPriorityCounts(priorityCounts))
-	lines = append(lines, styles.RenderLabel("Types:")+"  "+formatTypeCounts(typeCounts))
+	lines = append(lines, styles.RenderLabel("Status:")+"  "+formatStatusCounts(stats.Status))
+	lines = append(lines, styles.RenderLabel("Priority:")+"  "+formatPriorityCounts(stats.Priority))
+	lines = append(lines, styles.RenderLabel("Types:")+"  "+formatTypeCounts(stats.Type))
 	lines = append(lines, "")
 	lines = append(lines, fmt.Sprintf("%s %s",
 		styles.RenderLabel("Ready:"),
-		styles.StatusInProgressStyle.Render(fmt.Sprintf("%d", len(ready)))))
+		styles.StatusInProgressStyle.Render(fmt.Sprintf("%d", stats.Ready))))
 	lines = append(lines, fmt.Sprintf("%s %s",
 		styles.RenderLabel("Blocked:"),
-		styles.StatusBlockedStyle.Render(fmt.Sprintf("%d", len(blocked)))))
+		styles.StatusBlockedStyle.Render(fmt.Sprintf("%d", stats.Blocked))))
+	lines = append(lines, fmt.Sprintf("%s %d", styles.RenderLabel("Awaiting:"), stats.AwaitingHuman))
+	lines = append(lines, fmt.Sprintf("%s %d", styles.RenderLabel("Deferred:"), stats.Deferred))
+	if stats.Open > 0 {
+		lines = append(lines, fmt.Sprintf("%s %s", styles.RenderLabel("Median age:"), formatAge(stats.MedianOpenAgeHours)))
+	}
+
+	if statsByOwner {
+		names := make([]string, 0, len(owners))
+		for o := range owners {
+			names = append(names, o)
+		}
+		sort.Strings(names)
+		lines = append(lines, "")
+		lines = append(lines, styles.RenderLabel("Owners:"))
+		for _, o := range names {
+			st := owners[o]
+			lines = append(lines, fmt.Sprintf("  %-16s %d open · %d closed · %d ready · %d blocked · %d awaiting",
+				o, st.Open, st.Closed, st.Ready, st.Blocked, st.AwaitingHuman))
+		}
+	}
 
 	// Render in box
 	content := strings.Join(lines, "\n")
@@ -131,6 +176,57 @@ func runStats(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// computeStats aggregates counts over subset. allTicks resolves blockers
+// that fall outside the subset.
+func computeStats(subset, allTicks []tick.Tick, now time.Time) boardStats {
+	stats := boardStats{
+		Total:    len(subset),
+		Status:   make(map[string]int),
+		Priority: make(map[int]int),
+		Type:     make(map[string]int),
+		Ready:    len(query.Ready(subset, allTicks)),
+		Blocked:  len(query.Blocked(subset, allTicks)),
+	}
+
+	var ages []float64
+	for _, t := range subset {
+		stats.Status[t.Status]++
+		stats.Priority[t.Priority]++
+		stats.Type[t.Type]++
+		if t.Status == tick.StatusClosed {
+			stats.Closed++
+			continue
+		}
+		stats.Open++
+		if t.IsAwaitingHuman() {
+			stats.AwaitingHuman++
+		}
+		if t.DeferUntil != nil && t.DeferUntil.After(now) {
+			stats.Deferred++
+		}
+		ages = append(ages, t.Age(now).Hours())
+	}
+
+	if n := len(ages); n > 0 {
+		sort.Float64s(ages)
+		median := ages[n/2]
+		if n%2 == 0 {
+			median = (ages[n/2-1] + ages[n/2]) / 2
+		}
+		stats.MedianOpenAgeHours = math.Round(median*10) / 10
+	}
+	return stats
+}
+
+// formatAge renders an age in hours as days and hours, e.g. "3d 4h".
+func formatAge(hours float64) string {
+	h := int(hours)
+	if h < 24 {
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dd %dh", h/24, h%24)
+}
+
 func formatStatusCounts(counts map[string]int) string {
 	open := styles.StatusOpenStyle.Render(fmt.Sprintf("%s %d", styles.IconOpen, counts[tick.StatusOpen]))
 	inProgress := styles.StatusInProgressStyle.Render(fmt.Sprintf("%s %d", styles.IconInProgress, counts[tick.StatusInProgress]))
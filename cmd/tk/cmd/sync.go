@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/tickboard/cloud"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync ticks with ticks.sh cloud",
+	Long: `Sync ticks with ticks.sh cloud.
+
+Without flags, sync runs in the foreground until interrupted. Use --daemon
+to run it in the background; its PID and status are kept in .tick/logs/.
+
+Examples:
+  tk sync              # Sync in the foreground (Ctrl+C to stop)
+  tk sync --daemon     # Start a background sync daemon
+  tk sync --status     # Show whether the daemon is running and its state
+  tk sync --stop       # Stop the background daemon`,
+	Args: cobra.NoArgs,
+	RunE: runSync,
+}
+
+var (
+	syncDaemon bool
+	syncStatus bool
+	syncStop   bool
+	syncJSON   bool
+)
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncDaemon, "daemon", false, "run sync in the background")
+	syncCmd.Flags().BoolVar(&syncStatus, "status", false, "show background daemon status")
+	syncCmd.Flags().BoolVar(&syncStop, "stop", false, "stop the background daemon")
+	syncCmd.Flags().BoolVar(&syncJSON, "json", false, "output status as JSON")
+
+	rootCmd.AddCommand(syncCmd)
+}
+
+// cloudAuthHelp explains how to configure a cloud token.
+const cloudAuthHelp = `cloud sync requires authentication.
+Add token to ~/.ticksrc:
+  token=your-token-here
+
+Get a token at https://ticks.sh/settings`
+
+// syncStatusOutput is the JSON shape of `tk sync --status --json`.
+type syncStatusOutput struct {
+	Running bool                `json:"running"`
+	PID     int                 `json:"pid,omitempty"`
+	Stale   bool                `json:"stale,omitempty"`
+	Status  *cloud.DaemonStatus `json:"status,omitempty"`
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	modes := 0
+	for _, set := range []bool{syncDaemon, syncStatus, syncStop} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return NewExitError(ExitUsage, "--daemon, --status and --stop are mutually exclusive")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+	tickDir := filepath.Join(root, ".tick")
+	daemon := cloud.NewDaemon(tickDir)
+
+	switch {
+	case syncStatus:
+		return runSyncStatus(daemon)
+	case syncStop:
+		pid, err := daemon.Stop()
+		if errors.Is(err, cloud.ErrDaemonNotRunning) {
+			fmt.Println("sync daemon not running")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stop sync daemon: %w", err)
+		}
+		fmt.Printf("stopped sync daemon (pid %d)\n", pid)
+		return nil
+	}
+
+	if pid, _, err := daemon.Running(); err == nil && pid != os.Getpid() {
+		return NewExitError(ExitGeneric, "sync daemon already running (pid %d)", pid)
+	}
+
+	cloudCfg := cloud.LoadConfig(tickDir)
+	if cloudCfg == nil {
+		return NewExitError(ExitGeneric, cloudAuthHelp)
+	}
+
+	if syncDaemon {
+		return startSyncDaemon(root, daemon)
+	}
+	return runSyncForeground(daemon, *cloudCfg)
+}
+
+// runSyncStatus reports whether a daemon is running and its last known state.
+func runSyncStatus(daemon *cloud.Daemon) error {
+	out := syncStatusOutput{}
+	pid, stale, err := daemon.Running()
+	switch {
+	case err == nil:
+		out.Running = true
+		out.PID = pid
+		if status, err := daemon.ReadStatus(); err == nil {
+			out.Status = &status
+		}
+	case errors.Is(err, cloud.ErrDaemonNotRunning):
+		out.Stale = stale
+		out.PID = pid
+	default:
+		return fmt.Errorf("failed to read sync daemon state: %w", err)
+	}
+
+	if syncJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	if !out.Running {
+		fmt.Println("sync daemon not running")
+		if out.Stale {
+			fmt.Printf("removed stale pid file (pid %d)\n", out.PID)
+		}
+		return nil
+	}
+
+	fmt.Printf("sync daemon running (pid %d)\n", out.PID)
+	if out.Status != nil {
+		fmt.Printf("  state:     %s\n", out.Status.State)
+		fmt.Printf("  board:     %s\n", out.Status.Board)
+		if !out.Status.LastSync.IsZero() {
+			fmt.Printf("  last sync: %s\n", formatTime(out.Status.LastSync.Local()))
+		}
+		fmt.Printf("  pending:   %d\n", out.Status.Pending)
+	}
+	return nil
+}
+
+// startSyncDaemon re-executes `tk sync` detached from the terminal with its
+// output appended to .tick/logs/sync.log.
+func startSyncDaemon(root string, daemon *cloud.Daemon) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate tk executable: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(daemon.LogPath()), 0o755); err != nil {
+		return fmt.Errorf("failed to create logs dir: %w", err)
+	}
+	logFile, err := os.OpenFile(daemon.LogPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open sync log: %w", err)
+	}
+	defer logFile.Close()
+
+	child := exec.Command(exe, "sync")
+	child.Dir = root
+	child.Stdout = logFile
+	child.Stderr = logFile
+	child.SysProcAttr = daemonSysProcAttr()
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("failed to start sync daemon: %w", err)
+	}
+
+	pid := child.Process.Pid
+	if err := daemon.WritePID(pid); err != nil {
+		_ = child.Process.Kill()
+		return fmt.Errorf("failed to record sync daemon: %w", err)
+	}
+	_ = child.Process.Release()
+
+	fmt.Printf("sync daemon started (pid %d)\n", pid)
+	fmt.Printf("logs: %s\n", daemon.LogPath())
+	return nil
+}
+
+// runSyncForeground runs the cloud client until interrupted, keeping the
+// PID and status files current so --status works for daemonized runs too.
+func runSyncForeground(daemon *cloud.Daemon, cfg cloud.Config) error {
+	client, err := cloud.NewClient(cfg)
+	if err != nil {
+		return NewExitError(ExitGeneric, "failed to create cloud client: %v", err)
+	}
+
+	pid := os.Getpid()
+	if err := daemon.WritePID(pid); err != nil {
+		return fmt.Errorf("failed to record sync process: %w", err)
+	}
+	defer func() {
+		// Only clean up if the PID file still refers to this process
+		if recorded, err := daemon.ReadPID(); err == nil && recorded == pid {
+			_ = daemon.Remove()
+		}
+	}()
+
+	startedAt := time.Now().UTC()
+	writeStatus := func() {
+		_ = daemon.WriteStatus(cloud.DaemonStatus{
+			PID:       pid,
+			StartedAt: startedAt,
+			UpdatedAt: time.Now().UTC(),
+			Status:    client.Status(),
+		})
+	}
+	client.OnStateChange = func(cloud.SyncState) { writeStatus() }
+	writeStatus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			fmt.Fprintln(os.Stderr, "\nShutting down...")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	fmt.Printf("Cloud: syncing as %s\n", cfg.BoardName)
+	if err := client.Run(ctx); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("cloud sync failed: %w", err)
+	}
+	return nil
+}
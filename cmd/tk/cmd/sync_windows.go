@@ -0,0 +1,12 @@
+//go:build windows
+
+package cmd
+
+import "syscall"
+
+// daemonSysProcAttr detaches the daemon from the parent console.
+func daemonSysProcAttr() *syscall.SysProcAttr {
+	const createNewProcessGroup = 0x00000200
+	const detachedProcess = 0x00000008
+	return &syscall.SysProcAttr{CreationFlags: createNewProcessGroup | detachedProcess}
+}
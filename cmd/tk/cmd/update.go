@@ -11,11 +11,12 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
 var updateCmd = &cobra.Command{
-	Use:   "update <id>",
+	Use:   "update <id> | --filter-* ...",
 	Short: "Update tick fields",
 	Long: `Update tick fields. All flags are optional.
 
@@ -39,8 +40,19 @@ Examples:
   tk update abc123 --awaiting work
 
   # Set verdict on awaiting tick (lower-level alternative to tk approve/reject)
-  tk update abc123 --verdict approved`,
-	Args: cobra.ExactArgs(1),
+  tk update abc123 --verdict approved
+
+Bulk Updates:
+  Instead of an id, pass --filter-status, --filter-type, --filter-priority,
+  --filter-label, --filter-parent, or --filter-owner to update every
+  matching tick. Failures are reported at the end without stopping the rest.
+
+  # Label all open bugs as triaged
+  tk update --filter-status open --filter-type bug --add-labels triaged
+
+  # Preview which ticks would change
+  tk update --filter-priority 0 --add-labels urgent --dry-run`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runUpdate,
 }
 
@@ -64,6 +76,15 @@ var (
 	updateVerdict     string
 	updateJSON        bool
 
+	// Bulk mode: update every tick matching these filters
+	updateFilterStatus   []string
+	updateFilterType     string
+	updateFilterPriority int
+	updateFilterLabel    string
+	updateFilterParent   string
+	updateFilterOwner    string
+	updateDryRun         bool
+
 	// Track which flags were explicitly set
 	updateTitleSet       bool
 	updateDescriptionSet bool
@@ -103,6 +124,13 @@ func init() {
 	updateCmd.Flags().StringVarP(&updateAwaiting, "awaiting", "a", "", "wait state (work|approval|input|review|content|escalation|checkpoint, empty to clear)")
 	updateCmd.Flags().StringVarP(&updateVerdict, "verdict", "v", "", "set verdict and trigger processing (approved|rejected)")
 	updateCmd.Flags().BoolVar(&updateJSON, "json", false, "output as JSON")
+	updateCmd.Flags().StringArrayVar(&updateFilterStatus, "filter-status", nil, "bulk: match status (open|in_progress|closed|all); repeatable or comma-separated")
+	updateCmd.Flags().StringVar(&updateFilterType, "filter-type", "", "bulk: match type")
+	updateCmd.Flags().IntVar(&updateFilterPriority, "filter-priority", -1, "bulk: match priority (0-4)")
+	updateCmd.Flags().StringVar(&updateFilterLabel, "filter-label", "", "bulk: match label")
+	updateCmd.Flags().StringVar(&updateFilterParent, "filter-parent", "", "bulk: match parent epic id")
+	updateCmd.Flags().StringVar(&updateFilterOwner, "filter-owner", "", "bulk: match owner (default all owners)")
+	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "bulk: list matching ticks without updating")
 
 	rootCmd.AddCommand(updateCmd)
 }
@@ -127,28 +155,110 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	updateAwaitingSet = cmd.Flags().Changed("awaiting")
 	updateVerdictSet = cmd.Flags().Changed("verdict")
 
+	bulk := false
+	for _, name := range []string{"filter-status", "filter-type", "filter-priority", "filter-label", "filter-parent", "filter-owner"} {
+		bulk = bulk || cmd.Flags().Changed(name)
+	}
+	switch {
+	case bulk && len(args) > 0:
+		return NewExitError(ExitUsage, "--filter-* flags cannot be combined with an explicit id")
+	case !bulk && len(args) != 1:
+		return NewExitError(ExitUsage, "expected exactly one tick id, or --filter-* flags for a bulk update")
+	case updateDryRun && !bulk:
+		return NewExitError(ExitUsage, "--dry-run requires --filter-* flags")
+	}
+
+	deferUntil, err := validateUpdateFlags()
+	if err != nil {
+		return err
+	}
+
 	root, err := repoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to detect repo root: %w", err)
 	}
 
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+
+	if bulk {
+		return runBulkUpdate(store, deferUntil)
+	}
+
 	project, err := github.DetectProject(nil)
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
 
-	id, err := github.NormalizeID(project, args[0])
+	id, err := resolveTickID(store, project, args[0])
 	if err != nil {
-		return fmt.Errorf("invalid id: %w", err)
+		return err
 	}
 
-	store := tick.NewStore(filepath.Join(root, ".tick"))
 	t, err := store.Read(id)
 	if err != nil {
 		return fmt.Errorf("failed to read tick: %w", err)
 	}
 
-	// Apply updates for flags that were explicitly set
+	if err := applyUpdate(&t, deferUntil); err != nil {
+		return err
+	}
+
+	if err := store.WriteAs(t, currentActor()); err != nil {
+		return fmt.Errorf("failed to update tick: %w", err)
+	}
+
+	if updateJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(t); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// validateUpdateFlags checks the values of the set update flags before any
+// tick is touched, and returns the parsed --defer date.
+func validateUpdateFlags() (*time.Time, error) {
+	var deferUntil *time.Time
+	if updateDeferSet && updateDefer != "" {
+		parsed, err := time.Parse("2006-01-02", updateDefer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid defer date (use YYYY-MM-DD): %w", err)
+		}
+		deferUntil = &parsed
+	}
+	if updateRequiresSet && updateRequires != "" {
+		switch updateRequires {
+		case tick.RequiresApproval, tick.RequiresReview, tick.RequiresContent:
+		default:
+			return nil, NewExitError(ExitUsage, "invalid requires value: %s (must be approval, review, or content)", updateRequires)
+		}
+	}
+	if updateAwaitingSet && updateAwaiting != "" {
+		switch updateAwaiting {
+		case tick.AwaitingWork, tick.AwaitingApproval, tick.AwaitingInput, tick.AwaitingReview, tick.AwaitingContent, tick.AwaitingEscalation, tick.AwaitingCheckpoint:
+		default:
+			return nil, NewExitError(ExitUsage, "invalid awaiting value: %s (must be work, approval, input, review, content, escalation, or checkpoint)", updateAwaiting)
+		}
+	}
+	if updateVerdictSet {
+		switch updateVerdict {
+		case tick.VerdictApproved, tick.VerdictRejected:
+		default:
+			return nil, NewExitError(ExitUsage, "invalid verdict value: %s (must be approved or rejected)", updateVerdict)
+		}
+	}
+	if updateManualSet {
+		fmt.Fprintln(os.Stderr, "Warning: --manual is deprecated, use --awaiting work instead")
+	}
+	return deferUntil, nil
+}
+
+// applyUpdate applies the explicitly set update flags to t.
+// Flag values must already be checked by validateUpdateFlags.
+func applyUpdate(t *tick.Tick, deferUntil *time.Time) error {
 	if updateTitleSet {
 		t.Title = updateTitle
 	}
@@ -191,21 +301,12 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		t.AcceptanceCriteria = updateAcceptance
 	}
 	if updateDeferSet {
-		if updateDefer == "" {
-			t.DeferUntil = nil
-		} else {
-			parsed, err := time.Parse("2006-01-02", updateDefer)
-			if err != nil {
-				return fmt.Errorf("invalid defer date (use YYYY-MM-DD): %w", err)
-			}
-			t.DeferUntil = &parsed
-		}
+		t.DeferUntil = deferUntil
 	}
 	if updateExternalRefSet {
 		t.ExternalRef = updateExternalRef
 	}
 	if updateManualSet {
-		fmt.Fprintln(os.Stderr, "Warning: --manual is deprecated, use --awaiting work instead")
 		// Parse manual value
 		manualVal := strings.ToLower(strings.TrimSpace(updateManual))
 		isManual := manualVal == "true" || manualVal == "1" || manualVal == "yes"
@@ -224,57 +325,128 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		if updateRequires == "" {
 			t.Requires = nil
 		} else {
-			switch updateRequires {
-			case tick.RequiresApproval, tick.RequiresReview, tick.RequiresContent:
-				t.Requires = &updateRequires
-			default:
-				return NewExitError(ExitUsage, "invalid requires value: %s (must be approval, review, or content)", updateRequires)
-			}
+			requires := updateRequires
+			t.Requires = &requires
 		}
 	}
 	if updateAwaitingSet {
 		if updateAwaiting == "" {
 			t.ClearAwaiting()
 		} else {
-			switch updateAwaiting {
-			case tick.AwaitingWork, tick.AwaitingApproval, tick.AwaitingInput, tick.AwaitingReview, tick.AwaitingContent, tick.AwaitingEscalation, tick.AwaitingCheckpoint:
-				t.SetAwaiting(updateAwaiting)
-			default:
-				return NewExitError(ExitUsage, "invalid awaiting value: %s (must be work, approval, input, review, content, escalation, or checkpoint)", updateAwaiting)
-			}
+			t.SetAwaiting(updateAwaiting)
 		}
 	}
 	if updateVerdictSet {
-		switch updateVerdict {
-		case tick.VerdictApproved, tick.VerdictRejected:
-			t.Verdict = &updateVerdict
-		default:
-			return NewExitError(ExitUsage, "invalid verdict value: %s (must be approved or rejected)", updateVerdict)
-		}
+		verdict := updateVerdict
+		t.Verdict = &verdict
 	}
 
 	t.UpdatedAt = time.Now().UTC()
 
 	// Process verdict if it was set (triggers state machine)
 	if updateVerdictSet {
-		_, err := tick.ProcessVerdict(&t)
+		_, err := tick.ProcessVerdict(t)
 		if err != nil {
 			return fmt.Errorf("failed to process verdict: %w", err)
 		}
 	}
 
-	if err := store.Write(t); err != nil {
-		return fmt.Errorf("failed to update tick: %w", err)
+	return nil
+}
+
+// bulkUpdateOutput is the JSON output of a filtered tk update.
+type bulkUpdateOutput struct {
+	Updated []string          `json:"updated"`
+	Failed  []bulkUpdateError `json:"failed,omitempty"`
+	DryRun  bool              `json:"dry_run,omitempty"`
+}
+
+// bulkUpdateError records a tick that could not be updated.
+type bulkUpdateError struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// runBulkUpdate applies the update flags to every tick matching the
+// --filter-* flags. A failure on one tick does not stop the rest; failures
+// are reported at the end.
+func runBulkUpdate(store *tick.Store, deferUntil *time.Time) error {
+	if !updateTitleSet && !updateDescriptionSet && !updateNotesSet && !updateStatusSet &&
+		!updatePrioritySet && !updateTypeSet && !updateOwnerSet && !updateAddLabelsSet &&
+		!updateRemoveLabelsSet && !updateAcceptanceSet && !updateDeferSet && !updateExternalRefSet &&
+		!updateParentSet && !updateManualSet && !updateRequiresSet && !updateAwaitingSet && !updateVerdictSet {
+		return NewExitError(ExitUsage, "no fields to update")
+	}
+
+	ticks, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	var priority *int
+	if updateFilterPriority >= 0 {
+		p := updateFilterPriority
+		priority = &p
+	}
+	var statuses []string
+	for _, status := range splitCSV(strings.Join(updateFilterStatus, ",")) {
+		if status == "all" {
+			statuses = nil
+			break
+		}
+		statuses = append(statuses, status)
+	}
+
+	matches := query.Apply(ticks, query.Filter{
+		Owner:     strings.TrimSpace(updateFilterOwner),
+		StatusAny: statuses,
+		Priority:  priority,
+		Type:      strings.TrimSpace(updateFilterType),
+		Label:     strings.TrimSpace(updateFilterLabel),
+		Parent:    strings.TrimSpace(updateFilterParent),
+	})
+	query.SortByPriorityCreatedAt(matches)
+
+	out := bulkUpdateOutput{Updated: []string{}, DryRun: updateDryRun}
+	actor := currentActor()
+	for _, t := range matches {
+		if updateDryRun {
+			out.Updated = append(out.Updated, t.ID)
+			continue
+		}
+		if err := applyUpdate(&t, deferUntil); err != nil {
+			out.Failed = append(out.Failed, bulkUpdateError{ID: t.ID, Error: err.Error()})
+			continue
+		}
+		if err := store.WriteAs(t, actor); err != nil {
+			out.Failed = append(out.Failed, bulkUpdateError{ID: t.ID, Error: err.Error()})
+			continue
+		}
+		out.Updated = append(out.Updated, t.ID)
 	}
 
 	if updateJSON {
 		enc := json.NewEncoder(os.Stdout)
-		if err := enc.Encode(t); err != nil {
+		if err := enc.Encode(out); err != nil {
 			return fmt.Errorf("failed to encode json: %w", err)
 		}
-		return nil
+	} else {
+		verb := "Updated"
+		if out.DryRun {
+			verb = "Would update"
+		}
+		fmt.Printf("%s %d of %d matching ticks\n", verb, len(out.Updated), len(matches))
+		if len(out.Updated) > 0 {
+			fmt.Printf("  %s\n", strings.Join(out.Updated, ", "))
+		}
+		for _, f := range out.Failed {
+			fmt.Fprintf(os.Stderr, "failed to update %s: %s\n", f.ID, f.Error)
+		}
 	}
 
+	if len(out.Failed) > 0 {
+		return NewExitError(ExitGeneric, "failed to update %d of %d ticks", len(out.Failed), len(matches))
+	}
 	return nil
 }
 
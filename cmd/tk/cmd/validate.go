@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/query"
+	"github.com/pengelbrecht/ticks/internal/styles"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check ticks for broken references and cycles",
+	Long: `Check every tick for problems that can leave work stuck.
+
+Reports:
+  - ticks that fail to load or validate
+  - blockers and parents that don't exist
+  - parent cycles
+  - blocked-by cycles among open ticks, across epics
+  - closed ticks still blocked by open ones or still awaiting a human
+
+Exits 1 if any problems are found, so CI can gate on it.
+
+--fix repairs the safe subset and reports every change:
+  - removes blockers and parents that don't exist (they already
+    count as closed, so readiness is unchanged)
+  - clears awaiting state, and any unprocessed verdict, on closed ticks
+Cycles and closed ticks with open blockers need a human decision and
+are left alone; the exit code reflects what remains.
+
+Examples:
+  tk validate
+  tk validate --json
+  tk validate --fix --dry-run   # Show what --fix would change
+  tk validate --fix`,
+	Args: cobra.NoArgs,
+	RunE: runValidate,
+}
+
+var (
+	validateJSON   bool
+	validateFix    bool
+	validateDryRun bool
+)
+
+// validateOutput is the JSON output of tk validate.
+type validateOutput struct {
+	// Problems lists what is still wrong, after any fixes.
+	Problems []query.Problem  `json:"problems"`
+	Fixes    []validateChange `json:"fixes,omitempty"`
+	DryRun   bool             `json:"dry_run,omitempty"`
+}
+
+// validateChange is one change made (or, with --dry-run, proposed) by --fix.
+type validateChange struct {
+	Tick   string `json:"tick"`
+	Change string `json:"change"`
+}
+
+func init() {
+	validateCmd.Flags().BoolVar(&validateJSON, "json", false, "output as JSON")
+	validateCmd.Flags().BoolVar(&validateFix, "fix", false, "repair missing references and stale awaiting state")
+	validateCmd.Flags().BoolVar(&validateDryRun, "dry-run", false, "with --fix, report changes without writing them")
+
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	root, err := repoRoot()
+	if err != nil {
+		return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	ids, err := store.IDs()
+	if err != nil {
+		return NewExitError(ExitIO, "failed to list ticks: %v", err)
+	}
+
+	// Read ticks one by one so a bad file is reported, not fatal
+	problems := []query.Problem{}
+	present := make(map[string]bool, len(ids))
+	var ticks []tick.Tick
+	for _, id := range ids {
+		present[id] = true
+		t, err := store.Read(id)
+		if err != nil {
+			problems = append(problems, query.Problem{Kind: query.ProblemInvalid, Tick: id, Message: err.Error()})
+			continue
+		}
+		ticks = append(ticks, t)
+	}
+	problems = append(problems, query.CheckGraph(ticks, present)...)
+
+	var fixes []validateChange
+	if validateFix || validateDryRun {
+		fixes, problems, err = repairTicks(store, ticks, problems, validateDryRun)
+		if err != nil {
+			return err
+		}
+	}
+
+	if validateJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(validateOutput{Problems: problems, Fixes: fixes, DryRun: validateDryRun}); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+	} else {
+		verb := "fixed"
+		if validateDryRun {
+			verb = "would fix"
+		}
+		for _, f := range fixes {
+			fmt.Printf(" %-6s  %s  %s\n", f.Tick, styles.StatusClosedStyle.Render(fmt.Sprintf("%-19s", verb)), f.Change)
+		}
+		for _, p := range problems {
+			fmt.Printf(" %-6s  %s  %s\n", p.Tick, styles.StatusBlockedStyle.Render(fmt.Sprintf("%-19s", p.Kind)), p.Message)
+		}
+		if len(problems) == 0 {
+			fmt.Printf("No problems found in %d ticks\n", len(ids))
+		}
+	}
+
+	if len(problems) > 0 {
+		return NewExitError(ExitGeneric, "found %d problem(s)", len(problems))
+	}
+	return nil
+}
+
+// repairTicks applies the safe fixes for problems and returns the changes
+// made and the problems left over. Only modified ticks are written, with
+// UpdatedAt bumped. With dryRun nothing is written, so every problem is
+// left over.
+func repairTicks(store *tick.Store, ticks []tick.Tick, problems []query.Problem, dryRun bool) ([]validateChange, []query.Problem, error) {
+	byID := make(map[string]*tick.Tick, len(ticks))
+	for i := range ticks {
+		byID[ticks[i].ID] = &ticks[i]
+	}
+
+	var (
+		fixes     []validateChange
+		remaining = []query.Problem{}
+		changed   []string
+	)
+	for _, p := range problems {
+		t, ok := byID[p.Tick]
+		var change string
+		switch {
+		case !ok:
+		case p.Kind == query.ProblemDanglingBlocker:
+			t.BlockedBy = slices.DeleteFunc(t.BlockedBy, func(id string) bool { return id == p.Refs[0] })
+			change = "removed missing blocker " + p.Refs[0]
+		case p.Kind == query.ProblemDanglingParent:
+			t.Parent = ""
+			change = "removed missing parent " + p.Refs[0]
+		case p.Kind == query.ProblemClosedAwaiting:
+			change = "cleared awaiting " + t.GetAwaitingType()
+			// A verdict set alongside awaiting was never processed. Once
+			// processed, the verdict stays on closed ticks as an audit trail.
+			if t.Verdict != nil {
+				change += " and unprocessed verdict " + *t.Verdict
+				t.Verdict = nil
+			}
+			t.ClearAwaiting()
+		}
+		if change == "" {
+			remaining = append(remaining, p)
+			continue
+		}
+		fixes = append(fixes, validateChange{Tick: p.Tick, Change: change})
+		if !slices.Contains(changed, p.Tick) {
+			changed = append(changed, p.Tick)
+		}
+	}
+
+	if dryRun {
+		return fixes, problems, nil
+	}
+	now := time.Now().UTC()
+	for _, id := range changed {
+		t := byID[id]
+		t.UpdatedAt = now
+		if err := store.Write(*t); err != nil {
+			return fixes, remaining, NewExitError(ExitIO, "failed to write tick %s: %v", id, err)
+		}
+	}
+	return fixes, remaining, nil
+}
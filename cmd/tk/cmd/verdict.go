@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+var verdictCmd = &cobra.Command{
+	Use:   "verdict <id> --approve|--reject",
+	Short: "Record a human verdict on a tick awaiting decision",
+	Long: `Record a human verdict on a tick awaiting decision.
+
+Runs the same gate logic as the cloud approve/reject operations and
+appends a [human] note recording the verdict. --reason is optional
+when approving and required when rejecting.
+
+  awaiting=work|approval|review|content  -> approve closes, reject returns to agent
+  awaiting=input|escalation|checkpoint   -> returns tick to agent queue
+
+Examples:
+  tk verdict abc123 --approve
+  tk verdict abc123 --approve --reason "Looks good"
+  tk verdict abc123 --reject --reason "Error messages need friendlier wording"
+  tk verdict abc123 --reject --reason "Wrong API" --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerdict,
+}
+
+var (
+	verdictApprove bool
+	verdictReject  bool
+	verdictReason  string
+	verdictJSON    bool
+)
+
+func init() {
+	verdictCmd.Flags().BoolVar(&verdictApprove, "approve", false, "approve the tick")
+	verdictCmd.Flags().BoolVar(&verdictReject, "reject", false, "reject the tick (requires --reason)")
+	verdictCmd.Flags().StringVar(&verdictReason, "reason", "", "reason for the verdict")
+	verdictCmd.Flags().BoolVar(&verdictJSON, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(verdictCmd)
+}
+
+func runVerdict(cmd *cobra.Command, args []string) error {
+	if verdictApprove == verdictReject {
+		return NewExitError(ExitUsage, "exactly one of --approve or --reject is required")
+	}
+	reason := strings.TrimSpace(verdictReason)
+	if verdictReject && reason == "" {
+		return NewExitError(ExitUsage, "--reason is required when rejecting")
+	}
+
+	verdict := tick.VerdictApproved
+	label := "Approved"
+	if verdictReject {
+		verdict = tick.VerdictRejected
+		label = "Rejected"
+	}
+	note := label
+	if reason != "" {
+		note += ": " + reason
+	}
+
+	res, err := applyVerdict(args[0], verdict, note)
+	if err != nil {
+		return err
+	}
+	if verdictJSON {
+		return encodeVerdictJSON(res)
+	}
+
+	verb := strings.ToLower(label)
+	if res.Closed {
+		fmt.Printf("%s %s (closed)\n", verb, res.Tick.ID)
+	} else {
+		fmt.Printf("%s %s (awaiting %s -> %s, returned to agent)\n", verb, res.Tick.ID, res.Awaiting, res.Tick.Status)
+	}
+	return nil
+}
+
+// verdictResult is the outcome of applyVerdict.
+type verdictResult struct {
+	Tick     tick.Tick
+	Closed   bool
+	Awaiting string // what the tick was awaiting before the verdict
+}
+
+// applyVerdict records verdict on the tick arg names, which must be awaiting
+// a human decision, and runs the gate logic shared with the cloud
+// approve/reject operations. A non-empty note is appended as a [human] note
+// before the verdict is processed, so the feedback is saved with the
+// transition; rejections require one.
+func applyVerdict(arg, verdict, note string) (verdictResult, error) {
+	root, err := repoRoot()
+	if err != nil {
+		return verdictResult{}, NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+	}
+
+	project, err := github.DetectProject(nil)
+	if err != nil {
+		return verdictResult{}, NewExitError(ExitGitHub, "failed to detect project: %v", err)
+	}
+
+	id, err := github.NormalizeID(project, arg)
+	if err != nil {
+		return verdictResult{}, NewExitError(ExitNotFound, "invalid id: %v", err)
+	}
+
+	store := tick.NewStore(filepath.Join(root, ".tick"))
+	t, err := store.Read(id)
+	if err != nil {
+		return verdictResult{}, NewExitError(ExitNotFound, "failed to read tick: %v", err)
+	}
+
+	// Verify tick is awaiting human decision
+	if !t.IsAwaitingHuman() {
+		fmt.Fprintf(os.Stderr, "tick %s is not awaiting human decision\n", t.ID)
+		fmt.Fprintf(os.Stderr, "use `tk show %s` to check current status\n", t.ID)
+		return verdictResult{}, NewExitError(ExitUsage, "tick is not awaiting human decision")
+	}
+
+	// Handle legacy manual flag - normalize to awaiting=work before processing
+	if t.Awaiting == nil && t.Manual {
+		t.SetAwaiting(tick.AwaitingWork)
+	}
+	awaiting := t.GetAwaitingType()
+
+	// Feedback is required for reject
+	if verdict == tick.VerdictRejected && strings.TrimSpace(note) == "" {
+		fmt.Fprintln(os.Stderr, "feedback message is required for reject")
+		fmt.Fprintln(os.Stderr, "usage: tk reject <id> <feedback message>")
+		return verdictResult{}, NewExitError(ExitUsage, "feedback message is required")
+	}
+
+	// Add the note before processing so tk run can't pick the task up
+	// again before the feedback is saved
+	if note != "" {
+		line := fmt.Sprintf("%s - [human] %s", time.Now().Format("2006-01-02 15:04"), note)
+		if strings.TrimSpace(t.Notes) == "" {
+			t.Notes = line
+		} else {
+			t.Notes = strings.TrimRight(t.Notes, "\n") + "\n" + line
+		}
+	}
+
+	t.Verdict = &verdict
+	t.UpdatedAt = time.Now().UTC()
+
+	closed, err := tick.ProcessVerdict(&t)
+	if err != nil {
+		return verdictResult{}, fmt.Errorf("failed to process verdict: %w", err)
+	}
+
+	if err := store.Write(t); err != nil {
+		return verdictResult{}, fmt.Errorf("failed to save tick: %w", err)
+	}
+	return verdictResult{Tick: t, Closed: closed, Awaiting: awaiting}, nil
+}
+
+// encodeVerdictJSON prints the --json output of approve, reject and verdict.
+func encodeVerdictJSON(res verdictResult) error {
+	payload := map[string]any{"tick": res.Tick, "closed": res.Closed}
+	if err := json.NewEncoder(os.Stdout).Encode(payload); err != nil {
+		return fmt.Errorf("failed to encode json: %w", err)
+	}
+	return nil
+}
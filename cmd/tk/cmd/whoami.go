@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/tickboard/cloud"
 )
 
 var whoamiCmd = &cobra.Command{
@@ -16,14 +18,22 @@ var whoamiCmd = &cobra.Command{
 	Long: `Show the current GitHub user (owner) and project detected from the repository.
 
 This is useful for verifying that tk is correctly detecting your identity
-for ownership assignment.`,
+for ownership assignment.
+
+With --repo, also shows the repo identity used for cloud sync: the owner/repo
+from the origin remote, the worktree suffix (if any), the resulting board
+name, and whether cloud sync is configured.`,
 	RunE: runWhoami,
 }
 
-var whoamiJSON bool
+var (
+	whoamiJSON bool
+	whoamiRepo bool
+)
 
 func init() {
 	whoamiCmd.Flags().BoolVar(&whoamiJSON, "json", false, "output as JSON")
+	whoamiCmd.Flags().BoolVar(&whoamiRepo, "repo", false, "include repo identity used for cloud sync")
 	rootCmd.AddCommand(whoamiCmd)
 }
 
@@ -37,8 +47,27 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to detect owner: %w", err)
 	}
 
+	payload := map[string]any{"owner": owner, "project": project}
+	var identity cloud.RepoIdentity
+	cloudConfigured := false
+	if whoamiRepo {
+		root, err := repoRoot()
+		if err != nil {
+			return NewExitError(ExitNoRepo, "failed to detect repo root: %v", err)
+		}
+		tickDir := filepath.Join(root, ".tick")
+		identity = cloud.DetectRepoIdentity(tickDir)
+		cloudConfigured = cloud.LoadConfig(tickDir) != nil
+
+		payload["repo"] = identity.Repo
+		if identity.Worktree != "" {
+			payload["worktree"] = identity.Worktree
+		}
+		payload["board"] = identity.BoardName()
+		payload["cloud_configured"] = cloudConfigured
+	}
+
 	if whoamiJSON {
-		payload := map[string]string{"owner": owner, "project": project}
 		enc := json.NewEncoder(os.Stdout)
 		if err := enc.Encode(payload); err != nil {
 			return fmt.Errorf("failed to encode json: %w", err)
@@ -48,5 +77,17 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Owner: %s\n", owner)
 	fmt.Printf("Project: %s\n", project)
+	if whoamiRepo {
+		fmt.Printf("Repo: %s\n", identity.Repo)
+		if identity.Worktree != "" {
+			fmt.Printf("Worktree: %s\n", identity.Worktree)
+		}
+		fmt.Printf("Board: %s\n", identity.BoardName())
+		if cloudConfigured {
+			fmt.Println("Cloud: configured")
+		} else {
+			fmt.Println("Cloud: not configured")
+		}
+	}
 	return nil
 }
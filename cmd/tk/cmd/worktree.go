@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pengelbrecht/ticks/internal/worktree"
+)
+
+var worktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "List and clean up run worktrees",
+	Long: `List and clean up the git worktrees created by tk run --worktree.
+
+Subcommands:
+  list   Show each worktree with its branch and merge state
+  prune  Remove worktrees whose branches are merged into their parent`,
+}
+
+var worktreeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tick worktrees",
+	Long: `List tick worktrees with their path, branch, parent branch, and whether
+the branch is already merged into the parent (or the main branch when the
+parent is unknown).
+
+Examples:
+  tk worktree list         # Table of worktrees
+  tk worktree list --json  # Output as JSON`,
+	Args: cobra.NoArgs,
+	RunE: runWorktreeList,
+}
+
+var worktreePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove worktrees whose branches are merged",
+	Long: `Remove worktrees whose branches are merged into their parent branch,
+together with the branch. So no work is lost, a worktree is skipped if it
+belongs to a run that can still be resumed (tk run --resume), if its branch
+has no commits of its own yet, or if it has uncommitted changes. Git's
+records of worktree directories deleted by hand are cleaned up too.
+
+Examples:
+  tk worktree prune            # Remove merged worktrees
+  tk worktree prune --dry-run  # Show what would be removed`,
+	Args: cobra.NoArgs,
+	RunE: runWorktreePrune,
+}
+
+var (
+	worktreeListJSON  bool
+	worktreePruneDry  bool
+	worktreePruneJSON bool
+)
+
+// worktreeInfo is one worktree in tk worktree list.
+type worktreeInfo struct {
+	EpicID       string `json:"epic_id"`
+	Path         string `json:"path"`
+	Branch       string `json:"branch"`
+	ParentBranch string `json:"parent_branch,omitempty"`
+	Target       string `json:"target"`
+	Merged       bool   `json:"merged"`
+	// HasCommits is false while the branch has no commits of its own.
+	HasCommits bool `json:"has_commits"`
+}
+
+// worktreePruneOutput is the JSON output of tk worktree prune.
+type worktreePruneOutput struct {
+	Removed []worktreeInfo    `json:"removed"`
+	Skipped []worktreeSkipped `json:"skipped"`
+	DryRun  bool              `json:"dry_run,omitempty"`
+}
+
+// worktreeSkipped is a merged worktree that prune kept.
+type worktreeSkipped struct {
+	worktreeInfo
+	// Reason is skipRunInProgress, skipNoCommits or skipUncommitted.
+	Reason      string   `json:"reason"`
+	Uncommitted []string `json:"uncommitted,omitempty"`
+}
+
+// Reasons tk worktree prune keeps a merged worktree.
+const (
+	skipRunInProgress = "run in progress"
+	skipNoCommits     = "no commits"
+	skipUncommitted   = "uncommitted changes"
+)
+
+func init() {
+	worktreeListCmd.Flags().BoolVar(&worktreeListJSON, "json", false, "output as JSON")
+	worktreePruneCmd.Flags().BoolVar(&worktreePruneDry, "dry-run", false, "show what would be removed without removing")
+	worktreePruneCmd.Flags().BoolVar(&worktreePruneJSON, "json", false, "output as JSON")
+
+	worktreeCmd.AddCommand(worktreeListCmd)
+	worktreeCmd.AddCommand(worktreePruneCmd)
+	rootCmd.AddCommand(worktreeCmd)
+}
+
+// loadWorktrees returns the repo root, the worktree manager and every tick
+// worktree with its merge state. With prune, git's records of worktree
+// directories deleted by hand are dropped first.
+func loadWorktrees(prune bool) (string, *worktree.Manager, []*worktree.Worktree, []worktreeInfo, error) {
+	root, err := repoRoot()
+	if err != nil {
+		return "", nil, nil, nil, fmt.Errorf("failed to detect repo root: %w", err)
+	}
+
+	wtManager, err := worktree.NewManager(root)
+	if err != nil {
+		return "", nil, nil, nil, NewExitError(ExitNoRepo, "failed to create worktree manager: %v", err)
+	}
+	mergeManager, err := worktree.NewMergeManager(root)
+	if err != nil {
+		return "", nil, nil, nil, fmt.Errorf("failed to create merge manager: %w", err)
+	}
+
+	if prune {
+		_ = wtManager.Prune()
+	}
+
+	wts, err := wtManager.List()
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	infos := make([]worktreeInfo, 0, len(wts))
+	for _, wt := range wts {
+		merged, target := mergeManager.IsMerged(wt)
+		infos = append(infos, worktreeInfo{
+			EpicID:       wt.EpicID,
+			Path:         wt.Path,
+			Branch:       wt.Branch,
+			ParentBranch: wt.ParentBranch,
+			Target:       target,
+			Merged:       merged,
+			HasCommits:   mergeManager.HasOwnCommits(wt),
+		})
+	}
+	return root, wtManager, wts, infos, nil
+}
+
+func runWorktreeList(cmd *cobra.Command, args []string) error {
+	_, _, _, infos, err := loadWorktrees(false)
+	if err != nil {
+		return err
+	}
+
+	if worktreeListJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(infos); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	if len(infos) == 0 {
+		fmt.Println("No worktrees")
+		return nil
+	}
+	for _, info := range infos {
+		state := "not merged"
+		switch {
+		case !info.HasCommits:
+			state = "no commits"
+		case info.Merged:
+			state = "merged"
+		}
+		fmt.Printf("%s  %s  %s -> %s  %s\n", info.EpicID, relPath(info.Path), info.Branch, info.Target, state)
+	}
+	return nil
+}
+
+func runWorktreePrune(cmd *cobra.Command, args []string) error {
+	root, wtManager, wts, infos, err := loadWorktrees(!worktreePruneDry)
+	if err != nil {
+		return err
+	}
+	tickDir := filepath.Join(root, ".tick")
+
+	out := worktreePruneOutput{Removed: []worktreeInfo{}, Skipped: []worktreeSkipped{}, DryRun: worktreePruneDry}
+	for i, info := range infos {
+		if !info.Merged {
+			continue
+		}
+		state, err := worktree.LoadRunState(tickDir, info.EpicID)
+		if err != nil {
+			return err
+		}
+		if state != nil {
+			out.Skipped = append(out.Skipped, worktreeSkipped{worktreeInfo: info, Reason: skipRunInProgress})
+			continue
+		}
+		if !info.HasCommits {
+			out.Skipped = append(out.Skipped, worktreeSkipped{worktreeInfo: info, Reason: skipNoCommits})
+			continue
+		}
+		dirty, err := wtManager.UncommittedChanges(wts[i])
+		if err != nil {
+			return err
+		}
+		if len(dirty) > 0 {
+			out.Skipped = append(out.Skipped, worktreeSkipped{worktreeInfo: info, Reason: skipUncommitted, Uncommitted: dirty})
+			continue
+		}
+		if !worktreePruneDry {
+			if err := wtManager.Remove(info.EpicID); err != nil {
+				return fmt.Errorf("failed to remove worktree for %s: %w", info.EpicID, err)
+			}
+		}
+		out.Removed = append(out.Removed, info)
+	}
+
+	if worktreePruneJSON {
+		enc := json.NewEncoder(os.Stdout)
+		if err := enc.Encode(out); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	}
+
+	verb := "Removed"
+	if out.DryRun {
+		verb = "Would remove"
+	}
+	for _, info := range out.Removed {
+		fmt.Printf("%s %s (%s)\n", verb, info.EpicID, relPath(info.Path))
+	}
+	for _, s := range out.Skipped {
+		if s.Reason == skipUncommitted {
+			fmt.Fprintf(os.Stderr, "Skipped %s: %d uncommitted change(s)\n", s.EpicID, len(s.Uncommitted))
+		} else {
+			fmt.Fprintf(os.Stderr, "Skipped %s: %s\n", s.EpicID, s.Reason)
+		}
+	}
+	if len(out.Removed) == 0 && len(out.Skipped) == 0 {
+		fmt.Println("No merged worktrees")
+	}
+	return nil
+}
+
+// relPath shortens path relative to the working directory when possible.
+func relPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && !filepath.IsAbs(rel) && len(rel) < len(path) {
+		return rel
+	}
+	return path
+}
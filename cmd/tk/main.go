@@ -35,11 +35,19 @@ func run(args []string) int {
 		return exitSuccess
 	}
 
+	// Global flags may come before the command; move them after it so the
+	// command can be routed by name
+	if globals, rest := cobracmd.SplitGlobalFlags(args[1:]); len(globals) > 0 && len(rest) > 0 {
+		moved := []string{args[0], rest[0]}
+		moved = append(moved, globals...)
+		args = append(moved, rest[1:]...)
+	}
+
 	// Check for updates periodically (skip for certain commands)
 	cmd := args[1]
 	if cmd != "version" && cmd != "--version" && cmd != "-v" &&
 		cmd != "upgrade" && cmd != "--help" && cmd != "-h" &&
-		cmd != "merge-file" && cmd != "snippet" {
+		cmd != "merge-file" && cmd != "merge-driver" && cmd != "snippet" {
 		if notice := update.CheckPeriodically(Version); notice != "" {
 			fmt.Fprintln(os.Stderr, notice)
 			fmt.Fprintln(os.Stderr)
@@ -47,7 +55,7 @@ func run(args []string) int {
 	}
 
 	switch args[1] {
-	case "init", "whoami", "show", "create", "new", "update", "close", "reopen", "delete", "block", "unblock", "note", "notes", "list", "ls", "ready", "next", "blocked", "label", "labels", "deps", "graph", "status", "rebuild", "merge-file", "stats", "view", "snippet", "import", "approve", "reject", "version", "upgrade", "migrate", "gc", "run", "resume", "checkpoints", "merge":
+	case "init", "whoami", "show", "search", "create", "new", "duplicate", "update", "move", "epic", "close", "reopen", "delete", "block", "unblock", "note", "notes", "list", "ls", "ready", "next", "blocked", "label", "labels", "deps", "graph", "status", "rebuild", "reindex", "merge-file", "merge-driver", "stats", "view", "snippet", "import", "export", "approve", "reject", "verdict", "version", "upgrade", "migrate", "gc", "run", "resume", "checkpoint", "checkpoints", "merge", "sync", "validate", "config", "log", "costs", "rename-id", "worktree", "board", "stale", "bump":
 		// Route to Cobra command (pass args[1:] to include the subcommand)
 		// Handle aliases
 		cmdArgs := args[1:]
@@ -99,11 +107,12 @@ func runVersion() int {
 func printUsage() {
 	fmt.Printf("tk %s - multiplayer issue tracker for AI agents\n\n", Version)
 	fmt.Println("Usage: tk <command> [--help]")
-	fmt.Println("Commands: init, whoami, show, create (new), block, unblock, update, close, reopen, note, notes, list (ls), ready, next, blocked, rebuild, delete, label, labels, deps, graph, status, merge-file, stats, view, snippet, import, approve, reject, version, upgrade, migrate, gc, run, resume, checkpoints, merge")
+	fmt.Println("Commands: init, whoami, show, search, create (new), duplicate, block, unblock, update, move, epic, close, reopen, note, notes, list (ls), ready, next, blocked, rebuild (reindex), delete, label, labels, deps, graph, status, merge-file, stats, view, snippet, import, export, approve, reject, verdict, version, upgrade, migrate, gc, run, resume, checkpoint, checkpoints, merge, sync, validate, config, log, costs, rename-id, worktree, board, stale, bump")
 	fmt.Println()
 	fmt.Println("Agent-Human Workflow:")
 	fmt.Println("  tk approve <id>              Set verdict=approved on awaiting tick")
 	fmt.Println("  tk reject <id> [feedback]    Set verdict=rejected with optional note")
+	fmt.Println("  tk verdict <id> --approve|--reject [--reason]  Record a verdict in one command")
 	fmt.Println("  tk next --awaiting=          Get next task awaiting human (human mode)")
 	fmt.Println("  tk list --awaiting=          List all tasks awaiting human action")
 	fmt.Println("  tk note <id> \"msg\" --from human  Add human feedback note")
@@ -1,12 +1,30 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	cobracmd "github.com/pengelbrecht/ticks/cmd/tk/cmd"
+	"github.com/pengelbrecht/ticks/internal/checkpoint"
+	"github.com/pengelbrecht/ticks/internal/config"
+	"github.com/pengelbrecht/ticks/internal/migrate"
+	"github.com/pengelbrecht/ticks/internal/tick"
+	"github.com/pengelbrecht/ticks/internal/types/generated"
+	"github.com/pengelbrecht/ticks/internal/worktree"
 )
 
 func TestCLIWorkflow(t *testing.T) {
@@ -99,12 +117,89 @@ func captureStdout(fn func() int) (string, int) {
 	return buf.String(), code
 }
 
+func captureStderr(fn func() int) (string, int) {
+	orig := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	code := fn()
+	_ = w.Close()
+	os.Stderr = orig
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	_ = r.Close()
+
+	return buf.String(), code
+}
+
 func runGit(dir string, args ...string) error {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
 	return cmd.Run()
 }
 
+// setupTestRepo creates an initialized tick repo in a temp dir, chdirs into it
+// and sets TICK_OWNER=tester. Everything is restored when the test ends.
+func setupTestRepo(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	if err := runGit(repo, "init"); err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+	if err := runGit(repo, "remote", "add", "origin", "https://github.com/petere/chefswiz.git"); err != nil {
+		t.Fatalf("git remote add: %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+
+	t.Setenv("TICK_OWNER", "tester")
+
+	if code := run([]string{"tk", "init"}); code != exitSuccess {
+		t.Fatalf("expected init exit %d, got %d", exitSuccess, code)
+	}
+	return repo
+}
+
+// createTestTick runs `tk create <args> --json` and returns the new tick id.
+func createTestTick(t *testing.T, args ...string) string {
+	t.Helper()
+	cmdArgs := append([]string{"tk", "create"}, args...)
+	cmdArgs = append(cmdArgs, "--json")
+	out, code := captureStdout(func() int {
+		return run(cmdArgs)
+	})
+	if code != exitSuccess {
+		t.Fatalf("create %v: exit %d", args, code)
+	}
+	var created map[string]any
+	if err := json.Unmarshal([]byte(out), &created); err != nil {
+		t.Fatalf("parse create json: %v", err)
+	}
+	return created["id"].(string)
+}
+
+// readTestTick loads a tick's JSON file from the repo as a generic map.
+func readTestTick(t *testing.T, repo, id string) map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(repo, ".tick", "issues", id+".json"))
+	if err != nil {
+		t.Fatalf("read tick %s: %v", id, err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("parse tick %s: %v", id, err)
+	}
+	return m
+}
+
 func TestApproveCommand(t *testing.T) {
 	repo := t.TempDir()
 	if err := runGit(repo, "init"); err != nil {
@@ -2502,3 +2597,5069 @@ func TestReadyAwaitingFilter(t *testing.T) {
 	_ = approvalTickID
 	_ = manualTickID
 }
+
+func TestCloseDiscover(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	epicID := createTestTick(t, "Epic", "-t", "epic")
+	taskID := createTestTick(t, "Original task", "--parent", epicID)
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "close", taskID, "--reason", "done", "--discover", "Handle edge case"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("close --discover failed: exit %d", code)
+	}
+	newID := strings.TrimSpace(out)
+	if newID == "" || newID == taskID {
+		t.Fatalf("expected new tick id on stdout, got %q", out)
+	}
+
+	closed := readTestTick(t, repo, taskID)
+	if closed["status"] != "closed" {
+		t.Errorf("expected original tick closed, got %v", closed["status"])
+	}
+	if closed["closed_reason"] != "done" {
+		t.Errorf("expected closed_reason done, got %v", closed["closed_reason"])
+	}
+
+	followUp := readTestTick(t, repo, newID)
+	if followUp["title"] != "Handle edge case" {
+		t.Errorf("expected follow-up title, got %v", followUp["title"])
+	}
+	if followUp["status"] != "open" {
+		t.Errorf("expected follow-up open, got %v", followUp["status"])
+	}
+	if followUp["discovered_from"] != taskID {
+		t.Errorf("expected discovered_from %s, got %v", taskID, followUp["discovered_from"])
+	}
+	if followUp["parent"] != epicID {
+		t.Errorf("expected follow-up to inherit parent %s, got %v", epicID, followUp["parent"])
+	}
+
+	t.Run("json_includes_both_ticks", func(t *testing.T) {
+		id := createTestTick(t, "Another task")
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "close", id, "--discover", "Spawned work", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("close --discover --json failed: exit %d", code)
+		}
+		var payload struct {
+			Tick       map[string]any `json:"tick"`
+			Discovered map[string]any `json:"discovered"`
+		}
+		if err := json.Unmarshal([]byte(out), &payload); err != nil {
+			t.Fatalf("parse json: %v", err)
+		}
+		if payload.Tick["status"] != "closed" {
+			t.Errorf("expected closed tick in payload, got %v", payload.Tick["status"])
+		}
+		if payload.Discovered["discovered_from"] != id {
+			t.Errorf("expected discovered_from %s, got %v", id, payload.Discovered["discovered_from"])
+		}
+	})
+
+	t.Run("uses_repo_defaults", func(t *testing.T) {
+		cfg := []byte(`{"version": 1, "id_length": 3, "defaults": {"priority": 1, "type": "bug"}}`)
+		if err := os.WriteFile(filepath.Join(repo, ".tick", "config.json"), cfg, 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		id := createTestTick(t, "Defaults task", "-t", "task", "-p", "3")
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "close", id, "--discover", "Regression"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("close --discover failed: exit %d", code)
+		}
+		followUp := readTestTick(t, repo, strings.TrimSpace(out))
+		if followUp["priority"] != float64(1) {
+			t.Errorf("expected default priority 1, got %v", followUp["priority"])
+		}
+		if followUp["type"] != "bug" {
+			t.Errorf("expected default type bug, got %v", followUp["type"])
+		}
+	})
+}
+
+func TestListPriorityRange(t *testing.T) {
+	setupTestRepo(t)
+
+	ids := make(map[int]string)
+	for p := 0; p <= 4; p++ {
+		ids[p] = createTestTick(t, fmt.Sprintf("P%d tick", p), "-p", fmt.Sprint(p))
+	}
+
+	listIDs := func(t *testing.T, args ...string) map[string]bool {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "list", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("list %v: exit %d", args, code)
+		}
+		var result struct {
+			Ticks []map[string]any `json:"ticks"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse list json: %v", err)
+		}
+		got := make(map[string]bool)
+		for _, tk := range result.Ticks {
+			got[tk["id"].(string)] = true
+		}
+		return got
+	}
+
+	t.Run("names_are_inclusive", func(t *testing.T) {
+		got := listIDs(t, "--min-priority", "critical", "--max-priority", "high")
+		if len(got) != 2 || !got[ids[0]] || !got[ids[1]] {
+			t.Errorf("expected P0 and P1, got %v", got)
+		}
+	})
+
+	t.Run("p_labels_and_numbers_mix", func(t *testing.T) {
+		got := listIDs(t, "--min-priority", "P2", "--max-priority", "3")
+		if len(got) != 2 || !got[ids[2]] || !got[ids[3]] {
+			t.Errorf("expected P2 and P3, got %v", got)
+		}
+	})
+
+	t.Run("inverted_range_is_usage_error", func(t *testing.T) {
+		_, code := captureStdout(func() int {
+			return run([]string{"tk", "list", "--min-priority", "low", "--max-priority", "high"})
+		})
+		if code != exitUsage {
+			t.Errorf("expected exit %d, got %d", exitUsage, code)
+		}
+	})
+
+	t.Run("invalid_name_is_usage_error", func(t *testing.T) {
+		_, code := captureStdout(func() int {
+			return run([]string{"tk", "list", "--max-priority", "urgent"})
+		})
+		if code != exitUsage {
+			t.Errorf("expected exit %d, got %d", exitUsage, code)
+		}
+	})
+}
+
+func TestSyncStatus(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	t.Run("not_running", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "sync", "--status", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("sync --status failed: exit %d", code)
+		}
+		var status map[string]any
+		if err := json.Unmarshal([]byte(out), &status); err != nil {
+			t.Fatalf("parse status json: %v", err)
+		}
+		if status["running"] != false {
+			t.Errorf("expected running=false, got %v", status["running"])
+		}
+	})
+
+	t.Run("stale_pid_file_is_removed", func(t *testing.T) {
+		helper := exec.Command("git", "--version")
+		if err := helper.Run(); err != nil {
+			t.Fatalf("run helper: %v", err)
+		}
+		pidPath := filepath.Join(repo, ".tick", "logs", "sync.pid")
+		if err := os.MkdirAll(filepath.Dir(pidPath), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(pidPath, []byte(fmt.Sprintf("%d\n", helper.Process.Pid)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "sync", "--status"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("sync --status failed: exit %d", code)
+		}
+		if !strings.Contains(out, "not running") || !strings.Contains(out, "stale") {
+			t.Errorf("expected stale not-running report, got %q", out)
+		}
+		if _, err := os.Stat(pidPath); !os.IsNotExist(err) {
+			t.Errorf("expected stale pid file removed")
+		}
+	})
+
+	t.Run("stop_without_daemon", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "sync", "--stop"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("sync --stop failed: exit %d", code)
+		}
+		if !strings.Contains(out, "not running") {
+			t.Errorf("expected not running message, got %q", out)
+		}
+	})
+
+	t.Run("modes_are_exclusive", func(t *testing.T) {
+		_, code := captureStdout(func() int {
+			return run([]string{"tk", "sync", "--status", "--stop"})
+		})
+		if code != exitUsage {
+			t.Errorf("expected exit %d, got %d", exitUsage, code)
+		}
+	})
+}
+
+func TestListJSONEmpty(t *testing.T) {
+	setupTestRepo(t)
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "list", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("list --json: exit %d", code)
+	}
+	if !strings.Contains(out, `"ticks":[]`) {
+		t.Errorf("expected an empty ticks array, got %s", out)
+	}
+}
+
+func TestListFormatCSV(t *testing.T) {
+	setupTestRepo(t)
+
+	title := `Fix "login", then logout`
+	id := createTestTick(t, title, "-l", "auth,ui")
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "list", "--format", "csv"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("list --format csv: exit %d", code)
+	}
+
+	if !strings.Contains(out, `"Fix ""login"", then logout"`) {
+		t.Errorf("expected quoted title in csv output, got:\n%s", out)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header + 1 row, got %d rows", len(records))
+	}
+	wantHeader := "id,title,status,type,priority,owner,parent,labels,created_at,closed_at"
+	if got := strings.Join(records[0], ","); got != wantHeader {
+		t.Errorf("header = %q, want %q", got, wantHeader)
+	}
+	row := records[1]
+	if row[0] != id || row[1] != title || row[2] != "open" || row[7] != "auth,ui" || row[9] != "" {
+		t.Errorf("unexpected row: %q", row)
+	}
+
+	t.Run("invalid_format", func(t *testing.T) {
+		if code := run([]string{"tk", "list", "--format", "xml"}); code != exitUsage {
+			t.Errorf("expected usage exit for unknown format, got %d", code)
+		}
+	})
+
+	t.Run("json_conflicts_with_csv", func(t *testing.T) {
+		if code := run([]string{"tk", "list", "--json", "--format", "csv"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+}
+
+func TestListFormatTable(t *testing.T) {
+	setupTestRepo(t)
+	createTestTick(t, "Ship feature", "-t", "feature", "-p", "0")
+	createTestTick(t, "Fix bug", "-t", "bug")
+
+	for _, args := range [][]string{{"tk", "list"}, {"tk", "list", "--format", "table"}} {
+		out, code := captureStdout(func() int {
+			return run(args)
+		})
+		if code != exitSuccess {
+			t.Fatalf("%v: exit %d", args, code)
+		}
+		lines := strings.Split(out, "\n")
+		col := strings.Index(lines[0], "TITLE")
+		if col == -1 {
+			t.Fatalf("expected header, got:\n%s", out)
+		}
+		for _, title := range []string{"Ship feature", "Fix bug"} {
+			found := false
+			for _, line := range lines[1:] {
+				if i := strings.Index(line, title); i != -1 {
+					found = true
+					// Compare display columns: the status icon is multi-byte
+					if got := utf8.RuneCountInString(line[:i]); got != col {
+						t.Errorf("%v: %q starts at column %d, want %d:\n%s", args, title, got, col, out)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("%v: missing %q in output:\n%s", args, title, out)
+			}
+		}
+	}
+}
+
+func TestCreateCheckDup(t *testing.T) {
+	repo := setupTestRepo(t)
+	createTestTick(t, "Fix login bug")
+	closed := createTestTick(t, "Add OAuth support")
+	if code := run([]string{"tk", "close", closed, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+
+	countTicks := func() int {
+		entries, err := os.ReadDir(filepath.Join(repo, ".tick", "issues"))
+		if err != nil {
+			t.Fatalf("read issues: %v", err)
+		}
+		return len(entries)
+	}
+	before := countTicks()
+
+	if code := run([]string{"tk", "create", "Fix the login bug", "--check-dup"}); code != exitGeneric {
+		t.Fatalf("expected near-duplicate to be rejected, got exit %d", code)
+	}
+	if got := countTicks(); got != before {
+		t.Fatalf("expected no tick written, have %d (was %d)", got, before)
+	}
+
+	t.Run("dissimilar_title_passes", func(t *testing.T) {
+		createTestTick(t, "Update API docs", "--check-dup")
+	})
+
+	t.Run("closed_ticks_ignored", func(t *testing.T) {
+		createTestTick(t, "Add OAuth support", "--check-dup")
+	})
+
+	t.Run("force_overrides", func(t *testing.T) {
+		createTestTick(t, "Fix the login bug", "--check-dup", "--force")
+	})
+}
+
+func TestReopenReason(t *testing.T) {
+	repo := setupTestRepo(t)
+	id := createTestTick(t, "Flaky test")
+
+	reopenOnce := func(t *testing.T, args ...string) map[string]any {
+		t.Helper()
+		if code := run([]string{"tk", "close", id, "--reason", "fixed"}); code != exitSuccess {
+			t.Fatalf("close: exit %d", code)
+		}
+		if code := run(append([]string{"tk", "reopen", id}, args...)); code != exitSuccess {
+			t.Fatalf("reopen: exit %d", code)
+		}
+		return readTestTick(t, repo, id)
+	}
+
+	got := reopenOnce(t, "--reason", "still failing on CI")
+	if got["status"] != "open" {
+		t.Errorf("expected status open, got %v", got["status"])
+	}
+	if _, ok := got["closed_at"]; ok {
+		t.Errorf("expected closed_at cleared, got %v", got["closed_at"])
+	}
+	if _, ok := got["closed_reason"]; ok {
+		t.Errorf("expected closed_reason cleared, got %v", got["closed_reason"])
+	}
+	notes, _ := got["notes"].(string)
+	if !strings.HasSuffix(notes, " - (from: tester) Reopened: still failing on CI") {
+		t.Errorf("expected reopen note with reason, got %q", notes)
+	}
+
+	got = reopenOnce(t)
+	notes, _ = got["notes"].(string)
+	lines := strings.Split(notes, "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[1], " - (from: tester) Reopened") {
+		t.Errorf("expected plain reopen note appended, got %q", notes)
+	}
+}
+
+func TestReopenResetGates(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	closeGated := func(t *testing.T) string {
+		t.Helper()
+		id := createTestTick(t, "Gated task", "--requires", "approval")
+		if code := run([]string{"tk", "close", id, "--force"}); code != exitSuccess {
+			t.Fatalf("close --force: exit %d", code)
+		}
+		return id
+	}
+
+	t.Run("default_keeps_requires", func(t *testing.T) {
+		id := closeGated(t)
+		if code := run([]string{"tk", "reopen", id}); code != exitSuccess {
+			t.Fatalf("reopen: exit %d", code)
+		}
+		got := readTestTick(t, repo, id)
+		if got["status"] != "open" {
+			t.Errorf("expected status open, got %v", got["status"])
+		}
+		if got["requires"] != "approval" {
+			t.Errorf("expected requires kept, got %v", got["requires"])
+		}
+
+		// The preserved gate routes the next close to a human again
+		_, _ = captureStderr(func() int { return run([]string{"tk", "close", id}) })
+		if got := readTestTick(t, repo, id); got["awaiting"] != "approval" {
+			t.Errorf("expected close to route to approval, got %v", got["awaiting"])
+		}
+	})
+
+	t.Run("reset_gates_clears_gate_state", func(t *testing.T) {
+		id := closeGated(t)
+		// Leave stale gate state behind, as a verdict processed elsewhere might
+		path := filepath.Join(repo, ".tick", "issues", id+".json")
+		raw := readTestTick(t, repo, id)
+		raw["awaiting"] = "approval"
+		raw["verdict"] = "rejected"
+		data, _ := json.Marshal(raw)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+
+		if code := run([]string{"tk", "reopen", id, "--reset-gates"}); code != exitSuccess {
+			t.Fatalf("reopen --reset-gates: exit %d", code)
+		}
+		got := readTestTick(t, repo, id)
+		if got["status"] != "open" {
+			t.Errorf("expected status open, got %v", got["status"])
+		}
+		for _, field := range []string{"requires", "awaiting", "verdict"} {
+			if v, ok := got[field]; ok {
+				t.Errorf("expected %s cleared, got %v", field, v)
+			}
+		}
+
+		if code := run([]string{"tk", "close", id}); code != exitSuccess {
+			t.Errorf("expected ungated close to succeed, got exit %d", code)
+		}
+		if got := readTestTick(t, repo, id); got["status"] != "closed" {
+			t.Errorf("expected closed, got %v", got["status"])
+		}
+	})
+}
+
+func TestCreateTypePrefix(t *testing.T) {
+	repo := setupTestRepo(t)
+	cfgPath := filepath.Join(repo, ".tick", "config.json")
+	cfg := `{"version": 1, "id_length": 3, "type_prefixes": {"bug": "bug"}}`
+	if err := os.WriteFile(cfgPath, []byte(cfg), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	bugID := createTestTick(t, "Crash on save", "-t", "bug")
+	if !strings.HasPrefix(bugID, "bug-") || len(bugID) != len("bug-")+3 {
+		t.Fatalf("expected bug-xxx id, got %q", bugID)
+	}
+	if got := readTestTick(t, repo, bugID); got["type"] != "bug" {
+		t.Errorf("expected bug type, got %v", got["type"])
+	}
+
+	taskID := createTestTick(t, "Write docs")
+	if strings.Contains(taskID, "-") {
+		t.Errorf("expected unprefixed task id, got %q", taskID)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "show", "petere/chefswiz:" + bugID, "--json"})
+	})
+	if code != exitSuccess || !strings.Contains(out, bugID) {
+		t.Errorf("expected show by global id to work, exit %d: %s", code, out)
+	}
+}
+
+func TestListEpicToggles(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Roadmap", "-t", "epic")
+	task := createTestTick(t, "Work item", "--parent", epic)
+	bug := createTestTick(t, "Broken thing", "-t", "bug", "-p", "1")
+
+	listIDs := func(t *testing.T, args ...string) map[string]bool {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "list", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("list %v: exit %d", args, code)
+		}
+		var result struct {
+			Ticks []map[string]any `json:"ticks"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse list json: %v", err)
+		}
+		got := make(map[string]bool)
+		for _, tk := range result.Ticks {
+			got[tk["id"].(string)] = true
+		}
+		return got
+	}
+
+	t.Run("epics_only", func(t *testing.T) {
+		got := listIDs(t, "--epics-only")
+		if len(got) != 1 || !got[epic] {
+			t.Errorf("expected only %s, got %v", epic, got)
+		}
+	})
+
+	t.Run("no_epics", func(t *testing.T) {
+		got := listIDs(t, "--no-epics")
+		if len(got) != 2 || !got[task] || !got[bug] {
+			t.Errorf("expected %s and %s, got %v", task, bug, got)
+		}
+	})
+
+	t.Run("composes_with_filters", func(t *testing.T) {
+		got := listIDs(t, "--no-epics", "--max-priority", "1")
+		if len(got) != 1 || !got[bug] {
+			t.Errorf("expected only %s, got %v", bug, got)
+		}
+	})
+
+	t.Run("mutually_exclusive", func(t *testing.T) {
+		if code := run([]string{"tk", "list", "--epics-only", "--no-epics"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+
+	t.Run("conflicting_type", func(t *testing.T) {
+		if code := run([]string{"tk", "list", "--epics-only", "--type", "bug"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+}
+
+func TestListUpdatedBy(t *testing.T) {
+	repo := setupTestRepo(t)
+	recent := createTestTick(t, "Recent agent change")
+	old := createTestTick(t, "Old agent change")
+	human := createTestTick(t, "Human change")
+	overridden := createTestTick(t, "Agent then human")
+
+	now := time.Now().UTC()
+	entry := func(id, actor string, age time.Duration) string {
+		return fmt.Sprintf(`{"ts":%q,"tick":%q,"action":"update","actor":%q}`,
+			now.Add(-age).Format(time.RFC3339), id, actor)
+	}
+	log := strings.Join([]string{
+		entry(recent, "dependency-analyzer", 30*time.Minute),
+		entry(old, "dependency-analyzer", 10*24*time.Hour),
+		entry(human, "alice", 2*time.Hour),
+		entry(overridden, "dependency-analyzer", 3*time.Hour),
+		entry(overridden, "alice", 30*time.Minute),
+	}, "\n") + "\n"
+	activityPath := filepath.Join(repo, ".tick", "activity", "activity.jsonl")
+	if err := os.MkdirAll(filepath.Dir(activityPath), 0o755); err != nil {
+		t.Fatalf("mkdir activity: %v", err)
+	}
+	if err := os.WriteFile(activityPath, []byte(log), 0o644); err != nil {
+		t.Fatalf("seed activity: %v", err)
+	}
+
+	listIDs := func(t *testing.T, args ...string) map[string]bool {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "list", "--all", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("list %v: exit %d", args, code)
+		}
+		var result struct {
+			Ticks []map[string]any `json:"ticks"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse list json: %v", err)
+		}
+		got := make(map[string]bool)
+		for _, tk := range result.Ticks {
+			got[tk["id"].(string)] = true
+		}
+		return got
+	}
+
+	t.Run("last_modifier", func(t *testing.T) {
+		got := listIDs(t, "--updated-by", "dependency-analyzer")
+		if len(got) != 2 || !got[recent] || !got[old] {
+			t.Errorf("expected %s and %s, got %v", recent, old, got)
+		}
+	})
+
+	t.Run("window", func(t *testing.T) {
+		got := listIDs(t, "--updated-by", "dependency-analyzer", "--updated-within", "7d")
+		if len(got) != 1 || !got[recent] {
+			t.Errorf("expected only %s, got %v", recent, got)
+		}
+	})
+
+	t.Run("window_any_actor", func(t *testing.T) {
+		got := listIDs(t, "--updated-within", "1h")
+		if len(got) != 2 || !got[recent] || !got[overridden] {
+			t.Errorf("expected %s and %s, got %v", recent, overridden, got)
+		}
+	})
+
+	t.Run("invalid_window", func(t *testing.T) {
+		if code := run([]string{"tk", "list", "--updated-within", "soon"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+}
+
+func TestNextDispatchPlan(t *testing.T) {
+	repo := setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	schema := createTestTick(t, "Add schema", "--parent", epic, "-p", "0")
+	handler := createTestTick(t, "Add handler", "--parent", epic, "-p", "1")
+	docs := createTestTick(t, "Write docs", "--parent", epic, "-p", "2")
+	migrate := createTestTick(t, "Run migration", "--parent", epic, "-b", schema)
+
+	// Cached analysis predicts schema and handler edit the same file
+	depsDir := filepath.Join(repo, ".tick", "logs", "deps")
+	if err := os.MkdirAll(depsDir, 0o755); err != nil {
+		t.Fatalf("mkdir deps: %v", err)
+	}
+	analysis := fmt.Sprintf(`{"predictions":[],"added_deps":{},"conflicting_pairs":[{"task1":%q,"task2":%q,"shared_files":["db/schema.go"]}]}`, schema, handler)
+	if err := os.WriteFile(filepath.Join(depsDir, epic+".json"), []byte(analysis), 0o644); err != nil {
+		t.Fatalf("seed analysis: %v", err)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "next", epic, "--all", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("next --json --all: exit %d", code)
+	}
+	var plan struct {
+		Tasks []struct {
+			ID            string   `json:"id"`
+			Priority      int      `json:"priority"`
+			Wave          int      `json:"wave"`
+			Ready         bool     `json:"ready"`
+			Dispatchable  bool     `json:"dispatchable"`
+			NeedsWorktree bool     `json:"needs_worktree"`
+			ConflictsWith []string `json:"conflicts_with"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal([]byte(out), &plan); err != nil {
+		t.Fatalf("parse plan: %v\n%s", err, out)
+	}
+
+	byID := make(map[string]int)
+	for i, task := range plan.Tasks {
+		byID[task.ID] = i
+	}
+	if len(plan.Tasks) != 4 {
+		t.Fatalf("expected 4 tasks, got %d: %s", len(plan.Tasks), out)
+	}
+
+	s, h, d, m := plan.Tasks[byID[schema]], plan.Tasks[byID[handler]], plan.Tasks[byID[docs]], plan.Tasks[byID[migrate]]
+	if s.Wave != 1 || h.Wave != 1 || d.Wave != 1 || m.Wave != 2 {
+		t.Errorf("unexpected waves: schema=%d handler=%d docs=%d migrate=%d", s.Wave, h.Wave, d.Wave, m.Wave)
+	}
+	if !s.Dispatchable || !d.Dispatchable {
+		t.Errorf("expected schema and docs dispatchable, got %v and %v", s.Dispatchable, d.Dispatchable)
+	}
+	if !h.Ready || h.Dispatchable {
+		t.Errorf("expected handler ready but held back by conflict, got ready=%v dispatchable=%v", h.Ready, h.Dispatchable)
+	}
+	if len(h.ConflictsWith) != 1 || h.ConflictsWith[0] != schema {
+		t.Errorf("expected handler to conflict with %s, got %v", schema, h.ConflictsWith)
+	}
+	if m.Ready || m.Dispatchable {
+		t.Errorf("expected blocked migration not dispatchable, got ready=%v dispatchable=%v", m.Ready, m.Dispatchable)
+	}
+	if !s.NeedsWorktree || !d.NeedsWorktree || h.NeedsWorktree {
+		t.Errorf("expected worktrees for parallel dispatchable tasks only, got schema=%v docs=%v handler=%v",
+			s.NeedsWorktree, d.NeedsWorktree, h.NeedsWorktree)
+	}
+
+	// Without --all, next still returns a single tick
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "next", epic, "--json"})
+	})
+	var single map[string]any
+	if code != exitSuccess || json.Unmarshal([]byte(out), &single) != nil || single["id"] == nil {
+		t.Errorf("expected a single tick from next --json, got exit %d: %s", code, out)
+	}
+}
+
+func TestDeleteCleansBlockers(t *testing.T) {
+	repo := setupTestRepo(t)
+	epic := createTestTick(t, "Cleanup", "-t", "epic")
+	target := createTestTick(t, "Obsolete", "--parent", epic)
+	dependent := createTestTick(t, "Follow-up", "--parent", epic, "-b", target)
+	other := createTestTick(t, "Unrelated")
+
+	deleteJSON := func(t *testing.T, args ...string) map[string]any {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "delete", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("delete %v: exit %d", args, code)
+		}
+		var result map[string]any
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse delete json: %v\n%s", err, out)
+		}
+		return result
+	}
+
+	t.Run("dry_run", func(t *testing.T) {
+		result := deleteJSON(t, target, "--dry-run")
+		if result["id"] != target || result["dry_run"] != true {
+			t.Errorf("unexpected dry-run output: %v", result)
+		}
+		cleaned, _ := result["cleaned_blockers"].([]any)
+		if len(cleaned) != 1 || cleaned[0] != dependent {
+			t.Errorf("expected %s cleaned, got %v", dependent, cleaned)
+		}
+		if _, err := os.Stat(filepath.Join(repo, ".tick", "issues", target+".json")); err != nil {
+			t.Errorf("dry run should keep tick file: %v", err)
+		}
+		if blockers, _ := readTestTick(t, repo, dependent)["blocked_by"].([]any); len(blockers) != 1 {
+			t.Errorf("dry run should keep blockers, got %v", blockers)
+		}
+	})
+
+	t.Run("epic_guard", func(t *testing.T) {
+		if code := run([]string{"tk", "delete", epic, "--dry-run"}); code != exitGeneric {
+			t.Errorf("expected generic exit for epic with open children, got %d", code)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		// A leftover run would otherwise keep showing on the board
+		recordsDir := filepath.Join(repo, ".tick", "logs", "records")
+		if err := os.MkdirAll(recordsDir, 0o755); err != nil {
+			t.Fatalf("mkdir records: %v", err)
+		}
+		records := []string{filepath.Join(recordsDir, target+".live.json"), filepath.Join(recordsDir, target+".json")}
+		for _, path := range records {
+			if err := os.WriteFile(path, []byte(`{}`), 0o644); err != nil {
+				t.Fatalf("write record: %v", err)
+			}
+		}
+
+		result := deleteJSON(t, target, "--yes")
+		for _, path := range records {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("run record %s should be removed, stat err: %v", filepath.Base(path), err)
+			}
+		}
+		cleaned, _ := result["cleaned_blockers"].([]any)
+		if len(cleaned) != 1 || cleaned[0] != dependent {
+			t.Errorf("expected %s cleaned, got %v", dependent, cleaned)
+		}
+		if _, err := os.Stat(filepath.Join(repo, ".tick", "issues", target+".json")); !os.IsNotExist(err) {
+			t.Errorf("tick file should be removed, stat err: %v", err)
+		}
+		if blockers, _ := readTestTick(t, repo, dependent)["blocked_by"].([]any); len(blockers) != 0 {
+			t.Errorf("expected blockers cleaned, got %v", blockers)
+		}
+		if readTestTick(t, repo, other)["id"] != other {
+			t.Errorf("unrelated tick should be untouched")
+		}
+	})
+
+	t.Run("force_still_confirms", func(t *testing.T) {
+		stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+		if err != nil {
+			t.Fatalf("create stdin: %v", err)
+		}
+		if _, err := stdin.WriteString("n\n"); err != nil {
+			t.Fatalf("write stdin: %v", err)
+		}
+		if _, err := stdin.Seek(0, 0); err != nil {
+			t.Fatalf("seek stdin: %v", err)
+		}
+		origStdin := os.Stdin
+		os.Stdin = stdin
+		t.Cleanup(func() { os.Stdin = origStdin })
+
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "delete", epic, "--force"})
+		})
+		if code != exitSuccess || !strings.Contains(out, "Aborted.") {
+			t.Errorf("expected --force to still prompt and abort, got exit %d: %q", code, out)
+		}
+		if _, err := os.Stat(filepath.Join(repo, ".tick", "issues", epic+".json")); err != nil {
+			t.Errorf("aborted delete should keep epic: %v", err)
+		}
+	})
+
+	t.Run("epic_force", func(t *testing.T) {
+		result := deleteJSON(t, epic, "--force", "--yes")
+		if result["id"] != epic {
+			t.Errorf("expected epic deleted, got %v", result)
+		}
+		detached, _ := result["detached_children"].([]any)
+		if len(detached) != 1 || detached[0] != dependent {
+			t.Errorf("expected %s detached, got %v", dependent, detached)
+		}
+		if parent, ok := readTestTick(t, repo, dependent)["parent"]; ok {
+			t.Errorf("expected parent cleared on child, got %v", parent)
+		}
+	})
+
+	t.Run("unreadable_tick_is_not_not_found", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(repo, ".tick", "issues", other+".json"), []byte("not json"), 0o644); err != nil {
+			t.Fatalf("corrupt tick: %v", err)
+		}
+		if code := run([]string{"tk", "delete", other, "--yes"}); code != exitIO {
+			t.Errorf("expected IO exit for an unparsable tick, got %d", code)
+		}
+		if code := run([]string{"tk", "delete", "zzz", "--yes"}); code != exitNotFound {
+			t.Errorf("expected not-found exit for a missing tick, got %d", code)
+		}
+	})
+}
+
+func TestListRepeatableStatusAndAwaiting(t *testing.T) {
+	setupTestRepo(t)
+	approval := createTestTick(t, "Needs approval")
+	review := createTestTick(t, "Needs review")
+	input := createTestTick(t, "Needs input")
+	closedApproval := createTestTick(t, "Approved earlier")
+	plain := createTestTick(t, "Plain work")
+
+	for id, awaiting := range map[string]string{approval: "approval", review: "review", input: "input", closedApproval: "approval"} {
+		if code := run([]string{"tk", "update", id, "--awaiting", awaiting}); code != exitSuccess {
+			t.Fatalf("update %s: exit %d", id, code)
+		}
+	}
+	if code := run([]string{"tk", "update", review, "--status", "in_progress"}); code != exitSuccess {
+		t.Fatalf("update status: exit %d", code)
+	}
+	if code := run([]string{"tk", "update", closedApproval, "--status", "closed"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+
+	listIDs := func(t *testing.T, args ...string) map[string]bool {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "list", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("list %v: exit %d", args, code)
+		}
+		var result struct {
+			Ticks []map[string]any `json:"ticks"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse list json: %v", err)
+		}
+		got := make(map[string]bool)
+		for _, tk := range result.Ticks {
+			got[tk["id"].(string)] = true
+		}
+		return got
+	}
+
+	t.Run("combined", func(t *testing.T) {
+		got := listIDs(t, "--status", "open", "--status", "in_progress", "--awaiting", "approval", "--awaiting", "review")
+		if len(got) != 2 || !got[approval] || !got[review] {
+			t.Errorf("expected %s and %s, got %v", approval, review, got)
+		}
+	})
+
+	t.Run("comma_separated", func(t *testing.T) {
+		got := listIDs(t, "--status", "open,in_progress", "--awaiting", "approval,review")
+		if len(got) != 2 || !got[approval] || !got[review] {
+			t.Errorf("expected %s and %s, got %v", approval, review, got)
+		}
+	})
+
+	t.Run("empty_awaiting_means_any", func(t *testing.T) {
+		got := listIDs(t, "--status", "open", "--awaiting=")
+		if len(got) != 2 || !got[approval] || !got[input] {
+			t.Errorf("expected %s and %s, got %v", approval, input, got)
+		}
+	})
+
+	t.Run("no_filters", func(t *testing.T) {
+		got := listIDs(t)
+		if len(got) != 5 || !got[plain] || !got[closedApproval] {
+			t.Errorf("expected all 5 ticks, got %v", got)
+		}
+	})
+}
+
+func TestNoteFormatAndStdin(t *testing.T) {
+	repo := setupTestRepo(t)
+	id := createTestTick(t, "Note format tick")
+
+	if code := run([]string{"tk", "note", id, "First note"}); code != exitSuccess {
+		t.Fatalf("note: exit %d", code)
+	}
+
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatalf("create stdin: %v", err)
+	}
+	if _, err := stdin.WriteString("Piped note\n"); err != nil {
+		t.Fatalf("write stdin: %v", err)
+	}
+	if _, err := stdin.Seek(0, 0); err != nil {
+		t.Fatalf("seek stdin: %v", err)
+	}
+	origStdin := os.Stdin
+	os.Stdin = stdin
+	t.Cleanup(func() { os.Stdin = origStdin })
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "note", id, "-", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("note from stdin: exit %d", code)
+	}
+	var updated map[string]any
+	if err := json.Unmarshal([]byte(out), &updated); err != nil {
+		t.Fatalf("parse note json: %v\n%s", err, out)
+	}
+
+	notes, _ := updated["notes"].(string)
+	if notes != readTestTick(t, repo, id)["notes"] {
+		t.Errorf("json output should match stored notes")
+	}
+	lines := strings.Split(notes, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 newline-separated notes, got %q", notes)
+	}
+	for i, want := range []string{"First note", "Piped note"} {
+		// "2006-01-02 15:04 - (from: tester) message"
+		if len(lines[i]) < 19 || lines[i][16:19] != " - " {
+			t.Errorf("expected timestamp prefix, got %q", lines[i])
+			continue
+		}
+		if got := lines[i][19:]; got != "(from: tester) "+want {
+			t.Errorf("expected %q, got %q", "(from: tester) "+want, got)
+		}
+	}
+}
+
+func TestVerdict(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	verdictJSON := func(t *testing.T, args ...string) (map[string]any, bool) {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "verdict", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("verdict %v: exit %d", args, code)
+		}
+		var result struct {
+			Tick   map[string]any `json:"tick"`
+			Closed bool           `json:"closed"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse verdict json: %v\n%s", err, out)
+		}
+		return result.Tick, result.Closed
+	}
+
+	t.Run("approve_closes", func(t *testing.T) {
+		id := createTestTick(t, "Approve me", "--awaiting", "approval")
+		tk, closed := verdictJSON(t, id, "--approve", "--reason", "Looks good")
+		if !closed || tk["status"] != "closed" {
+			t.Errorf("expected closed tick, got closed=%v status=%v", closed, tk["status"])
+		}
+		if notes, _ := tk["notes"].(string); !strings.HasSuffix(notes, "[human] Approved: Looks good") {
+			t.Errorf("expected approval note, got %q", notes)
+		}
+	})
+
+	t.Run("reject_returns_to_agent", func(t *testing.T) {
+		id := createTestTick(t, "Reject me", "--awaiting", "review")
+		tk, closed := verdictJSON(t, id, "--reject", "--reason", "Needs tests")
+		if closed || tk["status"] == "closed" {
+			t.Errorf("expected rejected tick to stay open, got status %v", tk["status"])
+		}
+		if tk["awaiting"] != nil {
+			t.Errorf("expected awaiting cleared, got %v", tk["awaiting"])
+		}
+		stored := readTestTick(t, repo, id)
+		if notes, _ := stored["notes"].(string); !strings.HasSuffix(notes, "[human] Rejected: Needs tests") {
+			t.Errorf("expected rejection note, got %q", notes)
+		}
+	})
+
+	t.Run("reject_requires_reason", func(t *testing.T) {
+		id := createTestTick(t, "No reason", "--awaiting", "approval")
+		if code := run([]string{"tk", "verdict", id, "--reject"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+
+	t.Run("requires_awaiting", func(t *testing.T) {
+		id := createTestTick(t, "Not awaiting")
+		if code := run([]string{"tk", "verdict", id, "--approve"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+
+	t.Run("requires_one_verdict", func(t *testing.T) {
+		id := createTestTick(t, "Both", "--awaiting", "approval")
+		if code := run([]string{"tk", "verdict", id, "--approve", "--reject", "--reason", "x"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+}
+
+func TestDepsRecursive(t *testing.T) {
+	setupTestRepo(t)
+	root := createTestTick(t, "Root blocker")
+	mid := createTestTick(t, "Middle", "-b", root)
+	leaf := createTestTick(t, "Leaf", "-b", mid)
+	shared := createTestTick(t, "Shared", "-b", root+","+leaf)
+	// mid and leaf block each other
+	if code := run([]string{"tk", "block", mid, leaf}); code != exitSuccess {
+		t.Fatalf("block: exit %d", code)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "deps", root, "--recursive", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("deps --recursive: exit %d", code)
+	}
+	var result struct {
+		Blocks     []map[string]any `json:"blocks"`
+		Downstream []struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+			Depth  int    `json:"depth"`
+		} `json:"downstream"`
+		Cycles []string `json:"cycles"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse deps json: %v\n%s", err, out)
+	}
+
+	if len(result.Blocks) != 2 {
+		t.Errorf("expected 2 direct dependents, got %d", len(result.Blocks))
+	}
+	seen := make(map[string]int)
+	for _, d := range result.Downstream {
+		seen[d.ID]++
+		if d.Status != "open" {
+			t.Errorf("expected status for %s, got %q", d.ID, d.Status)
+		}
+	}
+	if len(result.Downstream) != 3 || seen[mid] != 1 || seen[leaf] != 1 || seen[shared] != 1 {
+		t.Errorf("expected mid, leaf and shared once each, got %+v", result.Downstream)
+	}
+	if len(result.Cycles) != 1 || result.Cycles[0] != leaf+" -> "+mid {
+		t.Errorf("expected cycle %s -> %s, got %v", leaf, mid, result.Cycles)
+	}
+}
+
+func TestRunEchoAgent(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	createTestTick(t, "Add refunds", "--parent", epic)
+
+	// The echo agent never closes tasks, so the run ends on the stuck-task limit.
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "run", epic, "--ralph", "--agent", "echo", "--max-task-retries", "2", "--skip-verify", "--jsonl"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("run --agent echo: exit %d\n%s", code, out)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	var result struct {
+		EpicID     string  `json:"epic_id"`
+		Iterations int     `json:"iterations"`
+		TotalCost  float64 `json:"total_cost"`
+		ExitReason string  `json:"exit_reason"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &result); err != nil {
+		t.Fatalf("parse run result: %v\n%s", err, out)
+	}
+	if result.EpicID != epic || result.Iterations != 2 || result.TotalCost != 0 {
+		t.Errorf("unexpected run result: %+v", result)
+	}
+	if !strings.Contains(result.ExitReason, "stuck on task") {
+		t.Errorf("expected stuck-task exit reason, got %q", result.ExitReason)
+	}
+
+	_, code = captureStdout(func() int {
+		return run([]string{"tk", "run", epic, "--agent", "gpt"})
+	})
+	if code != exitUsage {
+		t.Errorf("expected usage error for unknown agent, got %d", code)
+	}
+}
+
+func TestRunVerbosity(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	createTestTick(t, "Add refunds", "--parent", epic)
+
+	runWith := func(flag string) (string, int) {
+		args := []string{"tk", "run", epic, "--ralph", "--agent", "echo", "--max-task-retries", "1", "--skip-verify"}
+		if flag != "" {
+			args = append(args, flag)
+		}
+		return captureStdout(func() int { return run(args) })
+	}
+
+	for _, tt := range []struct {
+		flag    string
+		banners bool
+	}{
+		{"", true},
+		{"--verbose", true},
+		{"--quiet", false},
+	} {
+		out, code := runWith(tt.flag)
+		if code != exitSuccess {
+			t.Fatalf("run %s: exit %d\n%s", tt.flag, code, out)
+		}
+		if got := strings.Contains(out, "=== Iteration 1:"); got != tt.banners {
+			t.Errorf("run %s: iteration banner present = %v, want %v\n%s", tt.flag, got, tt.banners, out)
+		}
+		if got := strings.Contains(out, "--- Iteration 1 complete"); got != tt.banners {
+			t.Errorf("run %s: iteration end banner present = %v, want %v\n%s", tt.flag, got, tt.banners, out)
+		}
+		if !strings.Contains(out, "=== Run Complete ===") {
+			t.Errorf("run %s: expected final summary\n%s", tt.flag, out)
+		}
+	}
+
+	for _, flags := range [][]string{{"--quiet", "--verbose"}, {"--quiet", "--jsonl"}, {"--verbose", "--jsonl"}} {
+		args := append([]string{"tk", "run", epic, "--ralph", "--agent", "echo"}, flags...)
+		if _, code := captureStdout(func() int { return run(args) }); code != exitUsage {
+			t.Errorf("run %v: expected usage error, got %d", flags, code)
+		}
+	}
+}
+
+func TestRunBudgetReport(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	task := createTestTick(t, "Add refunds", "--parent", epic)
+
+	base := []string{"tk", "run", epic, "--ralph", "--agent", "echo", "--max-task-retries", "1", "--skip-verify", "--budget-report"}
+
+	out, code := captureStdout(func() int { return run(append(base, "--jsonl")) })
+	if code != exitSuccess {
+		t.Fatalf("run --budget-report --jsonl: exit %d\n%s", code, out)
+	}
+	var result struct {
+		Iterations   int `json:"iterations"`
+		BudgetReport *struct {
+			MaxIterations int      `json:"max_iterations"`
+			IterationsPct *float64 `json:"iterations_pct"`
+			CostPct       *float64 `json:"cost_pct"`
+			Tasks         []struct {
+				TaskID     string `json:"task_id"`
+				Iterations int    `json:"iterations"`
+			} `json:"tasks"`
+		} `json:"budget_report"`
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &result); err != nil {
+		t.Fatalf("unmarshal run result: %v\n%s", err, out)
+	}
+	report := result.BudgetReport
+	if report == nil {
+		t.Fatalf("expected budget_report in output\n%s", out)
+	}
+	if len(report.Tasks) != 1 || report.Tasks[0].TaskID != task {
+		t.Fatalf("expected %s in budget_report, got %+v", task, report.Tasks)
+	}
+	sum := 0
+	for _, task := range report.Tasks {
+		sum += task.Iterations
+	}
+	if sum != result.Iterations {
+		t.Errorf("per-task iterations sum to %d, run had %d", sum, result.Iterations)
+	}
+	if report.MaxIterations != 50 || report.IterationsPct == nil {
+		t.Errorf("expected iterations percent of the 50 iteration limit, got %+v", report)
+	}
+	if report.CostPct != nil {
+		t.Errorf("expected no cost percent without --max-cost, got %v", *report.CostPct)
+	}
+
+	out, code = captureStdout(func() int { return run(base) })
+	if code != exitSuccess {
+		t.Fatalf("run --budget-report: exit %d\n%s", code, out)
+	}
+	if !strings.Contains(out, "Budget by task:") || !strings.Contains(out, "Budget used: ") {
+		t.Errorf("expected budget report in summary\n%s", out)
+	}
+
+	if _, code := captureStdout(func() int { return run([]string{"tk", "run", epic, "--budget-report"}) }); code != exitUsage {
+		t.Errorf("--budget-report without --ralph: expected usage error, got %d", code)
+	}
+}
+
+func TestRunMaxDuration(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	createTestTick(t, "Add refunds", "--parent", epic)
+
+	// A deadline that has already passed when the engine starts stops it
+	// before the first iteration
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "run", epic, "--ralph", "--agent", "echo", "--skip-verify", "--max-duration", "1ns", "--jsonl"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("run --max-duration: exit %d\n%s", code, out)
+	}
+	var result struct {
+		Iterations int    `json:"iterations"`
+		ExitReason string `json:"exit_reason"`
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &result); err != nil {
+		t.Fatalf("unmarshal run result: %v\n%s", err, out)
+	}
+	if result.ExitReason != "max-duration" || result.Iterations != 0 {
+		t.Errorf("expected max-duration exit with no iterations, got %+v", result)
+	}
+
+	for _, args := range [][]string{{"--max-duration", "1h"}, {"--ralph", "--max-duration", "-1m"}} {
+		cmd := append([]string{"tk", "run", epic, "--agent", "echo"}, args...)
+		if _, code := captureStdout(func() int { return run(cmd) }); code != exitUsage {
+			t.Errorf("run %v: expected usage error, got %d", args, code)
+		}
+	}
+}
+
+func TestCheckpointListRestore(t *testing.T) {
+	repo := setupTestRepo(t)
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	done := createTestTick(t, "Add refunds", "--parent", epic)
+	if code := run([]string{"tk", "close", done, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close %s: exit %d", done, code)
+	}
+	git("add", ".")
+	git("commit", "-m", "refunds")
+	base := git("rev-parse", "HEAD")
+
+	mgr := checkpoint.NewManagerWithDir(filepath.Join(repo, ".tick", "logs", "checkpoints"))
+	now := time.Now()
+	first := &checkpoint.Checkpoint{ID: epic + "-1", EpicID: epic, Iteration: 1, Timestamp: now, CompletedTasks: []string{done}, GitCommit: base}
+
+	// Work after the checkpoint: a new task (left untracked) is closed and code is committed
+	later := createTestTick(t, "Add invoices", "--parent", epic)
+	if code := run([]string{"tk", "close", later, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close %s: exit %d", later, code)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "invoices.go"), []byte("package invoices\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	git("add", "invoices.go")
+	git("commit", "-m", "invoices")
+	head := git("rev-parse", "HEAD")
+
+	for _, cp := range []*checkpoint.Checkpoint{
+		{ID: epic + "-3", EpicID: epic, Iteration: 3, Timestamp: now.Add(2 * time.Second), CompletedTasks: []string{done, later}, GitCommit: head},
+		first,
+		{ID: epic + "-2", EpicID: epic, Iteration: 2, Timestamp: now.Add(time.Second), CompletedTasks: []string{done}, GitCommit: base},
+		{ID: "other-9", EpicID: "other", Iteration: 9, Timestamp: now, GitCommit: base},
+	} {
+		if err := mgr.Save(cp); err != nil {
+			t.Fatalf("save checkpoint: %v", err)
+		}
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "checkpoint", "list", epic, "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("checkpoint list exit %d", code)
+	}
+	var listed struct {
+		Checkpoints []struct {
+			ID        string `json:"id"`
+			Iteration int    `json:"iteration"`
+			GitCommit string `json:"git_commit"`
+		} `json:"checkpoints"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("parse list json: %v\n%s", err, out)
+	}
+	var ids []string
+	for _, cp := range listed.Checkpoints {
+		ids = append(ids, cp.ID)
+	}
+	if want := []string{epic + "-3", epic + "-2", epic + "-1"}; !slices.Equal(ids, want) {
+		t.Fatalf("expected checkpoints %v, got %v", want, ids)
+	}
+
+	// Declining the prompt leaves everything alone
+	stdinR, stdinW, _ := os.Pipe()
+	_, _ = stdinW.WriteString("n\n")
+	_ = stdinW.Close()
+	origStdin := os.Stdin
+	os.Stdin = stdinR
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "checkpoint", "restore", epic, first.ID})
+	})
+	os.Stdin = origStdin
+	_ = stdinR.Close()
+	if code != exitSuccess || !strings.Contains(out, "Restore cancelled") {
+		t.Fatalf("expected cancelled restore, got exit %d:\n%s", code, out)
+	}
+	if git("rev-parse", "HEAD") != head {
+		t.Fatalf("cancelled restore moved HEAD")
+	}
+
+	// Uncommitted code changes are never thrown away
+	if err := os.WriteFile(filepath.Join(repo, "invoices.go"), []byte("package invoices // wip\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if code := run([]string{"tk", "checkpoint", "restore", epic, first.ID, "--force"}); code != exitGeneric {
+		t.Fatalf("expected restore on a dirty tree to exit %d, got %d", exitGeneric, code)
+	}
+	if git("rev-parse", "HEAD") != head {
+		t.Fatalf("refused restore moved HEAD")
+	}
+	git("checkout", "invoices.go")
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "checkpoint", "restore", epic, first.ID, "--force", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("checkpoint restore exit %d\n%s", code, out)
+	}
+	var restored struct {
+		Reopened []string `json:"reopened"`
+		Deleted  []string `json:"deleted_checkpoints"`
+	}
+	if err := json.Unmarshal([]byte(out), &restored); err != nil {
+		t.Fatalf("parse restore json: %v\n%s", err, out)
+	}
+	if got := git("rev-parse", "HEAD"); got != base {
+		t.Errorf("expected HEAD %s after restore, got %s", base, got)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "invoices.go")); !os.IsNotExist(err) {
+		t.Errorf("expected invoices.go to be rolled back, stat err %v", err)
+	}
+	if !slices.Equal(restored.Reopened, []string{later}) {
+		t.Errorf("expected %s reopened, got %v", later, restored.Reopened)
+	}
+	if readTestTick(t, repo, later)["status"] != tick.StatusOpen {
+		t.Errorf("expected %s open after restore", later)
+	}
+	if readTestTick(t, repo, done)["status"] != tick.StatusClosed {
+		t.Errorf("expected %s, completed before the checkpoint, to stay closed", done)
+	}
+	if !slices.Equal(restored.Deleted, []string{epic + "-3", epic + "-2"}) {
+		t.Errorf("expected later checkpoints deleted, got %v", restored.Deleted)
+	}
+	if _, err := mgr.Load("other-9"); err != nil {
+		t.Errorf("expected other epic's checkpoint kept: %v", err)
+	}
+
+	if code := run([]string{"tk", "checkpoint", "restore", "other", first.ID, "--force"}); code != exitUsage {
+		t.Errorf("expected usage error for checkpoint of another epic, got %d", code)
+	}
+	if code := run([]string{"tk", "checkpoint", "restore", epic, epic + "-3", "--force"}); code != exitNotFound {
+		t.Errorf("expected not found for deleted checkpoint, got %d", code)
+	}
+}
+
+func TestRunVerifyOnly(t *testing.T) {
+	repo := setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	done := createTestTick(t, "Add refunds", "--parent", epic)
+	createTestTick(t, "Add invoices", "--parent", epic)
+	if code := run([]string{"tk", "close", done, "--reason", "shipped"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+	if err := runGit(repo, "add", "-A"); err != nil {
+		t.Fatalf("git add: %v", err)
+	}
+	if err := runGit(repo, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-m", "init"); err != nil {
+		t.Fatalf("git commit: %v", err)
+	}
+
+	verifyOnly := func() (map[string]any, int) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "run", epic, "--verify-only", "--jsonl"})
+		})
+		var result map[string]any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &result); err != nil {
+			t.Fatalf("parse verify-only output: %v\n%s", err, out)
+		}
+		return result, code
+	}
+
+	result, code := verifyOnly()
+	if code != exitSuccess {
+		t.Fatalf("verify-only on clean repo: exit %d (%v)", code, result)
+	}
+	if result["exit_reason"] != "verify-only" || result["iterations"] != float64(0) {
+		t.Errorf("unexpected result: %v", result)
+	}
+	verifications, _ := result["verifications"].([]any)
+	if len(verifications) != 1 {
+		t.Fatalf("expected only the closed task verified, got %v", result["verifications"])
+	}
+	if v := verifications[0].(map[string]any); v["task_id"] != done || v["passed"] != true {
+		t.Errorf("expected %s to pass, got %v", done, v)
+	}
+
+	// Uncommitted work fails the git verifier
+	if err := os.WriteFile(filepath.Join(repo, "stray.txt"), []byte("wip"), 0o644); err != nil {
+		t.Fatalf("write stray file: %v", err)
+	}
+	result, code = verifyOnly()
+	if code != exitGeneric {
+		t.Fatalf("verify-only on dirty repo: exit %d, want %d", code, exitGeneric)
+	}
+	v := result["verifications"].([]any)[0].(map[string]any)
+	if v["passed"] != false || fmt.Sprint(v["failed_verifiers"]) != "[git]" {
+		t.Errorf("expected git verifier failure, got %v", v)
+	}
+
+	if _, code := captureStdout(func() int {
+		return run([]string{"tk", "run", epic, "--verify-only", "--skip-verify"})
+	}); code != exitUsage {
+		t.Errorf("expected usage error for --verify-only --skip-verify, got %d", code)
+	}
+}
+
+func TestMoveCommand(t *testing.T) {
+	repo := setupTestRepo(t)
+	epicA := createTestTick(t, "Epic A", "-t", "epic")
+	epicB := createTestTick(t, "Epic B", "-t", "epic", "--parent", epicA)
+	task := createTestTick(t, "Task", "--parent", epicA)
+	other := createTestTick(t, "Not an epic")
+
+	t.Run("reparent", func(t *testing.T) {
+		if code := run([]string{"tk", "move", task, "--parent", epicB}); code != exitSuccess {
+			t.Fatalf("expected success, got %d", code)
+		}
+		if got := readTestTick(t, repo, task)["parent"]; got != epicB {
+			t.Errorf("expected parent %s, got %v", epicB, got)
+		}
+	})
+
+	t.Run("not_epic", func(t *testing.T) {
+		if code := run([]string{"tk", "move", task, "--parent", other}); code != exitUsage {
+			t.Errorf("expected usage exit for non-epic parent, got %d", code)
+		}
+		if code := run([]string{"tk", "move", task, "--parent", "zzz"}); code != exitNotFound {
+			t.Errorf("expected not-found exit for missing parent, got %d", code)
+		}
+		if got := readTestTick(t, repo, task)["parent"]; got != epicB {
+			t.Errorf("failed move should keep parent %s, got %v", epicB, got)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		if code := run([]string{"tk", "move", epicA, "--parent", epicB}); code != exitUsage {
+			t.Errorf("expected usage exit for parent cycle, got %d", code)
+		}
+		if code := run([]string{"tk", "move", epicA, "--parent", epicA}); code != exitUsage {
+			t.Errorf("expected usage exit for self-parent, got %d", code)
+		}
+		if _, ok := readTestTick(t, repo, epicA)["parent"]; ok {
+			t.Errorf("epic A should stay top-level")
+		}
+	})
+
+	t.Run("detach", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "move", task, "--parent", "", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("expected success, got %d", code)
+		}
+		var moved map[string]any
+		if err := json.Unmarshal([]byte(out), &moved); err != nil {
+			t.Fatalf("parse move json: %v\n%s", err, out)
+		}
+		if _, ok := moved["parent"]; ok {
+			t.Errorf("expected no parent in output, got %v", moved["parent"])
+		}
+		if _, ok := readTestTick(t, repo, task)["parent"]; ok {
+			t.Errorf("expected task detached")
+		}
+	})
+
+	t.Run("parent_required", func(t *testing.T) {
+		if code := run([]string{"tk", "move", task}); code != exitUsage {
+			t.Errorf("expected usage exit without --parent, got %d", code)
+		}
+	})
+}
+
+func TestEpicSplit(t *testing.T) {
+	repo := setupTestRepo(t)
+	source := createTestTick(t, "Payments", "-t", "epic", "-p", "1", "-l", "billing")
+	schema := createTestTick(t, "Refund schema", "--parent", source)
+	api := createTestTick(t, "Refund API", "--parent", source)
+	ui := createTestTick(t, "Refund UI", "--parent", source)
+	invoices := createTestTick(t, "Invoices", "--parent", source)
+	other := createTestTick(t, "Elsewhere")
+
+	// api stays behind but is blocked by schema; ui moves but is blocked by api
+	for _, pair := range [][2]string{{ui, api}, {api, schema}, {ui, schema}} {
+		if code := run([]string{"tk", "block", pair[0], pair[1]}); code != exitSuccess {
+			t.Fatalf("block %s %s: exit %d", pair[0], pair[1], code)
+		}
+	}
+
+	t.Run("dry_run", func(t *testing.T) {
+		var out string
+		stderr, code := captureStderr(func() int {
+			var code int
+			out, code = captureStdout(func() int {
+				return run([]string{"tk", "epic", "split", source, "--title", "Refunds", schema, ui, "--dry-run"})
+			})
+			return code
+		})
+		if code != exitSuccess {
+			t.Fatalf("dry run: exit %d\n%s", code, stderr)
+		}
+		if !strings.Contains(out, "Would create epic") {
+			t.Errorf("expected dry-run summary, got:\n%s", out)
+		}
+		if !strings.Contains(stderr, ui+" is blocked by "+api) {
+			t.Errorf("expected cross-epic warning for %s, got:\n%s", ui, stderr)
+		}
+		if got := readTestTick(t, repo, ui)["parent"]; got != source {
+			t.Errorf("dry run moved %s to %v", ui, got)
+		}
+	})
+
+	t.Run("split", func(t *testing.T) {
+		var out string
+		stderr, code := captureStderr(func() int {
+			var code int
+			out, code = captureStdout(func() int {
+				return run([]string{"tk", "epic", "split", source, "--title", "Refunds", schema, ui, "--json"})
+			})
+			return code
+		})
+		if code != exitSuccess {
+			t.Fatalf("split: exit %d\n%s", code, stderr)
+		}
+		var result struct {
+			Epic              map[string]any `json:"epic"`
+			Moved             []string       `json:"moved"`
+			CrossEpicBlockers []struct {
+				TaskID    string `json:"task_id"`
+				BlockedBy string `json:"blocked_by"`
+			} `json:"cross_epic_blockers"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse split json: %v\n%s", err, out)
+		}
+
+		newEpic, _ := result.Epic["id"].(string)
+		if newEpic == "" || result.Epic["type"] != "epic" || result.Epic["title"] != "Refunds" {
+			t.Fatalf("unexpected new epic: %v", result.Epic)
+		}
+		if result.Epic["priority"] != float64(1) || fmt.Sprint(result.Epic["labels"]) != "[billing]" {
+			t.Errorf("new epic should inherit priority and labels, got %v", result.Epic)
+		}
+		for _, id := range []string{schema, ui} {
+			if got := readTestTick(t, repo, id)["parent"]; got != newEpic {
+				t.Errorf("expected %s under %s, got %v", id, newEpic, got)
+			}
+		}
+		for _, id := range []string{api, invoices} {
+			if got := readTestTick(t, repo, id)["parent"]; got != source {
+				t.Errorf("expected %s to stay under %s, got %v", id, source, got)
+			}
+		}
+		if got := fmt.Sprint(readTestTick(t, repo, ui)["blocked_by"]); got != fmt.Sprintf("[%s %s]", api, schema) {
+			t.Errorf("expected blockers kept, got %s", got)
+		}
+
+		// Both directions of the api <-> {schema, ui} dependency now cross epics
+		want := map[string]bool{ui + "<-" + api: true, api + "<-" + schema: true}
+		if len(result.CrossEpicBlockers) != len(want) {
+			t.Errorf("expected %d cross-epic blockers, got %+v", len(want), result.CrossEpicBlockers)
+		}
+		for _, c := range result.CrossEpicBlockers {
+			if !want[c.TaskID+"<-"+c.BlockedBy] {
+				t.Errorf("unexpected cross-epic blocker %+v", c)
+			}
+		}
+		if !strings.Contains(stderr, "warning: "+ui+" is blocked by "+api) {
+			t.Errorf("expected cross-epic warning, got:\n%s", stderr)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if code := run([]string{"tk", "epic", "split", source, invoices}); code != exitUsage {
+			t.Errorf("expected usage exit without --title, got %d", code)
+		}
+		if code := run([]string{"tk", "epic", "split", source, "--title", "X", other}); code != exitUsage {
+			t.Errorf("expected usage exit for a task outside the epic, got %d", code)
+		}
+		if code := run([]string{"tk", "epic", "split", invoices, "--title", "X", api}); code != exitUsage {
+			t.Errorf("expected usage exit for a non-epic source, got %d", code)
+		}
+		if code := run([]string{"tk", "epic", "split", source, "--title", "X", "zzz"}); code != exitNotFound {
+			t.Errorf("expected not-found exit for a missing task, got %d", code)
+		}
+	})
+}
+
+func TestEpicMerge(t *testing.T) {
+	repo := setupTestRepo(t)
+	source := createTestTick(t, "Refunds", "-t", "epic")
+	target := createTestTick(t, "Payments", "-t", "epic")
+	schema := createTestTick(t, "Refund schema", "--parent", source)
+	api := createTestTick(t, "Refund API", "--parent", source)
+	done := createTestTick(t, "Refund spike", "--parent", source)
+	invoices := createTestTick(t, "Invoices", "--parent", target)
+	if code := run([]string{"tk", "close", done, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close %s: exit %d", done, code)
+	}
+	for _, pair := range [][2]string{{api, schema}, {invoices, api}} {
+		if code := run([]string{"tk", "block", pair[0], pair[1]}); code != exitSuccess {
+			t.Fatalf("block %s %s: exit %d", pair[0], pair[1], code)
+		}
+	}
+
+	t.Run("dry_run", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "epic", "merge", source, target, "--close-source", "--dry-run"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("dry run: exit %d", code)
+		}
+		if !strings.Contains(out, "Would move 3 task(s)") || !strings.Contains(out, "Would close "+source) {
+			t.Errorf("unexpected dry-run output:\n%s", out)
+		}
+		if got := readTestTick(t, repo, schema)["parent"]; got != source {
+			t.Errorf("dry run moved %s to %v", schema, got)
+		}
+		if got := readTestTick(t, repo, source)["status"]; got != "open" {
+			t.Errorf("dry run closed the source epic: %v", got)
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "epic", "merge", source, target, "--close-source", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("merge: exit %d", code)
+		}
+		var result struct {
+			Moved        []string `json:"moved"`
+			SourceClosed bool     `json:"source_closed"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse merge json: %v\n%s", err, out)
+		}
+		if len(result.Moved) != 3 || !result.SourceClosed {
+			t.Errorf("unexpected merge result: %+v", result)
+		}
+
+		for _, id := range []string{schema, api, done, invoices} {
+			if got := readTestTick(t, repo, id)["parent"]; got != target {
+				t.Errorf("expected %s under %s, got %v", id, target, got)
+			}
+		}
+		if got := fmt.Sprint(readTestTick(t, repo, api)["blocked_by"]); got != "["+schema+"]" {
+			t.Errorf("expected %s blockers kept, got %s", api, got)
+		}
+		if got := fmt.Sprint(readTestTick(t, repo, invoices)["blocked_by"]); got != "["+api+"]" {
+			t.Errorf("expected %s blockers kept, got %s", invoices, got)
+		}
+
+		src := readTestTick(t, repo, source)
+		if src["status"] != "closed" || src["closed_reason"] != "merged into "+target {
+			t.Errorf("expected source closed as merged, got status=%v reason=%v", src["status"], src["closed_reason"])
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if code := run([]string{"tk", "epic", "merge", target, target}); code != exitUsage {
+			t.Errorf("expected usage exit merging an epic into itself, got %d", code)
+		}
+		if code := run([]string{"tk", "epic", "merge", target, api}); code != exitUsage {
+			t.Errorf("expected usage exit for a non-epic target, got %d", code)
+		}
+		nested := createTestTick(t, "Nested", "-t", "epic", "--parent", target)
+		if code := run([]string{"tk", "epic", "merge", target, nested}); code != exitUsage {
+			t.Errorf("expected usage exit merging into a child epic, got %d", code)
+		}
+	})
+}
+
+func TestMigrateCommand(t *testing.T) {
+	repo := setupTestRepo(t)
+	id := createTestTick(t, "Ship refunds")
+
+	out, code := captureStdout(func() int { return run([]string{"tk", "migrate"}) })
+	if code != exitSuccess {
+		t.Fatalf("migrate: exit %d\n%s", code, out)
+	}
+	if !strings.Contains(out, "No migrations needed") {
+		t.Errorf("expected nothing to migrate, got:\n%s", out)
+	}
+
+	// Embed a run record the way older versions stored it
+	tickPath := filepath.Join(repo, ".tick", "issues", id+".json")
+	raw := readTestTick(t, repo, id)
+	raw["run"] = map[string]any{"session_id": "sess-1", "model": "opus", "output": "done", "success": true}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("marshal tick: %v", err)
+	}
+	if err := os.WriteFile(tickPath, data, 0o644); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+
+	out, code = captureStdout(func() int { return run([]string{"tk", "migrate", "--dry-run"}) })
+	if code != exitSuccess {
+		t.Fatalf("migrate --dry-run: exit %d\n%s", code, out)
+	}
+	if !strings.Contains(out, "run-records:") || !strings.Contains(out, "Would migrate: 1") {
+		t.Errorf("expected pending run-records migration, got:\n%s", out)
+	}
+	if _, ok := readTestTick(t, repo, id)["run"]; !ok {
+		t.Fatal("dry run removed the run record")
+	}
+
+	out, code = captureStdout(func() int { return run([]string{"tk", "migrate"}) })
+	if code != exitSuccess {
+		t.Fatalf("migrate: exit %d\n%s", code, out)
+	}
+	if !strings.Contains(out, "Migrated: 1") || !strings.Contains(out, "Skipped: 0") {
+		t.Errorf("expected one migrated run record, got:\n%s", out)
+	}
+	if _, ok := readTestTick(t, repo, id)["run"]; ok {
+		t.Error("run record still embedded in tick after migrate")
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".tick", "logs", "records", id+".json")); err != nil {
+		t.Errorf("expected migrated run record file: %v", err)
+	}
+
+	out, _ = captureStdout(func() int { return run([]string{"tk", "migrate"}) })
+	if !strings.Contains(out, "No migrations needed") {
+		t.Errorf("expected nothing left to migrate, got:\n%s", out)
+	}
+}
+
+func TestSchemaVersionGate(t *testing.T) {
+	repo := setupTestRepo(t)
+	id := createTestTick(t, "Ship refunds")
+
+	// A repo at the current schema version runs commands as before
+	if _, code := captureStdout(func() int { return run([]string{"tk", "show", id}) }); code != exitSuccess {
+		t.Fatalf("show on current repo: exit %d", code)
+	}
+
+	// A newer tk brings a v2 migration, so the v1 repo is now behind
+	migrated := 0
+	t.Cleanup(migrate.Register(migrate.Migration{
+		Name:        "test-v2",
+		Description: "Test migration to v2",
+		Version:     2,
+		Needed:      func(string) (bool, error) { return true, nil },
+		Apply: func(_ string, dryRun bool) (*migrate.MigrationResult, error) {
+			if !dryRun {
+				migrated++
+			}
+			return &migrate.MigrationResult{Migrated: 1}, nil
+		},
+	}))
+
+	stderr, code := captureStderr(func() int {
+		_, code := captureStdout(func() int { return run([]string{"tk", "show", id}) })
+		return code
+	})
+	if code == exitSuccess {
+		t.Fatalf("expected show on an old repo to be refused")
+	}
+	if !strings.Contains(stderr, "schema version 1") || !strings.Contains(stderr, "--migrate") {
+		t.Errorf("expected migration hint, got:\n%s", stderr)
+	}
+	if migrated != 0 {
+		t.Errorf("migration ran without --migrate")
+	}
+
+	// Exempt commands still run
+	if _, code := captureStdout(func() int { return run([]string{"tk", "migrate", "--dry-run"}) }); code != exitSuccess {
+		t.Errorf("migrate --dry-run on old repo: exit %d", code)
+	}
+
+	stderr, code = captureStderr(func() int {
+		_, code := captureStdout(func() int { return run([]string{"tk", "show", id, "--migrate"}) })
+		return code
+	})
+	if code != exitSuccess {
+		t.Fatalf("show --migrate: exit %d\n%s", code, stderr)
+	}
+	if migrated != 1 || !strings.Contains(stderr, "Upgraded .tick data to schema version 2") {
+		t.Errorf("expected one migration run (got %d), stderr:\n%s", migrated, stderr)
+	}
+	data, err := os.ReadFile(filepath.Join(repo, ".tick", "config.json"))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if !strings.Contains(string(data), `"schema_version": 2`) {
+		t.Errorf("expected schema_version 2 in config, got:\n%s", data)
+	}
+
+	// Once upgraded, commands run without migrating again
+	if _, code := captureStdout(func() int { return run([]string{"tk", "show", id}) }); code != exitSuccess {
+		t.Errorf("show after upgrade: exit %d", code)
+	}
+	if migrated != 1 {
+		t.Errorf("migration ran again after upgrade (%d runs)", migrated)
+	}
+}
+
+func TestInitExisting(t *testing.T) {
+	repo := setupTestRepo(t)
+	configPath := filepath.Join(repo, ".tick", "config.json")
+	gitignorePath := filepath.Join(repo, ".tick", ".gitignore")
+
+	custom := []byte(`{"version": 1, "id_length": 4, "defaults": {"priority": 1}}`)
+	if err := os.WriteFile(configPath, custom, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := os.WriteFile(gitignorePath, []byte("logs/\nscratch/\n"), 0o644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+
+	t.Run("refuses without force", func(t *testing.T) {
+		stderr, code := captureStderr(func() int {
+			return run([]string{"tk", "init"})
+		})
+		if code == exitSuccess {
+			t.Fatalf("expected init to fail on existing .tick")
+		}
+		if !strings.Contains(stderr, "--force") {
+			t.Errorf("expected error to mention --force, got %q", stderr)
+		}
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			t.Fatalf("read config: %v", err)
+		}
+		if string(data) != string(custom) {
+			t.Errorf("config was modified: %s", data)
+		}
+	})
+
+	t.Run("force keeps config", func(t *testing.T) {
+		if code := run([]string{"tk", "init", "--force"}); code != exitSuccess {
+			t.Fatalf("expected init --force exit %d, got %d", exitSuccess, code)
+		}
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		if cfg.IDLength != 4 {
+			t.Errorf("expected id_length 4 kept, got %d", cfg.IDLength)
+		}
+		if cfg.Defaults == nil || cfg.Defaults.Priority == nil || *cfg.Defaults.Priority != 1 {
+			t.Errorf("expected defaults.priority 1 kept, got %+v", cfg.Defaults)
+		}
+
+		data, err := os.ReadFile(gitignorePath)
+		if err != nil {
+			t.Fatalf("read .gitignore: %v", err)
+		}
+		for _, line := range []string{"scratch/", "logs/", ".index.json"} {
+			if !strings.Contains(string(data), line+"\n") {
+				t.Errorf("expected .gitignore to contain %q, got %q", line, data)
+			}
+		}
+		if strings.Count(string(data), "logs/") != 1 {
+			t.Errorf("expected logs/ once in .gitignore, got %q", data)
+		}
+
+		attrs, err := os.ReadFile(filepath.Join(repo, ".gitattributes"))
+		if err != nil {
+			t.Fatalf("read .gitattributes: %v", err)
+		}
+		if n := strings.Count(string(attrs), "merge=tick"); n != 1 {
+			t.Errorf("expected one merge driver line in .gitattributes, got %d", n)
+		}
+	})
+}
+
+func TestIDPrefixResolution(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	for i, newID := range []string{"qa1", "qb1", "qb2"} {
+		id := createTestTick(t, fmt.Sprintf("Prefix %d", i))
+		if code := run([]string{"tk", "rename-id", id, newID}); code != exitSuccess {
+			t.Fatalf("rename %s -> %s: exit %d", id, newID, code)
+		}
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "show", "qa", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("expected show by prefix exit %d, got %d", exitSuccess, code)
+	}
+	if !strings.Contains(out, `"id":"qa1"`) {
+		t.Fatalf("expected qa1 from prefix qa, got %s", out)
+	}
+
+	if code := run([]string{"tk", "update", "qb2", "--title", "Renamed"}); code != exitSuccess {
+		t.Fatalf("expected update by full id exit %d, got %d", exitSuccess, code)
+	}
+	if code := run([]string{"tk", "close", "qa"}); code != exitSuccess {
+		t.Fatalf("expected close by prefix exit %d, got %d", exitSuccess, code)
+	}
+	if got := readTestTick(t, repo, "qa1")["status"]; got != "closed" {
+		t.Errorf("expected qa1 closed via prefix, got %v", got)
+	}
+
+	stderr, code := captureStderr(func() int {
+		return run([]string{"tk", "update", "qb", "--title", "Nope"})
+	})
+	if code != exitUsage {
+		t.Fatalf("expected ambiguous prefix exit %d, got %d", exitUsage, code)
+	}
+	if !strings.Contains(stderr, "qb1, qb2") {
+		t.Errorf("expected candidates in error, got %q", stderr)
+	}
+
+	if _, code := captureStderr(func() int {
+		return run([]string{"tk", "show", "zz"})
+	}); code != exitNotFound {
+		t.Errorf("expected no-match exit %d, got %d", exitNotFound, code)
+	}
+}
+
+func TestUpdateBulkFilter(t *testing.T) {
+	repo := setupTestRepo(t)
+	bugA := createTestTick(t, "Crash on save", "-t", "bug")
+	bugB := createTestTick(t, "Crash on load", "-t", "bug", "-p", "0")
+	closedBug := createTestTick(t, "Old crash", "-t", "bug")
+	task := createTestTick(t, "Write docs")
+	if code := run([]string{"tk", "close", closedBug, "--reason", "fixed"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+
+	hasLabel := func(id, label string) bool {
+		labels, _ := readTestTick(t, repo, id)["labels"].([]any)
+		for _, l := range labels {
+			if l == label {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("explicit_id_conflict", func(t *testing.T) {
+		if code := run([]string{"tk", "update", bugA, "--filter-type", "bug", "--add-labels", "x"}); code != exitUsage {
+			t.Errorf("expected usage exit for id with filters, got %d", code)
+		}
+		if code := run([]string{"tk", "update", "--filter-type", "bug"}); code != exitUsage {
+			t.Errorf("expected usage exit with no update fields, got %d", code)
+		}
+	})
+
+	t.Run("dry_run", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "update", "--filter-status", "open", "--filter-type", "bug", "--add-labels", "triaged", "--dry-run", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("expected success, got %d", code)
+		}
+		var result map[string]any
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse json: %v\n%s", err, out)
+		}
+		updated, _ := result["updated"].([]any)
+		if len(updated) != 2 || result["dry_run"] != true {
+			t.Errorf("expected 2 would-be updates, got %v", result)
+		}
+		if hasLabel(bugA, "triaged") {
+			t.Errorf("dry run should not write labels")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "update", "--filter-status", "open", "--filter-type", "bug", "--add-labels", "triaged"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("expected success, got %d", code)
+		}
+		if !strings.Contains(out, "Updated 2 of 2 matching ticks") {
+			t.Errorf("expected summary, got %q", out)
+		}
+		if !hasLabel(bugA, "triaged") || !hasLabel(bugB, "triaged") {
+			t.Errorf("expected open bugs labeled")
+		}
+		if hasLabel(closedBug, "triaged") || hasLabel(task, "triaged") {
+			t.Errorf("non-matching ticks should be untouched")
+		}
+	})
+
+	t.Run("priority_filter", func(t *testing.T) {
+		if code := run([]string{"tk", "update", "--filter-priority", "0", "--title", "Crash on load (P0)"}); code != exitSuccess {
+			t.Fatalf("expected success, got %d", code)
+		}
+		if got := readTestTick(t, repo, bugB)["title"]; got != "Crash on load (P0)" {
+			t.Errorf("expected P0 bug retitled, got %v", got)
+		}
+		if got := readTestTick(t, repo, bugA)["title"]; got != "Crash on save" {
+			t.Errorf("expected P2 bug untouched, got %v", got)
+		}
+	})
+
+	t.Run("partial_failure", func(t *testing.T) {
+		// Every match is attempted even after the first one fails validation
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "update", "--filter-type", "bug", "--filter-status", "open", "--status", "bogus"})
+		})
+		if code != exitGeneric {
+			t.Fatalf("expected generic exit for failed updates, got %d\n%s", code, out)
+		}
+		if !strings.Contains(out, "Updated 0 of 2 matching ticks") {
+			t.Errorf("expected summary of failures, got %q", out)
+		}
+		if got := readTestTick(t, repo, bugA)["status"]; got != "open" {
+			t.Errorf("failed update should not be written, got status %v", got)
+		}
+	})
+}
+
+func TestSearchCommand(t *testing.T) {
+	setupTestRepo(t)
+	notesHit := createTestTick(t, "Refactor store")
+	if code := run([]string{"tk", "note", notesHit, "Check the Stripe webhook"}); code != exitSuccess {
+		t.Fatalf("note: exit %d", code)
+	}
+	descHit := createTestTick(t, "Add docs", "-d", "Document the stripe flow")
+	titleHit := createTestTick(t, "Stripe retries", "-t", "bug")
+	createTestTick(t, "Unrelated")
+
+	search := func(t *testing.T, args ...string) []map[string]any {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "search", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("search %v: exit %d", args, code)
+		}
+		var results []map[string]any
+		if err := json.Unmarshal([]byte(out), &results); err != nil {
+			t.Fatalf("parse search json: %v\n%s", err, out)
+		}
+		return results
+	}
+
+	t.Run("ranking", func(t *testing.T) {
+		results := search(t, "STRIPE")
+		var ids, fields []string
+		for _, r := range results {
+			ids = append(ids, r["tick"].(map[string]any)["id"].(string))
+			fields = append(fields, r["fields"].([]any)[0].(string))
+		}
+		want := []string{titleHit, descHit, notesHit}
+		if strings.Join(ids, ",") != strings.Join(want, ",") {
+			t.Errorf("expected order %v, got %v", want, ids)
+		}
+		if strings.Join(fields, ",") != "title,description,notes" {
+			t.Errorf("unexpected matched fields %v", fields)
+		}
+	})
+
+	t.Run("type_filter", func(t *testing.T) {
+		results := search(t, "stripe", "--type", "bug")
+		if len(results) != 1 || results[0]["tick"].(map[string]any)["id"] != titleHit {
+			t.Errorf("expected only the bug, got %v", results)
+		}
+	})
+
+	t.Run("regex", func(t *testing.T) {
+		results := search(t, "web.ook|flow$", "--regex")
+		if len(results) != 2 {
+			t.Errorf("expected 2 regex matches, got %v", results)
+		}
+		if results := search(t, "web.ook"); len(results) != 0 {
+			t.Errorf("literal search should not match a pattern, got %v", results)
+		}
+		if code := run([]string{"tk", "search", "(", "--regex"}); code != exitUsage {
+			t.Errorf("expected usage exit for invalid regex, got %d", code)
+		}
+	})
+}
+
+func TestDuplicateCommand(t *testing.T) {
+	repo := setupTestRepo(t)
+	epic := createTestTick(t, "Release", "-t", "epic")
+	blocker := createTestTick(t, "Prep")
+	src := createTestTick(t, "Rotate keys", "-t", "chore", "-p", "1", "-d", "All services",
+		"-l", "security,ops", "--parent", epic, "-b", blocker, "--awaiting", "review")
+	if code := run([]string{"tk", "close", src, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+	t.Setenv("TICK_OWNER", "copier")
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "duplicate", src, "--count", "2", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("duplicate: exit %d", code)
+	}
+	var copies []map[string]any
+	if err := json.Unmarshal([]byte(out), &copies); err != nil {
+		t.Fatalf("parse duplicate json: %v\n%s", err, out)
+	}
+	if len(copies) != 2 || copies[0]["id"] == copies[1]["id"] {
+		t.Fatalf("expected 2 distinct copies, got %v", copies)
+	}
+
+	for _, c := range copies {
+		saved := readTestTick(t, repo, c["id"].(string))
+		if saved["title"] != "Rotate keys" || saved["description"] != "All services" || saved["type"] != "chore" ||
+			saved["priority"] != float64(1) || saved["parent"] != epic {
+			t.Errorf("descriptive fields not carried over: %v", saved)
+		}
+		if labels, _ := saved["labels"].([]any); len(labels) != 2 {
+			t.Errorf("expected labels copied, got %v", saved["labels"])
+		}
+		if saved["status"] != "open" || saved["owner"] != "copier" || saved["created_by"] != "copier" {
+			t.Errorf("expected open copy owned by copier, got %v", saved)
+		}
+		for _, field := range []string{"blocked_by", "awaiting", "verdict", "closed_at", "closed_reason", "notes"} {
+			if _, ok := saved[field]; ok {
+				t.Errorf("expected %s reset, got %v", field, saved[field])
+			}
+		}
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "duplicate", src, "--title", "Rotate keys (staging)"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("duplicate --title: exit %d", code)
+	}
+	if got := readTestTick(t, repo, strings.TrimSpace(out))["title"]; got != "Rotate keys (staging)" {
+		t.Errorf("expected overridden title, got %v", got)
+	}
+
+	if code := run([]string{"tk", "duplicate", src, "--count", "0"}); code != exitUsage {
+		t.Errorf("expected usage exit for --count 0, got %d", code)
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	repo := setupTestRepo(t)
+	epic := createTestTick(t, "Launch", "-t", "epic")
+	task := createTestTick(t, "Write copy", "--parent", epic, "-l", "marketing")
+	old := createTestTick(t, "Old work")
+	if code := run([]string{"tk", "close", old, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+	// Backdate the close past the sync window
+	oldPath := filepath.Join(repo, ".tick", "issues", old+".json")
+	data, err := os.ReadFile(oldPath)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var oldTick map[string]any
+	if err := json.Unmarshal(data, &oldTick); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	oldTick["closed_at"] = "2020-01-01T00:00:00Z"
+	data, _ = json.MarshalIndent(oldTick, "", "  ")
+	if err := os.WriteFile(oldPath, data, 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	t.Setenv("HOME", t.TempDir()) // default closed_sync_window
+
+	export := func(args ...string) string {
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "export"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("export %v: exit %d", args, code)
+		}
+		return out
+	}
+
+	if lines := strings.Count(export(), "\n"); lines != 2 {
+		t.Errorf("expected open ticks only by default, got %d lines", lines)
+	}
+	all := export("--all")
+	if lines := strings.Count(all, "\n"); lines != 3 {
+		t.Fatalf("expected 3 ticks with --all, got %d lines", lines)
+	}
+	exportFile := filepath.Join(t.TempDir(), "ticks.jsonl")
+	if err := os.WriteFile(exportFile, []byte(all), 0o644); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+
+	// Import into a fresh repo
+	fresh := setupTestRepo(t)
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "import", exportFile, "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("import: exit %d\n%s", code, out)
+	}
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse import json: %v\n%s", err, out)
+	}
+	if result["imported"] != float64(3) {
+		t.Errorf("expected 3 imported, got %v", result)
+	}
+	for _, id := range []string{epic, task, old} {
+		want := readTestTick(t, repo, id)
+		got := readTestTick(t, fresh, id)
+		wantJSON, _ := json.Marshal(want)
+		gotJSON, _ := json.Marshal(got)
+		if string(wantJSON) != string(gotJSON) {
+			t.Errorf("tick %s differs after round trip:\nwant %s\ngot  %s", id, wantJSON, gotJSON)
+		}
+	}
+
+	// Re-import: existing ids are skipped by default, and newer needs a later updated_at
+	for _, mode := range []string{"skip", "newer"} {
+		out, code = captureStdout(func() int {
+			return run([]string{"tk", "import", exportFile, "--on-conflict", mode})
+		})
+		if code != exitSuccess || !strings.Contains(out, "Imported 0 ticks (0 overwritten, 3 skipped)") {
+			t.Errorf("--on-conflict %s: exit %d, output %q", mode, code, out)
+		}
+	}
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "import", exportFile, "--on-conflict", "overwrite"})
+	})
+	if code != exitSuccess || !strings.Contains(out, "3 overwritten") {
+		t.Errorf("--on-conflict overwrite: exit %d, output %q", code, out)
+	}
+
+	if code := run([]string{"tk", "import", exportFile, "--on-conflict", "merge"}); code != exitUsage {
+		t.Errorf("expected usage exit for invalid --on-conflict, got %d", code)
+	}
+}
+
+func TestListSince(t *testing.T) {
+	repo := setupTestRepo(t)
+	after := createTestTick(t, "Changed after cutoff")
+	before := createTestTick(t, "Changed before cutoff")
+	equal := createTestTick(t, "Changed at cutoff")
+
+	cutoff := time.Date(2025, 1, 8, 10, 30, 0, 0, time.UTC)
+	setUpdatedAt := func(id string, at time.Time) {
+		t.Helper()
+		tickPath := filepath.Join(repo, ".tick", "issues", id+".json")
+		tickData := readTestTick(t, repo, id)
+		tickData["updated_at"] = at.Format(time.RFC3339Nano)
+		newData, _ := json.MarshalIndent(tickData, "", "  ")
+		if err := os.WriteFile(tickPath, newData, 0o644); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+	}
+	setUpdatedAt(after, cutoff.Add(time.Hour))
+	setUpdatedAt(before, cutoff.Add(-time.Second))
+	setUpdatedAt(equal, cutoff)
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "list", "--all", "--status", "all", "--since", cutoff.Format(time.RFC3339), "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("list --since: exit %d", code)
+	}
+	var result struct {
+		Ticks []map[string]any `json:"ticks"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse list json: %v", err)
+	}
+	var ids []string
+	for _, tk := range result.Ticks {
+		ids = append(ids, tk["id"].(string))
+	}
+	if len(ids) != 2 || ids[0] != equal || ids[1] != after {
+		t.Errorf("expected [%s %s] oldest first, got %v", equal, after, ids)
+	}
+
+	if code := run([]string{"tk", "list", "--since", "yesterday"}); code != exitUsage {
+		t.Errorf("expected usage exit for invalid --since, got %d", code)
+	}
+}
+
+func TestStatsCounts(t *testing.T) {
+	setupTestRepo(t)
+	blocker := createTestTick(t, "Fix login", "-t", "bug", "-p", "1")
+	createTestTick(t, "Blocked work", "-b", blocker)
+	createTestTick(t, "Needs a human", "--awaiting", "work")
+	createTestTick(t, "Later", "--defer", "2099-01-01")
+	done := createTestTick(t, "Done")
+	if code := run([]string{"tk", "close", done, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+	createTestTick(t, "Someone else's", "-o", "alice")
+
+	stats := func(t *testing.T, args ...string) map[string]any {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "stats", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("stats %v: exit %d", args, code)
+		}
+		var result map[string]any
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse stats json: %v", err)
+		}
+		return result
+	}
+	expectCounts := func(t *testing.T, got map[string]any, want map[string]float64) {
+		t.Helper()
+		for key, n := range want {
+			if got[key] != n {
+				t.Errorf("%s: expected %v, got %v", key, n, got[key])
+			}
+		}
+	}
+
+	t.Run("current_owner", func(t *testing.T) {
+		got := stats(t)
+		expectCounts(t, got, map[string]float64{
+			"total": 5, "open": 4, "closed": 1,
+			"ready": 1, "blocked": 1, "awaiting_human": 1, "deferred": 1,
+		})
+		types := got["type"].(map[string]any)
+		if types["bug"] != 1.0 || types["task"] != 4.0 {
+			t.Errorf("unexpected type counts: %v", types)
+		}
+		if status := got["status"].(map[string]any); status["closed"] != 1.0 {
+			t.Errorf("unexpected status counts: %v", status)
+		}
+		if _, ok := got["median_open_age_hours"]; !ok {
+			t.Error("expected median_open_age_hours")
+		}
+		if _, ok := got["owners"]; ok {
+			t.Error("owners should be omitted without --by-owner")
+		}
+	})
+
+	t.Run("by_owner", func(t *testing.T) {
+		got := stats(t, "--by-owner")
+		expectCounts(t, got, map[string]float64{"total": 6, "open": 5})
+		owners := got["owners"].(map[string]any)
+		if len(owners) != 2 {
+			t.Fatalf("expected 2 owners, got %v", owners)
+		}
+		expectCounts(t, owners["tester"].(map[string]any), map[string]float64{"total": 5, "ready": 1})
+		expectCounts(t, owners["alice"].(map[string]any), map[string]float64{"total": 1, "open": 1, "ready": 1})
+	})
+}
+
+func TestListOwnerFilter(t *testing.T) {
+	setupTestRepo(t)
+	mine := createTestTick(t, "Mine")
+	alices := createTestTick(t, "Alice's", "-o", "alice")
+	bobs := createTestTick(t, "Bob's", "-o", "bob")
+	createTestTick(t, "Alice's lookalike", "-o", "alice2")
+
+	listIDs := func(t *testing.T, args ...string) map[string]bool {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "list", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("list %v: exit %d", args, code)
+		}
+		var result struct {
+			Ticks []map[string]any `json:"ticks"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse list json: %v", err)
+		}
+		got := make(map[string]bool)
+		for _, tk := range result.Ticks {
+			got[tk["id"].(string)] = true
+		}
+		return got
+	}
+
+	t.Run("single", func(t *testing.T) {
+		got := listIDs(t, "--owner", "alice")
+		if len(got) != 1 || !got[alices] {
+			t.Errorf("expected only %s, got %v", alices, got)
+		}
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+		got := listIDs(t, "--owner", "alice", "--owner", "bob")
+		if len(got) != 2 || !got[alices] || !got[bobs] {
+			t.Errorf("expected %s and %s, got %v", alices, bobs, got)
+		}
+		got = listIDs(t, "--owner", "alice,bob")
+		if len(got) != 2 || !got[alices] || !got[bobs] {
+			t.Errorf("comma-separated: expected %s and %s, got %v", alices, bobs, got)
+		}
+	})
+
+	t.Run("me", func(t *testing.T) {
+		got := listIDs(t, "--owner", "@me")
+		if len(got) != 1 || !got[mine] {
+			t.Errorf("expected only %s, got %v", mine, got)
+		}
+		got = listIDs(t, "--owner", "@me", "--owner", "bob")
+		if len(got) != 2 || !got[mine] || !got[bobs] {
+			t.Errorf("expected %s and %s, got %v", mine, bobs, got)
+		}
+	})
+}
+
+func TestListLabelsAllAny(t *testing.T) {
+	setupTestRepo(t)
+	both := createTestTick(t, "Both", "-l", "backend,auth")
+	backend := createTestTick(t, "Backend only", "-l", "backend")
+	createTestTick(t, "Neither", "-l", "docs")
+
+	listIDs := func(t *testing.T, args ...string) map[string]bool {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "list", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("list %v: exit %d", args, code)
+		}
+		var result struct {
+			Ticks []map[string]any `json:"ticks"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse list json: %v", err)
+		}
+		got := make(map[string]bool)
+		for _, tk := range result.Ticks {
+			got[tk["id"].(string)] = true
+		}
+		return got
+	}
+
+	if got := listIDs(t, "--labels-all", "backend", "--labels-all", "auth"); len(got) != 1 || !got[both] {
+		t.Errorf("labels-all: expected only %s, got %v", both, got)
+	}
+	if got := listIDs(t, "--labels-any", "auth,backend"); len(got) != 2 || !got[both] || !got[backend] {
+		t.Errorf("labels-any: expected %s and %s, got %v", both, backend, got)
+	}
+	if got := listIDs(t, "--labels-all", "backend", "--labels-any", "auth,docs"); len(got) != 1 || !got[both] {
+		t.Errorf("combined: expected only %s, got %v", both, got)
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	repo := setupTestRepo(t)
+	a := createTestTick(t, "First")
+	b := createTestTick(t, "Second", "-b", a)
+
+	if code := run([]string{"tk", "validate"}); code != exitSuccess {
+		t.Fatalf("validate clean repo: expected exit 0, got %d", code)
+	}
+
+	editTick := func(id string, edit func(map[string]any)) {
+		t.Helper()
+		tickData := readTestTick(t, repo, id)
+		edit(tickData)
+		newData, _ := json.MarshalIndent(tickData, "", "  ")
+		if err := os.WriteFile(filepath.Join(repo, ".tick", "issues", id+".json"), newData, 0o644); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+	}
+	// a <-> b blocker cycle, plus a missing blocker and parent on a
+	editTick(a, func(m map[string]any) {
+		m["blocked_by"] = []string{b, "gone"}
+		m["parent"] = "nope"
+	})
+	if err := os.WriteFile(filepath.Join(repo, ".tick", "issues", "bad.json"), []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("write bad tick: %v", err)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "validate", "--json"})
+	})
+	if code != exitGeneric {
+		t.Fatalf("validate: expected exit %d, got %d", exitGeneric, code)
+	}
+	var result struct {
+		Problems []struct {
+			Kind string   `json:"kind"`
+			Tick string   `json:"tick"`
+			Refs []string `json:"refs"`
+		} `json:"problems"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse validate json: %v\n%s", err, out)
+	}
+	kinds := make(map[string]string)
+	for _, p := range result.Problems {
+		kinds[p.Kind] = p.Tick
+	}
+	want := map[string]string{
+		"invalid":          "bad",
+		"dangling_blocker": a,
+		"dangling_parent":  a,
+		"blocker_cycle":    min(a, b),
+	}
+	for kind, id := range want {
+		if kinds[kind] != id {
+			t.Errorf("expected %s on %s, got problems %+v", kind, id, result.Problems)
+		}
+	}
+	if len(result.Problems) != len(want) {
+		t.Errorf("expected %d problems, got %+v", len(want), result.Problems)
+	}
+}
+
+func TestValidateFix(t *testing.T) {
+	repo := setupTestRepo(t)
+	a := createTestTick(t, "Valid blocker")
+	b := createTestTick(t, "Dangling refs", "-b", a)
+	c := createTestTick(t, "Closed but awaiting")
+	d := createTestTick(t, "Cycle one")
+	e := createTestTick(t, "Cycle two", "-b", d)
+
+	tickPath := func(id string) string { return filepath.Join(repo, ".tick", "issues", id+".json") }
+	editTick := func(id string, edit func(map[string]any)) {
+		t.Helper()
+		tickData := readTestTick(t, repo, id)
+		edit(tickData)
+		newData, _ := json.MarshalIndent(tickData, "", "  ")
+		if err := os.WriteFile(tickPath(id), newData, 0o644); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+	}
+	editTick(b, func(m map[string]any) {
+		m["blocked_by"] = []string{a, "gone"}
+		m["parent"] = "nope"
+	})
+	editTick(c, func(m map[string]any) {
+		m["status"] = "closed"
+		m["awaiting"] = "review"
+	})
+	editTick(d, func(m map[string]any) { m["blocked_by"] = []string{e} })
+
+	snapshot := func() map[string]string {
+		out := make(map[string]string)
+		for _, id := range []string{a, b, c, d, e} {
+			data, err := os.ReadFile(tickPath(id))
+			if err != nil {
+				t.Fatalf("read tick: %v", err)
+			}
+			out[id] = string(data)
+		}
+		return out
+	}
+	type result struct {
+		Problems []struct {
+			Kind string `json:"kind"`
+		} `json:"problems"`
+		Fixes []struct {
+			Tick   string `json:"tick"`
+			Change string `json:"change"`
+		} `json:"fixes"`
+	}
+	validate := func(args ...string) (result, int) {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "validate", "--json"}, args...))
+		})
+		var r result
+		if err := json.Unmarshal([]byte(out), &r); err != nil {
+			t.Fatalf("parse validate json: %v\n%s", err, out)
+		}
+		return r, code
+	}
+
+	t.Run("dry_run", func(t *testing.T) {
+		before := snapshot()
+		r, code := validate("--fix", "--dry-run")
+		if code != exitGeneric {
+			t.Errorf("expected exit %d, got %d", exitGeneric, code)
+		}
+		if len(r.Fixes) != 3 {
+			t.Errorf("expected 3 proposed fixes, got %+v", r.Fixes)
+		}
+		if len(r.Problems) != 4 {
+			t.Errorf("dry run should leave all 4 problems, got %+v", r.Problems)
+		}
+		after := snapshot()
+		for id := range before {
+			if before[id] != after[id] {
+				t.Errorf("dry run modified %s", id)
+			}
+		}
+	})
+
+	t.Run("fix", func(t *testing.T) {
+		before := snapshot()
+		r, code := validate("--fix")
+		if code != exitGeneric {
+			t.Errorf("cycle should remain; expected exit %d, got %d", exitGeneric, code)
+		}
+		if len(r.Problems) != 1 || r.Problems[0].Kind != "blocker_cycle" {
+			t.Errorf("expected only the blocker cycle to remain, got %+v", r.Problems)
+		}
+		if len(r.Fixes) != 3 {
+			t.Errorf("expected 3 fixes, got %+v", r.Fixes)
+		}
+
+		fixed := readTestTick(t, repo, b)
+		if blockers, _ := fixed["blocked_by"].([]any); len(blockers) != 1 || blockers[0] != a {
+			t.Errorf("expected blocked_by [%s], got %v", a, fixed["blocked_by"])
+		}
+		if _, ok := fixed["parent"]; ok {
+			t.Errorf("expected parent removed, got %v", fixed["parent"])
+		}
+		if _, ok := readTestTick(t, repo, c)["awaiting"]; ok {
+			t.Error("expected awaiting cleared on closed tick")
+		}
+
+		after := snapshot()
+		for _, id := range []string{b, c} {
+			if before[id] == after[id] {
+				t.Errorf("expected %s to be rewritten", id)
+			}
+			prev := map[string]any{}
+			_ = json.Unmarshal([]byte(before[id]), &prev)
+			if readTestTick(t, repo, id)["updated_at"] == prev["updated_at"] {
+				t.Errorf("expected updated_at bumped on %s", id)
+			}
+		}
+		for _, id := range []string{a, d, e} {
+			if before[id] != after[id] {
+				t.Errorf("valid or cyclic tick %s should be untouched", id)
+			}
+		}
+	})
+}
+
+func TestNextPicksBestReady(t *testing.T) {
+	repo := setupTestRepo(t)
+	newer := createTestTick(t, "Newer P1", "-p", "1")
+	older := createTestTick(t, "Older P1", "-p", "1")
+	createTestTick(t, "Lower priority", "-p", "3")
+	blocker := createTestTick(t, "Blocking task", "-p", "0")
+	createTestTick(t, "Blocked bug", "-t", "bug", "-p", "0", "-b", blocker)
+
+	// Make the second-created tick the oldest so creation order can't decide
+	tickData := readTestTick(t, repo, older)
+	tickData["created_at"] = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339Nano)
+	newData, _ := json.MarshalIndent(tickData, "", "  ")
+	if err := os.WriteFile(filepath.Join(repo, ".tick", "issues", older+".json"), newData, 0o644); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+
+	next := func(t *testing.T, args ...string) (string, int) {
+		t.Helper()
+		return captureStdout(func() int {
+			return run(append([]string{"tk", "next", "--json"}, args...))
+		})
+	}
+	nextID := func(t *testing.T, args ...string) string {
+		t.Helper()
+		out, code := next(t, args...)
+		if code != exitSuccess {
+			t.Fatalf("next %v: exit %d", args, code)
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(out), &got); err != nil || got == nil {
+			t.Fatalf("parse next json %q: %v", out, err)
+		}
+		return got["id"].(string)
+	}
+
+	t.Run("priority_first", func(t *testing.T) {
+		if got := nextID(t); got != blocker {
+			t.Errorf("expected P0 %s, got %s", blocker, got)
+		}
+	})
+
+	t.Run("created_at_breaks_ties", func(t *testing.T) {
+		if got := nextID(t, "--min-priority", "1"); got != older {
+			t.Errorf("expected older %s before %s, got %s", older, newer, got)
+		}
+	})
+
+	t.Run("blocker_outside_filter", func(t *testing.T) {
+		// The bug's blocker is a task, so it is outside the --type bug set
+		out, code := next(t, "--type", "bug")
+		if code != exitSuccess || strings.TrimSpace(out) != "null" {
+			t.Errorf("expected null with exit 0, got %q (exit %d)", out, code)
+		}
+	})
+
+	t.Run("none_ready_prints_nothing", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "next", "--type", "bug"})
+		})
+		if code != exitSuccess || out != "" {
+			t.Errorf("expected empty stdout with exit 0, got %q (exit %d)", out, code)
+		}
+	})
+}
+
+func TestConfigCommand(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	get := func(key string) (string, int) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "config", "get", key})
+		})
+		return strings.TrimSpace(out), code
+	}
+
+	if out, code := get("id_length"); code != exitSuccess || out != "3" {
+		t.Fatalf("get id_length: %q (exit %d)", out, code)
+	}
+	if _, code := get("context.max_tokens"); code != exitGeneric {
+		t.Errorf("get unset key: expected exit %d, got %d", exitGeneric, code)
+	}
+
+	for _, kv := range [][2]string{{"id_length", "4"}, {"context.max_tokens", "8000"}} {
+		if code := run([]string{"tk", "config", "set", kv[0], kv[1]}); code != exitSuccess {
+			t.Fatalf("set %s=%s: exit %d", kv[0], kv[1], code)
+		}
+		if out, code := get(kv[0]); code != exitSuccess || out != kv[1] {
+			t.Errorf("get %s: %q (exit %d), want %s", kv[0], out, code, kv[1])
+		}
+	}
+
+	before, err := os.ReadFile(filepath.Join(repo, ".tick", "config.json"))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	for _, kv := range [][2]string{{"id_length", "7"}, {"context.max_tokens", "10"}, {"bogus", "1"}} {
+		if code := run([]string{"tk", "config", "set", kv[0], kv[1]}); code != exitUsage {
+			t.Errorf("set %s=%s: expected exit %d, got %d", kv[0], kv[1], exitUsage, code)
+		}
+	}
+	after, _ := os.ReadFile(filepath.Join(repo, ".tick", "config.json"))
+	if string(before) != string(after) {
+		t.Errorf("rejected set modified config:\n%s", after)
+	}
+}
+
+func TestCreateConfigDefaults(t *testing.T) {
+	repo := setupTestRepo(t)
+	for _, kv := range [][2]string{
+		{"defaults.priority", "1"},
+		{"defaults.type", "bug"},
+		{"defaults.owner", "triage-bot"},
+		{"defaults.labels", `["needs-triage"]`},
+	} {
+		if code := run([]string{"tk", "config", "set", kv[0], kv[1]}); code != exitSuccess {
+			t.Fatalf("config set %s: exit %d", kv[0], code)
+		}
+	}
+
+	defaulted := readTestTick(t, repo, createTestTick(t, "Defaulted"))
+	if defaulted["priority"] != 1.0 || defaulted["type"] != "bug" || defaulted["owner"] != "triage-bot" {
+		t.Errorf("expected config defaults, got priority=%v type=%v owner=%v", defaulted["priority"], defaulted["type"], defaulted["owner"])
+	}
+	if labels, _ := defaulted["labels"].([]any); len(labels) != 1 || labels[0] != "needs-triage" {
+		t.Errorf("expected default labels, got %v", defaulted["labels"])
+	}
+
+	overridden := readTestTick(t, repo, createTestTick(t, "Overridden", "-p", "2", "-t", "task", "-o", "alice", "-l", "backend"))
+	if overridden["priority"] != 2.0 || overridden["type"] != "task" || overridden["owner"] != "alice" {
+		t.Errorf("expected flags to win, got priority=%v type=%v owner=%v", overridden["priority"], overridden["type"], overridden["owner"])
+	}
+	if labels, _ := overridden["labels"].([]any); len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("expected flag labels, got %v", overridden["labels"])
+	}
+
+	if code := run([]string{"tk", "config", "set", "defaults.type", "story"}); code != exitUsage {
+		t.Errorf("invalid default type: expected exit %d, got %d", exitUsage, code)
+	}
+}
+
+func TestLogCommand(t *testing.T) {
+	repo := setupTestRepo(t)
+	id := createTestTick(t, "Run me")
+
+	if code := run([]string{"tk", "log", id}); code != exitNotFound {
+		t.Fatalf("log without record: expected exit %d, got %d", exitNotFound, code)
+	}
+
+	recordsDir := filepath.Join(repo, ".tick", "logs", "records")
+	if err := os.MkdirAll(recordsDir, 0o755); err != nil {
+		t.Fatalf("mkdir records: %v", err)
+	}
+	record := `{"session_id":"sess-1","model":"opus","started_at":"2025-01-08T10:00:00Z","ended_at":"2025-01-08T10:05:00Z",` +
+		`"output":"done","tools":[{"name":"Read","duration_ms":5},{"name":"Edit","duration_ms":7},{"name":"Read","duration_ms":3}],` +
+		`"metrics":{"input_tokens":1200,"output_tokens":340,"cost_usd":0.0421,"duration_ms":300000},"success":true,"num_turns":6}`
+	if err := os.WriteFile(filepath.Join(recordsDir, id+".json"), []byte(record), 0o644); err != nil {
+		t.Fatalf("write record: %v", err)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "log", id})
+	})
+	if code != exitSuccess {
+		t.Fatalf("log: expected exit 0, got %d", code)
+	}
+	for _, want := range []string{"success", "sess-1", "opus", "2025-01-08 10:05", "Read ×2, Edit ×1", "1200 in, 340 out", "$0.0421", "5m0s"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "log", id, "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("log --json: expected exit 0, got %d", code)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("parse json: %v\n%s", err, out)
+	}
+	if got["session_id"] != "sess-1" || got["success"] != true || got["num_turns"] != float64(6) {
+		t.Fatalf("unexpected record json: %v", got)
+	}
+
+	// A live record takes precedence while the run is in progress
+	live := `{"session_id":"sess-2","model":"opus","started_at":"2025-01-08T11:00:00Z","output":"",` +
+		`"active_tool":{"name":"Bash","duration_ms":100},"metrics":{"input_tokens":10},"status":"tool_use","num_turns":1,` +
+		`"last_updated":"2025-01-08T11:01:00Z"}`
+	if err := os.WriteFile(filepath.Join(recordsDir, id+".live.json"), []byte(live), 0o644); err != nil {
+		t.Fatalf("write live record: %v", err)
+	}
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "log", id})
+	})
+	if code != exitSuccess {
+		t.Fatalf("log live: expected exit 0, got %d", code)
+	}
+	for _, want := range []string{"running", "sess-2", "Bash"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("live log output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "sess-1") {
+		t.Errorf("live log should not show the finished record:\n%s", out)
+	}
+}
+
+func TestCostsCommand(t *testing.T) {
+	repo := setupTestRepo(t)
+	epic := createTestTick(t, "Epic", "-t", "epic")
+	taskA := createTestTick(t, "Task A", "--parent", epic)
+	taskB := createTestTick(t, "Task B", "--parent", epic, "-o", "bob")
+	loose := createTestTick(t, "Loose")
+
+	recordsDir := filepath.Join(repo, ".tick", "logs", "records")
+	if err := os.MkdirAll(recordsDir, 0o755); err != nil {
+		t.Fatalf("mkdir records: %v", err)
+	}
+	writeRecord := func(id, startedAt string, in, out, cache int, cost float64, ms int) {
+		t.Helper()
+		record := fmt.Sprintf(`{"session_id":"s-%s","started_at":%q,"success":true,"metrics":`+
+			`{"input_tokens":%d,"output_tokens":%d,"cache_read_tokens":%d,"cost_usd":%g,"duration_ms":%d}}`,
+			id, startedAt, in, out, cache, cost, ms)
+		if err := os.WriteFile(filepath.Join(recordsDir, id+".json"), []byte(record), 0o644); err != nil {
+			t.Fatalf("write record: %v", err)
+		}
+	}
+	recent := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	writeRecord(taskA, recent, 1000, 200, 50, 0.25, 60000)
+	writeRecord(taskB, recent, 3000, 400, 0, 0.5, 120000)
+	writeRecord(loose, "2020-01-01T00:00:00Z", 100, 10, 0, 0.125, 1000)
+	writeRecord("gone", recent, 10, 1, 0, 0.0625, 500)
+
+	type output struct {
+		By    string `json:"by"`
+		Total struct {
+			Runs        int     `json:"runs"`
+			InputTokens int     `json:"input_tokens"`
+			CostUSD     float64 `json:"cost_usd"`
+			DurationMS  int     `json:"duration_ms"`
+		} `json:"total"`
+		Groups []struct {
+			Key          string  `json:"key"`
+			Runs         int     `json:"runs"`
+			OutputTokens int     `json:"output_tokens"`
+			CostUSD      float64 `json:"cost_usd"`
+		} `json:"groups"`
+	}
+	costs := func(args ...string) output {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "costs", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("costs %v: expected exit 0, got %d", args, code)
+		}
+		var result output
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse json: %v\n%s", err, out)
+		}
+		return result
+	}
+
+	all := costs()
+	if all.Total.Runs != 4 || all.Total.InputTokens != 4110 || all.Total.CostUSD != 0.9375 || all.Total.DurationMS != 181500 {
+		t.Fatalf("unexpected totals: %+v", all.Total)
+	}
+	if len(all.Groups) != 4 || all.Groups[0].Key != taskB || all.Groups[0].OutputTokens != 400 {
+		t.Fatalf("expected per-tick groups sorted by cost, got %+v", all.Groups)
+	}
+
+	byEpic := costs("--by", "epic")
+	if len(byEpic.Groups) != 2 || byEpic.Groups[0].Key != epic || byEpic.Groups[0].Runs != 2 || byEpic.Groups[0].CostUSD != 0.75 {
+		t.Fatalf("unexpected epic groups: %+v", byEpic.Groups)
+	}
+	if byEpic.Groups[1].Key != "-" || byEpic.Groups[1].CostUSD != 0.1875 {
+		t.Fatalf("expected unattributed group last, got %+v", byEpic.Groups[1])
+	}
+
+	if got := costs("--epic", epic); got.Total.Runs != 2 || got.Total.CostUSD != 0.75 {
+		t.Fatalf("--epic totals: %+v", got.Total)
+	}
+	if got := costs("--owner", "bob"); got.Total.Runs != 1 || got.Groups[0].Key != taskB {
+		t.Fatalf("--owner bob: %+v", got)
+	}
+	if got := costs("--since", "7d"); got.Total.Runs != 3 || got.Total.CostUSD != 0.8125 {
+		t.Fatalf("--since 7d totals: %+v", got.Total)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "costs", "--by", "epic"})
+	})
+	if code != exitSuccess || !strings.Contains(out, "$0.7500") || !strings.Contains(out, "$0.9375") {
+		t.Fatalf("costs text output (exit %d):\n%s", code, out)
+	}
+
+	if code := run([]string{"tk", "costs", "--by", "label"}); code != exitUsage {
+		t.Fatalf("costs --by label: expected exit %d, got %d", exitUsage, code)
+	}
+}
+
+func TestDepsAnalyze(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Epic", "-t", "epic")
+	task := createTestTick(t, "Only task", "--parent", epic)
+
+	if code := run([]string{"tk", "deps", task, "--analyze"}); code != exitUsage {
+		t.Fatalf("deps --analyze on a task: expected exit %d, got %d", exitUsage, code)
+	}
+	if code := run([]string{"tk", "deps", epic, "--dry-run"}); code != exitUsage {
+		t.Fatalf("deps --dry-run without --analyze: expected exit %d, got %d", exitUsage, code)
+	}
+
+	// A single open task can't conflict, so the agent is never run
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "deps", epic, "--analyze"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("deps --analyze: expected exit 0, got %d", code)
+	}
+	if !strings.Contains(out, "No conflicts found") {
+		t.Fatalf("expected no conflicts message, got %q", out)
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "deps", epic, "--analyze", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("deps --analyze --json: expected exit 0, got %d", code)
+	}
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse json: %v\n%s", err, out)
+	}
+	if _, ok := result["conflicting_pairs"]; !ok {
+		t.Fatalf("expected analysis result json, got %v", result)
+	}
+}
+
+func TestWhoamiRepo(t *testing.T) {
+	setupTestRepo(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TICKS_TOKEN", "")
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "whoami", "--repo", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("expected exit %d, got %d", exitSuccess, code)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("parse json: %v\n%s", err, out)
+	}
+	if payload["repo"] != "petere/chefswiz" || payload["board"] != "petere/chefswiz" {
+		t.Fatalf("unexpected repo identity: %v", payload)
+	}
+	if _, ok := payload["worktree"]; ok {
+		t.Fatalf("expected no worktree, got %v", payload["worktree"])
+	}
+	if payload["cloud_configured"] != false {
+		t.Fatalf("expected cloud not configured, got %v", payload["cloud_configured"])
+	}
+
+	t.Setenv("TICKS_TOKEN", "test-token")
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "whoami", "--repo"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("expected exit %d, got %d", exitSuccess, code)
+	}
+	for _, want := range []string{"Repo: petere/chefswiz", "Board: petere/chefswiz", "Cloud: configured"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "whoami", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("expected exit %d, got %d", exitSuccess, code)
+	}
+	if strings.Contains(out, `"board"`) {
+		t.Fatalf("expected no repo identity without --repo: %s", out)
+	}
+}
+
+func TestShowBlockersTree(t *testing.T) {
+	setupTestRepo(t)
+	base := createTestTick(t, "Pick vendor")
+	done := createTestTick(t, "Gather requirements")
+	if code := run([]string{"tk", "close", done, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+	design := createTestTick(t, "Design API", "-b", base)
+	build := createTestTick(t, "Build API", "-b", design+","+done)
+	docs := createTestTick(t, "Write docs")
+	target := createTestTick(t, "Launch", "-b", docs+","+build)
+
+	showTree := func(t *testing.T, id string) []map[string]any {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "show", id, "--blockers-tree", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("show --blockers-tree: exit %d", code)
+		}
+		var result struct {
+			Tick     map[string]any   `json:"tick"`
+			Blockers []map[string]any `json:"blockers"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse show json: %v\n%s", err, out)
+		}
+		if result.Tick["id"] != id {
+			t.Fatalf("expected tick %s, got %v", id, result.Tick["id"])
+		}
+		return result.Blockers
+	}
+	children := func(node map[string]any) []map[string]any {
+		raw, _ := node["blocked_by"].([]any)
+		nodes := make([]map[string]any, len(raw))
+		for i, r := range raw {
+			nodes[i] = r.(map[string]any)
+		}
+		return nodes
+	}
+
+	t.Run("multi_level_chain", func(t *testing.T) {
+		blockers := showTree(t, target)
+		if len(blockers) != 2 || blockers[0]["id"] != docs || blockers[1]["id"] != build {
+			t.Fatalf("expected %s and %s, got %v", docs, build, blockers)
+		}
+		if blockers[0]["critical"] == true || blockers[1]["critical"] != true {
+			t.Errorf("expected %s on the critical path, got %v", build, blockers)
+		}
+
+		buildDeps := children(blockers[1])
+		if len(buildDeps) != 2 || buildDeps[0]["id"] != design || buildDeps[1]["id"] != done {
+			t.Fatalf("expected %s and %s under %s, got %v", design, done, build, buildDeps)
+		}
+		if buildDeps[0]["critical"] != true || buildDeps[1]["critical"] == true {
+			t.Errorf("expected only %s critical, got %v", design, buildDeps)
+		}
+		if buildDeps[1]["status"] != "closed" || len(children(buildDeps[1])) != 0 {
+			t.Errorf("expected closed blocker %s as a leaf, got %v", done, buildDeps[1])
+		}
+
+		designDeps := children(buildDeps[0])
+		if len(designDeps) != 1 || designDeps[0]["id"] != base || designDeps[0]["critical"] != true {
+			t.Errorf("expected %s critical under %s, got %v", base, design, designDeps)
+		}
+	})
+
+	t.Run("no_blockers", func(t *testing.T) {
+		if blockers := showTree(t, base); len(blockers) != 0 {
+			t.Errorf("expected no blockers, got %v", blockers)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		if code := run([]string{"tk", "block", base, target}); code != exitSuccess {
+			t.Fatalf("block: exit %d", code)
+		}
+		blockers := showTree(t, target)
+		base := children(children(blockers[1])[0])[0]
+		cycle := children(base)
+		if len(cycle) != 1 || cycle[0]["id"] != target || cycle[0]["cycle"] != true {
+			t.Fatalf("expected cycle back to %s, got %v", target, cycle)
+		}
+		if len(children(cycle[0])) != 0 {
+			t.Errorf("expected cycle node not to be expanded, got %v", cycle[0])
+		}
+	})
+
+	t.Run("human_output", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "show", target, "--blockers-tree"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("show --blockers-tree: exit %d", code)
+		}
+		for _, want := range []string{"Blocker tree:", "└─", "Pick vendor", "(cycle)", "critical path"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected %q in output:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("shared_blocker_expanded_once", func(t *testing.T) {
+		root := createTestTick(t, "Root cause")
+		shared := createTestTick(t, "Shared dependency", "-b", root)
+		left := createTestTick(t, "Left", "-b", shared)
+		right := createTestTick(t, "Right", "-b", shared)
+		top := createTestTick(t, "Top", "-b", left+","+right)
+
+		blockers := showTree(t, top)
+		if len(blockers) != 2 {
+			t.Fatalf("expected %s and %s, got %v", left, right, blockers)
+		}
+		first, again := children(blockers[0])[0], children(blockers[1])[0]
+		if first["id"] != shared || first["repeat"] == true || len(children(first)) != 1 {
+			t.Errorf("expected %s expanded under %s, got %v", shared, left, first)
+		}
+		if again["id"] != shared || again["repeat"] != true || len(children(again)) != 0 {
+			t.Errorf("expected %s as a repeat under %s, got %v", shared, right, again)
+		}
+		if blockers[0]["critical"] != true || blockers[1]["critical"] == true {
+			t.Errorf("expected only %s critical, got %v", left, blockers)
+		}
+	})
+
+	t.Run("children_conflict", func(t *testing.T) {
+		if code := run([]string{"tk", "show", target, "--blockers-tree", "--children"}); code != exitUsage {
+			t.Errorf("expected exit %d, got %d", exitUsage, code)
+		}
+	})
+}
+
+func TestNoColorFlag(t *testing.T) {
+	setupTestRepo(t)
+	id := createTestTick(t, "Plain output")
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "--no-color", "show", id})
+	})
+	if code != exitSuccess {
+		t.Fatalf("expected exit %d, got %d", exitSuccess, code)
+	}
+	if !strings.Contains(out, "Plain output") || strings.Contains(out, "\x1b") {
+		t.Fatalf("expected plain show output, got %q", out)
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "list", "--no-color", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("expected exit %d, got %d", exitSuccess, code)
+	}
+	var listed map[string]any
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("expected JSON output unaffected by --no-color: %v\n%s", err, out)
+	}
+}
+
+func TestGlobalFlagsBeforeCommand(t *testing.T) {
+	setupTestRepo(t)
+	id := createTestTick(t, "Routed")
+
+	for _, args := range [][]string{
+		{"tk", "--migrate", "list", "--json"},
+		{"tk", "--migrate", "--no-color", "list", "--json"},
+		{"tk", "--no-color", "--migrate=true", "list", "--json"},
+	} {
+		out, code := captureStdout(func() int { return run(args) })
+		if code != exitSuccess {
+			t.Fatalf("%v: expected exit %d, got %d", args[1:], exitSuccess, code)
+		}
+		if !strings.Contains(out, id) {
+			t.Errorf("%v: expected %s listed, got %q", args[1:], id, out)
+		}
+	}
+}
+
+func TestCloseCascade(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	epicID := createTestTick(t, "Epic", "-t", "epic")
+	plainID := createTestTick(t, "Plain task", "--parent", epicID)
+	gatedID := createTestTick(t, "Gated task", "--parent", epicID, "--requires", "approval")
+	doneID := createTestTick(t, "Done task", "--parent", epicID)
+	otherID := createTestTick(t, "Unrelated task")
+
+	if _, code := captureStdout(func() int { return run([]string{"tk", "close", doneID, "--reason", "earlier"}) }); code != exitSuccess {
+		t.Fatalf("close %s failed: exit %d", doneID, code)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "close", epicID, "--cascade", "--reason", "shipped", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("close --cascade failed: exit %d", code)
+	}
+	var payload struct {
+		Tick    map[string]any `json:"tick"`
+		Cascade struct {
+			Closed   []string `json:"closed"`
+			Awaiting []struct {
+				ID       string `json:"id"`
+				Awaiting string `json:"awaiting"`
+			} `json:"awaiting"`
+		} `json:"cascade"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("parse json: %v (%s)", err, out)
+	}
+	if payload.Tick["status"] != "closed" {
+		t.Errorf("expected epic closed, got %v", payload.Tick["status"])
+	}
+	if len(payload.Cascade.Closed) != 1 || payload.Cascade.Closed[0] != plainID {
+		t.Errorf("expected only %s closed, got %v", plainID, payload.Cascade.Closed)
+	}
+	if len(payload.Cascade.Awaiting) != 1 || payload.Cascade.Awaiting[0].ID != gatedID || payload.Cascade.Awaiting[0].Awaiting != "approval" {
+		t.Errorf("expected %s awaiting approval, got %+v", gatedID, payload.Cascade.Awaiting)
+	}
+
+	plain := readTestTick(t, repo, plainID)
+	if plain["status"] != "closed" || plain["closed_reason"] != "shipped" {
+		t.Errorf("expected plain task closed with reason, got %v / %v", plain["status"], plain["closed_reason"])
+	}
+	gated := readTestTick(t, repo, gatedID)
+	if gated["status"] == "closed" {
+		t.Error("expected gated task not to be force-closed")
+	}
+	if gated["awaiting"] != "approval" {
+		t.Errorf("expected gated task awaiting approval, got %v", gated["awaiting"])
+	}
+	if done := readTestTick(t, repo, doneID); done["closed_reason"] != "earlier" {
+		t.Errorf("expected already-closed task untouched, got reason %v", done["closed_reason"])
+	}
+	if other := readTestTick(t, repo, otherID); other["status"] != "open" {
+		t.Errorf("expected unrelated task open, got %v", other["status"])
+	}
+
+	t.Run("text_report", func(t *testing.T) {
+		epic := createTestTick(t, "Second epic", "-t", "epic")
+		createTestTick(t, "Task A", "--parent", epic)
+		createTestTick(t, "Task B", "--parent", epic)
+		gated := createTestTick(t, "Task C", "--parent", epic, "--requires", "review")
+
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "close", epic, "--cascade"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("close --cascade failed: exit %d", code)
+		}
+		if !strings.Contains(out, "closed 2 tasks, 1 awaiting") {
+			t.Errorf("expected summary line, got %q", out)
+		}
+		if !strings.Contains(out, gated+": awaiting review") {
+			t.Errorf("expected gated task listed, got %q", out)
+		}
+	})
+
+	t.Run("rejects_force", func(t *testing.T) {
+		epic := createTestTick(t, "Third epic", "-t", "epic")
+		if code := run([]string{"tk", "close", epic, "--cascade", "--force"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+
+	t.Run("rejects_non_epic", func(t *testing.T) {
+		if code := run([]string{"tk", "close", otherID, "--cascade"}); code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+}
+
+func TestCloseRequiresGate(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	t.Run("ungated_closes", func(t *testing.T) {
+		id := createTestTick(t, "Plain task")
+		if code := run([]string{"tk", "close", id, "--reason", "done"}); code != exitSuccess {
+			t.Fatalf("close failed: exit %d", code)
+		}
+		got := readTestTick(t, repo, id)
+		if got["status"] != "closed" {
+			t.Errorf("expected closed, got %v", got["status"])
+		}
+		if got["closed_reason"] != "done" {
+			t.Errorf("expected closed_reason done, got %v", got["closed_reason"])
+		}
+	})
+
+	t.Run("gated_routes_to_awaiting", func(t *testing.T) {
+		id := createTestTick(t, "Gated task", "--requires", "review")
+		errOut, code := captureStderr(func() int {
+			return run([]string{"tk", "close", id, "--reason", "done"})
+		})
+		if code == exitSuccess {
+			t.Fatal("expected non-zero exit when close is routed to a gate")
+		}
+		if !strings.Contains(errOut, "awaiting review") {
+			t.Errorf("expected stderr to report awaiting review, got %q", errOut)
+		}
+		got := readTestTick(t, repo, id)
+		if got["status"] == "closed" {
+			t.Error("expected gated tick to stay open")
+		}
+		if got["awaiting"] != "review" {
+			t.Errorf("expected awaiting review, got %v", got["awaiting"])
+		}
+		if got["requires"] != "review" {
+			t.Errorf("expected requires to persist, got %v", got["requires"])
+		}
+		if _, ok := got["closed_at"]; ok {
+			t.Errorf("expected no closed_at, got %v", got["closed_at"])
+		}
+	})
+
+	t.Run("force_bypasses_gate", func(t *testing.T) {
+		id := createTestTick(t, "Forced task", "--requires", "approval")
+		if code := run([]string{"tk", "close", id, "--force", "--reason", "skip review"}); code != exitSuccess {
+			t.Fatalf("close --force failed: exit %d", code)
+		}
+		got := readTestTick(t, repo, id)
+		if got["status"] != "closed" {
+			t.Errorf("expected closed, got %v", got["status"])
+		}
+		if _, ok := got["awaiting"]; ok {
+			t.Errorf("expected awaiting cleared, got %v", got["awaiting"])
+		}
+	})
+}
+
+func TestExitCodes(t *testing.T) {
+	repo := setupTestRepo(t)
+	id := createTestTick(t, "Existing task")
+
+	quiet := func(args ...string) int {
+		_, code := captureStderr(func() int {
+			_, code := captureStdout(func() int { return run(append([]string{"tk"}, args...)) })
+			return code
+		})
+		return code
+	}
+
+	usage := [][]string{
+		{"list", "--bogus"},
+		{"show"},
+		{"create", "Bad priority", "-p", "high"},
+		{"close", id, "--cascade", "--force"},
+		{"list", "--json", "--format", "table"},
+	}
+	for _, args := range usage {
+		if code := quiet(args...); code != exitUsage {
+			t.Errorf("tk %s: expected exit %d, got %d", strings.Join(args, " "), exitUsage, code)
+		}
+	}
+
+	notFound := [][]string{
+		{"show", "zzz"},
+		{"close", "zzz"},
+		{"note", "zzz", "hello"},
+		{"update", "zzz", "--title", "x"},
+	}
+	for _, args := range notFound {
+		if code := quiet(args...); code != exitNotFound {
+			t.Errorf("tk %s: expected exit %d, got %d", strings.Join(args, " "), exitNotFound, code)
+		}
+	}
+
+	t.Run("json_output_failure", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("pipe: %v", err)
+		}
+		_ = r.Close()
+		_ = w.Close()
+		orig := os.Stdout
+		os.Stdout = w
+		defer func() { os.Stdout = orig }()
+
+		_, code := captureStderr(func() int { return run([]string{"tk", "show", id, "--json"}) })
+		if code != exitIO {
+			t.Errorf("expected exit %d when --json output cannot be written, got %d", exitIO, code)
+		}
+	})
+
+	t.Run("json_encode_failure", func(t *testing.T) {
+		// Encode errors are grouped with filesystem errors under exit 6
+		_, err := json.Marshal(make(chan int))
+		if err == nil {
+			t.Fatal("expected marshal error")
+		}
+		if code := cobracmd.GetExitCode(fmt.Errorf("failed to encode json: %w", err)); code != exitIO {
+			t.Errorf("expected exit %d for a JSON encode failure, got %d", exitIO, code)
+		}
+	})
+
+	t.Run("corrupt_tick", func(t *testing.T) {
+		bad := createTestTick(t, "Soon corrupt")
+		path := filepath.Join(repo, ".tick", "issues", bad+".json")
+		if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+			t.Fatalf("corrupt tick: %v", err)
+		}
+		if code := quiet("show", bad); code != exitIO {
+			t.Errorf("expected exit %d, got %d", exitIO, code)
+		}
+		_ = os.Remove(path)
+	})
+
+	t.Run("write_failure", func(t *testing.T) {
+		issues := filepath.Join(repo, ".tick", "issues")
+		backup := issues + ".bak"
+		if err := os.Rename(issues, backup); err != nil {
+			t.Fatalf("move issues: %v", err)
+		}
+		defer func() {
+			_ = os.Remove(issues)
+			_ = os.Rename(backup, issues)
+		}()
+		if err := os.WriteFile(issues, []byte("not a directory"), 0o644); err != nil {
+			t.Fatalf("write placeholder: %v", err)
+		}
+		if code := quiet("create", "Cannot be written"); code != exitIO {
+			t.Errorf("expected exit %d, got %d", exitIO, code)
+		}
+	})
+
+	t.Run("no_remote", func(t *testing.T) {
+		if err := runGit(repo, "remote", "remove", "origin"); err != nil {
+			t.Fatalf("remove origin: %v", err)
+		}
+		defer func() { _ = runGit(repo, "remote", "add", "origin", "https://github.com/petere/chefswiz.git") }()
+		if code := quiet("show", id); code != exitGitHub {
+			t.Errorf("expected exit %d, got %d", exitGitHub, code)
+		}
+	})
+
+	t.Run("not_a_repo", func(t *testing.T) {
+		wd, _ := os.Getwd()
+		if err := os.Chdir(t.TempDir()); err != nil {
+			t.Fatalf("chdir: %v", err)
+		}
+		defer func() { _ = os.Chdir(wd) }()
+		for _, args := range [][]string{{"list"}, {"show", id}, {"create", "Nowhere"}} {
+			if code := quiet(args...); code != exitNoRepo {
+				t.Errorf("tk %s: expected exit %d, got %d", strings.Join(args, " "), exitNoRepo, code)
+			}
+		}
+	})
+}
+
+func TestCreateInteractive(t *testing.T) {
+	repo := setupTestRepo(t)
+	epicID := createTestTick(t, "Epic", "-t", "epic")
+	taskID := createTestTick(t, "Not an epic")
+
+	withStdin := func(t *testing.T, body string) {
+		t.Helper()
+		stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+		if err != nil {
+			t.Fatalf("create stdin: %v", err)
+		}
+		if _, err := stdin.WriteString(body); err != nil {
+			t.Fatalf("write stdin: %v", err)
+		}
+		if _, err := stdin.Seek(0, 0); err != nil {
+			t.Fatalf("seek stdin: %v", err)
+		}
+		origStdin := os.Stdin
+		os.Stdin = stdin
+		t.Cleanup(func() { os.Stdin = origStdin })
+	}
+
+	t.Run("scripted answers", func(t *testing.T) {
+		t.Cleanup(cobracmd.SetTerminalCheck(func() bool { return true }))
+		// Invalid type, priority and parent answers are asked again
+		withStdin(t, strings.Join([]string{
+			"Wizard tick",
+			"story", "bug",
+			"9", "P1",
+			"alice",
+			"auth, backend",
+			taskID, epicID,
+			"y",
+		}, "\n")+"\n")
+
+		var out string
+		stderr, code := captureStderr(func() int {
+			var c int
+			out, c = captureStdout(func() int {
+				return run([]string{"tk", "create", "--interactive", "--json"})
+			})
+			return c
+		})
+		if code != exitSuccess {
+			t.Fatalf("expected create exit %d, got %d\n%s", exitSuccess, code, stderr)
+		}
+		for _, want := range []string{"invalid type: story", "invalid priority", "not an epic", "Create this tick?"} {
+			if !strings.Contains(stderr, want) {
+				t.Errorf("expected prompts to mention %q, got:\n%s", want, stderr)
+			}
+		}
+
+		var created map[string]any
+		if err := json.Unmarshal([]byte(out), &created); err != nil {
+			t.Fatalf("parse create json: %v (%q)", err, out)
+		}
+		saved := readTestTick(t, repo, created["id"].(string))
+		if saved["title"] != "Wizard tick" || saved["type"] != "bug" || saved["owner"] != "alice" || saved["parent"] != epicID {
+			t.Errorf("unexpected tick: %v", saved)
+		}
+		if saved["priority"] != float64(1) {
+			t.Errorf("expected priority 1, got %v", saved["priority"])
+		}
+		if labels, _ := saved["labels"].([]any); len(labels) != 2 || labels[0] != "auth" || labels[1] != "backend" {
+			t.Errorf("expected labels [auth backend], got %v", saved["labels"])
+		}
+	})
+
+	t.Run("defaults and decline", func(t *testing.T) {
+		t.Cleanup(cobracmd.SetTerminalCheck(func() bool { return true }))
+		withStdin(t, "\n\n\n\n\n\nn\n")
+
+		before, _ := os.ReadDir(filepath.Join(repo, ".tick", "issues"))
+		stderr, code := captureStderr(func() int {
+			return run([]string{"tk", "create", "Flag title", "-p", "3", "--interactive"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("expected exit %d, got %d\n%s", exitSuccess, code, stderr)
+		}
+		if !strings.Contains(stderr, "Title [Flag title]") || !strings.Contains(stderr, "Priority (0-4) [3]") {
+			t.Errorf("expected flag values offered as defaults, got:\n%s", stderr)
+		}
+		if !strings.Contains(stderr, "Aborted.") {
+			t.Errorf("expected Aborted., got:\n%s", stderr)
+		}
+		after, _ := os.ReadDir(filepath.Join(repo, ".tick", "issues"))
+		if len(after) != len(before) {
+			t.Errorf("expected no tick written after declining")
+		}
+	})
+
+	t.Run("falls back without a terminal", func(t *testing.T) {
+		t.Cleanup(cobracmd.SetTerminalCheck(func() bool { return false }))
+		id := createTestTick(t, "Plain", "--interactive", "-t", "chore")
+		if got := readTestTick(t, repo, id)["type"]; got != "chore" {
+			t.Errorf("expected type chore from flags, got %v", got)
+		}
+	})
+}
+
+func TestCreateFromStdin(t *testing.T) {
+	repo := setupTestRepo(t)
+	epicID := createTestTick(t, "Epic", "-t", "epic")
+	blockerID := createTestTick(t, "Blocker")
+
+	withStdin := func(t *testing.T, body string) {
+		t.Helper()
+		stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+		if err != nil {
+			t.Fatalf("create stdin: %v", err)
+		}
+		if _, err := stdin.WriteString(body); err != nil {
+			t.Fatalf("write stdin: %v", err)
+		}
+		if _, err := stdin.Seek(0, 0); err != nil {
+			t.Fatalf("seek stdin: %v", err)
+		}
+		origStdin := os.Stdin
+		os.Stdin = stdin
+		t.Cleanup(func() { os.Stdin = origStdin })
+	}
+
+	body := fmt.Sprintf(`{
+		"title": "Rich tick",
+		"description": "Line one\nLine two",
+		"type": "bug",
+		"priority": 1,
+		"labels": ["auth", "backend"],
+		"blocked_by": [%q],
+		"parent": %q
+	}`, blockerID, epicID)
+
+	t.Run("json_body", func(t *testing.T) {
+		withStdin(t, body)
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "create", "--from-stdin"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("create --from-stdin: exit %d", code)
+		}
+		got := readTestTick(t, repo, strings.TrimSpace(out))
+		if got["title"] != "Rich tick" || got["description"] != "Line one\nLine two" {
+			t.Errorf("unexpected title/description: %v / %v", got["title"], got["description"])
+		}
+		if got["type"] != "bug" || got["priority"] != float64(1) {
+			t.Errorf("unexpected type/priority: %v / %v", got["type"], got["priority"])
+		}
+		if labels, _ := got["labels"].([]any); len(labels) != 2 || labels[0] != "auth" || labels[1] != "backend" {
+			t.Errorf("unexpected labels: %v", got["labels"])
+		}
+		if blocked, _ := got["blocked_by"].([]any); len(blocked) != 1 || blocked[0] != blockerID {
+			t.Errorf("unexpected blocked_by: %v", got["blocked_by"])
+		}
+		if got["parent"] != epicID {
+			t.Errorf("expected parent %s, got %v", epicID, got["parent"])
+		}
+		if got["owner"] != "tester" || got["created_by"] != "tester" || got["status"] != "open" {
+			t.Errorf("expected generated owner/status, got %v / %v / %v", got["owner"], got["created_by"], got["status"])
+		}
+		if got["created_at"] == nil || got["updated_at"] == nil {
+			t.Error("expected generated timestamps")
+		}
+	})
+
+	t.Run("flags_override_stdin", func(t *testing.T) {
+		withStdin(t, body)
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "create", "Flag title", "--from-stdin", "-p", "3", "-t", "chore", "-l", "ops"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("create --from-stdin with flags: exit %d", code)
+		}
+		got := readTestTick(t, repo, strings.TrimSpace(out))
+		if got["title"] != "Flag title" {
+			t.Errorf("expected flag title, got %v", got["title"])
+		}
+		if got["priority"] != float64(3) || got["type"] != "chore" {
+			t.Errorf("expected flag priority/type, got %v / %v", got["priority"], got["type"])
+		}
+		if labels, _ := got["labels"].([]any); len(labels) != 1 || labels[0] != "ops" {
+			t.Errorf("expected flag labels, got %v", got["labels"])
+		}
+		if got["description"] != "Line one\nLine two" {
+			t.Errorf("expected description from stdin, got %v", got["description"])
+		}
+	})
+
+	t.Run("validation_failure", func(t *testing.T) {
+		before, _ := os.ReadDir(filepath.Join(repo, ".tick", "issues"))
+		for _, bad := range []string{
+			`{"title": "Bad priority", "priority": 9}`,
+			`{"title": "Bad type", "type": "story"}`,
+			`{"description": "no title"}`,
+			`{"title": "Unknown field", "status": "closed"}`,
+			`not json`,
+		} {
+			withStdin(t, bad)
+			_, code := captureStderr(func() int {
+				return run([]string{"tk", "create", "--from-stdin"})
+			})
+			if code == exitSuccess {
+				t.Errorf("expected failure for %s", bad)
+			}
+		}
+		after, _ := os.ReadDir(filepath.Join(repo, ".tick", "issues"))
+		if len(after) != len(before) {
+			t.Errorf("expected no ticks written, had %d now %d", len(before), len(after))
+		}
+	})
+
+	t.Run("title_still_required_without_stdin", func(t *testing.T) {
+		_, code := captureStderr(func() int { return run([]string{"tk", "create"}) })
+		if code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+}
+
+func TestCreateFromFile(t *testing.T) {
+	repo := setupTestRepo(t)
+	epicID := createTestTick(t, "Planning epic", "-t", "epic")
+
+	plan := filepath.Join(t.TempDir(), "tasks.md")
+	content := `# Sprint plan
+
+Some notes that are not tasks.
+
+- [ ] Set up CI (P1) #infra
+- [x] Already done
+- [ ] Auth overhaul #backend #security
+  - [ ] Rotate session keys (P0)
+  - [ ] Add SSO login #frontend
+- [ ] Write release notes
+`
+	if err := os.WriteFile(plan, []byte(content), 0o644); err != nil {
+		t.Fatalf("write plan: %v", err)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "create", "--from-file", plan, "--parent", epicID})
+	})
+	if code != exitSuccess {
+		t.Fatalf("create --from-file: exit %d", code)
+	}
+	ids := strings.Fields(out)
+	if len(ids) != 5 {
+		t.Fatalf("expected 5 created ids, got %q", out)
+	}
+
+	want := []struct {
+		title    string
+		priority float64
+		labels   []string
+		typ      string
+		parent   string
+	}{
+		{"Set up CI", 1, []string{"infra"}, "task", epicID},
+		{"Auth overhaul", 2, []string{"backend", "security"}, "epic", epicID},
+		{"Rotate session keys", 0, nil, "task", ids[1]},
+		{"Add SSO login", 2, []string{"frontend"}, "task", ids[1]},
+		{"Write release notes", 2, nil, "task", epicID},
+	}
+	for i, w := range want {
+		got := readTestTick(t, repo, ids[i])
+		if got["title"] != w.title {
+			t.Errorf("tick %d: expected title %q, got %v", i, w.title, got["title"])
+		}
+		if got["priority"] != w.priority {
+			t.Errorf("%s: expected priority %v, got %v", w.title, w.priority, got["priority"])
+		}
+		if got["type"] != w.typ {
+			t.Errorf("%s: expected type %s, got %v", w.title, w.typ, got["type"])
+		}
+		if got["parent"] != w.parent {
+			t.Errorf("%s: expected parent %s, got %v", w.title, w.parent, got["parent"])
+		}
+		labels, _ := got["labels"].([]any)
+		if len(labels) != len(w.labels) {
+			t.Errorf("%s: expected labels %v, got %v", w.title, w.labels, got["labels"])
+			continue
+		}
+		for j, l := range w.labels {
+			if labels[j] != l {
+				t.Errorf("%s: expected labels %v, got %v", w.title, w.labels, got["labels"])
+			}
+		}
+	}
+
+	t.Run("without_parent_flattens", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "create", "--from-file", plan, "-l", "sprint"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("create --from-file: exit %d", code)
+		}
+		for _, id := range strings.Fields(out) {
+			got := readTestTick(t, repo, id)
+			if got["type"] != "task" {
+				t.Errorf("%s: expected task, got %v", id, got["type"])
+			}
+			if _, ok := got["parent"]; ok {
+				t.Errorf("%s: expected no parent, got %v", id, got["parent"])
+			}
+			if labels, _ := got["labels"].([]any); len(labels) == 0 || labels[0] != "sprint" {
+				t.Errorf("%s: expected flag label first, got %v", id, got["labels"])
+			}
+		}
+	})
+
+	t.Run("rejects_non_epic_parent", func(t *testing.T) {
+		task := createTestTick(t, "Plain task")
+		_, code := captureStderr(func() int {
+			return run([]string{"tk", "create", "--from-file", plan, "--parent", task})
+		})
+		if code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+
+	t.Run("applies_shared_flags", func(t *testing.T) {
+		blocker := createTestTick(t, "Blocker")
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "create", "--from-file", plan, "-b", blocker, "--defer", "2030-01-02", "--awaiting", "input"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("create --from-file: exit %d", code)
+		}
+		for _, id := range strings.Fields(out) {
+			got := readTestTick(t, repo, id)
+			if blockers, _ := got["blocked_by"].([]any); len(blockers) != 1 || blockers[0] != blocker {
+				t.Errorf("%s: expected blocked_by [%s], got %v", id, blocker, got["blocked_by"])
+			}
+			if d, _ := got["defer_until"].(string); !strings.HasPrefix(d, "2030-01-02") {
+				t.Errorf("%s: expected defer_until 2030-01-02, got %v", id, got["defer_until"])
+			}
+			if got["awaiting"] != "input" {
+				t.Errorf("%s: expected awaiting input, got %v", id, got["awaiting"])
+			}
+		}
+	})
+
+	t.Run("rejects_single_tick_flags", func(t *testing.T) {
+		for _, flag := range []string{"--description", "--acceptance", "--external-ref"} {
+			_, code := captureStderr(func() int {
+				return run([]string{"tk", "create", "--from-file", plan, flag, "x"})
+			})
+			if code != exitUsage {
+				t.Errorf("%s: expected usage exit, got %d", flag, code)
+			}
+		}
+	})
+
+	t.Run("rejects_file_without_items", func(t *testing.T) {
+		empty := filepath.Join(t.TempDir(), "empty.md")
+		if err := os.WriteFile(empty, []byte("# Nothing here\n- [x] done\n"), 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		_, code := captureStderr(func() int {
+			return run([]string{"tk", "create", "--from-file", empty})
+		})
+		if code != exitUsage {
+			t.Errorf("expected usage exit, got %d", code)
+		}
+	})
+}
+
+func TestRenameID(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	epicID := createTestTick(t, "Epic", "-t", "epic")
+	childID := createTestTick(t, "Child", "--parent", epicID)
+	blockedID := createTestTick(t, "Blocked by epic", "-b", epicID)
+	followID := createTestTick(t, "Follow-up", "--discovered-from", epicID)
+	otherID := createTestTick(t, "Unrelated")
+
+	issues := filepath.Join(repo, ".tick", "issues")
+
+	t.Run("dry_run", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "rename-id", epicID, "renamed", "--dry-run", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("rename-id --dry-run: exit %d", code)
+		}
+		var payload struct {
+			From    string `json:"from"`
+			To      string `json:"to"`
+			DryRun  bool   `json:"dry_run"`
+			Updated []struct {
+				ID     string   `json:"id"`
+				Fields []string `json:"fields"`
+			} `json:"updated"`
+		}
+		if err := json.Unmarshal([]byte(out), &payload); err != nil {
+			t.Fatalf("parse json: %v (%s)", err, out)
+		}
+		if !payload.DryRun || payload.From != epicID || payload.To != "renamed" {
+			t.Errorf("unexpected payload: %+v", payload)
+		}
+		got := map[string]string{}
+		for _, u := range payload.Updated {
+			got[u.ID] = strings.Join(u.Fields, ",")
+		}
+		want := map[string]string{childID: "parent", blockedID: "blocked_by", followID: "discovered_from"}
+		for id, fields := range want {
+			if got[id] != fields {
+				t.Errorf("%s: expected %s updated, got %q", id, fields, got[id])
+			}
+		}
+		if len(got) != len(want) {
+			t.Errorf("expected %d updated ticks, got %v", len(want), got)
+		}
+		if _, err := os.Stat(filepath.Join(issues, epicID+".json")); err != nil {
+			t.Errorf("dry run removed old file: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(issues, "renamed.json")); err == nil {
+			t.Error("dry run wrote the new file")
+		}
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		recordsDir := filepath.Join(repo, ".tick", "logs", "records")
+		if err := os.MkdirAll(recordsDir, 0o755); err != nil {
+			t.Fatalf("mkdir records: %v", err)
+		}
+		for _, name := range []string{epicID + ".json", epicID + ".live.json"} {
+			if err := os.WriteFile(filepath.Join(recordsDir, name), []byte(`{}`), 0o644); err != nil {
+				t.Fatalf("write record: %v", err)
+			}
+		}
+
+		if code := run([]string{"tk", "rename-id", epicID, "renamed"}); code != exitSuccess {
+			t.Fatalf("rename-id: exit %d", code)
+		}
+		for _, suffix := range []string{".json", ".live.json"} {
+			if _, err := os.Stat(filepath.Join(recordsDir, epicID+suffix)); !os.IsNotExist(err) {
+				t.Errorf("expected run record %s%s moved, stat err %v", epicID, suffix, err)
+			}
+			if _, err := os.Stat(filepath.Join(recordsDir, "renamed"+suffix)); err != nil {
+				t.Errorf("expected run record renamed%s: %v", suffix, err)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(issues, epicID+".json")); !os.IsNotExist(err) {
+			t.Errorf("expected old file removed, stat err %v", err)
+		}
+		renamed := readTestTick(t, repo, "renamed")
+		if renamed["id"] != "renamed" || renamed["title"] != "Epic" {
+			t.Errorf("unexpected renamed tick: %v", renamed)
+		}
+		if got := readTestTick(t, repo, childID); got["parent"] != "renamed" {
+			t.Errorf("expected child parent rewritten, got %v", got["parent"])
+		}
+		if got := readTestTick(t, repo, blockedID); fmt.Sprint(got["blocked_by"]) != "[renamed]" {
+			t.Errorf("expected blocker rewritten, got %v", got["blocked_by"])
+		}
+		if got := readTestTick(t, repo, followID); got["discovered_from"] != "renamed" {
+			t.Errorf("expected discovered_from rewritten, got %v", got["discovered_from"])
+		}
+		if got := readTestTick(t, repo, otherID); got["updated_at"] != got["created_at"] {
+			t.Errorf("expected unrelated tick untouched")
+		}
+	})
+
+	t.Run("rejects_bad_new_ids", func(t *testing.T) {
+		for _, newID := range []string{otherID, "Bad ID", "../escape", childID} {
+			_, code := captureStderr(func() int { return run([]string{"tk", "rename-id", otherID, newID}) })
+			if code != exitUsage {
+				t.Errorf("rename to %q: expected usage exit, got %d", newID, code)
+			}
+		}
+		_, code := captureStderr(func() int { return run([]string{"tk", "rename-id", "zzz", "yyy"}) })
+		if code != exitNotFound {
+			t.Errorf("expected not found exit for missing tick, got %d", code)
+		}
+	})
+}
+
+func TestWorktreePrune(t *testing.T) {
+	repo := setupTestRepo(t)
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git(repo, "config", "user.email", "tester@example.com")
+	git(repo, "config", "user.name", "Tester")
+	git(repo, "add", "-A")
+	git(repo, "commit", "-m", "Initial commit")
+
+	manager, err := worktree.NewManager(repo)
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	commitIn := func(wt *worktree.Worktree, name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(wt.Path, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		git(wt.Path, "add", name)
+		git(wt.Path, "commit", "-m", "Add "+name)
+	}
+
+	merged, err := manager.Create("merged")
+	if err != nil {
+		t.Fatalf("create merged worktree: %v", err)
+	}
+	commitIn(merged, "merged.txt")
+	git(repo, "merge", "--no-ff", "-m", "Merge merged", merged.Branch)
+
+	unmerged, err := manager.Create("unmerged")
+	if err != nil {
+		t.Fatalf("create unmerged worktree: %v", err)
+	}
+	commitIn(unmerged, "unmerged.txt")
+
+	dirty, err := manager.Create("dirty")
+	if err != nil {
+		t.Fatalf("create dirty worktree: %v", err)
+	}
+	commitIn(dirty, "dirty.txt")
+	git(repo, "merge", "--no-ff", "-m", "Merge dirty", dirty.Branch)
+	if err := os.WriteFile(filepath.Join(dirty.Path, "wip.txt"), []byte("wip"), 0o644); err != nil {
+		t.Fatalf("write wip: %v", err)
+	}
+
+	// A run that just started has no commits yet, and an interrupted run
+	// keeps its worktree for tk run --resume
+	if _, err := manager.Create("fresh"); err != nil {
+		t.Fatalf("create fresh worktree: %v", err)
+	}
+	running, err := manager.Create("running")
+	if err != nil {
+		t.Fatalf("create running worktree: %v", err)
+	}
+	commitIn(running, "running.txt")
+	git(repo, "merge", "--no-ff", "-m", "Merge running", running.Branch)
+	if err := worktree.SaveRunState(filepath.Join(repo, ".tick"), running); err != nil {
+		t.Fatalf("save run state: %v", err)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "worktree", "list", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("worktree list: exit %d", code)
+	}
+	var listed []struct {
+		EpicID string `json:"epic_id"`
+		Branch string `json:"branch"`
+		Merged bool   `json:"merged"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("parse list json: %v (%s)", err, out)
+	}
+	state := map[string]bool{}
+	for _, wt := range listed {
+		state[wt.EpicID] = wt.Merged
+	}
+	if len(state) != 5 || !state["merged"] || state["unmerged"] || !state["dirty"] || !state["fresh"] {
+		t.Errorf("unexpected merge states: %v", state)
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "worktree", "prune", "--dry-run"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("worktree prune --dry-run: exit %d", code)
+	}
+	if !strings.Contains(out, "Would remove merged") {
+		t.Errorf("expected dry run to report merged worktree, got %q", out)
+	}
+	if !manager.Exists("merged") {
+		t.Fatal("dry run removed a worktree")
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "worktree", "prune", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("worktree prune: exit %d", code)
+	}
+	var pruned struct {
+		Removed []struct {
+			EpicID string `json:"epic_id"`
+		} `json:"removed"`
+		Skipped []struct {
+			EpicID      string   `json:"epic_id"`
+			Reason      string   `json:"reason"`
+			Uncommitted []string `json:"uncommitted"`
+		} `json:"skipped"`
+	}
+	if err := json.Unmarshal([]byte(out), &pruned); err != nil {
+		t.Fatalf("parse prune json: %v (%s)", err, out)
+	}
+	if len(pruned.Removed) != 1 || pruned.Removed[0].EpicID != "merged" {
+		t.Errorf("expected only merged removed, got %+v", pruned.Removed)
+	}
+	skipped := map[string]string{}
+	for _, s := range pruned.Skipped {
+		skipped[s.EpicID] = s.Reason
+		if s.EpicID == "dirty" && fmt.Sprint(s.Uncommitted) != "[wip.txt]" {
+			t.Errorf("expected wip.txt listed as uncommitted, got %v", s.Uncommitted)
+		}
+	}
+	wantSkipped := map[string]string{"dirty": "uncommitted changes", "fresh": "no commits", "running": "run in progress"}
+	if fmt.Sprint(skipped) != fmt.Sprint(wantSkipped) {
+		t.Errorf("expected skipped %v, got %v", wantSkipped, skipped)
+	}
+	for _, id := range []string{"fresh", "running"} {
+		if !manager.Exists(id) {
+			t.Errorf("expected %s worktree kept", id)
+		}
+	}
+
+	if manager.Exists("merged") {
+		t.Error("expected merged worktree removed")
+	}
+	if err := exec.Command("git", "-C", repo, "show-ref", "--verify", "--quiet", "refs/heads/"+merged.Branch).Run(); err == nil {
+		t.Error("expected merged branch deleted")
+	}
+	if !manager.Exists("unmerged") {
+		t.Error("expected unmerged worktree kept")
+	}
+	if !manager.Exists("dirty") {
+		t.Error("expected worktree with uncommitted changes kept")
+	}
+}
+
+func TestRunResumeWorktree(t *testing.T) {
+	repo := setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	task := createTestTick(t, "Add refunds", "--parent", epic)
+	if err := runGit(repo, "add", "-A"); err != nil {
+		t.Fatalf("git add: %v", err)
+	}
+	if err := runGit(repo, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-m", "init"); err != nil {
+		t.Fatalf("git commit: %v", err)
+	}
+
+	runJSONL := func(args ...string) (map[string]any, int) {
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "run"}, args...))
+		})
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		var result map[string]any
+		_ = json.Unmarshal([]byte(lines[len(lines)-1]), &result)
+		return result, code
+	}
+
+	// The echo agent never closes tasks, so the run stops with the worktree kept
+	result, code := runJSONL(epic, "--ralph", "--worktree", "--agent", "echo", "--max-task-retries", "1", "--skip-verify", "--jsonl")
+	if code != exitSuccess {
+		t.Fatalf("run --worktree: exit %d", code)
+	}
+	if !strings.Contains(fmt.Sprint(result["exit_reason"]), "stuck on task") {
+		t.Fatalf("expected stuck-task exit reason, got %v", result["exit_reason"])
+	}
+	tickDir := filepath.Join(repo, ".tick")
+	state, err := worktree.LoadRunState(tickDir, epic)
+	if err != nil || state == nil {
+		t.Fatalf("expected run state after interrupted run, got %+v (%v)", state, err)
+	}
+	marker := filepath.Join(state.Path, "wip.txt")
+	if err := os.WriteFile(marker, []byte("half done"), 0644); err != nil {
+		t.Fatalf("write marker: %v", err)
+	}
+
+	// Resuming reattaches to the same worktree
+	if _, code := runJSONL("--resume", epic, "--agent", "echo", "--max-task-retries", "1", "--skip-verify", "--jsonl"); code != exitSuccess {
+		t.Fatalf("run --resume: exit %d", code)
+	}
+	resumed, err := worktree.LoadRunState(tickDir, epic)
+	if err != nil || resumed == nil || resumed.Path != state.Path || resumed.Branch != state.Branch {
+		t.Fatalf("expected resume to keep worktree %s, got %+v (%v)", state.Path, resumed, err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("expected work in the worktree to survive resume: %v", err)
+	}
+
+	// Once the remaining work is done, resuming finishes and cleans up
+	if code := run([]string{"tk", "close", task}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+	result, code = runJSONL("--resume", epic, "--agent", "echo", "--skip-verify", "--jsonl")
+	if code != exitSuccess {
+		t.Fatalf("final run --resume: exit %d", code)
+	}
+	if result["exit_reason"] != "no tasks found" {
+		t.Errorf("expected no tasks found, got %v", result["exit_reason"])
+	}
+	if state, _ := worktree.LoadRunState(tickDir, epic); state != nil {
+		t.Errorf("expected run state to be removed on success, got %+v", state)
+	}
+	if _, err := os.Stat(state.Path); !os.IsNotExist(err) {
+		t.Errorf("expected worktree %s to be removed, stat err %v", state.Path, err)
+	}
+
+	quiet := func(args ...string) int {
+		_, code := captureStderr(func() int {
+			_, code := captureStdout(func() int { return run(append([]string{"tk", "run"}, args...)) })
+			return code
+		})
+		return code
+	}
+	if code := quiet("--resume", epic); code != exitNotFound {
+		t.Errorf("resume without run state: expected exit %d, got %d", exitNotFound, code)
+	}
+	if code := quiet("--resume", epic, "--swarm"); code != exitUsage {
+		t.Errorf("resume with --swarm: expected exit %d, got %d", exitUsage, code)
+	}
+	if code := quiet("--resume", epic, epic); code != exitUsage {
+		t.Errorf("resume with epic arg: expected exit %d, got %d", exitUsage, code)
+	}
+}
+
+func TestBoardServesTicks(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	task := createTestTick(t, "Add refunds", "--parent", epic)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	type boardResult struct {
+		out  string
+		code int
+	}
+	done := make(chan boardResult, 1)
+	go func() {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "board", "--port", fmt.Sprint(port)})
+		})
+		done <- boardResult{out, code}
+	}()
+
+	// The board may move to the next free port, so wait for the list endpoint
+	// on the requested one and give up if the command exits first
+	var resp *http.Response
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d/api/ticks", port))
+		if err == nil {
+			break
+		}
+		select {
+		case res := <-done:
+			t.Fatalf("board exited early: exit %d\n%s", res.code, res.out)
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("board did not start: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	var list generated.ListTicksResponse
+	err = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decode list response: %v", err)
+	}
+	ids := map[string]generated.TickResponse{}
+	for _, tk := range list.Ticks {
+		ids[tk.Id] = tk
+	}
+	if len(list.Ticks) != 2 || ids[task].Parent == nil || *ids[task].Parent != epic || ids[task].Column == "" {
+		t.Errorf("unexpected list response: %+v", list.Ticks)
+	}
+
+	// Ctrl+C shuts the board down cleanly
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatalf("send SIGINT: %v", err)
+	}
+	select {
+	case res := <-done:
+		if res.code != exitSuccess {
+			t.Errorf("board: exit %d\n%s", res.code, res.out)
+		}
+		if !strings.Contains(res.out, fmt.Sprintf("Board: http://localhost:%d", port)) {
+			t.Errorf("expected board URL in output, got %q", res.out)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("board did not shut down on SIGINT")
+	}
+}
+
+func TestStaleCommand(t *testing.T) {
+	repo := setupTestRepo(t)
+	fresh := createTestTick(t, "Touched yesterday")
+	old := createTestTick(t, "Untouched for a month")
+	older := createTestTick(t, "Untouched for two months")
+	waiting := createTestTick(t, "Waiting on review", "--awaiting", "review")
+	closed := createTestTick(t, "Closed long ago")
+	if code := run([]string{"tk", "close", closed, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+	if code := run([]string{"tk", "update", older, "--status", "in_progress"}); code != exitSuccess {
+		t.Fatalf("update: exit %d", code)
+	}
+
+	now := time.Now()
+	setUpdatedAt := func(id string, at time.Time) {
+		t.Helper()
+		tickPath := filepath.Join(repo, ".tick", "issues", id+".json")
+		tickData := readTestTick(t, repo, id)
+		tickData["updated_at"] = at.Format(time.RFC3339Nano)
+		newData, _ := json.MarshalIndent(tickData, "", "  ")
+		if err := os.WriteFile(tickPath, newData, 0o644); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+	}
+	setUpdatedAt(fresh, now.Add(-24*time.Hour))
+	setUpdatedAt(old, now.Add(-30*24*time.Hour))
+	setUpdatedAt(older, now.Add(-60*24*time.Hour))
+	setUpdatedAt(waiting, now.Add(-20*24*time.Hour))
+	setUpdatedAt(closed, now.Add(-90*24*time.Hour))
+
+	stale := func(t *testing.T, args ...string) []string {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "stale", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("stale %v: exit %d", args, code)
+		}
+		var result struct {
+			Ticks []map[string]any `json:"ticks"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse stale json: %v", err)
+		}
+		var ids []string
+		for _, tk := range result.Ticks {
+			ids = append(ids, tk["id"].(string))
+		}
+		return ids
+	}
+
+	if got, want := stale(t), []string{older, old, waiting}; !slices.Equal(got, want) {
+		t.Errorf("default: got %v, want %v oldest first", got, want)
+	}
+	if got, want := stale(t, "--days", "25"), []string{older, old}; !slices.Equal(got, want) {
+		t.Errorf("--days 25: got %v, want %v", got, want)
+	}
+	if got, want := stale(t, "--status", "in_progress"), []string{older}; !slices.Equal(got, want) {
+		t.Errorf("--status in_progress: got %v, want %v", got, want)
+	}
+	if got, want := stale(t, "--awaiting-only"), []string{waiting}; !slices.Equal(got, want) {
+		t.Errorf("--awaiting-only: got %v, want %v", got, want)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "stale"})
+	})
+	if code != exitSuccess || !strings.Contains(out, "3 ticks (stale > 14d)") || !strings.Contains(out, "60d 0h") {
+		t.Errorf("table output: exit %d, output %q", code, out)
+	}
+
+	if code := run([]string{"tk", "stale", "--status", "closed"}); code != exitUsage {
+		t.Errorf("expected usage exit for --status closed, got %d", code)
+	}
+	if code := run([]string{"tk", "stale", "--days", "-1"}); code != exitUsage {
+		t.Errorf("expected usage exit for negative --days, got %d", code)
+	}
+}
+
+func TestListAgentReady(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Release", "-t", "epic")
+	ready := createTestTick(t, "Write changelog", "--parent", epic)
+	review := createTestTick(t, "Review copy", "--parent", epic, "--awaiting", "review")
+	// Blocker owned by someone else, so the default owner filter hides it
+	outside := createTestTick(t, "Sign off budget", "-o", "alice")
+	blocked := createTestTick(t, "Publish", "--parent", epic, "-b", outside)
+
+	listJSON := func(t *testing.T, args ...string) map[string]bool {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run(append([]string{"tk", "list", "--json"}, args...))
+		})
+		if code != exitSuccess {
+			t.Fatalf("list %v: exit %d", args, code)
+		}
+		var result struct {
+			Ticks []map[string]any `json:"ticks"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse list json: %v", err)
+		}
+		agentReady := make(map[string]bool)
+		for _, tk := range result.Ticks {
+			agentReady[tk["id"].(string)] = tk["agent_ready"].(bool)
+		}
+		return agentReady
+	}
+
+	all := listJSON(t, "--parent", epic)
+	if len(all) != 3 || !all[ready] || all[review] || all[blocked] {
+		t.Errorf("expected only %s agent-ready among %s's tasks, got %v", ready, epic, all)
+	}
+
+	if got := listJSON(t, "--agent-ready", "--parent", epic); len(got) != 1 || !got[ready] {
+		t.Errorf("--agent-ready: expected only %s, got %v", ready, got)
+	}
+	// Awaiting ticks are ready for a human but not for an agent
+	if got := listJSON(t, "--agent-ready", "--awaiting="); len(got) != 0 {
+		t.Errorf("--agent-ready --awaiting=: expected no ticks, got %v", got)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "graph", epic, "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("graph: exit %d", code)
+	}
+	var graph struct {
+		Stats struct {
+			ReadyForAgent int `json:"ready_for_agent"`
+		} `json:"stats"`
+		Waves []struct {
+			Tasks []struct {
+				ID         string `json:"id"`
+				AgentReady bool   `json:"agent_ready"`
+			} `json:"tasks"`
+		} `json:"waves"`
+	}
+	if err := json.Unmarshal([]byte(out), &graph); err != nil {
+		t.Fatalf("parse graph json: %v", err)
+	}
+	if graph.Stats.ReadyForAgent != 1 {
+		t.Errorf("graph ready_for_agent = %d, want 1", graph.Stats.ReadyForAgent)
+	}
+	for _, w := range graph.Waves {
+		for _, task := range w.Tasks {
+			if task.AgentReady != all[task.ID] {
+				t.Errorf("graph agent_ready for %s = %v, list says %v", task.ID, task.AgentReady, all[task.ID])
+			}
+		}
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "list", "--parent", epic})
+	})
+	if code != exitSuccess || !strings.Contains(out, "AGENT") {
+		t.Errorf("expected AGENT column in table output, exit %d:\n%s", code, out)
+	}
+}
+
+func TestListWatch(t *testing.T) {
+	repo := setupTestRepo(t)
+	existing := createTestTick(t, "Existing work")
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = orig
+		_ = w.Close()
+		_ = r.Close()
+	}()
+
+	done := make(chan int, 1)
+	go func() {
+		done <- run([]string{"tk", "list", "--all", "--watch", "--json"})
+	}()
+	lines := make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	// Each frame is one JSON snapshot per line
+	nextSnapshot := func(t *testing.T) map[string]bool {
+		t.Helper()
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("watch output closed")
+			}
+			var snapshot struct {
+				Ticks []map[string]any `json:"ticks"`
+			}
+			if err := json.Unmarshal([]byte(line), &snapshot); err != nil {
+				t.Fatalf("parse snapshot %q: %v", line, err)
+			}
+			ids := make(map[string]bool)
+			for _, tk := range snapshot.Ticks {
+				ids[tk["id"].(string)] = true
+			}
+			return ids
+		case code := <-done:
+			t.Fatalf("watch exited early: exit %d", code)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a snapshot")
+		}
+		return nil
+	}
+
+	if first := nextSnapshot(t); len(first) != 1 || !first[existing] {
+		t.Fatalf("expected initial snapshot with %s, got %v", existing, first)
+	}
+
+	now := time.Now().UTC()
+	added := tick.Tick{
+		ID: "w9x", Title: "Added while watching", Status: tick.StatusOpen, Type: tick.TypeTask,
+		Owner: "tester", CreatedBy: "tester", CreatedAt: now, UpdatedAt: now,
+	}
+	if err := tick.NewStore(filepath.Join(repo, ".tick")).Write(added); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+	if next := nextSnapshot(t); len(next) != 2 || !next[existing] || !next[added.ID] {
+		t.Errorf("expected snapshot with %s and %s after the write, got %v", existing, added.ID, next)
+	}
+
+	// Ctrl+C stops watching cleanly
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatalf("send SIGINT: %v", err)
+	}
+	select {
+	case code := <-done:
+		if code != exitSuccess {
+			t.Errorf("watch: exit %d", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not stop on SIGINT")
+	}
+
+	if code := run([]string{"tk", "list", "--watch", "--format", "csv"}); code != exitUsage {
+		t.Errorf("expected usage exit for --watch with csv, got %d", code)
+	}
+}
+
+func TestBumpPriority(t *testing.T) {
+	repo := setupTestRepo(t)
+	critical := createTestTick(t, "Outage", "-p", "0")
+	backlog := createTestTick(t, "Someday", "-p", "4")
+	normal := createTestTick(t, "Docs", "-p", "2")
+
+	priorityOf := func(id string) int {
+		t.Helper()
+		return int(readTestTick(t, repo, id)["priority"].(float64))
+	}
+
+	// Steps clamp at the extremes
+	before := readTestTick(t, repo, critical)["updated_at"]
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "bump", critical, "--up"})
+	})
+	if code != exitSuccess || priorityOf(critical) != 0 || !strings.Contains(out, "unchanged") {
+		t.Errorf("bump P0 --up: exit %d, priority %d, output %q", code, priorityOf(critical), out)
+	}
+	if readTestTick(t, repo, critical)["updated_at"] != before {
+		t.Error("expected unchanged tick to keep its updated_at")
+	}
+	if code := run([]string{"tk", "bump", backlog, "--down"}); code != exitSuccess || priorityOf(backlog) != 4 {
+		t.Errorf("bump P4 --down: exit %d, priority %d", code, priorityOf(backlog))
+	}
+
+	// Several ids move together
+	before = readTestTick(t, repo, normal)["updated_at"]
+	if code := run([]string{"tk", "bump", normal, backlog, "--up"}); code != exitSuccess {
+		t.Fatalf("bump --up: exit %d", code)
+	}
+	if priorityOf(normal) != 1 || priorityOf(backlog) != 3 {
+		t.Errorf("expected P1 and P3, got P%d and P%d", priorityOf(normal), priorityOf(backlog))
+	}
+	if readTestTick(t, repo, normal)["updated_at"] == before {
+		t.Error("expected bump to update updated_at")
+	}
+	if code := run([]string{"tk", "bump", normal, backlog, "--down"}); code != exitSuccess {
+		t.Fatalf("bump --down: exit %d", code)
+	}
+	if priorityOf(normal) != 2 || priorityOf(backlog) != 4 {
+		t.Errorf("expected P2 and P4, got P%d and P%d", priorityOf(normal), priorityOf(backlog))
+	}
+
+	if code := run([]string{"tk", "bump", normal, critical, "--set", "high"}); code != exitSuccess {
+		t.Fatalf("bump --set: exit %d", code)
+	}
+	if priorityOf(normal) != 1 || priorityOf(critical) != 1 {
+		t.Errorf("expected both P1, got P%d and P%d", priorityOf(normal), priorityOf(critical))
+	}
+
+	// A missing id leaves every tick untouched
+	if code := run([]string{"tk", "bump", normal, "zzz", "--up"}); code != exitNotFound {
+		t.Errorf("expected not-found exit for missing id, got %d", code)
+	}
+	if priorityOf(normal) != 1 {
+		t.Errorf("expected %s untouched after failed bump, got P%d", normal, priorityOf(normal))
+	}
+
+	for _, args := range [][]string{
+		{"tk", "bump", normal, "--set", "5"},
+		{"tk", "bump", normal, "--set", "-1"},
+		{"tk", "bump", normal},
+		{"tk", "bump", normal, "--up", "--down"},
+	} {
+		if code := run(args); code != exitUsage {
+			t.Errorf("%v: expected usage exit, got %d", args[2:], code)
+		}
+	}
+}
+
+func TestLabelCommands(t *testing.T) {
+	repo := setupTestRepo(t)
+	a := createTestTick(t, "Login page", "-l", "ui,auth")
+	b := createTestTick(t, "Button colors", "-l", "ui")
+	c := createTestTick(t, "Token refresh")
+
+	labelsOf := func(id string) []string {
+		t.Helper()
+		var labels []string
+		raw, _ := readTestTick(t, repo, id)["labels"].([]any)
+		for _, l := range raw {
+			labels = append(labels, l.(string))
+		}
+		return labels
+	}
+	updatedAt := func(id string) any {
+		t.Helper()
+		return readTestTick(t, repo, id)["updated_at"]
+	}
+
+	// Adding labels the tick already has changes nothing
+	before := updatedAt(a)
+	if code := run([]string{"tk", "label", "add", a, "ui", "auth"}); code != exitSuccess {
+		t.Fatalf("label add: exit %d", code)
+	}
+	if got := labelsOf(a); !slices.Equal(got, []string{"ui", "auth"}) || updatedAt(a) != before {
+		t.Errorf("expected no-op add, got labels %v", got)
+	}
+	if code := run([]string{"tk", "label", "add", c, "auth", "backend", "auth"}); code != exitSuccess {
+		t.Fatalf("label add: exit %d", code)
+	}
+	if got := labelsOf(c); !slices.Equal(got, []string{"auth", "backend"}) {
+		t.Errorf("expected [auth backend], got %v", got)
+	}
+
+	// Removing labels the tick lacks changes nothing
+	before = updatedAt(b)
+	if code := run([]string{"tk", "label", "remove", b, "auth"}); code != exitSuccess {
+		t.Fatalf("label remove: exit %d", code)
+	}
+	if updatedAt(b) != before {
+		t.Error("expected no-op remove to keep updated_at")
+	}
+	if code := run([]string{"tk", "label", "rm", c, "backend", "missing"}); code != exitSuccess {
+		t.Fatalf("label rm: exit %d", code)
+	}
+	if got := labelsOf(c); !slices.Equal(got, []string{"auth"}) {
+		t.Errorf("expected [auth], got %v", got)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "label", "list", "--json"})
+	})
+	var counts map[string]int
+	if code != exitSuccess || json.Unmarshal([]byte(out), &counts) != nil {
+		t.Fatalf("label list: exit %d, output %q", code, out)
+	}
+	if len(counts) != 2 || counts["ui"] != 2 || counts["auth"] != 2 {
+		t.Errorf("expected ui:2 auth:2, got %v", counts)
+	}
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "label", "list", a})
+	})
+	if code != exitSuccess || out != "ui\nauth\n" {
+		t.Errorf("label list <id>: exit %d, output %q", code, out)
+	}
+
+	// Rename across ticks; a tick with both labels keeps just one
+	if code := run([]string{"tk", "label", "add", b, "frontend"}); code != exitSuccess {
+		t.Fatalf("label add: exit %d", code)
+	}
+	before = updatedAt(c)
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "label", "rename", "ui", "frontend"})
+	})
+	if code != exitSuccess || !strings.Contains(out, "on 2 ticks") {
+		t.Fatalf("label rename: exit %d, output %q", code, out)
+	}
+	if got := labelsOf(a); !slices.Equal(got, []string{"frontend", "auth"}) {
+		t.Errorf("expected [frontend auth] on %s, got %v", a, got)
+	}
+	if got := labelsOf(b); !slices.Equal(got, []string{"frontend"}) {
+		t.Errorf("expected [frontend] on %s, got %v", b, got)
+	}
+	if updatedAt(c) != before {
+		t.Errorf("expected %s without the label to be untouched", c)
+	}
+
+	if code := run([]string{"tk", "label", "rename", "auth", "auth"}); code != exitUsage {
+		t.Errorf("expected usage exit for same-name rename, got %d", code)
+	}
+}
+
+func TestMergeDriver(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	out, err := exec.Command("git", "-C", repo, "config", "--get", "merge.tick.driver").Output()
+	if err != nil {
+		t.Fatalf("git config: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "tk merge-driver %O %A %B %P" {
+		t.Errorf("merge.tick.driver = %q", got)
+	}
+
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		return path
+	}
+	base := write("base", `{"id":"a1b","title":"Fix login","status":"open","priority":2,"type":"bug","owner":"alice",
+		"labels":["auth"],"notes":"Started","created_by":"alice","created_at":"2025-01-08T09:00:00Z","updated_at":"2025-01-08T10:00:00Z"}`)
+	ours := write("ours", `{"id":"a1b","title":"Fix login timeout","status":"open","priority":2,"type":"bug","owner":"alice",
+		"labels":["auth","backend"],"notes":"Started\nOurs","created_by":"alice","created_at":"2025-01-08T09:00:00Z","updated_at":"2025-01-08T11:00:00Z"}`)
+	theirs := write("theirs", `{"id":"a1b","title":"Fix login","status":"open","priority":0,"type":"bug","owner":"alice",
+		"labels":["auth","ui"],"notes":"Started\nTheirs","created_by":"alice","created_at":"2025-01-08T09:00:00Z","updated_at":"2025-01-08T12:00:00Z"}`)
+
+	if code := run([]string{"tk", "merge-driver", base, ours, theirs, ".tick/issues/a1b.json"}); code != exitSuccess {
+		t.Fatalf("merge-driver: exit %d", code)
+	}
+
+	// git reads the result back from the ours file
+	data, err := os.ReadFile(ours)
+	if err != nil {
+		t.Fatalf("read merged: %v", err)
+	}
+	var merged map[string]any
+	if err := json.Unmarshal(data, &merged); err != nil {
+		t.Fatalf("parse merged: %v", err)
+	}
+	if merged["title"] != "Fix login timeout" || merged["priority"] != float64(0) {
+		t.Errorf("expected ours title and theirs priority, got %v / %v", merged["title"], merged["priority"])
+	}
+	if labels := fmt.Sprint(merged["labels"]); labels != "[auth backend ui]" {
+		t.Errorf("labels = %s, want union", labels)
+	}
+	if merged["notes"] != "Started\nOurs\nTheirs" {
+		t.Errorf("notes = %q", merged["notes"])
+	}
+
+	// A missing base means both sides added the file
+	empty := write("empty", "")
+	if code := run([]string{"tk", "merge-driver", empty, ours, theirs}); code != exitSuccess {
+		t.Errorf("merge-driver with empty base: exit %d", code)
+	}
+	if code := run([]string{"tk", "merge-driver", base, write("bad", "{"), theirs}); code == exitSuccess {
+		t.Error("expected failure for unparsable ours so git reports a conflict")
+	}
+}
+
+func TestShowHistory(t *testing.T) {
+	repo := setupTestRepo(t)
+	id := createTestTick(t, "Audited task")
+	blocker := createTestTick(t, "Blocker")
+
+	// Later writes come from a different user than the creator.
+	t.Setenv("TICK_OWNER", "reviewer")
+	steps := [][]string{
+		{"update", id, "--priority", "1", "--title", "Audited task v2"},
+		{"block", id, blocker},
+		{"unblock", id, blocker},
+		{"close", id, "--reason", "done"},
+		{"reopen", id},
+	}
+	for _, args := range steps {
+		if _, code := captureStdout(func() int { return run(append([]string{"tk"}, args...)) }); code != exitSuccess {
+			t.Fatalf("tk %v: exit %d", args, code)
+		}
+	}
+	if readTestTick(t, repo, id)["status"] != tick.StatusOpen {
+		t.Fatalf("expected %s reopened", id)
+	}
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "show", id, "--history", "--json"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("show --history exit %d", code)
+	}
+	var payload struct {
+		Tick    tick.Tick       `json:"tick"`
+		History []tick.Activity `json:"history"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("parse json: %v\n%s", err, out)
+	}
+	if payload.Tick.ID != id {
+		t.Fatalf("expected tick %s, got %s", id, payload.Tick.ID)
+	}
+
+	want := []struct {
+		action string
+		actor  string
+		fields []string
+	}{
+		{tick.ActivityCreate, "tester", nil},
+		{tick.ActivityUpdate, "reviewer", []string{"priority", "title"}},
+		{tick.ActivityBlock, "reviewer", []string{"blocked_by"}},
+		{tick.ActivityUnblock, "reviewer", []string{"blocked_by"}},
+		{tick.ActivityClose, "reviewer", []string{"closed_at", "closed_reason", "status"}},
+		{tick.ActivityReopen, "reviewer", []string{"closed_at", "closed_reason", "notes", "status"}},
+	}
+	if len(payload.History) != len(want) {
+		t.Fatalf("expected %d history entries, got %d: %+v", len(want), len(payload.History), payload.History)
+	}
+	for i, w := range want {
+		a := payload.History[i]
+		var fields []string
+		if raw, ok := a.Data["fields"].([]any); ok {
+			for _, f := range raw {
+				fields = append(fields, f.(string))
+			}
+		}
+		if a.Action != w.action || a.Actor != w.actor || !slices.Equal(fields, w.fields) {
+			t.Errorf("entry %d: got %s by %q %v, want %s by %q %v", i, a.Action, a.Actor, fields, w.action, w.actor, w.fields)
+		}
+	}
+
+	out, code = captureStdout(func() int {
+		return run([]string{"tk", "show", id, "--history"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("show --history exit %d", code)
+	}
+	if !strings.Contains(out, "History:") || !strings.Contains(out, "priority, title") {
+		t.Fatalf("expected history section, got:\n%s", out)
+	}
+
+	if code := run([]string{"tk", "show", id, "--history", "--children"}); code != exitUsage {
+		t.Fatalf("expected usage error for --history --children, got %d", code)
+	}
+}
+
+func TestShowRelated(t *testing.T) {
+	setupTestRepo(t)
+
+	epicID := createTestTick(t, "Epic", "-t", "epic")
+	rootID := createTestTick(t, "Root task", "--parent", epicID, "-p", "2")
+	sibID := createTestTick(t, "Sibling", "--parent", epicID, "-p", "1")
+	childID := createTestTick(t, "Follow-up", "--discovered-from", rootID, "--parent", epicID)
+	grandID := createTestTick(t, "Follow-up of follow-up", "--discovered-from", childID)
+	otherID := createTestTick(t, "Unrelated")
+
+	related := func(t *testing.T, id string) map[string]any {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "show", id, "--related", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("expected show --related exit %d, got %d", exitSuccess, code)
+		}
+		var payload map[string]any
+		if err := json.Unmarshal([]byte(out), &payload); err != nil {
+			t.Fatalf("parse json: %v (%q)", err, out)
+		}
+		return payload
+	}
+	ids := func(v any) []string {
+		var out []string
+		for _, item := range v.([]any) {
+			out = append(out, item.(map[string]any)["id"].(string))
+		}
+		return out
+	}
+
+	t.Run("middle of chain", func(t *testing.T) {
+		payload := related(t, childID)
+		if payload["tick"].(map[string]any)["id"] != childID {
+			t.Errorf("expected tick %s, got %v", childID, payload["tick"])
+		}
+		source, ok := payload["discovered_from"].(map[string]any)
+		if !ok || source["id"] != rootID || source["title"] != "Root task" {
+			t.Errorf("expected discovered_from %s, got %v", rootID, payload["discovered_from"])
+		}
+		if got := ids(payload["discovered"]); !slices.Equal(got, []string{grandID}) {
+			t.Errorf("expected discovered [%s], got %v", grandID, got)
+		}
+		// Siblings sort by priority, so the P1 sibling comes first
+		if got := ids(payload["siblings"]); !slices.Equal(got, []string{sibID, rootID}) {
+			t.Errorf("expected siblings [%s %s], got %v", sibID, rootID, got)
+		}
+	})
+
+	t.Run("root of chain", func(t *testing.T) {
+		payload := related(t, rootID)
+		if payload["discovered_from"] != nil {
+			t.Errorf("expected no discovered_from, got %v", payload["discovered_from"])
+		}
+		if got := ids(payload["discovered"]); !slices.Equal(got, []string{childID}) {
+			t.Errorf("expected discovered [%s], got %v", childID, got)
+		}
+	})
+
+	t.Run("unrelated tick", func(t *testing.T) {
+		payload := related(t, otherID)
+		if len(payload["discovered"].([]any)) != 0 || len(payload["siblings"].([]any)) != 0 {
+			t.Errorf("expected empty groups, got %v", payload)
+		}
+	})
+
+	t.Run("text output", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "show", childID, "--related"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("expected exit %d, got %d", exitSuccess, code)
+		}
+		for _, want := range []string{"Discovered from:", "Root task", "Discovered: (1)", "Follow-up of follow-up", "Siblings: (2)", "Sibling"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
+	if code := run([]string{"tk", "show", childID, "--related", "--history"}); code != exitUsage {
+		t.Errorf("expected --related --history exit %d, got %d", exitUsage, code)
+	}
+}
+
+func TestShowChildren(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Checkout revamp", "-t", "epic")
+	first := createTestTick(t, "Design API", "--parent", epic)
+	second := createTestTick(t, "Build API", "--parent", epic, "-b", first, "-p", "1")
+	urgent := createTestTick(t, "Fix typo", "--parent", epic, "-p", "0")
+	done := createTestTick(t, "Old task", "--parent", epic)
+	if code := run([]string{"tk", "close", done, "--reason", "done"}); code != exitSuccess {
+		t.Fatalf("close: exit %d", code)
+	}
+	outside := createTestTick(t, "Release notes", "-b", first)
+
+	showChildrenJSON := func(t *testing.T, id string) (string, []map[string]any) {
+		t.Helper()
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "show", id, "--children", "--json"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("show --children: exit %d", code)
+		}
+		var result struct {
+			Tick     map[string]any   `json:"tick"`
+			Relation string           `json:"relation"`
+			Children []map[string]any `json:"children"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("parse show json: %v", err)
+		}
+		if result.Tick["id"] != id {
+			t.Fatalf("expected tick %s, got %v", id, result.Tick["id"])
+		}
+		return result.Relation, result.Children
+	}
+
+	t.Run("epic_children_in_wave_order", func(t *testing.T) {
+		relation, children := showChildrenJSON(t, epic)
+		if relation != "children" {
+			t.Errorf("expected relation children, got %q", relation)
+		}
+		want := []struct {
+			id   string
+			wave float64
+		}{{urgent, 1}, {first, 1}, {second, 2}}
+		if len(children) != len(want) {
+			t.Fatalf("expected %d children, got %v", len(want), children)
+		}
+		for i, w := range want {
+			if children[i]["id"] != w.id || children[i]["wave"] != w.wave {
+				t.Errorf("child %d: expected %s in wave %v, got %v", i, w.id, w.wave, children[i])
+			}
+		}
+	})
+
+	t.Run("blocker_of", func(t *testing.T) {
+		relation, children := showChildrenJSON(t, first)
+		if relation != "blocks" {
+			t.Errorf("expected relation blocks, got %q", relation)
+		}
+		if len(children) != 2 || children[0]["id"] != second || children[1]["id"] != outside {
+			t.Errorf("expected %s and %s, got %v", second, outside, children)
+		}
+	})
+
+	t.Run("human_output", func(t *testing.T) {
+		out, code := captureStdout(func() int {
+			return run([]string{"tk", "show", epic, "--children"})
+		})
+		if code != exitSuccess {
+			t.Fatalf("show --children: exit %d", code)
+		}
+		if !strings.Contains(out, "Wave 2") || !strings.Contains(out, "Build API") {
+			t.Errorf("expected wave listing, got:\n%s", out)
+		}
+	})
+}
+
+func TestRunEstimateCostGuard(t *testing.T) {
+	setupTestRepo(t)
+	epic := createTestTick(t, "Payments", "-t", "epic")
+	for i := 0; i < 3; i++ {
+		createTestTick(t, fmt.Sprintf("Task %d", i), "--parent", epic, "-d", strings.Repeat("detail ", 50))
+	}
+	createTestTick(t, "Needs a human", "--parent", epic, "--awaiting", "work")
+
+	// Decline the confirmation prompt so no agent is started.
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatalf("create stdin: %v", err)
+	}
+	if _, err := stdin.WriteString("n\n"); err != nil {
+		t.Fatalf("write stdin: %v", err)
+	}
+	if _, err := stdin.Seek(0, 0); err != nil {
+		t.Fatalf("seek stdin: %v", err)
+	}
+	oldStdin := os.Stdin
+	os.Stdin = stdin
+	t.Cleanup(func() { os.Stdin = oldStdin })
+
+	out, code := captureStdout(func() int {
+		return run([]string{"tk", "run", epic, "--estimate-cost", "--max-cost", "0.01", "--max-iterations", "4", "--jsonl"})
+	})
+	if code != exitSuccess {
+		t.Fatalf("run --estimate-cost: exit %d\n%s", code, out)
+	}
+
+	var est struct {
+		Tasks              int     `json:"tasks"`
+		IterationsExpected int     `json:"iterations_expected"`
+		IterationsHigh     int     `json:"iterations_high"`
+		CostExpected       float64 `json:"cost_expected"`
+		ExceedsMaxCost     bool    `json:"exceeds_max_cost"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &est); err != nil {
+		t.Fatalf("parse estimate: %v\n%s", err, out)
+	}
+	if est.Tasks != 3 {
+		t.Errorf("expected 3 agent tasks (awaiting excluded), got %d", est.Tasks)
+	}
+	if est.IterationsExpected != 4 || est.IterationsHigh != 4 {
+		t.Errorf("expected iterations capped at 4, got expected=%d high=%d", est.IterationsExpected, est.IterationsHigh)
+	}
+	if !est.ExceedsMaxCost || est.CostExpected <= 0.01 {
+		t.Errorf("expected estimate to exceed guard, got %+v", est)
+	}
+}
@@ -45,6 +45,10 @@ type RunOpts struct {
 	// WorkDir is the working directory for the agent.
 	// If empty, the current working directory is used.
 	WorkDir string
+
+	// Model overrides the agent's default model (if supported by agent).
+	// If empty, the agent's default is used.
+	Model string
 }
 
 // Result contains the output and metrics from an agent run.
@@ -167,3 +167,51 @@ func TestErrTimeout(t *testing.T) {
 		t.Errorf("ErrTimeout.Error() = %q, want %q", ErrTimeout.Error(), "agent timed out")
 	}
 }
+
+func TestNew_Registry(t *testing.T) {
+	for _, name := range Names() {
+		a, err := New(name)
+		if err != nil {
+			t.Fatalf("New(%q) error = %v", name, err)
+		}
+		if a.Name() != name {
+			t.Errorf("New(%q).Name() = %q", name, a.Name())
+		}
+	}
+
+	if a, err := New(""); err != nil || a.Name() != DefaultName {
+		t.Errorf("New(\"\") = %v, %v; want %s agent", a, err, DefaultName)
+	}
+	if _, err := New("gpt"); err == nil || !strings.Contains(err.Error(), "echo") {
+		t.Errorf("New(\"gpt\") error = %v, want unknown agent listing names", err)
+	}
+}
+
+func TestStubAgent_Run(t *testing.T) {
+	prompt := "\n# Task abc: Add refunds\n\nEmit <promise>EJECT: reason</promise> if stuck."
+
+	result, err := NewEchoAgent().Run(context.Background(), prompt, RunOpts{})
+	if err != nil {
+		t.Fatalf("echo Run() error = %v", err)
+	}
+	if !strings.Contains(result.Output, "# Task abc: Add refunds") {
+		t.Errorf("echo Output = %q, want first prompt line", result.Output)
+	}
+	if strings.Contains(result.Output, "<promise>") {
+		t.Errorf("echo Output should not repeat signals from the prompt: %q", result.Output)
+	}
+	if result.Cost != 0 || result.TokensIn != 0 {
+		t.Errorf("echo should report no usage, got %+v", result)
+	}
+
+	result, err = NewNoopAgent().Run(context.Background(), prompt, RunOpts{})
+	if err != nil || result.Output != "" {
+		t.Errorf("noop Run() = %+v, %v; want empty output", result, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewNoopAgent().Run(ctx, prompt, RunOpts{}); err == nil {
+		t.Error("Run() with cancelled context should fail")
+	}
+}
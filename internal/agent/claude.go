@@ -51,8 +51,11 @@ func (a *ClaudeAgent) Run(ctx context.Context, prompt string, opts RunOpts) (*Re
 		"--include-partial-messages",
 		"--verbose",
 		"--no-session-persistence",
-		prompt,
 	}
+	if opts.Model != "" {
+		args = append(args, "--model", opts.Model)
+	}
+	args = append(args, prompt)
 
 	cmd := exec.CommandContext(ctx, a.command(), args...)
 
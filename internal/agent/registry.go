@@ -0,0 +1,39 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultName is the agent used when none is selected.
+const DefaultName = "claude"
+
+// registry maps agent names to constructors.
+var registry = map[string]func() Agent{
+	"claude": func() Agent { return NewClaudeAgent() },
+	"echo":   func() Agent { return NewEchoAgent() },
+	"noop":   func() Agent { return NewNoopAgent() },
+}
+
+// New returns a new agent by name (see Names). An empty name selects DefaultName.
+func New(name string) (Agent, error) {
+	if name == "" {
+		name = DefaultName
+	}
+	ctor, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown agent %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return ctor(), nil
+}
+
+// Names returns the registered agent names, sorted.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
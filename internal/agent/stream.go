@@ -46,6 +46,8 @@ const (
 	StatusToolUse  RunStatus = "tool_use"
 	StatusComplete RunStatus = "complete"
 	StatusError    RunStatus = "error"
+	// StatusInterrupted marks a run cut short by cancellation (e.g. SIGINT/SIGTERM).
+	StatusInterrupted RunStatus = "interrupted"
 )
 
 // ToolActivity represents a tool invocation.
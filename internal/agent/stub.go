@@ -0,0 +1,62 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StubAgent implements the Agent interface without calling a model.
+// It returns canned results so the orchestration pipeline can be exercised
+// (dry runs, demos, tests) at no cost. It never changes files or ticks, so
+// tasks stay open and a run ends through the engine's normal limits.
+type StubAgent struct {
+	name string
+	echo bool
+}
+
+// NewEchoAgent creates a stub agent whose output summarizes the prompt it received.
+func NewEchoAgent() *StubAgent {
+	return &StubAgent{name: "echo", echo: true}
+}
+
+// NewNoopAgent creates a stub agent that returns empty output.
+func NewNoopAgent() *StubAgent {
+	return &StubAgent{name: "noop"}
+}
+
+// Name returns "echo" or "noop".
+func (a *StubAgent) Name() string {
+	return a.name
+}
+
+// Available always returns true; stub agents need no CLI.
+func (a *StubAgent) Available() bool {
+	return true
+}
+
+// Run returns immediately with zero tokens and cost.
+func (a *StubAgent) Run(ctx context.Context, prompt string, opts RunOpts) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Duration: time.Millisecond}
+	if a.echo {
+		// Summarize rather than repeat the prompt: it documents the signal
+		// syntax, and echoing it back would trigger those signals
+		result.Output = fmt.Sprintf("[echo] received %d-byte prompt: %s\n", len(prompt), firstLine(prompt))
+	}
+	return result, nil
+}
+
+// firstLine returns the first non-blank line of s.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package budget
+
+// CostModel holds the per-iteration heuristics used by EstimateRun.
+type CostModel struct {
+	// Pricing is the model pricing used to convert tokens to USD.
+	Pricing ModelPricing
+
+	// TokensInPerIteration is the baseline input tokens per iteration
+	// (system prompt, epic context, tool results), excluding task text.
+	TokensInPerIteration int
+
+	// TokensOutPerIteration is the expected output tokens per iteration.
+	TokensOutPerIteration int
+
+	// IterationsPerTask is the expected number of iterations to finish a task.
+	IterationsPerTask int
+}
+
+// RunEstimate is a rough token and cost range for an agent run.
+// Low assumes one iteration per task, Expected uses CostModel.IterationsPerTask,
+// and High assumes the run uses its full iteration cap.
+type RunEstimate struct {
+	Tasks int
+
+	IterationsLow      int
+	IterationsExpected int
+	IterationsHigh     int
+
+	TokensLow      int
+	TokensExpected int
+	TokensHigh     int
+
+	CostLow      float64
+	CostExpected float64
+	CostHigh     float64
+}
+
+// EstimateRun estimates the cost of running an agent over tasks without invoking it.
+// taskTextSizes holds the title+description length in bytes of each task; text is
+// counted at roughly four bytes per token. maxIterations caps iterations for the
+// whole run (0 = unlimited, in which case High equals Expected).
+func EstimateRun(taskTextSizes []int, maxIterations int, model CostModel) RunEstimate {
+	est := RunEstimate{Tasks: len(taskTextSizes)}
+	if est.Tasks == 0 {
+		return est
+	}
+
+	textBytes := 0
+	for _, n := range taskTextSizes {
+		textBytes += n
+	}
+	tokensIn := model.TokensInPerIteration + textBytes/4/est.Tasks
+	tokensOut := model.TokensOutPerIteration
+
+	perTask := model.IterationsPerTask
+	if perTask < 1 {
+		perTask = 1
+	}
+	capIterations := func(n int) int {
+		if maxIterations > 0 && n > maxIterations {
+			return maxIterations
+		}
+		return n
+	}
+
+	est.IterationsLow = capIterations(est.Tasks)
+	est.IterationsExpected = capIterations(est.Tasks * perTask)
+	est.IterationsHigh = est.IterationsExpected
+	if maxIterations > 0 {
+		est.IterationsHigh = maxIterations
+	}
+
+	est.TokensLow = est.IterationsLow * (tokensIn + tokensOut)
+	est.TokensExpected = est.IterationsExpected * (tokensIn + tokensOut)
+	est.TokensHigh = est.IterationsHigh * (tokensIn + tokensOut)
+
+	perIteration := model.Pricing.EstimateCost(tokensIn, tokensOut)
+	est.CostLow = float64(est.IterationsLow) * perIteration
+	est.CostExpected = float64(est.IterationsExpected) * perIteration
+	est.CostHigh = float64(est.IterationsHigh) * perIteration
+	return est
+}
+
+// Add combines two estimates, e.g. across several epics.
+func (e RunEstimate) Add(other RunEstimate) RunEstimate {
+	return RunEstimate{
+		Tasks:              e.Tasks + other.Tasks,
+		IterationsLow:      e.IterationsLow + other.IterationsLow,
+		IterationsExpected: e.IterationsExpected + other.IterationsExpected,
+		IterationsHigh:     e.IterationsHigh + other.IterationsHigh,
+		TokensLow:          e.TokensLow + other.TokensLow,
+		TokensExpected:     e.TokensExpected + other.TokensExpected,
+		TokensHigh:         e.TokensHigh + other.TokensHigh,
+		CostLow:            e.CostLow + other.CostLow,
+		CostExpected:       e.CostExpected + other.CostExpected,
+		CostHigh:           e.CostHigh + other.CostHigh,
+	}
+}
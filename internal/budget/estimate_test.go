@@ -0,0 +1,70 @@
+package budget
+
+import "testing"
+
+func testCostModel() CostModel {
+	return CostModel{
+		Pricing:               ModelPricing{Name: "test", InputPer1M: 1, OutputPer1M: 10},
+		TokensInPerIteration:  10_000,
+		TokensOutPerIteration: 1_000,
+		IterationsPerTask:     2,
+	}
+}
+
+func TestEstimateRun_Empty(t *testing.T) {
+	est := EstimateRun(nil, 50, testCostModel())
+	if est.Tasks != 0 || est.CostHigh != 0 || est.IterationsHigh != 0 {
+		t.Errorf("expected zero estimate for no tasks, got %+v", est)
+	}
+}
+
+func TestEstimateRun_ScalesWithTaskCount(t *testing.T) {
+	model := testCostModel()
+	one := EstimateRun([]int{400}, 0, model)
+	three := EstimateRun([]int{400, 400, 400}, 0, model)
+
+	if one.IterationsExpected != 2 || three.IterationsExpected != 6 {
+		t.Fatalf("expected 2 and 6 iterations, got %d and %d", one.IterationsExpected, three.IterationsExpected)
+	}
+	if three.CostExpected <= one.CostExpected {
+		t.Errorf("expected cost to grow with tasks: one=%f three=%f", one.CostExpected, three.CostExpected)
+	}
+	// 10,100 tokens in ($0.0101) + 1,000 out ($0.01) per iteration
+	if want := 2 * 0.0201; !floatEquals(one.CostExpected, want, 1e-9) {
+		t.Errorf("CostExpected = %f, want %f", one.CostExpected, want)
+	}
+	if !(one.CostLow <= one.CostExpected && one.CostExpected <= one.CostHigh) {
+		t.Errorf("expected low <= expected <= high, got %+v", one)
+	}
+}
+
+func TestEstimateRun_RespectsIterationCap(t *testing.T) {
+	model := testCostModel()
+	tasks := []int{0, 0, 0, 0, 0}
+
+	capped := EstimateRun(tasks, 4, model)
+	if capped.IterationsLow != 4 || capped.IterationsExpected != 4 || capped.IterationsHigh != 4 {
+		t.Errorf("expected all bounds capped at 4, got %+v", capped)
+	}
+
+	roomy := EstimateRun(tasks, 50, model)
+	if roomy.IterationsExpected != 10 || roomy.IterationsHigh != 50 {
+		t.Errorf("expected 10 expected / 50 high, got %+v", roomy)
+	}
+	if roomy.CostHigh <= capped.CostHigh {
+		t.Errorf("expected higher cap to raise the high estimate: %f vs %f", roomy.CostHigh, capped.CostHigh)
+	}
+}
+
+func TestRunEstimate_Add(t *testing.T) {
+	model := testCostModel()
+	a := EstimateRun([]int{100}, 10, model)
+	b := EstimateRun([]int{100, 100}, 10, model)
+	sum := a.Add(b)
+	if sum.Tasks != 3 || sum.IterationsExpected != a.IterationsExpected+b.IterationsExpected {
+		t.Errorf("unexpected sum %+v", sum)
+	}
+	if !floatEquals(sum.CostHigh, a.CostHigh+b.CostHigh, 1e-9) {
+		t.Errorf("CostHigh = %f, want %f", sum.CostHigh, a.CostHigh+b.CostHigh)
+	}
+}
@@ -39,6 +39,24 @@ type EpicUsage struct {
 	Iterations int
 }
 
+// TaskUsage tracks usage attributed to a single task.
+type TaskUsage struct {
+	TaskID     string
+	TokensIn   int
+	TokensOut  int
+	Cost       float64
+	Iterations int
+}
+
+// Report is a snapshot of a tracker's usage broken down by task.
+type Report struct {
+	Limits Limits
+	Usage  Usage
+	// Tasks holds per-task usage in the order tasks were first charged.
+	// Usage added without a task (Add, AddIteration) is not included.
+	Tasks []TaskUsage
+}
+
 // TotalTokens returns the sum of input and output tokens.
 func (u *Usage) TotalTokens() int {
 	return u.TokensIn + u.TokensOut
@@ -64,6 +82,12 @@ type Tracker struct {
 	usage   Usage
 	mu      sync.RWMutex
 	perEpic map[string]*EpicUsage
+	perTask map[string]*TaskUsage
+	tasks   []string // task IDs in the order first charged
+
+	// parent, if set, is charged for all usage and checked by ShouldStop.
+	parent *Tracker
+	epicID string
 }
 
 // NewTracker creates a new budget tracker with the given limits.
@@ -74,26 +98,61 @@ func NewTracker(limits Limits) *Tracker {
 			StartTime: time.Now(),
 		},
 		perEpic: make(map[string]*EpicUsage),
+		perTask: make(map[string]*TaskUsage),
 	}
 }
 
+// WithParent links t to a parent tracker shared across epics. Usage added to
+// t is also recorded in parent under epicID, and ShouldStop reports the
+// parent's limits once t's own limits are not yet reached. Returns t.
+// Must be called before t is used.
+func (t *Tracker) WithParent(parent *Tracker, epicID string) *Tracker {
+	t.parent = parent
+	t.epicID = epicID
+	return t
+}
+
 // Add accumulates token and cost usage, and increments the iteration counter.
 func (t *Tracker) Add(tokensIn, tokensOut int, cost float64) {
 	t.mu.Lock()
-	defer t.mu.Unlock()
-
 	t.usage.Iterations++
 	t.usage.TokensIn += tokensIn
 	t.usage.TokensOut += tokensOut
 	t.usage.Cost += cost
+	t.mu.Unlock()
+
+	if t.parent != nil {
+		t.parent.AddForEpic(t.epicID, tokensIn, tokensOut, cost)
+	}
 }
 
-// AddIteration increments only the iteration counter without adding tokens/cost.
-func (t *Tracker) AddIteration() {
+// AddForTask is Add with the usage also attributed to taskID, for Report.
+func (t *Tracker) AddForTask(taskID string, tokensIn, tokensOut int, cost float64) {
+	t.Add(tokensIn, tokensOut, cost)
+
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	task, ok := t.perTask[taskID]
+	if !ok {
+		task = &TaskUsage{TaskID: taskID}
+		t.perTask[taskID] = task
+		t.tasks = append(t.tasks, taskID)
+	}
+	task.Iterations++
+	task.TokensIn += tokensIn
+	task.TokensOut += tokensOut
+	task.Cost += cost
+}
 
+// AddIteration increments only the iteration counter without adding tokens/cost.
+func (t *Tracker) AddIteration() {
+	t.mu.Lock()
 	t.usage.Iterations++
+	t.mu.Unlock()
+
+	if t.parent != nil {
+		t.parent.AddForEpic(t.epicID, 0, 0, 0)
+	}
 }
 
 // AddForEpic records usage attributed to a specific epic.
@@ -122,8 +181,22 @@ func (t *Tracker) AddForEpic(epicID string, tokensIn, tokensOut int, cost float6
 }
 
 // ShouldStop checks if any budget limit has been exceeded.
-// Returns true and a reason string if the budget is exhausted.
+// Returns true and a reason string if the budget is exhausted. Own limits are
+// checked before the parent's; a parent reason is prefixed with "total ".
 func (t *Tracker) ShouldStop() (bool, string) {
+	if stop, reason := t.checkLimits(); stop {
+		return true, reason
+	}
+	if t.parent != nil {
+		if stop, reason := t.parent.ShouldStop(); stop {
+			return true, "total " + reason
+		}
+	}
+	return false, ""
+}
+
+// checkLimits checks t's own limits, ignoring any parent.
+func (t *Tracker) checkLimits() (bool, string) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
@@ -242,3 +315,24 @@ func (t *Tracker) AllEpicUsage() map[string]*EpicUsage {
 	}
 	return result
 }
+
+// Report returns the limits, total usage and per-task usage recorded so far.
+func (t *Tracker) Report() Report {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tasks := make([]TaskUsage, 0, len(t.tasks))
+	for _, id := range t.tasks {
+		tasks = append(tasks, *t.perTask[id])
+	}
+	return Report{Limits: t.limits, Usage: t.usage, Tasks: tasks}
+}
+
+// Percent returns used as a percentage of limit. ok is false when limit is
+// 0 (unlimited).
+func Percent(used, limit float64) (pct float64, ok bool) {
+	if limit <= 0 {
+		return 0, false
+	}
+	return used / limit * 100, true
+}
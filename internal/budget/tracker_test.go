@@ -1,6 +1,7 @@
 package budget
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -527,3 +528,113 @@ func TestTracker_ShouldStop_WithAddForEpic(t *testing.T) {
 		t.Error("ShouldStop() returned empty reason at limit")
 	}
 }
+
+func TestTracker_WithParent_TotalCostAcrossEpics(t *testing.T) {
+	total := NewTracker(Limits{MaxCost: 1.0})
+
+	// runEpic drives iterations at $0.30 each until a tracker says stop,
+	// returning the number of iterations and the stop reason.
+	runEpic := func(epicID string) (int, string) {
+		tracker := NewTracker(Limits{MaxIterations: 10, MaxCost: 0.7}).WithParent(total, epicID)
+		for i := 0; ; i++ {
+			if stop, reason := tracker.ShouldStop(); stop {
+				return i, reason
+			}
+			tracker.Add(100, 50, 0.30)
+		}
+	}
+
+	// First epic hits its own limit ($0.90 >= $0.70) before the total ($1.00)
+	iters, reason := runEpic("epic1")
+	if iters != 3 {
+		t.Errorf("epic1 iterations = %d, want 3", iters)
+	}
+	if !strings.HasPrefix(reason, "cost limit reached") {
+		t.Errorf("epic1 reason = %q, want per-epic cost limit", reason)
+	}
+	if stop, _ := total.ShouldStop(); stop {
+		t.Fatal("total should not be exhausted after epic1")
+	}
+
+	// Second epic crosses the total cap after one iteration ($1.20 >= $1.00)
+	iters, reason = runEpic("epic2")
+	if iters != 1 {
+		t.Errorf("epic2 iterations = %d, want 1", iters)
+	}
+	if !strings.HasPrefix(reason, "total cost limit reached") {
+		t.Errorf("epic2 reason = %q, want total cost limit", reason)
+	}
+
+	usage := total.Usage()
+	if usage.Iterations != 4 {
+		t.Errorf("total Iterations = %d, want 4", usage.Iterations)
+	}
+	if e := total.UsageForEpic("epic2"); e == nil || e.Iterations != 1 {
+		t.Errorf("epic2 usage = %+v, want 1 iteration", e)
+	}
+}
+
+func TestTracker_Report_PerTask(t *testing.T) {
+	tracker := NewTracker(Limits{MaxIterations: 10, MaxCost: 2.0})
+
+	tracker.AddForTask("task-a", 100, 50, 0.10)
+	tracker.AddForTask("task-b", 400, 200, 0.75)
+	tracker.AddForTask("task-a", 300, 100, 0.25)
+	tracker.AddForTask("task-c", 10, 5, 0.02)
+
+	report := tracker.Report()
+	if report.Limits.MaxCost != 2.0 {
+		t.Errorf("Limits.MaxCost = %f, want 2.0", report.Limits.MaxCost)
+	}
+
+	wantOrder := []string{"task-a", "task-b", "task-c"}
+	if len(report.Tasks) != len(wantOrder) {
+		t.Fatalf("len(Tasks) = %d, want %d", len(report.Tasks), len(wantOrder))
+	}
+	for i, id := range wantOrder {
+		if report.Tasks[i].TaskID != id {
+			t.Errorf("Tasks[%d].TaskID = %q, want %q", i, report.Tasks[i].TaskID, id)
+		}
+	}
+	if a := report.Tasks[0]; a.Iterations != 2 || a.TokensIn != 400 || a.TokensOut != 150 {
+		t.Errorf("task-a = %+v, want 2 iterations, 400 in, 150 out", a)
+	}
+
+	// Per-task attribution sums to the total
+	var iterations, tokensIn, tokensOut int
+	var cost float64
+	for _, task := range report.Tasks {
+		iterations += task.Iterations
+		tokensIn += task.TokensIn
+		tokensOut += task.TokensOut
+		cost += task.Cost
+	}
+	if iterations != report.Usage.Iterations {
+		t.Errorf("sum of task iterations = %d, total = %d", iterations, report.Usage.Iterations)
+	}
+	if tokensIn != report.Usage.TokensIn || tokensOut != report.Usage.TokensOut {
+		t.Errorf("sum of task tokens = %d/%d, total = %d/%d", tokensIn, tokensOut, report.Usage.TokensIn, report.Usage.TokensOut)
+	}
+	if diff := cost - report.Usage.Cost; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("sum of task cost = %f, total = %f", cost, report.Usage.Cost)
+	}
+
+	if pct, ok := Percent(report.Usage.Cost, report.Limits.MaxCost); !ok || pct < 55.99 || pct > 56.01 {
+		t.Errorf("cost percent = %f (ok=%v), want 56", pct, ok)
+	}
+	if _, ok := Percent(float64(report.Usage.TotalTokens()), float64(report.Limits.MaxTokens)); ok {
+		t.Error("Percent of an unlimited budget should not be ok")
+	}
+}
+
+func TestTracker_Report_ReturnsCopy(t *testing.T) {
+	tracker := NewTracker(Limits{})
+	tracker.AddForTask("task-a", 10, 5, 0.01)
+
+	report := tracker.Report()
+	report.Tasks[0].Cost = 99
+
+	if got := tracker.Report().Tasks[0].Cost; got != 0.01 {
+		t.Errorf("Cost = %f after modifying report, want 0.01", got)
+	}
+}
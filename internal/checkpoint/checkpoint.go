@@ -34,9 +34,14 @@ type Checkpoint struct {
 	// CompletedTasks lists task IDs completed before this checkpoint.
 	CompletedTasks []string `json:"completed_tasks"`
 
-	// GitCommit is the commit SHA at checkpoint time for potential rollback.
+	// GitCommit is the commit SHA at checkpoint time for potential rollback:
+	// HEAD of the worktree in worktree mode, of the main repo otherwise.
 	GitCommit string `json:"git_commit"`
 
+	// GitBranch is the branch checked out where GitCommit was taken. Empty
+	// for a detached HEAD and for checkpoints saved by older versions.
+	GitBranch string `json:"git_branch,omitempty"`
+
 	// WorktreePath is the path to the worktree if running in worktree mode.
 	// Empty if running in normal mode (main repo).
 	WorktreePath string `json:"worktree_path,omitempty"`
@@ -192,6 +197,26 @@ func (m *Manager) Delete(id string) error {
 	return nil
 }
 
+// DeleteAfter removes the checkpoints for epicID taken at a later iteration
+// than iteration, and returns their IDs newest first.
+func (m *Manager) DeleteAfter(epicID string, iteration int) ([]string, error) {
+	checkpoints, err := m.ListForEpic(epicID)
+	if err != nil {
+		return nil, err
+	}
+	var deleted []string
+	for _, cp := range checkpoints {
+		if cp.Iteration <= iteration {
+			continue
+		}
+		if err := m.Delete(cp.ID); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, cp.ID)
+	}
+	return deleted, nil
+}
+
 // GenerateID creates a checkpoint ID from epic ID and iteration number.
 func GenerateID(epicID string, iteration int) string {
 	return fmt.Sprintf("%s-%d", epicID, iteration)
@@ -199,7 +224,20 @@ func GenerateID(epicID string, iteration int) string {
 
 // GetGitCommit returns the current HEAD commit SHA, or empty string if not in a git repo.
 func GetGitCommit() string {
-	cmd := exec.Command("git", "rev-parse", "HEAD")
+	return gitOutput("", "rev-parse", "HEAD")
+}
+
+// gitBranch returns the branch checked out in dir, or "" for a detached
+// HEAD or outside a git repo.
+func gitBranch(dir string) string {
+	return gitOutput(dir, "symbolic-ref", "--quiet", "--short", "HEAD")
+}
+
+// gitOutput runs git in dir (the current directory if empty) and returns
+// its trimmed output, or "" if it fails.
+func gitOutput(dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -218,14 +256,22 @@ func NewCheckpoint(epicID string, iteration int, tokens int, cost float64, compl
 		TotalCost:      cost,
 		CompletedTasks: completedTasks,
 		GitCommit:      GetGitCommit(),
+		GitBranch:      gitBranch(""),
 	}
 }
 
 // NewCheckpointWithWorktree creates a checkpoint with worktree information.
 // Use this when running in worktree mode to enable resuming in the correct worktree.
+// The git commit and branch are read from the worktree; an empty
+// worktreeBranch is filled in from the branch checked out there.
 func NewCheckpointWithWorktree(epicID string, iteration int, tokens int, cost float64, completedTasks []string, worktreePath, worktreeBranch string) *Checkpoint {
 	cp := NewCheckpoint(epicID, iteration, tokens, cost, completedTasks)
 	cp.WorktreePath = worktreePath
+	cp.GitCommit = gitOutput(worktreePath, "rev-parse", "HEAD")
+	cp.GitBranch = gitBranch(worktreePath)
+	if worktreeBranch == "" {
+		worktreeBranch = cp.GitBranch
+	}
 	cp.WorktreeBranch = worktreeBranch
 	return cp
 }
@@ -267,6 +313,105 @@ func (m *Manager) PrepareResume(cp *Checkpoint, repoRoot string) (workDir string
 	return newPath, nil
 }
 
+// ErrNoCommit is returned when rolling back to a checkpoint that recorded no
+// git commit.
+var ErrNoCommit = fmt.Errorf("checkpoint has no git commit")
+
+// ErrDirtyTree is returned by Rollback when the working directory has
+// uncommitted changes to tracked files outside .tick.
+var ErrDirtyTree = fmt.Errorf("working tree has uncommitted changes")
+
+// ErrDiverged is returned by Rollback when HEAD is no longer on the
+// checkpoint's branch or does not descend from its commit.
+var ErrDiverged = fmt.Errorf("HEAD has moved away from the checkpoint")
+
+// Rollback moves the checkpoint's working directory (its worktree, or
+// repoRoot in normal mode) back to the commit recorded at checkpoint time,
+// discarding the commits made since. Files under .tick are left as they
+// are, so tick edits from other epics survive. It refuses with ErrDirtyTree
+// if other tracked files have uncommitted changes, and with ErrDiverged if
+// HEAD is not on the checkpoint's branch or does not descend from its
+// commit. Returns the directory that was reset.
+func (m *Manager) Rollback(cp *Checkpoint, repoRoot string) (workDir string, err error) {
+	if cp.GitCommit == "" {
+		return "", ErrNoCommit
+	}
+
+	workDir, err = m.PrepareResume(cp, repoRoot)
+	if err != nil {
+		return "", err
+	}
+	if cp.WorktreePath == "" {
+		workDir = repoRoot
+	}
+
+	if err := checkRollback(cp, workDir); err != nil {
+		return "", err
+	}
+
+	// Files added since the checkpoint are not touched by checking out the
+	// old index, so remove them by hand afterwards.
+	added, err := git(workDir, "diff", "--name-only", "-z", "--diff-filter=A", cp.GitCommit, "HEAD", "--", ".", ":(exclude).tick")
+	if err != nil {
+		return "", err
+	}
+	if _, err := git(workDir, "reset", "--quiet", "--mixed", cp.GitCommit); err != nil {
+		return "", err
+	}
+	if _, err := git(workDir, "checkout", "--", ".", ":(exclude).tick"); err != nil {
+		return "", err
+	}
+	for _, name := range strings.Split(added, "\x00") {
+		if name == "" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(workDir, name)); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("removing %s: %w", name, err)
+		}
+	}
+	return workDir, nil
+}
+
+// checkRollback reports whether workDir can safely be reset to cp's commit.
+func checkRollback(cp *Checkpoint, workDir string) error {
+	status, err := git(workDir, "status", "--porcelain", "--untracked-files=no", "--", ".", ":(exclude).tick")
+	if err != nil {
+		return err
+	}
+	if status != "" {
+		return fmt.Errorf("%w in %s; commit or stash them first", ErrDirtyTree, workDir)
+	}
+
+	if cp.GitBranch != "" {
+		if branch := gitBranch(workDir); branch != cp.GitBranch {
+			if branch == "" {
+				branch = "a detached HEAD"
+			}
+			return fmt.Errorf("%w: checkpoint was taken on %s but %s has %s checked out", ErrDiverged, cp.GitBranch, workDir, branch)
+		}
+	}
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", cp.GitCommit, "HEAD")
+	cmd.Dir = workDir
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: HEAD in %s does not descend from %s", ErrDiverged, workDir, cp.GitCommit)
+	}
+	return nil
+}
+
+// git runs git in dir and returns its output, with git's message in the
+// error if it fails.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(stderr.String()), err)
+	}
+	return string(output), nil
+}
+
 // branchExists checks if a branch exists in the repository.
 func branchExists(branch, repoRoot string) bool {
 	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
@@ -1,9 +1,11 @@
 package checkpoint
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -579,3 +581,136 @@ func TestPrepareResume_BranchGone(t *testing.T) {
 		t.Errorf("PrepareResume() error = %v, want ErrWorktreeGone", err)
 	}
 }
+
+func TestManager_DeleteAfter(t *testing.T) {
+	m := NewManagerWithDir(t.TempDir())
+	for _, cp := range []*Checkpoint{
+		{ID: "abc-5", EpicID: "abc", Iteration: 5},
+		{ID: "abc-10", EpicID: "abc", Iteration: 10},
+		{ID: "abc-15", EpicID: "abc", Iteration: 15},
+		{ID: "xyz-20", EpicID: "xyz", Iteration: 20},
+	} {
+		if err := m.Save(cp); err != nil {
+			t.Fatalf("Save(%s) error = %v", cp.ID, err)
+		}
+	}
+
+	deleted, err := m.DeleteAfter("abc", 5)
+	if err != nil {
+		t.Fatalf("DeleteAfter() error = %v", err)
+	}
+	if len(deleted) != 2 || deleted[0] != "abc-15" || deleted[1] != "abc-10" {
+		t.Errorf("DeleteAfter() = %v, want [abc-15 abc-10]", deleted)
+	}
+
+	remaining, err := m.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var ids []string
+	for _, cp := range remaining {
+		ids = append(ids, cp.ID)
+	}
+	if len(ids) != 2 {
+		t.Errorf("remaining checkpoints = %v, want abc-5 and xyz-20", ids)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	repoDir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@test.com", "-c", "user.name=Test User"}, args...)...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	testFile := filepath.Join(repoDir, "test.txt")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+			t.Fatalf("writing test file: %v", err)
+		}
+	}
+
+	tickFile := filepath.Join(repoDir, ".tick", "issues", "abc.json")
+	if err := os.MkdirAll(filepath.Dir(tickFile), 0755); err != nil {
+		t.Fatalf("creating .tick: %v", err)
+	}
+	if err := os.WriteFile(tickFile, []byte("v1"), 0644); err != nil {
+		t.Fatalf("writing tick file: %v", err)
+	}
+
+	git("init", "-b", "main")
+	write("v1")
+	git("add", ".")
+	git("commit", "-m", "v1")
+	commit := git("rev-parse", "HEAD")
+	cp := &Checkpoint{ID: "abc-5", EpicID: "abc", GitCommit: commit, GitBranch: "main"}
+	if wtCP := NewCheckpointWithWorktree("abc", 5, 0, 0, nil, repoDir, ""); wtCP.GitCommit != commit || wtCP.WorktreeBranch != "main" {
+		t.Errorf("NewCheckpointWithWorktree() commit %q branch %q, want the worktree's %q on main", wtCP.GitCommit, wtCP.WorktreeBranch, commit)
+	}
+
+	write("v2")
+	if err := os.WriteFile(filepath.Join(repoDir, "added.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("writing added file: %v", err)
+	}
+	git("add", ".")
+	git("commit", "-m", "v2")
+	write("v3 uncommitted")
+
+	m := NewManagerWithDir(t.TempDir())
+	if _, err := m.Rollback(cp, repoDir); !errors.Is(err, ErrDirtyTree) {
+		t.Fatalf("Rollback() on dirty tree error = %v, want ErrDirtyTree", err)
+	}
+	git("checkout", "test.txt")
+
+	// Uncommitted tick edits neither block the rollback nor are reset by it
+	if err := os.WriteFile(tickFile, []byte("v2 uncommitted"), 0644); err != nil {
+		t.Fatalf("writing tick file: %v", err)
+	}
+
+	onOtherBranch := *cp
+	onOtherBranch.GitBranch = "feature"
+	if _, err := m.Rollback(&onOtherBranch, repoDir); !errors.Is(err, ErrDiverged) {
+		t.Errorf("Rollback() from another branch error = %v, want ErrDiverged", err)
+	}
+
+	workDir, err := m.Rollback(cp, repoDir)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if workDir != repoDir {
+		t.Errorf("workDir = %q, want %q", workDir, repoDir)
+	}
+	if head := git("rev-parse", "HEAD"); head != commit {
+		t.Errorf("HEAD = %s, want %s", head, commit)
+	}
+	data, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("reading test file: %v", err)
+	}
+	if string(data) != "v1" {
+		t.Errorf("test.txt = %q, want %q", data, "v1")
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "added.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected added.txt removed, stat error = %v", err)
+	}
+	if data, _ := os.ReadFile(tickFile); string(data) != "v2 uncommitted" {
+		t.Errorf("tick file = %q, want uncommitted edit kept", data)
+	}
+
+	// A HEAD that no longer descends from the checkpoint is refused
+	git("checkout", "-q", "--orphan", "rewritten")
+	git("commit", "-q", "-m", "unrelated")
+	if _, err := m.Rollback(&Checkpoint{ID: "abc-7", EpicID: "abc", GitCommit: commit}, repoDir); !errors.Is(err, ErrDiverged) {
+		t.Errorf("Rollback() onto unrelated history error = %v, want ErrDiverged", err)
+	}
+
+	if _, err := m.Rollback(&Checkpoint{ID: "abc-6", EpicID: "abc"}, repoDir); err != ErrNoCommit {
+		t.Errorf("Rollback() without commit error = %v, want ErrNoCommit", err)
+	}
+}
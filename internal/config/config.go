@@ -6,16 +6,34 @@ import (
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
 const (
 	DefaultVersion  = 1
 	DefaultIDLength = 3
 
+	// DefaultSchemaVersion is the schema version of .tick data in repos
+	// whose config does not record one.
+	DefaultSchemaVersion = 1
+
 	// Default values for context configuration.
 	DefaultContextMaxTokens       = 4000
 	DefaultContextAutoRefreshDays = 0
 	DefaultContextTimeout         = 5 * time.Minute
+
+	// DefaultGCMaxAgeDays matches gc.DefaultMaxAge.
+	DefaultGCMaxAgeDays = 30
+
+	// Default values for cost estimation.
+	DefaultCostTokensInPerIteration  = 40000
+	DefaultCostTokensOutPerIteration = 4000
+	DefaultCostIterationsPerTask     = 2
+
+	// Fallbacks for tk create when .tick/config.json sets no defaults.
+	DefaultTickPriority = 2
+	DefaultTickType     = tick.TypeTask
 )
 
 // Config defines project configuration stored in .tick/config.json.
@@ -24,12 +42,49 @@ type Config struct {
 	IDLength     int               `json:"id_length"`
 	Verification *VerificationConfig `json:"verification,omitempty"`
 	Context      *ContextConfig      `json:"context,omitempty"`
+	CostModel    *CostModel          `json:"cost_model,omitempty"`
+
+	RunRecordRetention *RunRecordRetention `json:"run_record_retention,omitempty"`
+
+	// GC holds the retention used by the automatic cleanup in tk run and tk resume.
+	GC *GCConfig `json:"gc,omitempty"`
+
+	// Defaults holds repo-wide defaults for tk create.
+	Defaults *Defaults `json:"defaults,omitempty"`
+
+	// TypePrefixes maps tick types to ID prefixes (e.g. "bug" -> "bug" gives "bug-a1b").
+	// Types without an entry get plain random IDs.
+	TypePrefixes map[string]string `json:"type_prefixes,omitempty"`
+
+	// SchemaVersion is the format version of the .tick data, raised by
+	// tk migrate (0 = DefaultSchemaVersion). Unlike Version, it describes
+	// the tick files rather than this config.
+	SchemaVersion int `json:"schema_version,omitempty"`
+}
+
+// maxTypePrefixLength bounds type prefixes so IDs stay short.
+const maxTypePrefixLength = 10
+
+// GetSchemaVersion returns the schema version of the .tick data.
+func (c Config) GetSchemaVersion() int {
+	if c.SchemaVersion == 0 {
+		return DefaultSchemaVersion
+	}
+	return c.SchemaVersion
+}
+
+// IDPrefix returns the configured ID prefix for a tick type, or "" if none.
+func (c Config) IDPrefix(tickType string) string {
+	return c.TypePrefixes[tickType]
 }
 
 // VerificationConfig holds verification settings.
 type VerificationConfig struct {
 	// Enabled controls whether verification runs (default true).
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// CreateBugOnFailure files a bug tick blocking the task when verification fails (default false).
+	CreateBugOnFailure *bool `json:"create_bug_on_failure,omitempty"`
 }
 
 // IsEnabled returns whether verification is enabled (default true).
@@ -40,6 +95,237 @@ func (c *VerificationConfig) IsEnabled() bool {
 	return *c.Enabled
 }
 
+// ShouldCreateBugOnFailure returns whether failed verifications file bug ticks (default false).
+func (c *VerificationConfig) ShouldCreateBugOnFailure() bool {
+	if c == nil || c.CreateBugOnFailure == nil {
+		return false
+	}
+	return *c.CreateBugOnFailure
+}
+
+// CostModel holds heuristics for tk run --estimate-cost.
+type CostModel struct {
+	// Model selects token pricing by model name (default "" = default pricing).
+	Model *string `json:"model,omitempty"`
+
+	// TokensInPerIteration is baseline input tokens per iteration, excluding task text (default 40000).
+	TokensInPerIteration *int `json:"tokens_in_per_iteration,omitempty"`
+
+	// TokensOutPerIteration is expected output tokens per iteration (default 4000).
+	TokensOutPerIteration *int `json:"tokens_out_per_iteration,omitempty"`
+
+	// IterationsPerTask is the expected iterations to finish a task (default 2).
+	IterationsPerTask *int `json:"iterations_per_task,omitempty"`
+}
+
+// GetModel returns the pricing model name (default "").
+func (c *CostModel) GetModel() string {
+	if c == nil || c.Model == nil {
+		return ""
+	}
+	return *c.Model
+}
+
+// GetTokensInPerIteration returns baseline input tokens per iteration (default 40000).
+func (c *CostModel) GetTokensInPerIteration() int {
+	if c == nil || c.TokensInPerIteration == nil {
+		return DefaultCostTokensInPerIteration
+	}
+	return *c.TokensInPerIteration
+}
+
+// GetTokensOutPerIteration returns expected output tokens per iteration (default 4000).
+func (c *CostModel) GetTokensOutPerIteration() int {
+	if c == nil || c.TokensOutPerIteration == nil {
+		return DefaultCostTokensOutPerIteration
+	}
+	return *c.TokensOutPerIteration
+}
+
+// GetIterationsPerTask returns expected iterations per task (default 2).
+func (c *CostModel) GetIterationsPerTask() int {
+	if c == nil || c.IterationsPerTask == nil {
+		return DefaultCostIterationsPerTask
+	}
+	return *c.IterationsPerTask
+}
+
+// Validate checks that cost model values are non-negative.
+func (c *CostModel) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.TokensInPerIteration != nil && *c.TokensInPerIteration < 0 {
+		return fmt.Errorf("tokens_in_per_iteration must be non-negative, got %d", *c.TokensInPerIteration)
+	}
+	if c.TokensOutPerIteration != nil && *c.TokensOutPerIteration < 0 {
+		return fmt.Errorf("tokens_out_per_iteration must be non-negative, got %d", *c.TokensOutPerIteration)
+	}
+	if c.IterationsPerTask != nil && *c.IterationsPerTask < 1 {
+		return fmt.Errorf("iterations_per_task must be at least 1, got %d", *c.IterationsPerTask)
+	}
+	return nil
+}
+
+// Defaults holds values tk create uses when the matching flag is not given.
+type Defaults struct {
+	// Priority is the default priority (default 2).
+	Priority *int `json:"priority,omitempty"`
+
+	// Type is the default tick type (default "task").
+	Type *string `json:"type,omitempty"`
+
+	// Owner is the default owner (default "" = the creator).
+	Owner *string `json:"owner,omitempty"`
+
+	// Labels are the default labels (default none).
+	Labels []string `json:"labels,omitempty"`
+}
+
+// GetPriority returns the default priority (default 2).
+func (d *Defaults) GetPriority() int {
+	if d == nil || d.Priority == nil {
+		return DefaultTickPriority
+	}
+	return *d.Priority
+}
+
+// GetType returns the default tick type (default "task").
+func (d *Defaults) GetType() string {
+	if d == nil || d.Type == nil {
+		return DefaultTickType
+	}
+	return *d.Type
+}
+
+// GetOwner returns the default owner (default "" = the creator).
+func (d *Defaults) GetOwner() string {
+	if d == nil || d.Owner == nil {
+		return ""
+	}
+	return *d.Owner
+}
+
+// GetLabels returns the default labels (default none).
+func (d *Defaults) GetLabels() []string {
+	if d == nil {
+		return nil
+	}
+	return d.Labels
+}
+
+// Validate checks that the default priority and type are valid for a tick.
+func (d *Defaults) Validate() error {
+	if d == nil {
+		return nil
+	}
+	if d.Priority != nil && (*d.Priority < 0 || *d.Priority > 4) {
+		return fmt.Errorf("priority must be 0-4, got %d", *d.Priority)
+	}
+	if d.Type != nil {
+		switch *d.Type {
+		case tick.TypeTask, tick.TypeEpic, tick.TypeBug, tick.TypeFeature, tick.TypeChore:
+		default:
+			return fmt.Errorf("invalid type: %s (must be task, epic, bug, feature, or chore)", *d.Type)
+		}
+	}
+	return nil
+}
+
+// RunRecordRetention holds outcome-aware pruning settings for tk gc.
+type RunRecordRetention struct {
+	// SuccessMaxAgeDays is how many days successful run records are kept (default 0 = use --max-age).
+	SuccessMaxAgeDays *int `json:"success_max_age_days,omitempty"`
+
+	// FailureMaxAgeDays is how many days failed run records are kept (default 0 = use --max-age).
+	FailureMaxAgeDays *int `json:"failure_max_age_days,omitempty"`
+
+	// KeepSuccessful is how many of the most recent successful records are always kept (default 0).
+	KeepSuccessful *int `json:"keep_successful,omitempty"`
+}
+
+// GetSuccessMaxAgeDays returns the successful record retention in days (default 0).
+func (c *RunRecordRetention) GetSuccessMaxAgeDays() int {
+	if c == nil || c.SuccessMaxAgeDays == nil {
+		return 0
+	}
+	return *c.SuccessMaxAgeDays
+}
+
+// GetFailureMaxAgeDays returns the failed record retention in days (default 0).
+func (c *RunRecordRetention) GetFailureMaxAgeDays() int {
+	if c == nil || c.FailureMaxAgeDays == nil {
+		return 0
+	}
+	return *c.FailureMaxAgeDays
+}
+
+// GetKeepSuccessful returns how many recent successful records are always kept (default 0).
+func (c *RunRecordRetention) GetKeepSuccessful() int {
+	if c == nil || c.KeepSuccessful == nil {
+		return 0
+	}
+	return *c.KeepSuccessful
+}
+
+// Validate checks that retention values are non-negative.
+func (c *RunRecordRetention) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.SuccessMaxAgeDays != nil && *c.SuccessMaxAgeDays < 0 {
+		return fmt.Errorf("success_max_age_days must be non-negative, got %d", *c.SuccessMaxAgeDays)
+	}
+	if c.FailureMaxAgeDays != nil && *c.FailureMaxAgeDays < 0 {
+		return fmt.Errorf("failure_max_age_days must be non-negative, got %d", *c.FailureMaxAgeDays)
+	}
+	if c.KeepSuccessful != nil && *c.KeepSuccessful < 0 {
+		return fmt.Errorf("keep_successful must be non-negative, got %d", *c.KeepSuccessful)
+	}
+	return nil
+}
+
+// GCConfig holds log retention settings for automatic cleanup.
+// Each tick has a single run record file, so KeepLast counts records across
+// ticks rather than runs of one tick.
+type GCConfig struct {
+	// MaxAgeDays is how many days logs and run records are kept (default 30).
+	MaxAgeDays *int `json:"max_age_days,omitempty"`
+
+	// KeepLast is how many of the most recent run records are always kept, whatever their age (default 0).
+	KeepLast *int `json:"keep_last,omitempty"`
+}
+
+// GetMaxAgeDays returns the log retention in days (default 30).
+func (c *GCConfig) GetMaxAgeDays() int {
+	if c == nil || c.MaxAgeDays == nil {
+		return DefaultGCMaxAgeDays
+	}
+	return *c.MaxAgeDays
+}
+
+// GetKeepLast returns how many recent run records are always kept (default 0).
+func (c *GCConfig) GetKeepLast() int {
+	if c == nil || c.KeepLast == nil {
+		return 0
+	}
+	return *c.KeepLast
+}
+
+// Validate checks that max_age_days is positive and keep_last non-negative.
+func (c *GCConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxAgeDays != nil && *c.MaxAgeDays < 1 {
+		return fmt.Errorf("max_age_days must be at least 1, got %d", *c.MaxAgeDays)
+	}
+	if c.KeepLast != nil && *c.KeepLast < 0 {
+		return fmt.Errorf("keep_last must be non-negative, got %d", *c.KeepLast)
+	}
+	return nil
+}
+
 // ContextConfig holds context generation configuration.
 type ContextConfig struct {
 	// Enabled controls whether context generation runs (default true).
@@ -209,6 +495,9 @@ func (c Config) Validate() error {
 	if c.Version != DefaultVersion {
 		return fmt.Errorf("unsupported config version: %d", c.Version)
 	}
+	if c.SchemaVersion < 0 {
+		return fmt.Errorf("schema_version must be non-negative, got %d", c.SchemaVersion)
+	}
 	if c.IDLength < 3 || c.IDLength > 4 {
 		return fmt.Errorf("id_length must be 3 or 4, got %d", c.IDLength)
 	}
@@ -217,6 +506,55 @@ func (c Config) Validate() error {
 			return fmt.Errorf("invalid context config: %w", err)
 		}
 	}
+	if c.CostModel != nil {
+		if err := c.CostModel.Validate(); err != nil {
+			return fmt.Errorf("invalid cost_model config: %w", err)
+		}
+	}
+	if c.RunRecordRetention != nil {
+		if err := c.RunRecordRetention.Validate(); err != nil {
+			return fmt.Errorf("invalid run_record_retention config: %w", err)
+		}
+	}
+	if c.GC != nil {
+		if err := c.GC.Validate(); err != nil {
+			return fmt.Errorf("invalid gc config: %w", err)
+		}
+	}
+	if c.Defaults != nil {
+		if err := c.Defaults.Validate(); err != nil {
+			return fmt.Errorf("invalid defaults config: %w", err)
+		}
+	}
+	if err := validateTypePrefixes(c.TypePrefixes); err != nil {
+		return fmt.Errorf("invalid type_prefixes: %w", err)
+	}
+	return nil
+}
+
+// validateTypePrefixes ensures prefixes belong to known types, are
+// filename-safe (lowercase letters and digits), and are distinct.
+func validateTypePrefixes(prefixes map[string]string) error {
+	seen := make(map[string]string, len(prefixes))
+	for typ, prefix := range prefixes {
+		switch typ {
+		case tick.TypeTask, tick.TypeEpic, tick.TypeBug, tick.TypeFeature, tick.TypeChore:
+		default:
+			return fmt.Errorf("unknown type %q", typ)
+		}
+		if prefix == "" || len(prefix) > maxTypePrefixLength {
+			return fmt.Errorf("prefix for %s must be 1-%d characters", typ, maxTypePrefixLength)
+		}
+		for _, r := range prefix {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+				return fmt.Errorf("prefix %q for %s must contain only lowercase letters and digits", prefix, typ)
+			}
+		}
+		if other, ok := seen[prefix]; ok {
+			return fmt.Errorf("prefix %q is used by both %s and %s", prefix, other, typ)
+		}
+		seen[prefix] = typ
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -40,6 +41,36 @@ func TestLoadDefaults(t *testing.T) {
 	if cfg.IDLength != DefaultIDLength {
 		t.Fatalf("expected default id_length %d, got %d", DefaultIDLength, cfg.IDLength)
 	}
+	if got := cfg.GetSchemaVersion(); got != DefaultSchemaVersion {
+		t.Fatalf("expected default schema version %d, got %d", DefaultSchemaVersion, got)
+	}
+}
+
+func TestSchemaVersionRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	cfg := Default()
+	cfg.SchemaVersion = 3
+	if err := Save(path, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if got := loaded.GetSchemaVersion(); got != 3 {
+		t.Fatalf("expected schema version 3, got %d", got)
+	}
+
+	cfg.SchemaVersion = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected schema_version 0 to be valid, got %v", err)
+	}
+
+	cfg.SchemaVersion = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "non-negative") {
+		t.Fatalf("expected non-negative error for negative schema_version, got %v", err)
+	}
 }
 
 func TestValidateRejectsInvalidIDLength(t *testing.T) {
@@ -67,3 +98,102 @@ func TestSaveAndLoadRoundTrip(t *testing.T) {
 		t.Fatalf("expected id_length 4, got %d", loaded.IDLength)
 	}
 }
+
+func TestValidateTypePrefixes(t *testing.T) {
+	cfg := Default()
+	cfg.TypePrefixes = map[string]string{"bug": "bug", "epic": "ep"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid prefixes, got %v", err)
+	}
+	if got := cfg.IDPrefix("bug"); got != "bug" {
+		t.Fatalf("expected bug prefix, got %q", got)
+	}
+	if got := cfg.IDPrefix("task"); got != "" {
+		t.Fatalf("expected no task prefix, got %q", got)
+	}
+
+	invalid := []map[string]string{
+		{"story": "st"},
+		{"bug": ""},
+		{"bug": "Bug"},
+		{"bug": "b/g"},
+		{"bug": "b-g"},
+		{"bug": "averyveryverylongprefix"},
+		{"bug": "x", "chore": "x"},
+	}
+	for _, prefixes := range invalid {
+		cfg.TypePrefixes = prefixes
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected error for %v", prefixes)
+		}
+	}
+}
+
+func TestValidateRunRecordRetention(t *testing.T) {
+	cfg := Default()
+	if got := cfg.RunRecordRetention.GetKeepSuccessful(); got != 0 {
+		t.Fatalf("expected default keep_successful 0, got %d", got)
+	}
+
+	days, keep := 90, 5
+	cfg.RunRecordRetention = &RunRecordRetention{FailureMaxAgeDays: &days, KeepSuccessful: &keep}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid retention, got %v", err)
+	}
+	if got := cfg.RunRecordRetention.GetFailureMaxAgeDays(); got != 90 {
+		t.Fatalf("expected failure_max_age_days 90, got %d", got)
+	}
+
+	negative := -1
+	cfg.RunRecordRetention = &RunRecordRetention{SuccessMaxAgeDays: &negative}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected error for negative success_max_age_days")
+	}
+}
+
+func TestValidateGC(t *testing.T) {
+	cfg := Default()
+	if got := cfg.GC.GetMaxAgeDays(); got != DefaultGCMaxAgeDays {
+		t.Fatalf("expected default max_age_days %d, got %d", DefaultGCMaxAgeDays, got)
+	}
+	if got := cfg.GC.GetKeepLast(); got != 0 {
+		t.Fatalf("expected default keep_last 0, got %d", got)
+	}
+
+	days, keep := 7, 10
+	cfg.GC = &GCConfig{MaxAgeDays: &days, KeepLast: &keep}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid gc config, got %v", err)
+	}
+	if cfg.GC.GetMaxAgeDays() != 7 || cfg.GC.GetKeepLast() != 10 {
+		t.Fatalf("unexpected gc config: %+v", cfg.GC)
+	}
+
+	zero := 0
+	cfg.GC = &GCConfig{MaxAgeDays: &zero}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected error for zero max_age_days")
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	var unset *Defaults
+	if unset.GetPriority() != 2 || unset.GetType() != "task" || unset.GetOwner() != "" || unset.GetLabels() != nil {
+		t.Fatalf("unexpected fallbacks: %d %q %q %v", unset.GetPriority(), unset.GetType(), unset.GetOwner(), unset.GetLabels())
+	}
+
+	priority, typ := 1, "bug"
+	cfg := Default()
+	cfg.Defaults = &Defaults{Priority: &priority, Type: &typ, Labels: []string{"triage"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid defaults rejected: %v", err)
+	}
+
+	badPriority, badType := 5, "story"
+	for _, d := range []*Defaults{{Priority: &badPriority}, {Type: &badType}} {
+		cfg.Defaults = d
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("expected error for defaults %+v", d)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Keys are dotted JSON paths into Config, e.g. "id_length",
+// "context.max_tokens", or "type_prefixes.bug".
+
+// Get returns the stored value for key. ok is false if the key is valid but
+// not set, in which case the built-in default applies.
+func (c Config) Get(key string) (value any, ok bool, err error) {
+	path := strings.Split(key, ".")
+	if _, err := keyType(path); err != nil {
+		return nil, false, err
+	}
+
+	tree, err := configTree(c)
+	if err != nil {
+		return nil, false, err
+	}
+	var node any = tree
+	for _, part := range path {
+		m, isMap := node.(map[string]any)
+		if !isMap {
+			return nil, false, nil
+		}
+		if node, ok = m[part]; !ok {
+			return nil, false, nil
+		}
+	}
+	return node, true, nil
+}
+
+// Set parses value for key and stores it. Values are parsed as JSON, falling
+// back to a plain string for string keys. The result must pass Validate;
+// on error c is unchanged.
+func (c *Config) Set(key, value string) error {
+	path := strings.Split(key, ".")
+	typ, err := keyType(path)
+	if err != nil {
+		return err
+	}
+
+	parsed := reflect.New(typ)
+	if err := json.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		if baseKind(typ) != reflect.String {
+			return fmt.Errorf("invalid value for %s: %q", key, value)
+		}
+		quoted, _ := json.Marshal(value)
+		if err := json.Unmarshal(quoted, parsed.Interface()); err != nil {
+			return fmt.Errorf("invalid value for %s: %q", key, value)
+		}
+	}
+
+	tree, err := configTree(*c)
+	if err != nil {
+		return err
+	}
+	node := tree
+	for _, part := range path[:len(path)-1] {
+		child, ok := node[part].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			node[part] = child
+		}
+		node = child
+	}
+	node[path[len(path)-1]] = parsed.Elem().Interface()
+
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	var updated Config
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+	*c = updated
+	return nil
+}
+
+// configTree returns cfg as generic JSON.
+func configTree(cfg Config) (map[string]any, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	return tree, nil
+}
+
+// keyType resolves a key path to the Go type stored there, following JSON
+// field names through nested structs and string-keyed maps.
+func keyType(path []string) (reflect.Type, error) {
+	key := strings.Join(path, ".")
+	typ := reflect.TypeOf(Config{})
+	for _, part := range path {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		switch typ.Kind() {
+		case reflect.Struct:
+			field, ok := jsonField(typ, part)
+			if !ok {
+				return nil, fmt.Errorf("unknown config key: %s", key)
+			}
+			typ = field.Type
+		case reflect.Map:
+			typ = typ.Elem()
+		default:
+			return nil, fmt.Errorf("unknown config key: %s", key)
+		}
+	}
+	if k := baseKind(typ); k == reflect.Struct || k == reflect.Map {
+		return nil, fmt.Errorf("config key %s is a section; set one of its keys instead", key)
+	}
+	return typ, nil
+}
+
+// jsonField finds the struct field serialized as name.
+func jsonField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func baseKind(typ reflect.Type) reflect.Kind {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind()
+}
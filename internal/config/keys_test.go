@@ -0,0 +1,66 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetAndGet(t *testing.T) {
+	cfg := Default()
+
+	tests := []struct {
+		key, value string
+		want       any
+	}{
+		{"id_length", "4", float64(4)},
+		{"context.max_tokens", "8000", float64(8000)},
+		{"context.generation_timeout", "10m", "10m"},
+		{"verification.enabled", "false", false},
+		{"type_prefixes.bug", "bug", "bug"},
+	}
+	for _, tt := range tests {
+		if err := cfg.Set(tt.key, tt.value); err != nil {
+			t.Fatalf("set %s=%s: %v", tt.key, tt.value, err)
+		}
+		got, ok, err := cfg.Get(tt.key)
+		if err != nil || !ok || got != tt.want {
+			t.Fatalf("get %s = %v (ok=%v, err=%v), want %v", tt.key, got, ok, err, tt.want)
+		}
+	}
+
+	if cfg.IDLength != 4 || cfg.Context.GetMaxTokens() != 8000 || cfg.Verification.IsEnabled() {
+		t.Fatalf("typed fields not updated: %+v", cfg)
+	}
+}
+
+func TestGetUnset(t *testing.T) {
+	_, ok, err := Default().Get("context.max_tokens")
+	if err != nil || ok {
+		t.Fatalf("expected unset key, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestSetRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		key, value string
+		wantErr    string
+	}{
+		{"id_length", "5", "id_length must be 3 or 4, got 5"},
+		{"context.max_tokens", "50", "max_tokens must be at least 100, got 50"},
+		{"context.generation_timeout", "2h", "generation_timeout must be at most 1h"},
+		{"id_length", "four", "invalid value for id_length"},
+		{"no_such_key", "1", "unknown config key: no_such_key"},
+		{"context.nope", "1", "unknown config key: context.nope"},
+		{"context", "1", "is a section"},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		err := cfg.Set(tt.key, tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Fatalf("set %s=%s: got %v, want error containing %q", tt.key, tt.value, err, tt.wantErr)
+		}
+		if cfg.IDLength != DefaultIDLength || cfg.Context != nil {
+			t.Fatalf("set %s=%s changed config on error: %+v", tt.key, tt.value, cfg)
+		}
+	}
+}
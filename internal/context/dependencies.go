@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,6 +24,7 @@ type DependencyAnalyzer struct {
 	store   *tick.Store
 	logger  *slog.Logger
 	timeout time.Duration
+	dryRun  bool
 }
 
 // DependencyAnalyzerOption configures a DependencyAnalyzer.
@@ -39,6 +44,14 @@ func WithDepLogger(logger *slog.Logger) DependencyAnalyzerOption {
 	}
 }
 
+// WithDepDryRun makes Analyze report the dependencies it would add without
+// writing them to the tick store or caching the analysis.
+func WithDepDryRun(dryRun bool) DependencyAnalyzerOption {
+	return func(da *DependencyAnalyzer) {
+		da.dryRun = dryRun
+	}
+}
+
 // NewDependencyAnalyzer creates a new dependency analyzer.
 func NewDependencyAnalyzer(a agent.Agent, store *tick.Store, opts ...DependencyAnalyzerOption) *DependencyAnalyzer {
 	da := &DependencyAnalyzer{
@@ -77,7 +90,8 @@ type ConflictPair struct {
 var filePredictionPattern = regexp.MustCompile(`(?s)<file_predictions>\s*(.*?)\s*</file_predictions>`)
 
 // Analyze predicts file conflicts and adds dependencies to prevent parallel edits.
-// Returns the analysis result showing what dependencies were added.
+// Returns the analysis result showing what dependencies were added. In dry-run
+// mode nothing is written, and AddedDeps holds the dependencies it would add.
 func (da *DependencyAnalyzer) Analyze(ctx context.Context, epic *ticks.Epic, tasks []ticks.Task) (*AnalysisResult, error) {
 	if len(tasks) <= 1 {
 		return &AnalysisResult{}, nil // No conflicts possible with 0-1 tasks
@@ -116,46 +130,20 @@ func (da *DependencyAnalyzer) Analyze(ctx context.Context, epic *ticks.Epic, tas
 		return &AnalysisResult{}, nil
 	}
 
-	// Build file -> tasks map
-	fileToTasks := make(map[string][]string)
-	for _, pred := range predictions {
-		for _, file := range pred.Files {
-			fileToTasks[file] = append(fileToTasks[file], pred.TaskID)
-		}
-	}
-
-	// Find conflicting pairs
+	// Find conflicting pairs. Predictions may be glob patterns, so a
+	// pattern conflicts with the paths and patterns it overlaps.
 	var conflicts []ConflictPair
-	seen := make(map[string]bool)
-	for file, taskIDs := range fileToTasks {
-		if len(taskIDs) < 2 {
-			continue
-		}
-		// All pairs of tasks touching this file conflict
-		for i := 0; i < len(taskIDs); i++ {
-			for j := i + 1; j < len(taskIDs); j++ {
-				key := taskIDs[i] + ":" + taskIDs[j]
-				if taskIDs[j] < taskIDs[i] {
-					key = taskIDs[j] + ":" + taskIDs[i]
-				}
-				if seen[key] {
-					// Already recorded, add file to existing conflict
-					for k := range conflicts {
-						if (conflicts[k].Task1 == taskIDs[i] && conflicts[k].Task2 == taskIDs[j]) ||
-							(conflicts[k].Task1 == taskIDs[j] && conflicts[k].Task2 == taskIDs[i]) {
-							conflicts[k].SharedFiles = append(conflicts[k].SharedFiles, file)
-							break
-						}
-					}
-					continue
-				}
-				seen[key] = true
-				conflicts = append(conflicts, ConflictPair{
-					Task1:       taskIDs[i],
-					Task2:       taskIDs[j],
-					SharedFiles: []string{file},
-				})
+	for i := 0; i < len(predictions); i++ {
+		for j := i + 1; j < len(predictions); j++ {
+			p1, p2 := predictions[i], predictions[j]
+			if p1.TaskID == p2.TaskID {
+				continue
 			}
+			shared := sharedFiles(p1.Files, p2.Files)
+			if len(shared) == 0 {
+				continue
+			}
+			conflicts = appendConflict(conflicts, p1.TaskID, p2.TaskID, shared)
 		}
 	}
 
@@ -177,13 +165,239 @@ func (da *DependencyAnalyzer) Analyze(ctx context.Context, epic *ticks.Epic, tas
 		"predictions", len(predictions),
 		"conflicts", len(conflicts),
 		"deps_added", len(addedDeps),
+		"dry_run", da.dryRun,
 	)
 
-	return &AnalysisResult{
+	analysis := &AnalysisResult{
 		Predictions:      predictions,
 		AddedDeps:        addedDeps,
 		ConflictingPairs: conflicts,
-	}, nil
+	}
+
+	if da.dryRun {
+		return analysis, nil
+	}
+
+	// Cache for schedulers (tk next --waves); analysis itself succeeded either way
+	if err := SaveAnalysis(da.store.Root, epic.ID, analysis); err != nil {
+		da.logger.Warn("failed to cache dependency analysis",
+			"epic_id", epic.ID,
+			"error", err,
+		)
+	}
+
+	return analysis, nil
+}
+
+// analysisPath returns the cache file for an epic's dependency analysis.
+// Analyses are stored in <tickDir>/logs/deps/<epic-id>.json.
+func analysisPath(tickDir, epicID string) string {
+	return filepath.Join(tickDir, "logs", "deps", epicID+".json")
+}
+
+// SaveAnalysis caches the dependency analysis for an epic.
+func SaveAnalysis(tickDir, epicID string, result *AnalysisResult) error {
+	if epicID == "" {
+		return fmt.Errorf("epic ID is required")
+	}
+
+	path := analysisPath(tickDir, epicID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating deps directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal analysis: %w", err)
+	}
+
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		return fmt.Errorf("writing temp analysis file: %w", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("renaming analysis file: %w", err)
+	}
+	return nil
+}
+
+// LoadAnalysis reads the cached dependency analysis for an epic.
+// Returns nil (not error) if no analysis has been cached.
+func LoadAnalysis(tickDir, epicID string) (*AnalysisResult, error) {
+	if epicID == "" {
+		return nil, fmt.Errorf("epic ID is required")
+	}
+
+	data, err := os.ReadFile(analysisPath(tickDir, epicID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading analysis file: %w", err)
+	}
+
+	var result AnalysisResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parse analysis file: %w", err)
+	}
+	return &result, nil
+}
+
+// appendConflict records that task1 and task2 share files, merging with an
+// existing pair for the same tasks.
+func appendConflict(conflicts []ConflictPair, task1, task2 string, files []string) []ConflictPair {
+	for k := range conflicts {
+		c := &conflicts[k]
+		if (c.Task1 == task1 && c.Task2 == task2) || (c.Task1 == task2 && c.Task2 == task1) {
+			for _, f := range files {
+				if !slices.Contains(c.SharedFiles, f) {
+					c.SharedFiles = append(c.SharedFiles, f)
+				}
+			}
+			return conflicts
+		}
+	}
+	return append(conflicts, ConflictPair{Task1: task1, Task2: task2, SharedFiles: files})
+}
+
+// sharedFiles returns the entries of a and b that overlap. Identical paths
+// and a pattern matching a concrete path are reported as the path; two
+// overlapping patterns are both reported.
+func sharedFiles(a, b []string) []string {
+	var shared []string
+	add := func(f string) {
+		if !slices.Contains(shared, f) {
+			shared = append(shared, f)
+		}
+	}
+	for _, fa := range a {
+		for _, fb := range b {
+			if !pathsOverlap(fa, fb) {
+				continue
+			}
+			switch {
+			case !isGlob(fa):
+				add(fa)
+			case !isGlob(fb):
+				add(fb)
+			default:
+				add(fa)
+				add(fb)
+			}
+		}
+	}
+	return shared
+}
+
+// isGlob reports whether p contains glob metacharacters.
+func isGlob(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// pathsOverlap reports whether two predicted paths can name the same file.
+// Either side may be a glob: *, ? and [...] match within a path segment and
+// a "**" segment matches any number of segments. Character classes are
+// treated as matching any character when both sides are patterns, which
+// errs towards reporting a conflict.
+func pathsOverlap(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if !isGlob(a) && !isGlob(b) {
+		return false
+	}
+	return segmentsOverlap(strings.Split(a, "/"), strings.Split(b, "/"))
+}
+
+func segmentsOverlap(a, b []string) bool {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return true
+	case len(a) > 0 && a[0] == "**":
+		return segmentsOverlap(a[1:], b) || (len(b) > 0 && segmentsOverlap(a, b[1:]))
+	case len(b) > 0 && b[0] == "**":
+		return segmentsOverlap(a, b[1:]) || (len(a) > 0 && segmentsOverlap(a[1:], b))
+	case len(a) == 0 || len(b) == 0:
+		return false
+	}
+	return segmentOverlap(a[0], b[0]) && segmentsOverlap(a[1:], b[1:])
+}
+
+// segmentOverlap reports whether two path segments can match the same name.
+func segmentOverlap(a, b string) bool {
+	switch {
+	case !isGlob(a) && !isGlob(b):
+		return a == b
+	case !isGlob(b):
+		ok, _ := path.Match(a, b)
+		return ok
+	case !isGlob(a):
+		ok, _ := path.Match(b, a)
+		return ok
+	}
+	return globTokensOverlap(globTokens(a), globTokens(b))
+}
+
+// globToken is one element of a segment pattern: '*', '?' (also used for
+// character classes) or a literal character.
+type globToken rune
+
+// globTokens splits a segment pattern into tokens.
+func globTokens(p string) []globToken {
+	var tokens []globToken
+	runes := []rune(p)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*', '?':
+			tokens = append(tokens, globToken(r))
+		case '[':
+			// Skip to the closing bracket; the class matches one character
+			for i < len(runes) && runes[i] != ']' {
+				i++
+			}
+			tokens = append(tokens, '?')
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+			}
+			tokens = append(tokens, globToken(runes[i]))
+		default:
+			tokens = append(tokens, globToken(r))
+		}
+	}
+	return tokens
+}
+
+// globTokensOverlap reports whether some string matches both patterns.
+func globTokensOverlap(a, b []globToken) bool {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return true
+	case len(a) > 0 && a[0] == '*':
+		return globTokensOverlap(a[1:], b) || (len(b) > 0 && globTokensOverlap(a, b[1:]))
+	case len(b) > 0 && b[0] == '*':
+		return globTokensOverlap(a, b[1:]) || (len(a) > 0 && globTokensOverlap(a[1:], b))
+	case len(a) == 0 || len(b) == 0:
+		return false
+	}
+	if a[0] != '?' && b[0] != '?' && a[0] != b[0] {
+		return false
+	}
+	return globTokensOverlap(a[1:], b[1:])
+}
+
+// runsFirst reports whether a should block b when they conflict: the
+// higher-priority (lower number) task goes first, then the older one, then
+// the lower id.
+func runsFirst(a, b ticks.Task) bool {
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
 }
 
 // buildPredictionPrompt creates the prompt for file prediction.
@@ -273,13 +487,15 @@ func (da *DependencyAnalyzer) parsePredictions(output string) ([]TaskFilePredict
 }
 
 // addDependencies adds blocked_by relationships to resolve conflicts.
-// Uses a simple heuristic: for each conflict pair, add a dependency from one to the other.
-// Tries to minimize the number of added dependencies while ensuring no parallel conflicts.
+// In dry-run mode the dependencies are returned but not written.
+// For each conflicting pair the higher-priority task blocks the other, so
+// urgent work isn't serialized behind less important work (see runsFirst).
+// Pairs that are already ordered by an existing blocker are left alone.
 func (da *DependencyAnalyzer) addDependencies(conflicts []ConflictPair, tasks []ticks.Task) (map[string][]string, error) {
 	// Build task index for quick lookup
-	taskIndex := make(map[string]int)
-	for i, t := range tasks {
-		taskIndex[t.ID] = i
+	taskByID := make(map[string]ticks.Task)
+	for _, t := range tasks {
+		taskByID[t.ID] = t
 	}
 
 	// Track existing blocked_by relationships
@@ -292,24 +508,21 @@ func (da *DependencyAnalyzer) addDependencies(conflicts []ConflictPair, tasks []
 	}
 
 	// For each conflict, add dependency if not already present
-	// Simple strategy: task that appears first in the list blocks the later one
 	addedDeps := make(map[string][]string)
 	for _, c := range conflicts {
-		idx1, ok1 := taskIndex[c.Task1]
-		idx2, ok2 := taskIndex[c.Task2]
+		t1, ok1 := taskByID[c.Task1]
+		t2, ok2 := taskByID[c.Task2]
 		if !ok1 || !ok2 {
 			continue
 		}
 
-		var blocker, blocked string
-		if idx1 < idx2 {
-			blocker, blocked = c.Task1, c.Task2
-		} else {
-			blocker, blocked = c.Task2, c.Task1
+		blocker, blocked := t1.ID, t2.ID
+		if runsFirst(t2, t1) {
+			blocker, blocked = t2.ID, t1.ID
 		}
 
-		// Skip if dependency already exists (directly or transitively)
-		if blockedBy[blocked][blocker] {
+		// Skip if the pair is already ordered, either way round
+		if blockedBy[blocked][blocker] || blockedBy[blocker][blocked] {
 			continue
 		}
 
@@ -318,6 +531,10 @@ func (da *DependencyAnalyzer) addDependencies(conflicts []ConflictPair, tasks []
 		addedDeps[blocked] = append(addedDeps[blocked], blocker)
 	}
 
+	if da.dryRun {
+		return addedDeps, nil
+	}
+
 	// Persist the changes
 	for taskID, newBlockers := range addedDeps {
 		t, err := da.store.Read(taskID)
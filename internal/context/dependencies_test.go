@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -182,7 +183,7 @@ func TestDependencyAnalyzer_Analyze_DetectsConflicts(t *testing.T) {
 		}
 	}
 
-	// Should add dependency: t2 blocked by t1 (t1 comes first in list)
+	// Should add dependency: t2 blocked by t1 (equal priority and age, lower id first)
 	if len(result.AddedDeps) != 1 {
 		t.Errorf("expected 1 added dep, got %d", len(result.AddedDeps))
 	}
@@ -203,6 +204,100 @@ func TestDependencyAnalyzer_Analyze_DetectsConflicts(t *testing.T) {
 	if len(updatedTask.BlockedBy) != 1 || updatedTask.BlockedBy[0] != "t1" {
 		t.Errorf("task t2 should be blocked by t1, got %v", updatedTask.BlockedBy)
 	}
+
+	// Analysis should be cached for the epic
+	cached, err := LoadAnalysis(tmpDir, "e1")
+	if err != nil {
+		t.Fatalf("LoadAnalysis() error = %v", err)
+	}
+	if cached == nil || len(cached.ConflictingPairs) != 1 {
+		t.Errorf("expected cached analysis with 1 conflict, got %+v", cached)
+	}
+}
+
+func TestDependencyAnalyzer_Analyze_DryRun(t *testing.T) {
+	mock := &mockAgent{
+		name: "test",
+		runFunc: func(ctx context.Context, prompt string, opts agent.RunOpts) (*agent.Result, error) {
+			return &agent.Result{
+				Output: `<file_predictions>
+[
+  {"task_id": "t1", "files": ["src/shared.go"]},
+  {"task_id": "t2", "files": ["src/shared.go"]}
+]
+</file_predictions>`,
+			}, nil
+		},
+	}
+
+	tmpDir := t.TempDir()
+	store := tick.NewStore(tmpDir)
+	if err := store.Ensure(); err != nil {
+		t.Fatalf("store.Ensure() error = %v", err)
+	}
+	now := time.Now()
+	for _, id := range []string{"t1", "t2"} {
+		task := tick.Tick{
+			ID:        id,
+			Title:     "Task " + id,
+			Status:    tick.StatusOpen,
+			Type:      tick.TypeTask,
+			Owner:     "test",
+			CreatedBy: "test",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := store.Write(task); err != nil {
+			t.Fatalf("store.Write(%s) error = %v", id, err)
+		}
+	}
+	t2Path := filepath.Join(tmpDir, "issues", "t2.json")
+	before, err := os.ReadFile(t2Path)
+	if err != nil {
+		t.Fatalf("read t2: %v", err)
+	}
+
+	da := NewDependencyAnalyzer(mock, store, WithDepDryRun(true))
+	result, err := da.Analyze(context.Background(), &ticks.Epic{ID: "e1", Title: "Test"}, []ticks.Task{
+		{ID: "t1", Title: "Task 1"},
+		{ID: "t2", Title: "Task 2"},
+	})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if len(result.ConflictingPairs) != 1 {
+		t.Errorf("expected 1 conflict, got %d", len(result.ConflictingPairs))
+	}
+	if blockers := result.AddedDeps["t2"]; len(blockers) != 1 || blockers[0] != "t1" {
+		t.Errorf("expected proposed t2 blocked by t1, got %v", result.AddedDeps)
+	}
+
+	// The tick file must be untouched and nothing cached
+	after, err := os.ReadFile(t2Path)
+	if err != nil {
+		t.Fatalf("read t2: %v", err)
+	}
+	if string(after) != string(before) {
+		t.Errorf("dry run modified t2:\nbefore: %s\nafter: %s", before, after)
+	}
+	cached, err := LoadAnalysis(tmpDir, "e1")
+	if err != nil {
+		t.Fatalf("LoadAnalysis() error = %v", err)
+	}
+	if cached != nil {
+		t.Errorf("dry run should not cache the analysis, got %+v", cached)
+	}
+}
+
+func TestLoadAnalysis_Missing(t *testing.T) {
+	cached, err := LoadAnalysis(t.TempDir(), "e1")
+	if err != nil {
+		t.Fatalf("LoadAnalysis() error = %v", err)
+	}
+	if cached != nil {
+		t.Errorf("expected nil for missing analysis, got %+v", cached)
+	}
 }
 
 func TestDependencyAnalyzer_Analyze_SkipsExistingDeps(t *testing.T) {
@@ -322,6 +417,169 @@ func TestDependencyAnalyzer_Analyze_MultipleConflicts(t *testing.T) {
 	}
 }
 
+func TestDependencyAnalyzer_Analyze_BlockerByPriority(t *testing.T) {
+	mock := &mockAgent{
+		name: "test",
+		runFunc: func(ctx context.Context, prompt string, opts agent.RunOpts) (*agent.Result, error) {
+			return &agent.Result{
+				Output: `<file_predictions>
+[
+  {"task_id": "low", "files": ["shared.go"]},
+  {"task_id": "high", "files": ["shared.go"]},
+  {"task_id": "old", "files": ["other.go"]},
+  {"task_id": "new", "files": ["other.go"]}
+]
+</file_predictions>`,
+			}, nil
+		},
+	}
+
+	tmpDir := t.TempDir()
+	store := tick.NewStore(tmpDir)
+	if err := store.Ensure(); err != nil {
+		t.Fatalf("store.Ensure() error = %v", err)
+	}
+	now := time.Now()
+	for _, id := range []string{"low", "high", "old", "new"} {
+		if err := store.Write(tick.Tick{
+			ID: id, Title: "Task " + id, Status: tick.StatusOpen, Type: tick.TypeTask,
+			Owner: "test", CreatedBy: "test", CreatedAt: now, UpdatedAt: now,
+		}); err != nil {
+			t.Fatalf("store.Write(%s) error = %v", id, err)
+		}
+	}
+
+	da := NewDependencyAnalyzer(mock, store)
+
+	// List order is the reverse of the expected order in both pairs
+	tasks := []ticks.Task{
+		{ID: "low", Title: "Low", Priority: 3, CreatedAt: now},
+		{ID: "high", Title: "High", Priority: 0, CreatedAt: now},
+		{ID: "new", Title: "New", Priority: 2, CreatedAt: now},
+		{ID: "old", Title: "Old", Priority: 2, CreatedAt: now.Add(-time.Hour)},
+	}
+	result, err := da.Analyze(context.Background(), &ticks.Epic{ID: "e1", Title: "Test"}, tasks)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	// Higher priority blocks lower priority
+	if blockers := result.AddedDeps["low"]; len(blockers) != 1 || blockers[0] != "high" {
+		t.Errorf("expected low blocked by high, got %v", result.AddedDeps)
+	}
+	// Equal priority falls back to the older task
+	if blockers := result.AddedDeps["new"]; len(blockers) != 1 || blockers[0] != "old" {
+		t.Errorf("expected new blocked by old, got %v", result.AddedDeps)
+	}
+	if len(result.AddedDeps) != 2 {
+		t.Errorf("expected 2 added deps, got %v", result.AddedDeps)
+	}
+
+	updated, err := store.Read("low")
+	if err != nil {
+		t.Fatalf("store.Read(low) error = %v", err)
+	}
+	if len(updated.BlockedBy) != 1 || updated.BlockedBy[0] != "high" {
+		t.Errorf("task low should be blocked by high, got %v", updated.BlockedBy)
+	}
+}
+
+func TestDependencyAnalyzer_Analyze_GlobConflicts(t *testing.T) {
+	mock := &mockAgent{
+		name: "test",
+		runFunc: func(ctx context.Context, prompt string, opts agent.RunOpts) (*agent.Result, error) {
+			return &agent.Result{
+				Output: `<file_predictions>
+[
+  {"task_id": "t1", "files": ["src/components/*.ts"]},
+  {"task_id": "t2", "files": ["src/components/Button.ts"]},
+  {"task_id": "t3", "files": ["lib/**/api_*.go"]},
+  {"task_id": "t4", "files": ["lib/*/api_v?.go"]},
+  {"task_id": "t5", "files": ["src/components/Button.css", "docs/*.md"]}
+]
+</file_predictions>`,
+			}, nil
+		},
+	}
+
+	da := NewDependencyAnalyzer(mock, tick.NewStore(t.TempDir()), WithDepDryRun(true))
+	tasks := []ticks.Task{{ID: "t1"}, {ID: "t2"}, {ID: "t3"}, {ID: "t4"}, {ID: "t5"}}
+	result, err := da.Analyze(context.Background(), &ticks.Epic{ID: "e1", Title: "Test"}, tasks)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	got := make(map[string][]string)
+	for _, c := range result.ConflictingPairs {
+		got[c.Task1+":"+c.Task2] = c.SharedFiles
+	}
+	want := map[string][]string{
+		// Glob overlapping a concrete path reports the path
+		"t1:t2": {"src/components/Button.ts"},
+		// Two overlapping globs report both patterns
+		"t3:t4": {"lib/**/api_*.go", "lib/*/api_v?.go"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("conflicts = %v, want %v", got, want)
+	}
+	for key, files := range want {
+		if strings.Join(got[key], ",") != strings.Join(files, ",") {
+			t.Errorf("conflict %s shared files = %v, want %v", key, got[key], files)
+		}
+	}
+}
+
+func TestPathsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"src/a.go", "src/a.go", true},
+		{"src/a.go", "src/b.go", false},
+		{"src/*.go", "src/a.go", true},
+		{"src/a.go", "src/*.go", true},
+		{"src/*.go", "src/sub/a.go", false},
+		{"src/*.go", "src/a.ts", false},
+		{"src/[ab].go", "src/b.go", true},
+		{"src/[ab].go", "src/c.go", false},
+		{"src/**", "src/sub/deep/a.go", true},
+		{"src/**/*.go", "src/a.go", true},
+		{"src/**/*.go", "lib/a.go", false},
+		{"src/*_test.go", "src/foo_*.go", true},
+		{"src/*.ts", "src/*.go", false},
+		{"src/a*", "src/b*", false},
+		{"src/?.go", "src/ab*.go", false},
+		{"*/x.go", "src/*", true},
+	}
+	for _, tt := range tests {
+		if got := pathsOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("pathsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRunsFirst(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		a, b ticks.Task
+		want bool
+	}{
+		{"higher priority first", ticks.Task{ID: "b", Priority: 1}, ticks.Task{ID: "a", Priority: 2}, true},
+		{"lower priority second", ticks.Task{ID: "a", Priority: 3}, ticks.Task{ID: "b", Priority: 2}, false},
+		{"older first on tie", ticks.Task{ID: "b", CreatedAt: now.Add(-time.Minute)}, ticks.Task{ID: "a", CreatedAt: now}, true},
+		{"id breaks full tie", ticks.Task{ID: "a", CreatedAt: now}, ticks.Task{ID: "b", CreatedAt: now}, true},
+		{"id breaks full tie reversed", ticks.Task{ID: "b", CreatedAt: now}, ticks.Task{ID: "a", CreatedAt: now}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runsFirst(tt.a, tt.b); got != tt.want {
+				t.Errorf("runsFirst() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDependencyAnalyzer_ParsePredictions(t *testing.T) {
 	da := &DependencyAnalyzer{}
 
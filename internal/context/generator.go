@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/pengelbrecht/ticks/internal/agent"
+	"github.com/pengelbrecht/ticks/internal/config"
 	"github.com/pengelbrecht/ticks/internal/ticks"
 )
 
@@ -25,6 +26,7 @@ type Generator struct {
 	timeout       time.Duration
 	logger        *slog.Logger
 	maxTokens     int // stored for creating prompt builder with correct value
+	model         string
 }
 
 // GeneratorOption configures a Generator.
@@ -44,6 +46,24 @@ func WithMaxTokens(tokens int) GeneratorOption {
 	}
 }
 
+// WithModel overrides the agent model used for generation.
+// An empty model uses the agent's default.
+func WithModel(model string) GeneratorOption {
+	return func(g *Generator) {
+		g.model = model
+	}
+}
+
+// OptionsFromConfig returns generator options for the timeout, max tokens
+// and model set in cfg. A nil cfg yields the defaults.
+func OptionsFromConfig(cfg *config.ContextConfig) []GeneratorOption {
+	return []GeneratorOption{
+		WithTimeout(cfg.GetGenerationTimeout()),
+		WithMaxTokens(cfg.GetMaxTokens()),
+		WithModel(cfg.GetGenerationModel()),
+	}
+}
+
 // WithLogger sets the logger for the generator.
 func WithLogger(logger *slog.Logger) GeneratorOption {
 	return func(g *Generator) {
@@ -100,6 +120,7 @@ func (g *Generator) Generate(ctx context.Context, epic *ticks.Epic, tasks []tick
 	// Run the agent with timeout
 	result, err := g.agent.Run(ctx, prompt, agent.RunOpts{
 		Timeout: g.timeout,
+		Model:   g.model,
 	})
 	if err != nil {
 		// Log the failure
@@ -0,0 +1,161 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pengelbrecht/ticks/internal/agent"
+)
+
+// Verbosity selects how much of a run ConsoleOutput prints.
+type Verbosity int
+
+const (
+	// VerbosityNormal prints the agent's output and iteration banners.
+	VerbosityNormal Verbosity = iota
+	// VerbosityQuiet prints nothing while the run is going; only the
+	// caller's final summary is shown.
+	VerbosityQuiet
+	// VerbosityVerbose adds the agent's tool calls and thinking.
+	VerbosityVerbose
+)
+
+// maxToolInputLen caps how much of a tool's input a verbose tool line shows.
+const maxToolInputLen = 80
+
+// ConsoleOutput prints an engine run for a terminal at a given verbosity.
+// With a label (multi-epic runs), every line is prefixed with [label].
+type ConsoleOutput struct {
+	verbosity Verbosity
+	label     string
+	writer    io.Writer
+	mu        sync.Mutex
+
+	// Progress through the agent's cumulative state snapshots, reset at the
+	// start of every iteration.
+	toolsSeen    int
+	thinkingSeen int
+}
+
+// NewConsoleOutput creates a console printer writing to stdout.
+func NewConsoleOutput(verbosity Verbosity, label string) *ConsoleOutput {
+	return &ConsoleOutput{
+		verbosity: verbosity,
+		label:     label,
+		writer:    os.Stdout,
+	}
+}
+
+// SetWriter sets a custom writer (mainly for testing).
+func (c *ConsoleOutput) SetWriter(w io.Writer) {
+	c.writer = w
+}
+
+// Attach sets e's output and iteration callbacks for the verbosity level.
+// Quiet leaves them unset.
+func (c *ConsoleOutput) Attach(e *Engine) {
+	if c.verbosity == VerbosityQuiet {
+		return
+	}
+	e.OnOutput = c.Output
+	e.OnIterationStart = c.IterationStart
+	e.OnIterationEnd = c.IterationEnd
+	if c.verbosity == VerbosityVerbose {
+		e.OnAgentState = c.AgentState
+	}
+}
+
+// Output prints a chunk of agent output.
+func (c *ConsoleOutput) Output(chunk string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.label != "" {
+		fmt.Fprintf(c.writer, "[%s] %s", c.label, chunk)
+		return
+	}
+	fmt.Fprint(c.writer, chunk)
+}
+
+// IterationStart prints the banner for a new iteration.
+func (c *ConsoleOutput) IterationStart(ctx IterationContext) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.toolsSeen, c.thinkingSeen = 0, 0
+	if c.label != "" {
+		fmt.Fprintf(c.writer, "\n=== [%s] Iteration %d: %s (%s) ===\n", c.label, ctx.Iteration, ctx.Task.ID, ctx.Task.Title)
+		return
+	}
+	fmt.Fprintf(c.writer, "\n=== Iteration %d: %s (%s) ===\n", ctx.Iteration, ctx.Task.ID, ctx.Task.Title)
+}
+
+// IterationEnd prints the closing banner for an iteration.
+func (c *ConsoleOutput) IterationEnd(result *IterationResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.label != "" {
+		fmt.Fprintf(c.writer, "\n--- [%s] Iteration %d complete (tokens: %d, cost: $%.4f) ---\n",
+			c.label, result.Iteration, result.TokensIn+result.TokensOut, result.Cost)
+		return
+	}
+	fmt.Fprintf(c.writer, "\n--- Iteration %d complete (tokens: %d in, %d out, cost: $%.4f) ---\n",
+		result.Iteration, result.TokensIn, result.TokensOut, result.Cost)
+}
+
+// AgentState prints what is new in snap since the previous snapshot: whole
+// lines of thinking and one line per finished tool call.
+func (c *ConsoleOutput) AgentState(snap agent.AgentStateSnapshot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(snap.Thinking) < c.thinkingSeen || len(snap.ToolHistory) < c.toolsSeen {
+		// A new agent run started without an iteration banner (pool workers)
+		c.toolsSeen, c.thinkingSeen = 0, 0
+	}
+
+	if pending := snap.Thinking[c.thinkingSeen:]; strings.Contains(pending, "\n") {
+		complete := pending[:strings.LastIndex(pending, "\n")+1]
+		for _, line := range strings.Split(complete, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				c.printLine("💭 " + line)
+			}
+		}
+		c.thinkingSeen += len(complete)
+	}
+
+	for _, tool := range snap.ToolHistory[c.toolsSeen:] {
+		line := "🔧 " + tool.Name
+		if input := summarizeToolInput(tool.Input); input != "" {
+			line += " " + input
+		}
+		line += fmt.Sprintf(" (%s)", tool.Duration.Round(100*time.Millisecond))
+		if tool.IsError {
+			line += " ✗"
+		}
+		c.printLine(line)
+	}
+	c.toolsSeen = len(snap.ToolHistory)
+}
+
+// printLine writes one line, prefixed with the label if there is one.
+// Callers must hold c.mu.
+func (c *ConsoleOutput) printLine(line string) {
+	if c.label != "" {
+		fmt.Fprintf(c.writer, "[%s] %s\n", c.label, line)
+		return
+	}
+	fmt.Fprintln(c.writer, line)
+}
+
+// summarizeToolInput flattens a tool's input onto one line, truncated to
+// maxToolInputLen runes.
+func summarizeToolInput(input string) string {
+	input = strings.Join(strings.Fields(input), " ")
+	if runes := []rune(input); len(runes) > maxToolInputLen {
+		return string(runes[:maxToolInputLen-1]) + "…"
+	}
+	return input
+}
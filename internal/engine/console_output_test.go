@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pengelbrecht/ticks/internal/agent"
+	"github.com/pengelbrecht/ticks/internal/ticks"
+)
+
+// simulateIteration drives e's callbacks the way Run does for one iteration
+// in which the agent thinks, calls one tool and writes some output.
+func simulateIteration(e *Engine) {
+	if e.OnIterationStart != nil {
+		e.OnIterationStart(IterationContext{Iteration: 1, Task: &ticks.Task{ID: "t1", Title: "Add refunds"}})
+	}
+	if e.OnAgentState != nil {
+		e.OnAgentState(agent.AgentStateSnapshot{Thinking: "Look at the payments "})
+		e.OnAgentState(agent.AgentStateSnapshot{
+			Thinking:   "Look at the payments module first.\nThen",
+			ActiveTool: &agent.ToolActivity{ID: "tool-1", Name: "Read"},
+		})
+		e.OnAgentState(agent.AgentStateSnapshot{
+			Thinking: "Look at the payments module first.\nThen",
+			ToolHistory: []agent.ToolActivity{
+				{ID: "tool-1", Name: "Read", Input: `{"file_path":` + "\n" + `"payments.go"}`, Duration: 1200 * time.Millisecond},
+			},
+		})
+	}
+	if e.OnOutput != nil {
+		e.OnOutput("Refunds are done.\n")
+	}
+	if e.OnIterationEnd != nil {
+		e.OnIterationEnd(&IterationResult{Iteration: 1, TaskID: "t1", TokensIn: 10, TokensOut: 5, Cost: 0.01})
+	}
+}
+
+func TestConsoleOutput_Verbosity(t *testing.T) {
+	tests := []struct {
+		name      string
+		verbosity Verbosity
+		label     string
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "normal",
+			verbosity: VerbosityNormal,
+			want: []string{
+				"=== Iteration 1: t1 (Add refunds) ===",
+				"Refunds are done.",
+				"--- Iteration 1 complete (tokens: 10 in, 5 out, cost: $0.0100) ---",
+			},
+			notWant: []string{"🔧", "💭"},
+		},
+		{
+			name:      "quiet",
+			verbosity: VerbosityQuiet,
+			notWant:   []string{"Iteration", "Refunds are done.", "🔧", "💭"},
+		},
+		{
+			name:      "verbose",
+			verbosity: VerbosityVerbose,
+			want: []string{
+				"=== Iteration 1: t1 (Add refunds) ===",
+				"💭 Look at the payments module first.\n",
+				`🔧 Read {"file_path": "payments.go"} (1.2s)`,
+				"Refunds are done.",
+				"--- Iteration 1 complete",
+			},
+			// Thinking is printed by whole lines; the unfinished "Then" waits.
+			notWant: []string{"💭 Then", "💭 Look at the payments \n"},
+		},
+		{
+			name:      "verbose with label",
+			verbosity: VerbosityVerbose,
+			label:     "epic1",
+			want: []string{
+				"=== [epic1] Iteration 1: t1 (Add refunds) ===",
+				"[epic1] 💭 Look at the payments module first.",
+				"[epic1] 🔧 Read",
+				"[epic1] Refunds are done.",
+				"--- [epic1] Iteration 1 complete (tokens: 15, cost: $0.0100) ---",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			out := NewConsoleOutput(tt.verbosity, tt.label)
+			out.SetWriter(&buf)
+
+			e := &Engine{}
+			out.Attach(e)
+			simulateIteration(e)
+
+			got := buf.String()
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("expected output to contain %q, got:\n%s", s, got)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(got, s) {
+					t.Errorf("expected output not to contain %q, got:\n%s", s, got)
+				}
+			}
+			if tt.verbosity == VerbosityQuiet && got != "" {
+				t.Errorf("expected no output in quiet mode, got:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestConsoleOutput_ToolsPrintedOnce(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewConsoleOutput(VerbosityVerbose, "")
+	out.SetWriter(&buf)
+
+	read := agent.ToolActivity{Name: "Read"}
+	edit := agent.ToolActivity{Name: "Edit", IsError: true}
+	out.AgentState(agent.AgentStateSnapshot{ToolHistory: []agent.ToolActivity{read}})
+	out.AgentState(agent.AgentStateSnapshot{ToolHistory: []agent.ToolActivity{read}})
+	out.AgentState(agent.AgentStateSnapshot{ToolHistory: []agent.ToolActivity{read, edit}})
+
+	got := buf.String()
+	if n := strings.Count(got, "🔧 Read"); n != 1 {
+		t.Errorf("expected Read once, got %d times:\n%s", n, got)
+	}
+	if !strings.Contains(got, "🔧 Edit (0s) ✗") {
+		t.Errorf("expected failed Edit line, got:\n%s", got)
+	}
+
+	// A new iteration starts counting again.
+	out.IterationStart(IterationContext{Iteration: 2, Task: &ticks.Task{ID: "t1"}})
+	out.AgentState(agent.AgentStateSnapshot{ToolHistory: []agent.ToolActivity{read}})
+	if n := strings.Count(buf.String(), "🔧 Read"); n != 2 {
+		t.Errorf("expected Read again after a new iteration, got %d times", n)
+	}
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/pengelbrecht/ticks/internal/agent"
 	"github.com/pengelbrecht/ticks/internal/budget"
 	"github.com/pengelbrecht/ticks/internal/checkpoint"
+	"github.com/pengelbrecht/ticks/internal/config"
 	epiccontext "github.com/pengelbrecht/ticks/internal/context"
 	"github.com/pengelbrecht/ticks/internal/ticks"
 )
@@ -20,8 +22,9 @@ type mockAgentForContext struct {
 	available     bool
 	runCallCount  int
 	lastPrompt    string
-	contextOutput string   // Output for context generation call
-	taskOutputs   []string // Outputs for task iteration calls
+	runOpts       []agent.RunOpts // Opts passed to each call
+	contextOutput string          // Output for context generation call
+	taskOutputs   []string        // Outputs for task iteration calls
 	err           error
 }
 
@@ -30,6 +33,7 @@ func (m *mockAgentForContext) Available() bool { return m.available }
 
 func (m *mockAgentForContext) Run(ctx context.Context, prompt string, opts agent.RunOpts) (*agent.Result, error) {
 	m.lastPrompt = prompt
+	m.runOpts = append(m.runOpts, opts)
 	callIdx := m.runCallCount
 	m.runCallCount++
 
@@ -323,6 +327,107 @@ func TestEngine_ContextGeneration_SingleTask(t *testing.T) {
 	}
 }
 
+// newContextConfigEngine returns an engine for a three-task epic with context
+// components built from the config.json at configJSON.
+func newContextConfigEngine(t *testing.T, configJSON string) (*Engine, *mockAgentForContext, *epiccontext.Store) {
+	t.Helper()
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(configJSON), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+
+	mockTicks := newMockTicksClientForContext()
+	mockTicks.epic = &ticks.Epic{ID: "epic-cfg", Title: "Configured Epic", Type: "epic"}
+	mockTicks.tasks = []*ticks.Task{
+		{ID: "task-1", Title: "First Task", Status: "open"},
+		{ID: "task-2", Title: "Second Task", Status: "open"},
+		{ID: "task-3", Title: "Third Task", Status: "open"},
+	}
+
+	mockAg := &mockAgentForContext{
+		name:          "test",
+		available:     true,
+		contextOutput: "# Generated Context",
+		taskOutputs:   []string{"Task done"},
+	}
+
+	engine := &Engine{
+		agent:      mockAg,
+		ticks:      mockTicks,
+		budget:     budget.NewTracker(budget.Limits{MaxIterations: 1}),
+		checkpoint: checkpoint.NewManagerWithDir(filepath.Join(dir, "checkpoints")),
+		prompt:     NewPromptBuilder(),
+	}
+
+	store := epiccontext.NewStoreWithDir(filepath.Join(dir, "context"))
+	generator, err := epiccontext.NewGenerator(mockAg, epiccontext.OptionsFromConfig(cfg.Context)...)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	engine.SetContextComponents(store, generator)
+	engine.SetContextConfig(cfg.Context)
+	return engine, mockAg, store
+}
+
+func TestEngine_ContextGeneration_DisabledInConfig(t *testing.T) {
+	engine, mockAg, store := newContextConfigEngine(t, `{"context": {"enabled": false}}`)
+
+	var skipReason string
+	engine.OnContextSkipped = func(epicID, reason string) { skipReason = reason }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := engine.Run(ctx, RunConfig{EpicID: "epic-cfg", MaxIterations: 1, AgentTimeout: time.Second}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if store.Exists("epic-cfg") {
+		t.Error("context file should NOT exist when context is disabled")
+	}
+	if mockAg.runCallCount != 1 {
+		t.Errorf("agent.Run() called %d times, want 1 (no context generation)", mockAg.runCallCount)
+	}
+	if skipReason != "disabled in config" {
+		t.Errorf("OnContextSkipped reason = %q, want %q", skipReason, "disabled in config")
+	}
+}
+
+func TestEngine_ContextGeneration_ConfigOverrides(t *testing.T) {
+	engine, mockAg, store := newContextConfigEngine(t,
+		`{"context": {"generation_timeout": "30s", "generation_model": "haiku"}}`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := engine.Run(ctx, RunConfig{EpicID: "epic-cfg", MaxIterations: 1, AgentTimeout: time.Second}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !store.Exists("epic-cfg") {
+		t.Fatal("context file should exist after generation")
+	}
+	if len(mockAg.runOpts) < 2 {
+		t.Fatalf("agent.Run() called %d times, want at least 2", len(mockAg.runOpts))
+	}
+	genOpts := mockAg.runOpts[0]
+	if genOpts.Timeout != 30*time.Second {
+		t.Errorf("generation timeout = %v, want 30s", genOpts.Timeout)
+	}
+	if genOpts.Model != "haiku" {
+		t.Errorf("generation model = %q, want %q", genOpts.Model, "haiku")
+	}
+	// The override applies to generation only, not task iterations
+	if taskOpts := mockAg.runOpts[1]; taskOpts.Model != "" {
+		t.Errorf("task iteration model = %q, want default", taskOpts.Model)
+	}
+}
+
 func TestEngine_ContextGeneration_AlreadyExists(t *testing.T) {
 	// Test: Context already exists - generation skipped
 	dir := t.TempDir()
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pengelbrecht/ticks/internal/agent"
 	"github.com/pengelbrecht/ticks/internal/budget"
 	"github.com/pengelbrecht/ticks/internal/checkpoint"
+	"github.com/pengelbrecht/ticks/internal/config"
 	epiccontext "github.com/pengelbrecht/ticks/internal/context"
 	"github.com/pengelbrecht/ticks/internal/runlog"
 	"github.com/pengelbrecht/ticks/internal/runrecord"
@@ -39,6 +42,12 @@ type TicksClient interface {
 	GetRunRecord(taskID string) (*agent.RunRecord, error)
 }
 
+// BugFiler files bug ticks for failed verifications.
+// Implemented by *ticks.Client; kept separate from TicksClient because it is optional.
+type BugFiler interface {
+	FileVerificationBug(taskID, title, description string) (string, bool, error)
+}
+
 // Engine orchestrates the Ralph iteration loop.
 type Engine struct {
 	agent      agent.Agent
@@ -50,6 +59,7 @@ type Engine struct {
 	// Context generation components (optional)
 	contextStore     *epiccontext.Store
 	contextGenerator *epiccontext.Generator
+	contextDisabled  bool
 
 	// Run record store for live file tracking (optional)
 	runRecordStore *runrecord.Store
@@ -57,6 +67,12 @@ type Engine struct {
 	// Verification enabled flag (set via EnableVerification)
 	verifyEnabled bool
 
+	// Verifiers to run instead of the default GitVerifier (optional, set via SetVerifiers)
+	verifiers []verify.Verifier
+
+	// Files bug ticks for verification failures (optional, set via EnableBugOnFailure)
+	bugFiler BugFiler
+
 	// Baseline of uncommitted files at engine start (for git verification)
 	gitBaseline map[string]bool
 
@@ -101,7 +117,9 @@ type RunConfig struct {
 	// MaxCost is the maximum cost in USD (0 = disabled/unlimited).
 	MaxCost float64
 
-	// MaxDuration is the maximum wall-clock time (0 = unlimited).
+	// MaxDuration is the maximum wall-clock time (0 = unlimited). When it
+	// passes, the run context is cancelled as for SIGINT and the run ends
+	// with ExitReasonMaxDuration.
 	MaxDuration time.Duration
 
 	// CheckpointEvery saves a checkpoint every N iterations (0 = 5 default).
@@ -131,6 +149,10 @@ type RunConfig struct {
 	// Required when UseWorktree is true. If empty, current working directory is used.
 	RepoRoot string
 
+	// ResumeWorktree reattaches to the worktree recorded by an earlier,
+	// interrupted run of the epic instead of creating one. Requires UseWorktree.
+	ResumeWorktree bool
+
 	// WorkDir overrides the working directory for the agent.
 	// If set, the agent runs in this directory instead of the current directory.
 	// Used by parallel runner to pass pre-created worktree paths.
@@ -151,6 +173,10 @@ type RunConfig struct {
 	// This prevents race conditions when a human is still editing (e.g., adding notes after reject).
 	// 0 means no debounce (default, backwards compatible).
 	DebounceInterval time.Duration
+
+	// BudgetReport attaches a per-task breakdown of budget usage to the
+	// RunResult (RunResult.Budget).
+	BudgetReport bool
 }
 
 // Defaults for RunConfig.
@@ -176,8 +202,17 @@ const (
 
 	// ExitReasonWatchTimeout indicates watch mode timed out - preserve worktree.
 	ExitReasonWatchTimeout = "watch timeout"
+
+	// ExitReasonVerifyOnly indicates a verification-only run (no agent iterations).
+	ExitReasonVerifyOnly = "verify-only"
+
+	// ExitReasonMaxDuration indicates RunConfig.MaxDuration passed - preserve worktree.
+	ExitReasonMaxDuration = "max-duration"
 )
 
+// errMaxDuration is the cause of a run context cancelled by RunConfig.MaxDuration.
+var errMaxDuration = errors.New("max duration reached")
+
 // ShouldCleanupWorktree determines if a worktree should be removed based on exit reason.
 // Returns true only when the epic is fully complete (all tasks done or no tasks found).
 // Returns false for handoffs, budget limits, interruptions, and other cases where
@@ -226,6 +261,22 @@ type RunResult struct {
 
 	// ExitReason describes why the run ended.
 	ExitReason string
+
+	// Verifications holds per-task results of a VerifyOnly run.
+	Verifications []TaskVerification
+
+	// Budget is the budget usage broken down by task. Only set when
+	// RunConfig.BudgetReport is true.
+	Budget *budget.Report
+}
+
+// TaskVerification is the verification outcome for one completed task.
+type TaskVerification struct {
+	TaskID string
+	Passed bool
+
+	// Results is nil if verification could not run (e.g. not a git repo).
+	Results *verify.Results
 }
 
 // IterationResult contains the outcome of a single iteration.
@@ -266,6 +317,10 @@ type IterationResult struct {
 	// IsTimeout indicates the iteration was terminated due to timeout.
 	// When true, Output may contain partial output captured before timeout.
 	IsTimeout bool
+
+	// TaskClosed reports whether the task was closed when the iteration
+	// ended. Only set when OnIterationEnd is.
+	TaskClosed bool
 }
 
 // NewEngine creates a new engine with the given dependencies.
@@ -285,6 +340,17 @@ func (e *Engine) EnableVerification() {
 	e.verifyEnabled = true
 }
 
+// SetVerifiers replaces the default GitVerifier with the given verifiers.
+func (e *Engine) SetVerifiers(verifiers ...verify.Verifier) {
+	e.verifiers = verifiers
+}
+
+// EnableBugOnFailure files a bug tick for each failed verifier, linked to
+// the task via discovered_from and blocking it until the bug is closed.
+func (e *Engine) EnableBugOnFailure(filer BugFiler) {
+	e.bugFiler = filer
+}
+
 // SetContextComponents sets the context store and generator for epic context.
 // When both are set, the engine will generate context before the first iteration
 // of an epic (if the epic has >1 children and context doesn't already exist).
@@ -293,9 +359,18 @@ func (e *Engine) SetContextComponents(store *epiccontext.Store, generator *epicc
 	e.contextGenerator = generator
 }
 
+// SetContextConfig applies the project's context config. When context is
+// disabled, generation is skipped entirely; existing context is still loaded.
+// Generator settings (timeout, max tokens, model) are applied when the
+// generator is built, see epiccontext.OptionsFromConfig.
+func (e *Engine) SetContextConfig(cfg *config.ContextConfig) {
+	e.contextDisabled = !cfg.IsEnabled()
+}
+
 // ensureEpicContext generates epic context if needed.
 // Context is generated when:
 //   - Context store and generator are configured
+//   - Context is not disabled in config
 //   - Context doesn't already exist for this epic
 //   - Epic has >1 children (no benefit for single-task epics)
 //
@@ -306,6 +381,17 @@ func (e *Engine) ensureEpicContext(ctx context.Context, epic *ticks.Epic) {
 		return
 	}
 
+	// Skip if context is disabled in config
+	if e.contextDisabled {
+		if e.runLog != nil {
+			e.runLog.LogContextSkipped(epic.ID, "disabled in config", 0)
+		}
+		if e.OnContextSkipped != nil {
+			e.OnContextSkipped(epic.ID, "disabled in config")
+		}
+		return
+	}
+
 	// Skip if context already exists - will be loaded in loadEpicContext
 	if e.contextStore.Exists(epic.ID) {
 		if e.runLog != nil {
@@ -505,6 +591,23 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 		})
 	}
 
+	// Bound the whole run, including agent iterations, idle watching and
+	// debounce waits. Cancellation is then handled like SIGINT.
+	if config.MaxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, config.MaxDuration, errMaxDuration)
+		defer cancel()
+	}
+
+	if config.BudgetReport {
+		defer func() {
+			if result != nil {
+				report := e.budget.Report()
+				result.Budget = &report
+			}
+		}()
+	}
+
 	// Calculate watch deadline (0 = unlimited)
 	var watchDeadline time.Time
 	if config.Watch && config.WatchTimeout > 0 {
@@ -538,29 +641,54 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 			return nil, fmt.Errorf("creating worktree manager: %w", err)
 		}
 
-		// Create worktree for this epic
-		wt, err = wtManager.Create(config.EpicID)
-		if err != nil {
-			// If worktree already exists, try to get it
-			if errors.Is(err, worktree.ErrWorktreeExists) {
-				wt, err = wtManager.Get(config.EpicID)
-				if err != nil {
-					return nil, fmt.Errorf("getting existing worktree: %w", err)
+		tickDir := filepath.Join(repoRoot, ".tick")
+		if config.ResumeWorktree {
+			// Reattach to the worktree recorded by the interrupted run
+			runState, err := worktree.LoadRunState(tickDir, config.EpicID)
+			if err != nil {
+				return nil, fmt.Errorf("loading run state: %w", err)
+			}
+			if runState == nil {
+				return nil, fmt.Errorf("no interrupted worktree run for epic %s", config.EpicID)
+			}
+			wt, err = wtManager.Attach(runState)
+			if err != nil {
+				return nil, fmt.Errorf("attaching to worktree: %w", err)
+			}
+			if e.runLog != nil {
+				e.runLog.LogWorktreeReused(wt.Path)
+			}
+		} else {
+			// Create worktree for this epic
+			wt, err = wtManager.Create(config.EpicID)
+			if err != nil {
+				// If worktree already exists, try to get it
+				if errors.Is(err, worktree.ErrWorktreeExists) {
+					wt, err = wtManager.Get(config.EpicID)
+					if err != nil {
+						return nil, fmt.Errorf("getting existing worktree: %w", err)
+					}
+					if e.runLog != nil {
+						e.runLog.LogWorktreeReused(wt.Path)
+					}
+				} else {
+					return nil, fmt.Errorf("creating worktree: %w", err)
 				}
+			} else {
 				if e.runLog != nil {
-					e.runLog.LogWorktreeReused(wt.Path)
+					e.runLog.LogWorktreeCreated(wt.Path)
 				}
-			} else {
-				return nil, fmt.Errorf("creating worktree: %w", err)
-			}
-		} else {
-			if e.runLog != nil {
-				e.runLog.LogWorktreeCreated(wt.Path)
 			}
 		}
 
+		// Record the worktree so an interrupted run can be resumed in it
+		if err := worktree.SaveRunState(tickDir, wt); err != nil {
+			return nil, fmt.Errorf("saving run state: %w", err)
+		}
+
 		// Set the work directory in state
 		state.workDir = wt.Path
+		state.worktreeBranch = wt.Branch
 
 		// Cleanup worktree based on exit reason when function returns.
 		// Only cleanup when epic is truly complete (all tasks done or no tasks found).
@@ -573,6 +701,7 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 				}
 				if shouldCleanup {
 					_ = wtManager.Remove(config.EpicID)
+					_ = worktree.RemoveRunState(tickDir, config.EpicID)
 				}
 			}
 		}()
@@ -645,8 +774,8 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 	for {
 		// Check context cancellation
 		if ctx.Err() != nil {
-			e.writeInterruptionNotes(state, config.EpicID)
-			return state.toResult("context cancelled", e.budget.Usage()), ctx.Err()
+			e.writeInterruptionNotes(ctx, state, config.EpicID)
+			return e.cancelledResult(ctx, state, "context cancelled")
 		}
 
 		// Check budget limits before starting iteration
@@ -677,8 +806,8 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 					for paused {
 						select {
 						case <-ctx.Done():
-							e.writeInterruptionNotes(state, config.EpicID)
-							return state.toResult("context cancelled while paused", e.budget.Usage()), ctx.Err()
+							e.writeInterruptionNotes(ctx, state, config.EpicID)
+							return e.cancelledResult(ctx, state, "context cancelled while paused")
 						case paused = <-config.PauseChan:
 						}
 					}
@@ -694,6 +823,10 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 		// Get next task with optional debounce
 		task, err := e.getNextTaskWithDebounce(ctx, config)
 		if err != nil {
+			if ctx.Err() != nil {
+				e.writeInterruptionNotes(ctx, state, config.EpicID)
+				return e.cancelledResult(ctx, state, "context cancelled")
+			}
 			return nil, fmt.Errorf("getting next task: %w", err)
 		}
 
@@ -772,10 +905,11 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 		iterResult := e.runIteration(ctx, state, task, config.AgentTimeout)
 
 		// Update budget
-		e.budget.Add(iterResult.TokensIn, iterResult.TokensOut, iterResult.Cost)
+		e.budget.AddForTask(task.ID, iterResult.TokensIn, iterResult.TokensOut, iterResult.Cost)
 
 		// Call callback
 		if e.OnIterationEnd != nil {
+			iterResult.TaskClosed, _ = e.wasTaskClosed(task.ID)
 			e.OnIterationEnd(iterResult)
 		}
 
@@ -885,6 +1019,7 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 					// Add epic note with failure details
 					note := buildVerificationFailureNote(state.iteration, task.ID, verifyResult)
 					_ = e.ticks.AddNote(config.EpicID, note)
+					e.fileVerificationBugs(task.ID, config.EpicID, verifyResult)
 					// Continue to next iteration - agent will see the failure in notes
 					continue
 				}
@@ -946,13 +1081,27 @@ func (e *Engine) Run(ctx context.Context, config RunConfig) (result *RunResult,
 		// Checkpoint if at interval
 		if config.CheckpointEvery > 0 && state.iteration%config.CheckpointEvery == 0 {
 			usage := e.budget.Usage()
-			cp := checkpoint.NewCheckpoint(
-				config.EpicID,
-				state.iteration,
-				usage.TotalTokens(),
-				usage.Cost,
-				state.completedTasks,
-			)
+			var cp *checkpoint.Checkpoint
+			if state.workDir != "" {
+				// Record the worktree so a rollback resets it, not the main repo
+				cp = checkpoint.NewCheckpointWithWorktree(
+					config.EpicID,
+					state.iteration,
+					usage.TotalTokens(),
+					usage.Cost,
+					state.completedTasks,
+					state.workDir,
+					state.worktreeBranch,
+				)
+			} else {
+				cp = checkpoint.NewCheckpoint(
+					config.EpicID,
+					state.iteration,
+					usage.TotalTokens(),
+					usage.Cost,
+					state.completedTasks,
+				)
+			}
 			if err := e.checkpoint.Save(cp); err != nil {
 				// Log but don't fail on checkpoint error
 				_ = e.ticks.AddNote(config.EpicID, fmt.Sprintf("Checkpoint error at iteration %d: %v", state.iteration, err))
@@ -982,7 +1131,8 @@ type runState struct {
 	currentTaskTitle string
 
 	// Worktree support
-	workDir string // Working directory for agent (worktree path or empty for current dir)
+	workDir        string // Working directory for agent (worktree path or empty for current dir)
+	worktreeBranch string // Branch of the worktree, if created by this run
 
 	// Epic context (pre-computed context for the epic, loaded once at start)
 	epicContext string
@@ -1012,13 +1162,9 @@ func (e *Engine) runIteration(ctx context.Context, state *runState, task *ticks.
 	}
 
 	// Mark task as in_progress before starting (enables crash recovery)
-	fmt.Fprintf(os.Stderr, "[DEBUG] Setting task %s status to in_progress\n", task.ID)
 	if err := e.ticks.SetStatus(task.ID, "in_progress"); err != nil {
-		// Log but continue - status update is not critical
-		fmt.Fprintf(os.Stderr, "[DEBUG] Failed to set status: %v\n", err)
+		// Note it but continue - status update is not critical
 		_ = e.ticks.AddNote(state.epicID, fmt.Sprintf("Warning: could not mark %s as in_progress: %v", task.ID, err))
-	} else {
-		fmt.Fprintf(os.Stderr, "[DEBUG] Successfully set task %s to in_progress\n", task.ID)
 	}
 
 	// Refresh epic to get latest notes
@@ -1078,22 +1224,25 @@ func (e *Engine) runIteration(ctx context.Context, state *runState, task *ticks.
 		WorkDir: state.workDir,
 	}
 
+	// Track the run in .live.json for external watchers (e.g., ticks board).
+	// The deferred finalize guarantees the live record is resolved however the
+	// run ends, including cancellation (SIGINT/SIGTERM) and panics.
+	var live *liveRecorder
+	if e.runRecordStore != nil {
+		live = &liveRecorder{store: e.runRecordStore, taskID: task.ID}
+		defer live.finalize(ctx)
+	}
+
 	// Set up rich streaming callback with live file tracking
 	// If runRecordStore is configured, we wrap the callback to also write .live.json
-	if e.OnAgentState != nil || e.runRecordStore != nil {
+	if e.OnAgentState != nil || live != nil {
 		opts.StateCallback = func(snap agent.AgentStateSnapshot) {
 			// Call user-provided callback if set
 			if e.OnAgentState != nil {
 				e.OnAgentState(snap)
 			}
-			// Write to .live.json file for external watchers (e.g., ticks board)
-			if e.runRecordStore != nil {
-				// Ignore write errors - live tracking is best-effort
-				if err := e.runRecordStore.WriteLive(task.ID, snap); err != nil {
-					fmt.Fprintf(os.Stderr, "[DEBUG] WriteLive error for %s: %v\n", task.ID, err)
-				} else {
-					fmt.Fprintf(os.Stderr, "[DEBUG] WriteLive success for %s (output len=%d)\n", task.ID, len(snap.Output))
-				}
+			if live != nil {
+				live.write(snap)
 			}
 		}
 	}
@@ -1115,9 +1264,9 @@ func (e *Engine) runIteration(ctx context.Context, state *runState, task *ticks.
 	agentResult, err := e.agent.Run(iterCtx2, prompt, opts)
 
 	// Finalize live record if store is configured
-	// This renames .live.json to .json (or deletes on error)
-	if e.runRecordStore != nil {
-		_ = e.runRecordStore.FinalizeLive(task.ID)
+	// This renames .live.json to .json before the run record is persisted
+	if live != nil {
+		live.finalize(ctx)
 	}
 
 	// Close stream channel
@@ -1163,6 +1312,45 @@ func (e *Engine) runIteration(ctx context.Context, state *runState, task *ticks.
 	return result
 }
 
+// liveRecorder writes a task's .live.json during an agent run and resolves it
+// into a final run record exactly once. Snapshots arriving after finalize are
+// dropped so a late callback can't leave a stale live file behind.
+type liveRecorder struct {
+	store  *runrecord.Store
+	taskID string
+
+	mu        sync.Mutex
+	finalized bool
+}
+
+// write records an agent state snapshot unless the run is already finalized.
+func (l *liveRecorder) write(snap agent.AgentStateSnapshot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.finalized {
+		return
+	}
+	// Ignore write errors - live tracking is best-effort
+	_ = l.store.WriteLive(l.taskID, snap)
+}
+
+// finalize promotes the live record to a final record. If ctx was cancelled
+// the agent never reported a terminal state, so the last snapshot is first
+// marked as interrupted. Safe to call more than once.
+func (l *liveRecorder) finalize(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.finalized {
+		return
+	}
+	l.finalized = true
+
+	if err := ctx.Err(); err != nil {
+		_ = l.store.MarkLiveInterrupted(l.taskID, fmt.Sprintf("run interrupted: %v", err))
+	}
+	_ = l.store.FinalizeLive(l.taskID)
+}
+
 // buildTimeoutNote creates a detailed note about a timeout for recovery.
 // Includes iteration number, task ID, timeout duration, and partial output summary.
 func buildTimeoutNote(iteration int, taskID string, timeout time.Duration, partialOutput string) string {
@@ -1186,23 +1374,40 @@ func buildTimeoutNote(iteration int, taskID string, timeout time.Duration, parti
 	return note
 }
 
-// writeInterruptionNotes writes notes to both the epic and current task when interrupted.
-func (e *Engine) writeInterruptionNotes(state *runState, epicID string) {
+// cancelledResult returns the result of a run stopped by ctx. Reaching
+// RunConfig.MaxDuration is a limit like the budget, so it ends the run with
+// ExitReasonMaxDuration and no error; any other cancellation returns reason
+// and ctx.Err().
+func (e *Engine) cancelledResult(ctx context.Context, state *runState, reason string) (*RunResult, error) {
+	if errors.Is(context.Cause(ctx), errMaxDuration) {
+		return state.toResult(ExitReasonMaxDuration, e.budget.Usage()), nil
+	}
+	return state.toResult(reason, e.budget.Usage()), ctx.Err()
+}
+
+// writeInterruptionNotes writes notes to both the epic and current task when
+// interrupted, by the user or by RunConfig.MaxDuration (read from ctx).
+func (e *Engine) writeInterruptionNotes(ctx context.Context, state *runState, epicID string) {
+	by := "by user"
+	if errors.Is(context.Cause(ctx), errMaxDuration) {
+		by = "at max duration"
+	}
+
 	if state.currentTaskID == "" {
 		// No task in progress, just write epic note
-		_ = e.ticks.AddNote(epicID, fmt.Sprintf("Run interrupted by user at iteration %d. No task was in progress.", state.iteration))
+		_ = e.ticks.AddNote(epicID, fmt.Sprintf("Run interrupted %s at iteration %d. No task was in progress.", by, state.iteration))
 		return
 	}
 
 	// Build interruption message
-	msg := fmt.Sprintf("Run interrupted by user at iteration %d while working on task %s (%s).",
-		state.iteration, state.currentTaskID, state.currentTaskTitle)
+	msg := fmt.Sprintf("Run interrupted %s at iteration %d while working on task %s (%s).",
+		by, state.iteration, state.currentTaskID, state.currentTaskTitle)
 
 	// Write note to epic
 	_ = e.ticks.AddNote(epicID, msg+" Task may be partially complete - review before continuing.")
 
 	// Write note to the interrupted task
-	_ = e.ticks.AddNote(state.currentTaskID, "Work on this task was interrupted by user. May be partially complete.")
+	_ = e.ticks.AddNote(state.currentTaskID, fmt.Sprintf("Work on this task was interrupted %s. May be partially complete.", by))
 }
 
 // wasTaskClosed checks if a task was closed by the agent.
@@ -1214,6 +1419,49 @@ func (e *Engine) wasTaskClosed(taskID string) (bool, error) {
 	return task.Status == "closed", nil
 }
 
+// VerifyOnly runs only the verification phase for the epic's closed tasks,
+// without invoking the agent. Failed verifications are reported in
+// RunResult.Verifications but tasks are not reopened. Passing tasks are listed
+// in CompletedTasks. Verification must be enabled via EnableVerification.
+func (e *Engine) VerifyOnly(ctx context.Context, config RunConfig) (*RunResult, error) {
+	if !e.verifyEnabled {
+		return nil, errors.New("verification is not enabled")
+	}
+
+	start := time.Now()
+	if _, err := e.ticks.GetEpic(config.EpicID); err != nil {
+		return nil, fmt.Errorf("getting epic: %w", err)
+	}
+	tasks, err := e.ticks.ListTasks(config.EpicID)
+	if err != nil {
+		return nil, fmt.Errorf("listing tasks: %w", err)
+	}
+
+	// Verify the repo itself; empty RepoRoot falls back to the working directory
+	workDir := config.RepoRoot
+
+	result := &RunResult{EpicID: config.EpicID, ExitReason: ExitReasonVerifyOnly}
+	for _, task := range tasks {
+		if task.Status != "closed" {
+			continue
+		}
+		if ctx.Err() != nil {
+			result.Duration = time.Since(start)
+			return result, ctx.Err()
+		}
+
+		results := e.runVerification(ctx, task.ID, "", config.EpicID, workDir)
+		tv := TaskVerification{TaskID: task.ID, Passed: results != nil && results.AllPassed, Results: results}
+		result.Verifications = append(result.Verifications, tv)
+		if tv.Passed {
+			result.CompletedTasks = append(result.CompletedTasks, task.ID)
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
 // runVerification executes verification for a completed task.
 // workDir specifies the directory to verify (worktree path or empty for cwd).
 // Returns nil if verification is not enabled or cannot run.
@@ -1231,21 +1479,25 @@ func (e *Engine) runVerification(ctx context.Context, taskID string, agentOutput
 		}
 	}
 
-	gitVerifier := verify.NewGitVerifier(dir)
-	if gitVerifier == nil {
-		return nil
-	}
+	verifiers := e.verifiers
+	if len(verifiers) == 0 {
+		gitVerifier := verify.NewGitVerifier(dir)
+		if gitVerifier == nil {
+			return nil
+		}
 
-	// Set baseline so only NEW uncommitted changes are flagged
-	if e.gitBaseline != nil {
-		gitVerifier.SetBaseline(e.gitBaseline)
+		// Set baseline so only NEW uncommitted changes are flagged
+		if e.gitBaseline != nil {
+			gitVerifier.SetBaseline(e.gitBaseline)
+		}
+		verifiers = []verify.Verifier{gitVerifier}
 	}
 
 	if e.OnVerificationStart != nil {
 		e.OnVerificationStart(taskID)
 	}
 
-	runner := verify.NewRunner(dir, gitVerifier)
+	runner := verify.NewRunner(dir, verifiers...)
 	results := runner.Run(ctx, taskID, agentOutput)
 
 	if e.OnVerificationEnd != nil {
@@ -1321,6 +1573,45 @@ func buildVerificationFailureNote(iteration int, taskID string, results *verify.
 	return sb.String()
 }
 
+// fileVerificationBugs files a bug tick for each failed verifier when bug filing is enabled.
+// Failures are reported as epic notes rather than interrupting the run.
+func (e *Engine) fileVerificationBugs(taskID, epicID string, results *verify.Results) {
+	if e.bugFiler == nil || results == nil {
+		return
+	}
+	for _, r := range results.FailedResults() {
+		title, description := buildVerificationBug(taskID, r)
+		bugID, created, err := e.bugFiler.FileVerificationBug(taskID, title, description)
+		if err != nil {
+			_ = e.ticks.AddNote(epicID, fmt.Sprintf("Warning: could not file verification bug for task %s: %v", taskID, err))
+			continue
+		}
+		if created {
+			_ = e.ticks.AddNote(epicID, fmt.Sprintf("Filed bug %s for failed %s verification of task %s.", bugID, r.Verifier, taskID))
+		}
+	}
+}
+
+// buildVerificationBug returns the title and description for a verification bug.
+// The title is stable per task and verifier so repeated failures dedupe onto one bug.
+func buildVerificationBug(taskID string, r *verify.Result) (string, string) {
+	title := fmt.Sprintf("Verification failed: %s check for %s", r.Verifier, taskID)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("The %s verifier failed after task %s was closed.\n", r.Verifier, taskID))
+	if r.Error != nil {
+		sb.WriteString(fmt.Sprintf("\nError: %v\n", r.Error))
+	}
+	if output := strings.TrimSpace(r.Output); output != "" {
+		const maxLen = 2000
+		if len(output) > maxLen {
+			output = output[:maxLen] + "\n..."
+		}
+		sb.WriteString("\nOutput:\n```\n" + output + "\n```\n")
+	}
+	return title, strings.TrimSuffix(sb.String(), "\n")
+}
+
 // verifyResultsToRecord converts verify.Results to agent.VerificationRecord for storage.
 func verifyResultsToRecord(results *verify.Results) *agent.VerificationRecord {
 	if results == nil {
@@ -1425,8 +1716,9 @@ func (e *Engine) handleWatchIdle(ctx context.Context, config RunConfig, state *r
 		// that might not trigger fsnotify events (e.g., NFS, some edge cases)
 		select {
 		case <-ctx.Done():
-			e.writeInterruptionNotes(state, config.EpicID)
-			return state.toResult("context cancelled while idle", e.budget.Usage())
+			e.writeInterruptionNotes(ctx, state, config.EpicID)
+			result, _ := e.cancelledResult(ctx, state, "context cancelled while idle")
+			return result
 
 		case <-fileChanges:
 			// File change detected - check for new tasks immediately
@@ -1453,8 +1745,9 @@ func (e *Engine) handleWatchIdle(ctx context.Context, config RunConfig, state *r
 			// becoming ready (e.g., after rejection) but files still settling.
 			select {
 			case <-ctx.Done():
-				e.writeInterruptionNotes(state, config.EpicID)
-				return state.toResult("context cancelled while idle", e.budget.Usage())
+				e.writeInterruptionNotes(ctx, state, config.EpicID)
+				result, _ := e.cancelledResult(ctx, state, "context cancelled while idle")
+				return result
 			case <-time.After(200 * time.Millisecond):
 			}
 			// Retry NextTask after delay
@@ -2,7 +2,11 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -10,6 +14,7 @@ import (
 	"github.com/pengelbrecht/ticks/internal/agent"
 	"github.com/pengelbrecht/ticks/internal/budget"
 	"github.com/pengelbrecht/ticks/internal/checkpoint"
+	"github.com/pengelbrecht/ticks/internal/runrecord"
 	"github.com/pengelbrecht/ticks/internal/ticks"
 	"github.com/pengelbrecht/ticks/internal/verify"
 )
@@ -424,6 +429,158 @@ func TestEngine_Run_BudgetExceeded(t *testing.T) {
 	}
 }
 
+func TestEngine_Run_BudgetReport(t *testing.T) {
+	dir := t.TempDir()
+	taskA := &ticks.Task{ID: "task-a", Title: "Task A", Parent: "epic-1"}
+	taskB := &ticks.Task{ID: "task-b", Title: "Task B", Parent: "epic-1"}
+
+	mockTicks := newMockTicksClient()
+	mockTicks.epic = &ticks.Epic{ID: "epic-1", Title: "Epic", Type: "epic"}
+	mockTicks.tasks = []*ticks.Task{taskA, taskB, taskA}
+
+	e := &Engine{
+		agent: &mockAgent{
+			name:      "test",
+			available: true,
+			responses: []mockResponse{
+				{output: "working on a", tokensIn: 100, tokensOut: 50, cost: 0.10},
+				{output: "working on b", tokensIn: 400, tokensOut: 200, cost: 0.60},
+				{output: "finished a", tokensIn: 300, tokensOut: 100, cost: 0.30},
+			},
+		},
+		ticks:      mockTicks,
+		budget:     budget.NewTracker(budget.Limits{MaxIterations: 10, MaxCost: 2.0}),
+		checkpoint: checkpoint.NewManagerWithDir(dir),
+		prompt:     NewPromptBuilder(),
+	}
+
+	result, err := e.Run(context.Background(), RunConfig{EpicID: "epic-1", MaxIterations: 10, MaxCost: 2.0, AgentTimeout: time.Second, BudgetReport: true})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Budget == nil {
+		t.Fatal("expected Budget to be set with BudgetReport")
+	}
+
+	tasks := result.Budget.Tasks
+	if len(tasks) != 2 || tasks[0].TaskID != "task-a" || tasks[1].TaskID != "task-b" {
+		t.Fatalf("Budget.Tasks = %+v, want task-a then task-b", tasks)
+	}
+	if tasks[0].Iterations != 2 || tasks[0].TokensIn+tasks[0].TokensOut != 550 {
+		t.Errorf("task-a = %+v, want 2 iterations and 550 tokens", tasks[0])
+	}
+
+	var iterations, tokens int
+	var cost float64
+	for _, task := range tasks {
+		iterations += task.Iterations
+		tokens += task.TokensIn + task.TokensOut
+		cost += task.Cost
+	}
+	if iterations != result.Iterations {
+		t.Errorf("sum of task iterations = %d, result.Iterations = %d", iterations, result.Iterations)
+	}
+	if tokens != result.TotalTokens {
+		t.Errorf("sum of task tokens = %d, result.TotalTokens = %d", tokens, result.TotalTokens)
+	}
+	if diff := cost - result.TotalCost; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("sum of task cost = %f, result.TotalCost = %f", cost, result.TotalCost)
+	}
+
+	// Without the flag the result carries no report
+	mockTicks.taskIndex = 0
+	e.agent.(*mockAgent).callCount = 0
+	result, err = e.Run(context.Background(), RunConfig{EpicID: "epic-1", MaxIterations: 10, AgentTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Budget != nil {
+		t.Errorf("Budget = %+v, want nil without BudgetReport", result.Budget)
+	}
+}
+
+// mockAgentSlow takes delay per run, or returns early if ctx is cancelled.
+type mockAgentSlow struct {
+	delay time.Duration
+	calls int
+}
+
+func (m *mockAgentSlow) Name() string    { return "slow" }
+func (m *mockAgentSlow) Available() bool { return true }
+
+func (m *mockAgentSlow) Run(ctx context.Context, prompt string, opts agent.RunOpts) (*agent.Result, error) {
+	m.calls++
+	select {
+	case <-time.After(m.delay):
+		return &agent.Result{Output: "working", TokensIn: 10, TokensOut: 5, Cost: 0.01, Duration: m.delay}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestEngine_Run_MaxDuration(t *testing.T) {
+	dir := t.TempDir()
+	mockTicks := newMockTicksClient()
+	mockTicks.epic = &ticks.Epic{ID: "epic-1", Title: "Epic", Type: "epic"}
+	for i := 0; i < 100; i++ {
+		mockTicks.tasks = append(mockTicks.tasks, &ticks.Task{ID: fmt.Sprintf("task-%d", i), Title: "Task", Parent: "epic-1"})
+	}
+
+	slow := &mockAgentSlow{delay: 10 * time.Millisecond}
+	e := &Engine{
+		agent:      slow,
+		ticks:      mockTicks,
+		budget:     budget.NewTracker(budget.Limits{MaxIterations: 100}),
+		checkpoint: checkpoint.NewManagerWithDir(dir),
+		prompt:     NewPromptBuilder(),
+	}
+
+	start := time.Now()
+	result, err := e.Run(context.Background(), RunConfig{
+		EpicID:        "epic-1",
+		MaxIterations: 100,
+		MaxDuration:   55 * time.Millisecond,
+		AgentTimeout:  time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v, want nil for max-duration", err)
+	}
+	if result.ExitReason != ExitReasonMaxDuration {
+		t.Errorf("ExitReason = %q, want %q", result.ExitReason, ExitReasonMaxDuration)
+	}
+	if result.Iterations == 0 || result.Iterations >= 100 {
+		t.Errorf("Iterations = %d, want some but not all", result.Iterations)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("run took %v, want it stopped near max duration", elapsed)
+	}
+	if ShouldCleanupWorktree(result.ExitReason) {
+		t.Error("max-duration runs should keep their worktree for resuming")
+	}
+
+	var noted bool
+	for _, n := range mockTicks.addedNotes {
+		if strings.Contains(n, "interrupted at max duration") {
+			noted = true
+		}
+	}
+	if !noted {
+		t.Errorf("expected an interruption note naming max duration, got %v", mockTicks.addedNotes)
+	}
+
+	// A cancelled parent context is still reported as an interruption
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockTicks.taskIndex = 0
+	result, err = e.Run(ctx, RunConfig{EpicID: "epic-1", MaxDuration: time.Minute, AgentTimeout: time.Second})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() with cancelled parent error = %v, want context.Canceled", err)
+	}
+	if result == nil || result.ExitReason != "context cancelled" {
+		t.Errorf("ExitReason = %v, want context cancelled", result)
+	}
+}
+
 func TestIterationResult_Fields(t *testing.T) {
 	result := &IterationResult{
 		Iteration:    1,
@@ -869,6 +1026,69 @@ func TestBuildVerificationFailureNote(t *testing.T) {
 	}
 }
 
+// mockBugFiler records FileVerificationBug calls and dedupes by title like ticks.Client.
+type mockBugFiler struct {
+	bugs  map[string]string // title -> bug ID
+	calls int
+}
+
+func (m *mockBugFiler) FileVerificationBug(taskID, title, description string) (string, bool, error) {
+	m.calls++
+	if id, ok := m.bugs[title]; ok {
+		return id, false, nil
+	}
+	id := fmt.Sprintf("bug%d", len(m.bugs)+1)
+	m.bugs[title] = id
+	return id, true, nil
+}
+
+func TestFileVerificationBugs(t *testing.T) {
+	mockTicks := newMockTicksClient()
+	filer := &mockBugFiler{bugs: make(map[string]string)}
+	eng := NewEngine(&mockAgent{}, mockTicks, nil, nil)
+
+	results := verify.NewResults([]*verify.Result{
+		{Verifier: "git", Passed: false, Output: "M  main.go"},
+	})
+
+	// Disabled by default
+	eng.fileVerificationBugs("task1", "epic1", results)
+	if filer.calls != 0 || len(mockTicks.addedNotes) != 0 {
+		t.Fatalf("expected no bug filing when disabled, got %d calls", filer.calls)
+	}
+
+	eng.EnableBugOnFailure(filer)
+	eng.fileVerificationBugs("task1", "epic1", results)
+	if len(filer.bugs) != 1 {
+		t.Fatalf("expected 1 bug, got %d", len(filer.bugs))
+	}
+	if len(mockTicks.addedNotes) != 1 || !strings.Contains(mockTicks.addedNotes[0], "Filed bug bug1") {
+		t.Errorf("expected filed-bug note, got %v", mockTicks.addedNotes)
+	}
+
+	// Second failure reuses the bug and adds no new note
+	eng.fileVerificationBugs("task1", "epic1", results)
+	if len(filer.bugs) != 1 {
+		t.Errorf("expected bug to be reused, got %d bugs", len(filer.bugs))
+	}
+	if len(mockTicks.addedNotes) != 1 {
+		t.Errorf("expected no additional note, got %v", mockTicks.addedNotes)
+	}
+}
+
+func TestBuildVerificationBug(t *testing.T) {
+	title, description := buildVerificationBug("abc", &verify.Result{
+		Verifier: "git",
+		Output:   strings.Repeat("M  file.go\n", 500),
+	})
+	if title != "Verification failed: git check for abc" {
+		t.Errorf("unexpected title %q", title)
+	}
+	if !strings.Contains(description, "M  file.go") || !strings.Contains(description, "...") {
+		t.Errorf("expected truncated output in description, got %q", description)
+	}
+}
+
 func TestRunConfig_SkipVerify(t *testing.T) {
 	// Test that SkipVerify field exists and defaults to false
 	config := RunConfig{
@@ -2464,3 +2684,152 @@ func TestGetNextTaskWithDebounce_NegativeDebounce(t *testing.T) {
 		t.Errorf("GetTask called %d times, want 0 (negative debounce = no debounce)", len(mock.getTaskCalls))
 	}
 }
+
+// mockVerifier fails for the task IDs in fail and records what it verified.
+type mockVerifier struct {
+	fail     map[string]bool
+	verified []string
+}
+
+func (m *mockVerifier) Name() string { return "mock" }
+
+func (m *mockVerifier) Verify(ctx context.Context, taskID string, agentOutput string) *verify.Result {
+	m.verified = append(m.verified, taskID)
+	return &verify.Result{Verifier: "mock", Passed: !m.fail[taskID]}
+}
+
+func TestEngine_VerifyOnly(t *testing.T) {
+	mockTicks := newMockTicksClient()
+	mockTicks.epic = &ticks.Epic{ID: "epic1", Title: "Epic"}
+	mockTicks.tasks = []*ticks.Task{
+		{ID: "t1", Title: "Done and clean", Status: "closed"},
+		{ID: "t2", Title: "Done but dirty", Status: "closed"},
+		{ID: "t3", Title: "Still open", Status: "open"},
+	}
+	mockAg := &mockAgent{name: "test", available: true}
+	verifier := &mockVerifier{fail: map[string]bool{"t2": true}}
+
+	e := NewEngine(mockAg, mockTicks, budget.NewTracker(budget.Limits{MaxIterations: 10}), checkpoint.NewManagerWithDir(t.TempDir()))
+
+	if _, err := e.VerifyOnly(context.Background(), RunConfig{EpicID: "epic1"}); err == nil {
+		t.Fatal("expected error when verification is not enabled")
+	}
+
+	e.EnableVerification()
+	e.SetVerifiers(verifier)
+
+	result, err := e.VerifyOnly(context.Background(), RunConfig{EpicID: "epic1", RepoRoot: t.TempDir()})
+	if err != nil {
+		t.Fatalf("VerifyOnly() error = %v", err)
+	}
+
+	if mockAg.callCount != 0 || result.Iterations != 0 || result.TotalCost != 0 {
+		t.Errorf("expected no agent iterations, got calls=%d iterations=%d cost=%v", mockAg.callCount, result.Iterations, result.TotalCost)
+	}
+	if result.ExitReason != ExitReasonVerifyOnly {
+		t.Errorf("ExitReason = %q, want %q", result.ExitReason, ExitReasonVerifyOnly)
+	}
+	if strings.Join(verifier.verified, ",") != "t1,t2" {
+		t.Errorf("verified %v, want closed tasks [t1 t2]", verifier.verified)
+	}
+	if len(result.Verifications) != 2 || !result.Verifications[0].Passed || result.Verifications[1].Passed {
+		t.Errorf("unexpected verifications: %+v", result.Verifications)
+	}
+	if len(result.CompletedTasks) != 1 || result.CompletedTasks[0] != "t1" {
+		t.Errorf("CompletedTasks = %v, want [t1]", result.CompletedTasks)
+	}
+	if len(mockTicks.setAwaitingCalls) != 0 || mockTicks.closedTasks["t2"] {
+		t.Error("verify-only should not change task state")
+	}
+
+	// No completed tasks: nothing to verify, still a result
+	mockTicks.tasks = []*ticks.Task{{ID: "t3", Title: "Still open", Status: "open"}}
+	result, err = e.VerifyOnly(context.Background(), RunConfig{EpicID: "epic1"})
+	if err != nil {
+		t.Fatalf("VerifyOnly() with no closed tasks error = %v", err)
+	}
+	if len(result.Verifications) != 0 || result.ExitReason != ExitReasonVerifyOnly {
+		t.Errorf("unexpected result for epic without closed tasks: %+v", result)
+	}
+}
+
+// mockAgentUntilCancelled reports a running snapshot, then blocks until its
+// context is cancelled, like an agent killed by SIGINT/SIGTERM.
+type mockAgentUntilCancelled struct {
+	started       chan struct{}
+	stateCallback func(agent.AgentStateSnapshot)
+}
+
+func (m *mockAgentUntilCancelled) Name() string    { return "until-cancelled" }
+func (m *mockAgentUntilCancelled) Available() bool { return true }
+
+func (m *mockAgentUntilCancelled) Run(ctx context.Context, prompt string, opts agent.RunOpts) (*agent.Result, error) {
+	m.stateCallback = opts.StateCallback
+	opts.StateCallback(agent.AgentStateSnapshot{
+		SessionID:  "sess-1",
+		StartedAt:  time.Now(),
+		Status:     agent.StatusToolUse,
+		ActiveTool: &agent.ToolActivity{Name: "Bash", StartedAt: time.Now()},
+		NumTurns:   2,
+	})
+	close(m.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestEngine_RunIteration_CancelFinalizesLiveRecord(t *testing.T) {
+	dir := t.TempDir()
+	store := runrecord.NewStore(dir)
+	mockAg := &mockAgentUntilCancelled{started: make(chan struct{})}
+	mockTicks := newMockTicksClient()
+	mockTicks.epic = &ticks.Epic{ID: "epic-1", Title: "Epic"}
+
+	e := &Engine{
+		agent:          mockAg,
+		ticks:          mockTicks,
+		prompt:         NewPromptBuilder(),
+		runRecordStore: store,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-mockAg.started
+		if !store.LiveExists("task-1") {
+			t.Error("expected live record while the agent is running")
+		}
+		cancel()
+	}()
+
+	state := &runState{epicID: "epic-1", iteration: 1, startTime: time.Now()}
+	result := e.runIteration(ctx, state, &ticks.Task{ID: "task-1", Title: "Task"}, time.Minute)
+	if result.Error == nil {
+		t.Fatal("expected cancelled iteration to report an error")
+	}
+
+	if store.LiveExists("task-1") {
+		t.Fatal("expected .live.json to be finalized after cancellation")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ".tick", "logs", "records", "task-1.json"))
+	if err != nil {
+		t.Fatalf("expected finalized record: %v", err)
+	}
+	var final runrecord.LiveRecord
+	if err := json.Unmarshal(data, &final); err != nil {
+		t.Fatalf("parse finalized record: %v", err)
+	}
+	if final.Status != string(agent.StatusInterrupted) {
+		t.Errorf("Status = %q, want %q", final.Status, agent.StatusInterrupted)
+	}
+	if final.ActiveTool != nil {
+		t.Errorf("expected no active tool on an interrupted record, got %+v", final.ActiveTool)
+	}
+	if !strings.Contains(final.ErrorMsg, "interrupted") || final.NumTurns != 2 {
+		t.Errorf("unexpected finalized record: %+v", final)
+	}
+
+	// A snapshot arriving after the run ended must not resurrect the live file
+	mockAg.stateCallback(agent.AgentStateSnapshot{Status: agent.StatusThinking})
+	if store.LiveExists("task-1") {
+		t.Error("expected late snapshot to be dropped after finalization")
+	}
+}
@@ -796,3 +796,112 @@ func TestWorktree_WorkDirPassedToAgent(t *testing.T) {
 		t.Errorf("WorkDir should be a valid git directory: %v", err)
 	}
 }
+
+// TestWorktree_ResumeAfterInterrupt tests that an interrupted worktree run
+// records its worktree and that ResumeWorktree continues in the same tree.
+func TestWorktree_ResumeAfterInterrupt(t *testing.T) {
+	repoRoot := createTempGitRepo(t)
+	tickDir := filepath.Join(repoRoot, ".tick")
+
+	mock := newHandoffMockTicksClient()
+	mock.setEpic("epic1", "Test Epic")
+	mock.addTask("task1", "First task")
+	mock.addTask("task2", "Second task")
+
+	agentMock := newWorktreeMockAgent()
+	checkpointDir := t.TempDir()
+
+	// First run: finish task1, then get interrupted
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first := NewEngine(agentMock, mock, budget.NewTracker(budget.Limits{MaxIterations: 10}), checkpoint.NewManagerWithDir(checkpointDir))
+	first.OnIterationEnd = func(result *IterationResult) {
+		mock.CloseTask("task1", "Completed")
+		cancel()
+	}
+	_, _ = first.Run(ctx, RunConfig{
+		EpicID:      "epic1",
+		UseWorktree: true,
+		RepoRoot:    repoRoot,
+	})
+
+	state, err := worktree.LoadRunState(tickDir, "epic1")
+	if err != nil {
+		t.Fatalf("LoadRunState() error = %v", err)
+	}
+	if state == nil {
+		t.Fatal("interrupted run should leave run state behind")
+	}
+	if len(agentMock.lastWorkDirs) != 1 {
+		t.Fatalf("agent calls after interrupt = %d, want 1", len(agentMock.lastWorkDirs))
+	}
+
+	// Second run: resume and finish task2
+	second := NewEngine(agentMock, mock, budget.NewTracker(budget.Limits{MaxIterations: 10}), checkpoint.NewManagerWithDir(checkpointDir))
+	second.OnIterationEnd = func(result *IterationResult) {
+		mock.CloseTask("task2", "Completed")
+	}
+	result, err := second.Run(context.Background(), RunConfig{
+		EpicID:         "epic1",
+		UseWorktree:    true,
+		ResumeWorktree: true,
+		RepoRoot:       repoRoot,
+	})
+	if err != nil {
+		t.Fatalf("resumed engine.Run() error = %v", err)
+	}
+	if result.ExitReason != ExitReasonAllTasksCompleted {
+		t.Errorf("ExitReason = %q, want %q", result.ExitReason, ExitReasonAllTasksCompleted)
+	}
+
+	if len(agentMock.lastWorkDirs) != 2 {
+		t.Fatalf("agent calls = %d, want 2", len(agentMock.lastWorkDirs))
+	}
+	if agentMock.lastWorkDirs[1] != agentMock.lastWorkDirs[0] {
+		t.Errorf("resumed WorkDir = %q, want the interrupted worktree %q", agentMock.lastWorkDirs[1], agentMock.lastWorkDirs[0])
+	}
+	if !strings.Contains(agentMock.lastPrompts[1], "task2") {
+		t.Error("resumed run should work on the remaining task")
+	}
+
+	// Success removes the state file along with the worktree
+	if state, _ := worktree.LoadRunState(tickDir, "epic1"); state != nil {
+		t.Error("run state should be removed after the epic completes")
+	}
+	wtManager, err := worktree.NewManager(repoRoot)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if wtManager.Exists("epic1") {
+		t.Error("worktree should be cleaned up after epic completion")
+	}
+}
+
+// TestWorktree_ResumeWithoutRunState tests that resuming an epic that has no
+// interrupted run fails instead of creating a fresh worktree.
+func TestWorktree_ResumeWithoutRunState(t *testing.T) {
+	repoRoot := createTempGitRepo(t)
+
+	mock := newHandoffMockTicksClient()
+	mock.setEpic("epic1", "Test Epic")
+	mock.addTask("task1", "First task")
+
+	engine := NewEngine(newWorktreeMockAgent(), mock, budget.NewTracker(budget.Limits{MaxIterations: 10}), checkpoint.NewManagerWithDir(t.TempDir()))
+	_, err := engine.Run(context.Background(), RunConfig{
+		EpicID:         "epic1",
+		UseWorktree:    true,
+		ResumeWorktree: true,
+		RepoRoot:       repoRoot,
+	})
+	if err == nil || !strings.Contains(err.Error(), "no interrupted worktree run") {
+		t.Fatalf("engine.Run() error = %v, want no interrupted worktree run", err)
+	}
+
+	wtManager, err := worktree.NewManager(repoRoot)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if wtManager.Exists("epic1") {
+		t.Error("resume without run state should not create a worktree")
+	}
+}
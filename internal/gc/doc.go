@@ -1,7 +1,7 @@
 // Package gc provides garbage collection for log files in the .tick directory.
 // It cleans up old log files to prevent unbounded growth of:
 //   - .tick/activity/activity.jsonl (trims old entries)
-//   - .tick/logs/records/*.json (deletes old run records)
+//   - .tick/logs/records/*.json (deletes old run records, optionally by outcome)
 //   - .tick/logs/runs/*.jsonl (deletes old run logs)
 //   - .tick/logs/checkpoints/*.json (deletes old checkpoints)
 //   - .tick/logs/context/*.md (deletes old context files)
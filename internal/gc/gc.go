@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -25,6 +26,23 @@ type Result struct {
 	Errors []error
 }
 
+// RecordRetention controls how run records are pruned based on their outcome.
+// Each tick has a single record file, so KeepSuccessful counts records across
+// ticks rather than runs of one tick. Zero values fall back to the cleaner's
+// max age (for the age fields) or keep nothing extra (for KeepSuccessful).
+type RecordRetention struct {
+	// SuccessMaxAge is how long successful run records are kept.
+	SuccessMaxAge time.Duration
+	// FailureMaxAge is how long failed run records are kept.
+	FailureMaxAge time.Duration
+	// KeepSuccessful is how many of the most recent successful records are
+	// kept regardless of age.
+	KeepSuccessful int
+	// KeepLast is how many of the most recent records, of any outcome, are
+	// kept regardless of age.
+	KeepLast int
+}
+
 // Cleaner handles garbage collection for log files.
 type Cleaner struct {
 	// tickRoot is the root directory containing .tick/
@@ -35,6 +53,8 @@ type Cleaner struct {
 	dryRun bool
 	// now is the current time (for testing)
 	now time.Time
+	// retention if set, prunes run records by outcome instead of by maxAge alone
+	retention *RecordRetention
 }
 
 // NewCleaner creates a new garbage collector.
@@ -64,12 +84,23 @@ func (c *Cleaner) WithNow(t time.Time) *Cleaner {
 	return c
 }
 
+// WithRecordRetention sets an outcome-aware retention policy for run records.
+func (c *Cleaner) WithRecordRetention(r RecordRetention) *Cleaner {
+	c.retention = &r
+	return c
+}
+
 // Cleanup runs garbage collection on all target directories.
 func (c *Cleaner) Cleanup() (*Result, error) {
 	result := &Result{}
 
 	// Clean each directory type
-	c.cleanDirectory(filepath.Join(c.tickRoot, ".tick", "logs", "records"), ".json", result)
+	recordsDir := filepath.Join(c.tickRoot, ".tick", "logs", "records")
+	if c.retention != nil {
+		c.cleanRunRecords(recordsDir, result)
+	} else {
+		c.cleanDirectory(recordsDir, ".json", result)
+	}
 	c.cleanDirectory(filepath.Join(c.tickRoot, ".tick", "logs", "runs"), ".jsonl", result)
 	c.cleanDirectory(filepath.Join(c.tickRoot, ".tick", "logs", "checkpoints"), ".json", result)
 	c.cleanDirectory(filepath.Join(c.tickRoot, ".tick", "logs", "context"), ".md", result)
@@ -139,6 +170,121 @@ func (c *Cleaner) cleanDirectory(dir, ext string, result *Result) {
 	}
 }
 
+// recordEntry holds the run record fields needed for retention decisions.
+type recordEntry struct {
+	Success bool      `json:"success"`
+	EndedAt time.Time `json:"ended_at"`
+}
+
+// runRecordFile is a run record file with its parsed outcome and age.
+type runRecordFile struct {
+	path    string
+	size    int64
+	success bool
+	parsed  bool
+	endedAt time.Time
+}
+
+// cleanRunRecords deletes run records according to the retention policy.
+// Failed and successful records have separate age limits, and the most recent
+// KeepLast records and KeepSuccessful successful records are always kept. Records that cannot be
+// parsed fall back to the cleaner's max age, using the file modification time.
+func (c *Cleaner) cleanRunRecords(dir string, result *Result) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return
+		}
+		result.Errors = append(result.Errors, fmt.Errorf("reading %s: %w", dir, err))
+		return
+	}
+
+	var records []runRecordFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") || isLiveFile(name) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("stat %s: %w", name, err))
+			continue
+		}
+
+		rec := runRecordFile{
+			path:    filepath.Join(dir, name),
+			size:    info.Size(),
+			endedAt: info.ModTime(),
+		}
+		if data, err := os.ReadFile(rec.path); err == nil {
+			var re recordEntry
+			if json.Unmarshal(data, &re) == nil {
+				rec.parsed = true
+				rec.success = re.Success
+				if !re.EndedAt.IsZero() {
+					rec.endedAt = re.EndedAt
+				}
+			}
+		}
+		records = append(records, rec)
+	}
+
+	// Protect the most recent records, and the most recent successful ones.
+	keep := make(map[string]bool)
+	if c.retention.KeepLast > 0 {
+		recent := append([]runRecordFile(nil), records...)
+		sort.Slice(recent, func(i, j int) bool {
+			return recent[i].endedAt.After(recent[j].endedAt)
+		})
+		for i := 0; i < len(recent) && i < c.retention.KeepLast; i++ {
+			keep[recent[i].path] = true
+		}
+	}
+	if c.retention.KeepSuccessful > 0 {
+		var successes []runRecordFile
+		for _, rec := range records {
+			if rec.parsed && rec.success {
+				successes = append(successes, rec)
+			}
+		}
+		sort.Slice(successes, func(i, j int) bool {
+			return successes[i].endedAt.After(successes[j].endedAt)
+		})
+		for i := 0; i < len(successes) && i < c.retention.KeepSuccessful; i++ {
+			keep[successes[i].path] = true
+		}
+	}
+
+	for _, rec := range records {
+		if keep[rec.path] {
+			continue
+		}
+
+		maxAge := c.maxAge
+		switch {
+		case !rec.parsed:
+		case rec.success && c.retention.SuccessMaxAge > 0:
+			maxAge = c.retention.SuccessMaxAge
+		case !rec.success && c.retention.FailureMaxAge > 0:
+			maxAge = c.retention.FailureMaxAge
+		}
+		if rec.endedAt.After(c.now.Add(-maxAge)) {
+			continue
+		}
+
+		if !c.dryRun {
+			if err := os.Remove(rec.path); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("delete %s: %w", rec.path, err))
+				continue
+			}
+		}
+
+		result.FilesDeleted++
+		result.BytesFreed += rec.size
+	}
+}
+
 // activityEntry represents a single entry in activity.jsonl for timestamp parsing.
 type activityEntry struct {
 	TS time.Time `json:"ts"`
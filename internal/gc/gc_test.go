@@ -1,6 +1,7 @@
 package gc
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -375,6 +376,143 @@ func TestCleaner_BytesFreed(t *testing.T) {
 	}
 }
 
+func TestCleaner_RecordRetention(t *testing.T) {
+	tickRoot := t.TempDir()
+	recordsDir := filepath.Join(tickRoot, ".tick", "logs", "records")
+	if err := os.MkdirAll(recordsDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	now := time.Now()
+	days := func(n int) time.Time { return now.Add(-time.Duration(n) * 24 * time.Hour) }
+
+	writeRecord := func(name string, success bool, endedAt time.Time) string {
+		t.Helper()
+		path := filepath.Join(recordsDir, name+".json")
+		data := fmt.Sprintf(`{"success":%t,"ended_at":%q}`, success, endedAt.Format(time.RFC3339))
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write record: %v", err)
+		}
+		// File mtime is recent; retention must use ended_at
+		return path
+	}
+
+	newestOK := writeRecord("ok1", true, days(20))
+	secondOK := writeRecord("ok2", true, days(25))
+	oldOK := writeRecord("ok3", true, days(30))
+	freshOK := writeRecord("ok4", true, days(2))
+	failRecent := writeRecord("fail1", false, days(40))
+	failAncient := writeRecord("fail2", false, days(120))
+
+	// Unparsable record falls back to maxAge on mtime
+	garbage := filepath.Join(recordsDir, "garbage.json")
+	if err := os.WriteFile(garbage, []byte("not json"), 0644); err != nil {
+		t.Fatalf("Failed to write garbage: %v", err)
+	}
+	os.Chtimes(garbage, days(60), days(60))
+
+	cleaner := NewCleaner(tickRoot).
+		WithMaxAge(30 * 24 * time.Hour).
+		WithNow(now).
+		WithRecordRetention(RecordRetention{
+			SuccessMaxAge:  7 * 24 * time.Hour,
+			FailureMaxAge:  90 * 24 * time.Hour,
+			KeepSuccessful: 2,
+		})
+	result, err := cleaner.Cleanup()
+	if err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+
+	kept := []string{freshOK, newestOK, failRecent}
+	deleted := []string{secondOK, oldOK, failAncient, garbage}
+
+	for _, path := range kept {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s should have been kept", filepath.Base(path))
+		}
+	}
+	for _, path := range deleted {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s should have been deleted", filepath.Base(path))
+		}
+	}
+	if result.FilesDeleted != len(deleted) {
+		t.Errorf("Expected %d files deleted, got %d", len(deleted), result.FilesDeleted)
+	}
+}
+
+func TestCleaner_KeepLast(t *testing.T) {
+	tickRoot := t.TempDir()
+	recordsDir := filepath.Join(tickRoot, ".tick", "logs", "records")
+	if err := os.MkdirAll(recordsDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	now := time.Now()
+	days := func(n int) time.Time { return now.Add(-time.Duration(n) * 24 * time.Hour) }
+	writeRecord := func(name string, success bool, endedAt time.Time) string {
+		t.Helper()
+		path := filepath.Join(recordsDir, name+".json")
+		data := fmt.Sprintf(`{"success":%t,"ended_at":%q}`, success, endedAt.Format(time.RFC3339))
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write record: %v", err)
+		}
+		return path
+	}
+
+	fresh := writeRecord("fresh", true, days(1))
+	// Older than the cutoff, but among the two most recent records
+	oldFail := writeRecord("old-fail", false, days(40))
+	older := writeRecord("older", true, days(50))
+	oldest := writeRecord("oldest", false, days(60))
+
+	tests := []struct {
+		name     string
+		keepLast int
+		deleted  int
+	}{
+		{"age only", 0, 3},
+		{"keep last protects old records", 2, 2},
+		{"keep last beyond count keeps all", 10, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewCleaner(tickRoot).
+				WithMaxAge(30 * 24 * time.Hour).
+				WithNow(now).
+				WithDryRun(true).
+				WithRecordRetention(RecordRetention{KeepLast: tt.keepLast}).
+				Cleanup()
+			if err != nil {
+				t.Fatalf("Cleanup failed: %v", err)
+			}
+			if result.FilesDeleted != tt.deleted {
+				t.Errorf("Expected %d files deleted, got %d", tt.deleted, result.FilesDeleted)
+			}
+		})
+	}
+
+	// Delete for real with keep-last and check which files remain
+	if _, err := NewCleaner(tickRoot).
+		WithMaxAge(30 * 24 * time.Hour).
+		WithNow(now).
+		WithRecordRetention(RecordRetention{KeepLast: 2}).
+		Cleanup(); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	for _, path := range []string{fresh, oldFail} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s should have been kept", filepath.Base(path))
+		}
+	}
+	for _, path := range []string{older, oldest} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s should have been deleted", filepath.Base(path))
+		}
+	}
+}
+
 func TestCleanup_ConvenienceFunction(t *testing.T) {
 	dir := t.TempDir()
 	tickRoot := dir
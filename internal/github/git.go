@@ -37,17 +37,24 @@ func EnsureGitAttributes(repoRoot string) error {
 }
 
 // ConfigureMergeDriver sets the local git merge driver configuration.
+// Keys that already hold the expected value are left untouched.
 func ConfigureMergeDriver(repoRoot string) error {
 	if err := runGitConfig(repoRoot, "merge.tick.name", "tick JSON merge"); err != nil {
 		return err
 	}
-	if err := runGitConfig(repoRoot, "merge.tick.driver", "tk merge-file %O %A %B %P"); err != nil {
+	if err := runGitConfig(repoRoot, "merge.tick.driver", "tk merge-driver %O %A %B %P"); err != nil {
 		return err
 	}
 	return nil
 }
 
 func runGitConfig(repoRoot, key, value string) error {
+	get := exec.Command("git", "config", "--local", "--get", key)
+	get.Dir = repoRoot
+	if out, err := get.Output(); err == nil && strings.TrimSuffix(string(out), "\n") == value {
+		return nil
+	}
+
 	cmd := exec.Command("git", "config", key, value)
 	cmd.Dir = repoRoot
 	if err := cmd.Run(); err != nil {
@@ -2,6 +2,7 @@ package github
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 )
@@ -55,3 +56,26 @@ func splitLines(contents string) []string {
 	}
 	return lines
 }
+
+func TestConfigureMergeDriver(t *testing.T) {
+	dir := t.TempDir()
+	if err := exec.Command("git", "init", "-q", dir).Run(); err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := ConfigureMergeDriver(dir); err != nil {
+			t.Fatalf("configure (run %d): %v", i+1, err)
+		}
+	}
+
+	cmd := exec.Command("git", "config", "--local", "--get-all", "merge.tick.driver")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git config: %v", err)
+	}
+	if got := string(out); got != "tk merge-driver %O %A %B %P\n" {
+		t.Fatalf("expected single driver entry, got %q", got)
+	}
+}
@@ -6,21 +6,22 @@ import (
 )
 
 // NormalizeID accepts short or global IDs and returns the short ID.
+// Type-prefixed IDs (e.g. "bug-a1b") are short IDs and are returned as-is.
+// Global IDs for GitHub projects may include the host, so
+// "github.com/owner/repo:a1b" matches project "owner/repo".
 func NormalizeID(project, input string) (string, error) {
 	input = strings.TrimSpace(input)
 	if input == "" {
 		return "", fmt.Errorf("id is required")
 	}
-
 	parts := strings.SplitN(input, ":", 2)
 	if len(parts) == 1 {
 		return input, nil
 	}
-
 	if project == "" {
 		return "", fmt.Errorf("project is required for global ids")
 	}
-	if parts[0] != project {
+	if !sameProject(parts[0], project) {
 		return "", fmt.Errorf("global id project mismatch: %s", parts[0])
 	}
 	if parts[1] == "" {
@@ -28,3 +29,26 @@ func NormalizeID(project, input string) (string, error) {
 	}
 	return parts[1], nil
 }
+
+// GlobalID returns the global form "project:id" of a tick id.
+func GlobalID(project, id string) string {
+	return project + ":" + id
+}
+
+// sameProject reports whether two project identifiers name the same project,
+// treating hosts case-insensitively and "github.com/owner/repo" as "owner/repo".
+func sameProject(a, b string) bool {
+	return canonicalProject(a) == canonicalProject(b)
+}
+
+func canonicalProject(project string) string {
+	host, path, ok := strings.Cut(project, "/")
+	if !ok || !strings.Contains(path, "/") {
+		return project
+	}
+	host = strings.ToLower(host)
+	if host == githubHost {
+		return path
+	}
+	return host + "/" + path
+}
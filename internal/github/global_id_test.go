@@ -1,6 +1,12 @@
 package github
 
-import "testing"
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
 
 func TestNormalizeID(t *testing.T) {
 	project := "petere/chefswiz"
@@ -26,3 +32,72 @@ func TestNormalizeID(t *testing.T) {
 		t.Fatalf("expected mismatch error")
 	}
 }
+
+func TestNormalizeIDHostAware(t *testing.T) {
+	cases := []struct {
+		project string
+		input   string
+		want    string
+		ok      bool
+	}{
+		{"petere/chefswiz", "github.com/petere/chefswiz:a1b", "a1b", true},
+		{"petere/chefswiz", "GitHub.com/petere/chefswiz:a1b", "a1b", true},
+		{"gitlab.com/group/sub/repo", "gitlab.com/group/sub/repo:a1b", "a1b", true},
+		{"gitlab.com/group/sub/repo", "GitLab.com/group/sub/repo:a1b", "a1b", true},
+		{"bitbucket.org/team/repo", "bitbucket.org/team/repo:a1b", "a1b", true},
+		{"gitlab.com/petere/chefswiz", "petere/chefswiz:a1b", "", false},
+		{"petere/chefswiz", "gitlab.com/petere/chefswiz:a1b", "", false},
+		{"gitlab.com/group/sub/repo", "gitlab.com/group/repo:a1b", "", false},
+	}
+
+	for _, tc := range cases {
+		got, err := NormalizeID(tc.project, tc.input)
+		if tc.ok && err != nil {
+			t.Fatalf("NormalizeID(%q, %q): unexpected error: %v", tc.project, tc.input, err)
+		}
+		if !tc.ok && err == nil {
+			t.Fatalf("NormalizeID(%q, %q): expected mismatch error", tc.project, tc.input)
+		}
+		if got != tc.want {
+			t.Errorf("NormalizeID(%q, %q) = %q, want %q", tc.project, tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestGlobalIDRoundTrip(t *testing.T) {
+	for _, project := range []string{"petere/chefswiz", "gitlab.com/group/sub/repo", "bitbucket.org/team/repo"} {
+		global := GlobalID(project, "a1b")
+		id, err := NormalizeID(project, global)
+		if err != nil {
+			t.Fatalf("NormalizeID(%q): %v", global, err)
+		}
+		if id != "a1b" {
+			t.Errorf("NormalizeID(%q) = %q, want a1b", global, id)
+		}
+	}
+}
+
+func TestNormalizeIDPrefixedRoundTrip(t *testing.T) {
+	project := "petere/chefswiz"
+	gen := tick.NewIDGenerator(rand.New(rand.NewSource(3)))
+
+	for _, prefix := range []string{"bug", "epic", ""} {
+		generated, _, err := gen.GenerateWithPrefix(func(string) bool { return false }, 3, prefix)
+		if err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		if prefix != "" && !strings.HasPrefix(generated, prefix+"-") {
+			t.Fatalf("expected %s- prefix, got %q", prefix, generated)
+		}
+
+		for _, input := range []string{generated, " " + generated + " ", project + ":" + generated} {
+			id, err := NormalizeID(project, input)
+			if err != nil {
+				t.Fatalf("NormalizeID(%q): %v", input, err)
+			}
+			if id != generated {
+				t.Errorf("NormalizeID(%q) = %q, want %q", input, id, generated)
+			}
+		}
+	}
+}
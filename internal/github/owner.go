@@ -18,12 +18,12 @@ func DetectOwner(run CommandRunner) (string, error) {
 
 	out, err := run("git", "config", "user.email")
 	if err != nil {
-		return "", fmt.Errorf("failed to resolve owner via git config user.email: %w", err)
+		return "", &DetectError{Err: fmt.Errorf("failed to resolve owner via git config user.email: %w", err)}
 	}
 
 	owner := strings.TrimSpace(string(out))
 	if owner == "" {
-		return "", fmt.Errorf("git config user.email returned empty owner")
+		return "", &DetectError{Err: fmt.Errorf("git config user.email returned empty owner")}
 	}
 
 	return owner, nil
@@ -5,24 +5,93 @@ import (
 	"strings"
 )
 
-const (
-	sshPrefix   = "git@github.com:"
-	httpsPrefix = "https://github.com/"
-)
+// githubHost is the host whose projects keep the short "owner/repo" form.
+const githubHost = "github.com"
 
-// ParseProjectFromRemote extracts owner/repo from a git remote URL.
-func ParseProjectFromRemote(remote string) (string, error) {
+// Remote is a git remote split into its host and repository path.
+type Remote struct {
+	// Host is the lowercased host name without user or port, e.g. "gitlab.com".
+	Host string
+	// Path is the repository path on the host, e.g. "owner/repo" or
+	// "group/subgroup/repo" for nested GitLab groups.
+	Path string
+}
+
+// Project returns the project identifier for the remote. GitHub remotes keep
+// the plain "owner/repo" form so existing ids are unchanged; other hosts are
+// qualified as "host/path" so they cannot collide with GitHub projects.
+func (r Remote) Project() string {
+	if r.Host == githubHost {
+		return r.Path
+	}
+	return r.Host + "/" + r.Path
+}
+
+// ParseRemote parses a git remote URL in SSH (git@host:path), ssh:// or
+// https:// form.
+func ParseRemote(remote string) (Remote, error) {
 	remote = strings.TrimSpace(remote)
-	switch {
-	case strings.HasPrefix(remote, sshPrefix):
-		return parsePath(strings.TrimPrefix(remote, sshPrefix))
-	case strings.HasPrefix(remote, httpsPrefix):
-		return parsePath(strings.TrimPrefix(remote, httpsPrefix))
-	default:
-		return "", fmt.Errorf("unsupported remote format: %s", remote)
+
+	var hostPart, path string
+	if scheme, rest, ok := strings.Cut(remote, "://"); ok {
+		if scheme == "" {
+			return Remote{}, fmt.Errorf("unsupported remote format: %s", remote)
+		}
+		hostPart, path, ok = strings.Cut(rest, "/")
+		if !ok {
+			return Remote{}, fmt.Errorf("invalid remote path: %s", remote)
+		}
+		// Drop credentials (user:token@) and the port (host:2222)
+		if i := strings.LastIndex(hostPart, "@"); i != -1 {
+			hostPart = hostPart[i+1:]
+		}
+		if i := strings.LastIndex(hostPart, ":"); i != -1 {
+			hostPart = hostPart[:i]
+		}
+	} else {
+		var ok bool
+		hostPart, path, ok = strings.Cut(remote, ":")
+		if !ok {
+			return Remote{}, fmt.Errorf("unsupported remote format: %s", remote)
+		}
+		// Drop the ssh user (git@)
+		if i := strings.LastIndex(hostPart, "@"); i != -1 {
+			hostPart = hostPart[i+1:]
+		}
+	}
+
+	host := strings.ToLower(hostPart)
+	if host == "" {
+		return Remote{}, fmt.Errorf("unsupported remote format: %s", remote)
+	}
+
+	path, err := parsePath(host, path)
+	if err != nil {
+		return Remote{}, err
 	}
+	return Remote{Host: host, Path: path}, nil
 }
 
+// ParseProjectFromRemote extracts the project identifier from a git remote URL:
+// "owner/repo" for GitHub, "host/path" for other hosts.
+func ParseProjectFromRemote(remote string) (string, error) {
+	r, err := ParseRemote(remote)
+	if err != nil {
+		return "", err
+	}
+	return r.Project(), nil
+}
+
+// DetectError reports that the project or owner could not be detected from
+// git. Callers can use errors.As to tell it apart from other failures.
+type DetectError struct {
+	Err error
+}
+
+func (e *DetectError) Error() string { return e.Err.Error() }
+
+func (e *DetectError) Unwrap() error { return e.Err }
+
 // DetectProject resolves the current git remote project via origin.
 func DetectProject(run CommandRunner) (string, error) {
 	if run == nil {
@@ -30,25 +99,29 @@ func DetectProject(run CommandRunner) (string, error) {
 	}
 	out, err := run("git", "remote", "get-url", "origin")
 	if err != nil {
-		return "", fmt.Errorf("failed to read git remote: %w", err)
+		return "", &DetectError{Err: fmt.Errorf("failed to read git remote: %w", err)}
 	}
 	project, err := ParseProjectFromRemote(string(out))
 	if err != nil {
-		return "", err
+		return "", &DetectError{Err: err}
 	}
 	return project, nil
 }
 
-func parsePath(path string) (string, error) {
-	path = strings.TrimSuffix(path, ".git")
+func parsePath(host, path string) (string, error) {
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
 	parts := strings.Split(path, "/")
 	if len(parts) < 2 {
 		return "", fmt.Errorf("invalid remote path: %s", path)
 	}
-	owner := parts[0]
-	repo := parts[1]
-	if owner == "" || repo == "" {
-		return "", fmt.Errorf("invalid remote path: %s", path)
+	// GitHub paths are always owner/repo; other hosts may nest groups
+	if host == githubHost {
+		parts = parts[:2]
+	}
+	for _, part := range parts {
+		if part == "" {
+			return "", fmt.Errorf("invalid remote path: %s", path)
+		}
 	}
-	return owner + "/" + repo, nil
+	return strings.Join(parts, "/"), nil
 }
@@ -0,0 +1,191 @@
+package merge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+// ThreeWay merges two edits of a tick against their common base, field by
+// field. A field changed on one side only takes that side's value; a field
+// changed on both takes the value from the side updated most recently.
+// status, closed_at and closed_reason are merged as one field, so a result
+// never pairs one side's status with the other side's close details.
+// Labels and blocked_by are merged as sets, so additions from both sides are
+// kept and an entry removed on one side stays removed. Notes keep every line
+// of ours followed by the lines of theirs that ours doesn't already have.
+// updated_at is the later of the two.
+//
+// base may be the zero Tick when both sides added the file.
+func ThreeWay(base, ours, theirs tick.Tick) (tick.Tick, error) {
+	baseFields, err := fields(base)
+	if err != nil {
+		return tick.Tick{}, err
+	}
+	ourFields, err := fields(ours)
+	if err != nil {
+		return tick.Tick{}, err
+	}
+	theirFields, err := fields(theirs)
+	if err != nil {
+		return tick.Tick{}, err
+	}
+
+	newer := ourFields
+	if theirs.UpdatedAt.After(ours.UpdatedAt) {
+		newer = theirFields
+	}
+
+	lifecycle := lifecycleSide(baseFields, ourFields, theirFields, newer)
+
+	merged := make(map[string]json.RawMessage)
+	for _, name := range fieldNames(baseFields, ourFields, theirFields) {
+		if slices.Contains(lifecycleFields, name) {
+			if value, ok := lifecycle[name]; ok {
+				merged[name] = value
+			}
+			continue
+		}
+
+		b, inBase := baseFields[name]
+		o, inOurs := ourFields[name]
+		t, inTheirs := theirFields[name]
+
+		var value json.RawMessage
+		var present bool
+		switch {
+		case sameField(o, inOurs, t, inTheirs):
+			value, present = o, inOurs
+		case sameField(o, inOurs, b, inBase):
+			value, present = t, inTheirs
+		case sameField(t, inTheirs, b, inBase):
+			value, present = o, inOurs
+		default:
+			value, present = newer[name]
+		}
+		if present {
+			merged[name] = value
+		}
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return tick.Tick{}, fmt.Errorf("encode merged tick: %w", err)
+	}
+	var out tick.Tick
+	if err := json.Unmarshal(data, &out); err != nil {
+		return tick.Tick{}, fmt.Errorf("decode merged tick: %w", err)
+	}
+
+	out.Labels = mergeSet(base.Labels, ours.Labels, theirs.Labels)
+	out.BlockedBy = mergeSet(base.BlockedBy, ours.BlockedBy, theirs.BlockedBy)
+	out.Notes = mergeNoteLines(ours.Notes, theirs.Notes)
+	out.UpdatedAt = latestTime(ours.UpdatedAt, theirs.UpdatedAt)
+	return out, nil
+}
+
+// lifecycleFields are the fields that open and close a tick, merged together.
+var lifecycleFields = []string{"status", "closed_at", "closed_reason"}
+
+// lifecycleSide picks the side whose lifecycleFields go into the merge, with
+// the same rules as a single field: the side that changed them, or newer if
+// both did.
+func lifecycleSide(base, ours, theirs, newer map[string]json.RawMessage) map[string]json.RawMessage {
+	same := func(a, b map[string]json.RawMessage) bool {
+		for _, name := range lifecycleFields {
+			av, inA := a[name]
+			bv, inB := b[name]
+			if !sameField(av, inA, bv, inB) {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case same(ours, theirs), same(theirs, base):
+		return ours
+	case same(ours, base):
+		return theirs
+	default:
+		return newer
+	}
+}
+
+// fields splits a tick into its JSON fields.
+func fields(t tick.Tick) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("encode tick %s: %w", t.ID, err)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decode tick %s: %w", t.ID, err)
+	}
+	return m, nil
+}
+
+// fieldNames returns every field name present in any of the maps, sorted.
+func fieldNames(maps ...map[string]json.RawMessage) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, m := range maps {
+		for name := range m {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sameField reports whether two field values are equal, treating an omitted
+// field as different from any present value.
+func sameField(a json.RawMessage, aPresent bool, b json.RawMessage, bPresent bool) bool {
+	if aPresent != bPresent {
+		return false
+	}
+	return !aPresent || bytes.Equal(a, b)
+}
+
+// mergeSet merges two edits of a set: entries added on either side are kept
+// and entries removed from base on either side are dropped. The result is
+// sorted, or nil if empty.
+func mergeSet(base, ours, theirs []string) []string {
+	var out []string
+	for _, item := range unionStrings(ours, theirs) {
+		inBase := slices.Contains(base, item)
+		if inBase && (!slices.Contains(ours, item) || !slices.Contains(theirs, item)) {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// mergeNoteLines returns ours followed by the non-empty lines of theirs that
+// ours doesn't contain.
+func mergeNoteLines(ours, theirs string) string {
+	have := make(map[string]bool)
+	for _, line := range strings.Split(ours, "\n") {
+		have[line] = true
+	}
+	merged := strings.TrimRight(ours, "\n")
+	for _, line := range strings.Split(theirs, "\n") {
+		if strings.TrimSpace(line) == "" || have[line] {
+			continue
+		}
+		have[line] = true
+		if merged != "" {
+			merged += "\n"
+		}
+		merged += line
+	}
+	return merged
+}
@@ -0,0 +1,155 @@
+package merge
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+func decodeTick(t *testing.T, data string) tick.Tick {
+	t.Helper()
+	var tk tick.Tick
+	if err := json.Unmarshal([]byte(data), &tk); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	return tk
+}
+
+func TestThreeWay(t *testing.T) {
+	base := decodeTick(t, `{
+		"id": "a1b", "title": "Fix login", "description": "Old text", "status": "open",
+		"priority": 2, "type": "bug", "owner": "alice", "labels": ["auth", "stale"],
+		"blocked_by": ["c2d"], "notes": "2025-01-08 10:00 - Started",
+		"created_by": "alice", "created_at": "2025-01-08T09:00:00Z", "updated_at": "2025-01-08T10:00:00Z"
+	}`)
+	ours := decodeTick(t, `{
+		"id": "a1b", "title": "Fix login timeout", "description": "Ours text", "status": "open",
+		"priority": 2, "type": "bug", "owner": "alice", "labels": ["auth", "stale", "backend"],
+		"blocked_by": ["c2d", "e3f"], "notes": "2025-01-08 10:00 - Started\n2025-01-08 11:00 - Ours",
+		"created_by": "alice", "created_at": "2025-01-08T09:00:00Z", "updated_at": "2025-01-08T11:00:00Z"
+	}`)
+	theirs := decodeTick(t, `{
+		"id": "a1b", "title": "Fix login", "description": "Theirs text", "status": "in_progress",
+		"priority": 1, "type": "bug", "owner": "bob", "labels": ["auth", "ui"],
+		"blocked_by": ["c2d"], "notes": "2025-01-08 10:00 - Started\n2025-01-08 12:00 - Theirs",
+		"created_by": "alice", "created_at": "2025-01-08T09:00:00Z", "updated_at": "2025-01-08T12:00:00Z"
+	}`)
+
+	merged, err := ThreeWay(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("ThreeWay: %v", err)
+	}
+
+	// Changed on one side only: that side wins, even if it is older
+	if merged.Title != "Fix login timeout" {
+		t.Errorf("title = %q, want ours", merged.Title)
+	}
+	if merged.Status != tick.StatusInProgress || merged.Priority != 1 || merged.Owner != "bob" {
+		t.Errorf("expected theirs status/priority/owner, got %s/%d/%s", merged.Status, merged.Priority, merged.Owner)
+	}
+	// Changed on both sides: the newer side wins
+	if merged.Description != "Theirs text" {
+		t.Errorf("description = %q, want the newer side's", merged.Description)
+	}
+
+	// Sets keep additions from both sides and drop removals
+	if want := []string{"auth", "backend", "ui"}; !slices.Equal(merged.Labels, want) {
+		t.Errorf("labels = %v, want %v", merged.Labels, want)
+	}
+	if want := []string{"c2d", "e3f"}; !slices.Equal(merged.BlockedBy, want) {
+		t.Errorf("blocked_by = %v, want %v", merged.BlockedBy, want)
+	}
+
+	wantNotes := "2025-01-08 10:00 - Started\n2025-01-08 11:00 - Ours\n2025-01-08 12:00 - Theirs"
+	if merged.Notes != wantNotes {
+		t.Errorf("notes = %q, want %q", merged.Notes, wantNotes)
+	}
+	if !merged.UpdatedAt.Equal(time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("updated_at = %v, want the later side's", merged.UpdatedAt)
+	}
+	if err := merged.Validate(); err != nil {
+		t.Errorf("merged tick should be valid: %v", err)
+	}
+}
+
+func TestThreeWayClearedField(t *testing.T) {
+	created := time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC)
+	closedAt := created.Add(time.Hour)
+	base := tick.Tick{ID: "a1b", Title: "Ship", Status: tick.StatusClosed, ClosedAt: &closedAt, ClosedReason: "done", UpdatedAt: closedAt}
+	// Ours reopened the tick, clearing closed_at; theirs only touched notes
+	ours := base
+	ours.Status, ours.ClosedAt, ours.ClosedReason = tick.StatusOpen, nil, ""
+	ours.UpdatedAt = created.Add(2 * time.Hour)
+	theirs := base
+	theirs.Notes = "Shipped to prod"
+	theirs.UpdatedAt = created.Add(3 * time.Hour)
+
+	merged, err := ThreeWay(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("ThreeWay: %v", err)
+	}
+	if merged.Status != tick.StatusOpen || merged.ClosedAt != nil || merged.ClosedReason != "" {
+		t.Errorf("expected reopen from ours to stick, got status %s closed_at %v reason %q", merged.Status, merged.ClosedAt, merged.ClosedReason)
+	}
+	if merged.Notes != "Shipped to prod" {
+		t.Errorf("notes = %q, want theirs", merged.Notes)
+	}
+}
+
+func TestThreeWayLifecycleTogether(t *testing.T) {
+	created := time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC)
+	closedAt := created.Add(time.Hour)
+	base := tick.Tick{ID: "a1b", Title: "Ship", Status: tick.StatusClosed, ClosedAt: &closedAt, ClosedReason: "done", UpdatedAt: closedAt}
+	// Ours only rewrote the close reason; theirs reopened and closed again
+	ours := base
+	ours.ClosedReason = "duplicate"
+	ours.UpdatedAt = created.Add(2 * time.Hour)
+	theirs := base
+	reclosed := created.Add(3 * time.Hour)
+	theirs.ClosedAt = &reclosed
+	theirs.UpdatedAt = reclosed
+
+	merged, err := ThreeWay(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("ThreeWay: %v", err)
+	}
+	if merged.ClosedAt == nil || !merged.ClosedAt.Equal(reclosed) || merged.ClosedReason != "done" {
+		t.Errorf("expected theirs closed_at and reason together, got %v %q", merged.ClosedAt, merged.ClosedReason)
+	}
+
+	// Ours reopened; theirs edited the reason later. The newer side's close wins whole
+	ours = base
+	ours.Status, ours.ClosedAt, ours.ClosedReason = tick.StatusOpen, nil, ""
+	ours.UpdatedAt = created.Add(2 * time.Hour)
+	theirs = base
+	theirs.ClosedReason = "wontfix"
+	theirs.UpdatedAt = created.Add(3 * time.Hour)
+
+	merged, err = ThreeWay(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("ThreeWay: %v", err)
+	}
+	if merged.Status != tick.StatusClosed || merged.ClosedAt == nil || merged.ClosedReason != "wontfix" {
+		t.Errorf("expected theirs close whole, got status %s closed_at %v reason %q", merged.Status, merged.ClosedAt, merged.ClosedReason)
+	}
+}
+
+func TestThreeWayWithoutBase(t *testing.T) {
+	older := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	ours := tick.Tick{ID: "a1b", Title: "Ours", Labels: []string{"a"}, UpdatedAt: older}
+	theirs := tick.Tick{ID: "a1b", Title: "Theirs", Labels: []string{"b"}, UpdatedAt: older.Add(time.Minute)}
+
+	merged, err := ThreeWay(tick.Tick{}, ours, theirs)
+	if err != nil {
+		t.Fatalf("ThreeWay: %v", err)
+	}
+	if merged.Title != "Theirs" {
+		t.Errorf("title = %q, want the newer side's", merged.Title)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(merged.Labels, want) {
+		t.Errorf("labels = %v, want %v", merged.Labels, want)
+	}
+}
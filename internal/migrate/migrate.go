@@ -0,0 +1,147 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pengelbrecht/ticks/internal/config"
+)
+
+// Migration is a registered data migration. RunAll checks and applies
+// migrations in registration order.
+type Migration struct {
+	// Name identifies the migration in reports (e.g. "run-records").
+	Name string
+
+	// Description says what the migration does, for tk migrate output.
+	Description string
+
+	// Version is the schema version (config.Config.SchemaVersion) of data
+	// that has been through this migration. A repo recording an older
+	// version has to be migrated before commands run on it.
+	Version int
+
+	// Needed reports whether the .tick directory has data to migrate.
+	Needed func(tickDir string) (bool, error)
+
+	// Apply runs the migration. With dryRun, nothing is written but the
+	// result counts what would be migrated.
+	Apply func(tickDir string, dryRun bool) (*MigrationResult, error)
+}
+
+// Report is the outcome of one migration in RunAll.
+type Report struct {
+	Name        string
+	Description string
+	Needed      bool
+	Result      *MigrationResult // nil if the migration was not needed
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = []Migration{
+		{
+			Name:        "run-records",
+			Description: "Move run records from tick JSON to .tick/logs/records/",
+			Version:     config.DefaultSchemaVersion,
+			Needed:      NeedsMigration,
+			Apply: func(tickDir string, dryRun bool) (*MigrationResult, error) {
+				m := NewRunRecordMigration(tickDir)
+				m.SetDryRun(dryRun)
+				return m.Run()
+			},
+		},
+	}
+)
+
+// Register adds a migration after the ones already registered. The
+// returned function unregisters it again, for tests.
+func Register(m Migration) (unregister func()) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, m)
+	return func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		for i := range registry {
+			if registry[i].Name == m.Name {
+				registry = append(registry[:i:i], registry[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Migrations returns the registered migrations in order.
+func Migrations() []Migration {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append([]Migration(nil), registry...)
+}
+
+// LatestVersion returns the schema version this build of tk writes: the
+// highest Version of the registered migrations.
+func LatestVersion() int {
+	latest := config.DefaultSchemaVersion
+	for _, m := range Migrations() {
+		latest = max(latest, m.Version)
+	}
+	return latest
+}
+
+// RunAll checks every registered migration in order and applies the ones
+// that are needed. It stops at the first migration that fails, returning
+// the reports so far along with the error.
+func RunAll(tickDir string, dryRun bool) ([]Report, error) {
+	var reports []Report
+	for _, m := range Migrations() {
+		needed, err := m.Needed(tickDir)
+		if err != nil {
+			return reports, fmt.Errorf("check %s migration: %w", m.Name, err)
+		}
+		report := Report{Name: m.Name, Description: m.Description, Needed: needed}
+		if needed {
+			report.Result, err = m.Apply(tickDir, dryRun)
+			if err != nil {
+				return reports, fmt.Errorf("%s migration: %w", m.Name, err)
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Upgrade applies the pending migrations with RunAll and then records
+// LatestVersion as the schema version in tickDir's config.json. The version
+// is left alone if any migration reported per-tick errors, so the upgrade is
+// retried next time.
+func Upgrade(tickDir string) ([]Report, error) {
+	reports, err := RunAll(tickDir, false)
+	if err != nil {
+		return reports, err
+	}
+	for _, r := range reports {
+		if r.Result != nil && len(r.Result.Errors) > 0 {
+			return reports, nil
+		}
+	}
+
+	path := filepath.Join(tickDir, "config.json")
+	cfg, err := config.Load(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return reports, nil
+		}
+		return reports, err
+	}
+	if latest := LatestVersion(); cfg.GetSchemaVersion() < latest {
+		cfg.SchemaVersion = latest
+		if err := config.Save(path, cfg); err != nil {
+			return reports, err
+		}
+	}
+	return reports, nil
+}
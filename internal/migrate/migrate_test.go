@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pengelbrecht/ticks/internal/config"
+)
+
+func TestRunAll_NothingToMigrate(t *testing.T) {
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	if err := os.MkdirAll(filepath.Join(tickDir, "issues"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := RunAll(tickDir, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reports) != len(Migrations()) {
+		t.Fatalf("expected a report per migration, got %d", len(reports))
+	}
+	for _, r := range reports {
+		if r.Needed || r.Result != nil {
+			t.Errorf("expected %s not needed, got %+v", r.Name, r)
+		}
+	}
+}
+
+func TestRunAll_RegisteredInOrder(t *testing.T) {
+	var applied []string
+	register := func(name string, needed bool) {
+		t.Cleanup(Register(Migration{
+			Name:   name,
+			Needed: func(string) (bool, error) { return needed, nil },
+			Apply: func(_ string, dryRun bool) (*MigrationResult, error) {
+				if dryRun {
+					t.Errorf("%s: unexpected dry run", name)
+				}
+				applied = append(applied, name)
+				return &MigrationResult{Migrated: 1}, nil
+			},
+		}))
+	}
+	register("second", true)
+	register("third", false)
+	register("fourth", true)
+
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	reports, err := RunAll(tickDir, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := make([]string, len(reports))
+	for i, r := range reports {
+		names[i] = r.Name
+	}
+	if want := []string{"run-records", "second", "third", "fourth"}; len(names) != len(want) || names[1] != "second" || names[3] != "fourth" {
+		t.Fatalf("reports = %v, want %v", names, want)
+	}
+	if len(applied) != 2 || applied[0] != "second" || applied[1] != "fourth" {
+		t.Errorf("applied = %v, want [second fourth]", applied)
+	}
+	if reports[2].Needed || reports[2].Result != nil {
+		t.Errorf("third should not run, got %+v", reports[2])
+	}
+	if reports[3].Result == nil || reports[3].Result.Migrated != 1 {
+		t.Errorf("fourth result = %+v, want 1 migrated", reports[3].Result)
+	}
+}
+
+func TestUpgrade_RecordsLatestVersion(t *testing.T) {
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	if err := os.MkdirAll(filepath.Join(tickDir, "issues"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(tickDir, "config.json")
+	if err := config.Save(path, config.Default()); err != nil {
+		t.Fatal(err)
+	}
+
+	applied := false
+	t.Cleanup(Register(Migration{
+		Name:    "v2",
+		Version: 2,
+		Needed:  func(string) (bool, error) { return true, nil },
+		Apply: func(string, bool) (*MigrationResult, error) {
+			applied = true
+			return &MigrationResult{}, nil
+		},
+	}))
+	if got := LatestVersion(); got != 2 {
+		t.Fatalf("LatestVersion() = %d, want 2", got)
+	}
+
+	if _, err := Upgrade(tickDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !applied {
+		t.Error("expected the v2 migration to be applied")
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.GetSchemaVersion(); got != 2 {
+		t.Errorf("schema version = %d after Upgrade, want 2", got)
+	}
+}
@@ -45,6 +45,8 @@ type RunnerConfig struct {
 	// PoolRunTaskFactory creates a RunTask function for pool workers.
 	// If nil and PoolSize > 0, a default implementation is used.
 	PoolRunTaskFactory PoolRunTaskFactory
+	// PoolShouldStop is passed to every epic's pool as pool.Config.ShouldStop.
+	PoolShouldStop func() bool
 }
 
 // EngineFactory creates Engine instances for parallel runs.
@@ -231,6 +233,7 @@ func (r *Runner) runEpic(ctx context.Context, epicID string) {
 			StaleTimeout: r.config.StaleTimeout,
 			EpicID:       epicID,
 			TickDir:      tickDir,
+			ShouldStop:   r.config.PoolShouldStop,
 		}
 
 		// Use factory if provided, otherwise pool will need RunTask set externally
@@ -36,6 +36,7 @@ type Config struct {
 	RunTask      RunTaskFunc
 	OnStatus     StatusCallback // optional callback for task status updates
 	EpicContext  string         // pre-computed context shared by all workers
+	ShouldStop   func() bool    // optional; workers claim no more tasks once it returns true
 }
 
 // Result contains the aggregated results from all workers in a pool run.
@@ -77,6 +78,7 @@ func RunPool(ctx context.Context, cfg Config) (*Result, error) {
 			defer wg.Done()
 			w := NewWorker(workerID, cfg.TickDir, cfg.EpicID)
 			w.OnStatus = cfg.OnStatus
+			w.ShouldStop = cfg.ShouldStop
 			results <- w.Run(ctx, cfg.RunTask)
 		}(i)
 	}
@@ -14,6 +14,10 @@ type Worker struct {
 	TickDir  string
 	EpicID   string
 	OnStatus StatusCallback // optional callback for status updates
+
+	// ShouldStop is checked before claiming each task; once it returns
+	// true the worker exits, leaving the remaining tasks open. Optional.
+	ShouldStop func() bool
 }
 
 // WorkerResult contains the execution metrics from a worker's run.
@@ -47,6 +51,9 @@ func (w *Worker) Run(ctx context.Context, runTask func(ctx context.Context, task
 			return result
 		default:
 		}
+		if w.ShouldStop != nil && w.ShouldStop() {
+			return result
+		}
 
 		// Claim next available task
 		task, err := ClaimTask(ctx, w.TickDir, w.EpicID)
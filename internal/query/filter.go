@@ -3,6 +3,7 @@ package query
 import (
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
@@ -10,11 +11,25 @@ import (
 // Filter describes filtering criteria for ticks.
 type Filter struct {
 	Owner   string
+	// Owners filters to ticks whose owner exactly matches any of the listed owners.
+	Owners []string
 	Status  string
+	// StatusAny filters to ticks matching any of the listed statuses.
+	StatusAny []string
 	Priority *int
+	// MinPriority and MaxPriority bound Priority numerically, inclusive on both ends.
+	// Lower numbers are more urgent, so MinPriority=0, MaxPriority=1 selects P0-P1.
+	// A nil bound leaves that side open.
+	MinPriority *int
+	MaxPriority *int
 	Type    string
+	// ExcludeType drops ticks of this type (e.g. "epic" to list only work items).
+	ExcludeType string
 	Label   string
-	LabelAny []string
+	// LabelsAll requires every listed label on the tick; LabelsAny requires
+	// at least one. When both are set a tick must satisfy both.
+	LabelsAll []string
+	LabelsAny []string
 	Parent  string
 	TitleContains string
 	DescContains  string
@@ -27,6 +42,8 @@ type Filter struct {
 	// AwaitingAny filters to ticks matching any of the listed awaiting values.
 	// Treats Manual=true as awaiting="work" for backwards compatibility.
 	AwaitingAny []string
+	// Since keeps only ticks whose UpdatedAt is at or after this time.
+	Since *time.Time
 }
 
 // Apply filters ticks according to Filter fields.
@@ -36,19 +53,37 @@ func Apply(ticks []tick.Tick, f Filter) []tick.Tick {
 		if f.Owner != "" && t.Owner != f.Owner {
 			continue
 		}
+		if len(f.Owners) > 0 && !containsString(f.Owners, t.Owner) {
+			continue
+		}
 		if f.Status != "" && t.Status != f.Status {
 			continue
 		}
+		if len(f.StatusAny) > 0 && !containsString(f.StatusAny, t.Status) {
+			continue
+		}
 		if f.Priority != nil && t.Priority != *f.Priority {
 			continue
 		}
+		if f.MinPriority != nil && t.Priority < *f.MinPriority {
+			continue
+		}
+		if f.MaxPriority != nil && t.Priority > *f.MaxPriority {
+			continue
+		}
 		if f.Type != "" && t.Type != f.Type {
 			continue
 		}
+		if f.ExcludeType != "" && t.Type == f.ExcludeType {
+			continue
+		}
 		if f.Label != "" && !containsString(t.Labels, f.Label) {
 			continue
 		}
-		if len(f.LabelAny) > 0 && !containsAnyString(t.Labels, f.LabelAny) {
+		if len(f.LabelsAll) > 0 && !containsAllStrings(t.Labels, f.LabelsAll) {
+			continue
+		}
+		if len(f.LabelsAny) > 0 && !containsAnyString(t.Labels, f.LabelsAny) {
 			continue
 		}
 		if f.Parent != "" && t.Parent != f.Parent {
@@ -69,6 +104,9 @@ func Apply(ticks []tick.Tick, f Filter) []tick.Tick {
 		if len(f.AwaitingAny) > 0 && !matchesAwaitingAny(t, f.AwaitingAny) {
 			continue
 		}
+		if f.Since != nil && t.UpdatedAt.Before(*f.Since) {
+			continue
+		}
 		out = append(out, t)
 	}
 	return out
@@ -118,6 +156,17 @@ func SortByPriorityCreatedAt(ticks []tick.Tick) {
 	})
 }
 
+// SortByUpdatedAt sorts ticks by updated_at ascending, then id, so the last
+// tick carries the high-water mark for incremental polling.
+func SortByUpdatedAt(ticks []tick.Tick) {
+	sort.Slice(ticks, func(i, j int) bool {
+		if !ticks[i].UpdatedAt.Equal(ticks[j].UpdatedAt) {
+			return ticks[i].UpdatedAt.Before(ticks[j].UpdatedAt)
+		}
+		return strings.Compare(ticks[i].ID, ticks[j].ID) < 0
+	})
+}
+
 func containsString(values []string, value string) bool {
 	for _, item := range values {
 		if item == value {
@@ -136,6 +185,15 @@ func containsAnyString(values []string, needles []string) bool {
 	return false
 }
 
+func containsAllStrings(values []string, needles []string) bool {
+	for _, needle := range needles {
+		if !containsString(values, needle) {
+			return false
+		}
+	}
+	return true
+}
+
 func containsFold(haystack, needle string) bool {
 	haystack = strings.ToLower(haystack)
 	needle = strings.ToLower(needle)
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -201,3 +202,175 @@ func TestFilterAwaitingAnyWithManual(t *testing.T) {
 		t.Fatalf("expected awaiting-approval and manual, got %v", ids)
 	}
 }
+
+func TestFilterPriorityRange(t *testing.T) {
+	base := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	items := []tick.Tick{
+		{ID: "p0", Priority: 0, CreatedAt: base},
+		{ID: "p1", Priority: 1, CreatedAt: base},
+		{ID: "p2", Priority: 2, CreatedAt: base},
+		{ID: "p3", Priority: 3, CreatedAt: base},
+		{ID: "p4", Priority: 4, CreatedAt: base},
+	}
+	intPtr := func(v int) *int { return &v }
+	ids := func(ts []tick.Tick) []string {
+		out := make([]string, 0, len(ts))
+		for _, t := range ts {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		min  *int
+		max  *int
+		want []string
+	}{
+		{"unbounded", nil, nil, []string{"p0", "p1", "p2", "p3", "p4"}},
+		{"max inclusive", nil, intPtr(1), []string{"p0", "p1"}},
+		{"min inclusive", intPtr(3), nil, []string{"p3", "p4"}},
+		{"two-sided inclusive", intPtr(1), intPtr(2), []string{"p1", "p2"}},
+		{"single value", intPtr(2), intPtr(2), []string{"p2"}},
+		{"inverted range is empty", intPtr(3), intPtr(1), []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(Apply(items, Filter{MinPriority: tt.min, MaxPriority: tt.max}))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterExcludeType(t *testing.T) {
+	items := []tick.Tick{
+		{ID: "e1", Type: tick.TypeEpic, Status: tick.StatusOpen},
+		{ID: "t1", Type: tick.TypeTask, Status: tick.StatusOpen},
+		{ID: "b1", Type: tick.TypeBug, Status: tick.StatusClosed},
+	}
+
+	filtered := Apply(items, Filter{ExcludeType: tick.TypeEpic})
+	if len(filtered) != 2 || filtered[0].ID != "t1" || filtered[1].ID != "b1" {
+		t.Fatalf("expected epics excluded, got %+v", filtered)
+	}
+
+	filtered = Apply(items, Filter{ExcludeType: tick.TypeEpic, Status: tick.StatusOpen})
+	if len(filtered) != 1 || filtered[0].ID != "t1" {
+		t.Fatalf("expected exclusion to compose with status, got %+v", filtered)
+	}
+}
+
+func TestFilterStatusAnyWithAwaitingAny(t *testing.T) {
+	base := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	approval := tick.AwaitingApproval
+	review := tick.AwaitingReview
+	input := tick.AwaitingInput
+
+	items := []tick.Tick{
+		{ID: "open-approval", Status: tick.StatusOpen, Awaiting: &approval, CreatedAt: base},
+		{ID: "wip-review", Status: tick.StatusInProgress, Awaiting: &review, CreatedAt: base},
+		{ID: "closed-approval", Status: tick.StatusClosed, Awaiting: &approval, CreatedAt: base},
+		{ID: "open-input", Status: tick.StatusOpen, Awaiting: &input, CreatedAt: base},
+		{ID: "open-plain", Status: tick.StatusOpen, CreatedAt: base},
+		{ID: "wip-manual", Status: tick.StatusInProgress, Manual: true, CreatedAt: base},
+	}
+	ids := func(ts []tick.Tick) string {
+		out := make([]string, 0, len(ts))
+		for _, t := range ts {
+			out = append(out, t.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   string
+	}{
+		{"empty filter returns everything", Filter{}, "open-approval,wip-review,closed-approval,open-input,open-plain,wip-manual"},
+		{"status any", Filter{StatusAny: []string{tick.StatusOpen, tick.StatusInProgress}}, "open-approval,wip-review,open-input,open-plain,wip-manual"},
+		{"status and awaiting any", Filter{
+			StatusAny:   []string{tick.StatusOpen, tick.StatusInProgress},
+			AwaitingAny: []string{tick.AwaitingApproval, tick.AwaitingReview},
+		}, "open-approval,wip-review"},
+		{"work includes legacy manual", Filter{
+			StatusAny:   []string{tick.StatusOpen, tick.StatusInProgress},
+			AwaitingAny: []string{tick.AwaitingWork},
+		}, "wip-manual"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(Apply(items, tt.filter)); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterSince(t *testing.T) {
+	cutoff := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	items := []tick.Tick{
+		{ID: "after", Type: tick.TypeTask, UpdatedAt: cutoff.Add(time.Hour)},
+		{ID: "before", Type: tick.TypeTask, UpdatedAt: cutoff.Add(-time.Second)},
+		{ID: "equal", Type: tick.TypeTask, UpdatedAt: cutoff},
+		{ID: "bug", Type: tick.TypeBug, UpdatedAt: cutoff.Add(time.Minute)},
+	}
+
+	filtered := Apply(items, Filter{Since: &cutoff})
+	SortByUpdatedAt(filtered)
+	var ids []string
+	for _, tk := range filtered {
+		ids = append(ids, tk.ID)
+	}
+	if got := strings.Join(ids, ","); got != "equal,bug,after" {
+		t.Fatalf("unexpected since result: %s", got)
+	}
+
+	filtered = Apply(items, Filter{Since: &cutoff, Type: tick.TypeTask})
+	SortByUpdatedAt(filtered)
+	if len(filtered) != 2 || filtered[0].ID != "equal" || filtered[1].ID != "after" {
+		t.Fatalf("unexpected since+type result: %+v", filtered)
+	}
+}
+
+func TestFilterOwners(t *testing.T) {
+	items := []tick.Tick{
+		{ID: "a", Owner: "alice"},
+		{ID: "b", Owner: "bob"},
+		{ID: "c", Owner: "carol"},
+		{ID: "d", Owner: "Alice"},
+	}
+
+	filtered := Apply(items, Filter{Owners: []string{"alice", "bob"}})
+	if len(filtered) != 2 || filtered[0].ID != "a" || filtered[1].ID != "b" {
+		t.Fatalf("unexpected owners filter result: %+v", filtered)
+	}
+}
+
+func TestFilterLabelsAllAny(t *testing.T) {
+	items := []tick.Tick{{ID: "ab", Labels: []string{"a", "b"}}}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		match  bool
+	}{
+		{"all present", Filter{LabelsAll: []string{"a", "b"}}, true},
+		{"all missing one", Filter{LabelsAll: []string{"a", "c"}}, false},
+		{"any one present", Filter{LabelsAny: []string{"c", "b"}}, true},
+		{"any none present", Filter{LabelsAny: []string{"c", "d"}}, false},
+		{"both satisfied", Filter{LabelsAll: []string{"a"}, LabelsAny: []string{"c", "b"}}, true},
+		{"both, all fails", Filter{LabelsAll: []string{"a", "c"}, LabelsAny: []string{"b"}}, false},
+		{"both, any fails", Filter{LabelsAll: []string{"a", "b"}, LabelsAny: []string{"c"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := len(Apply(items, tt.filter)) == 1
+			if got != tt.match {
+				t.Fatalf("match = %v, want %v", got, tt.match)
+			}
+		})
+	}
+}
@@ -37,6 +37,19 @@ func readyWithOptions(candidates []tick.Tick, includeAwaiting bool, allTicks ...
 	return out
 }
 
+// AgentReady returns the IDs of candidates an agent can pick up now: open or
+// in_progress, not deferred, not awaiting human action, and with no open
+// blockers. These are exactly the ticks Ready returns; the set form lets
+// callers such as tk list and tk graph mark readiness per tick.
+// allTicks is used for blocker lookup as in Ready.
+func AgentReady(candidates []tick.Tick, allTicks ...[]tick.Tick) map[string]bool {
+	ready := make(map[string]bool)
+	for _, t := range Ready(candidates, allTicks...) {
+		ready[t.ID] = true
+	}
+	return ready
+}
+
 // Blocked returns ticks that are open or in_progress with open blockers.
 // Missing blockers are treated as closed (not blocked) - this handles orphaned
 // references when blockers are deleted.
@@ -363,3 +363,37 @@ func TestMixedOrphanedAndRealBlockers(t *testing.T) {
 		t.Fatalf("closed-blocker should not be ready (it's closed)")
 	}
 }
+
+func TestAgentReady(t *testing.T) {
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	awaiting := "review"
+	allTicks := []tick.Tick{
+		{ID: "ready", Status: tick.StatusOpen, Parent: "e1", CreatedAt: now, UpdatedAt: now},
+		{ID: "review", Status: tick.StatusOpen, Parent: "e1", Awaiting: &awaiting, CreatedAt: now, UpdatedAt: now},
+		{ID: "outside", Status: tick.StatusOpen, Parent: "e2", CreatedAt: now, UpdatedAt: now},
+		{ID: "blocked", Status: tick.StatusOpen, Parent: "e1", BlockedBy: []string{"outside"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "done", Status: tick.StatusClosed, Parent: "e1", CreatedAt: now, UpdatedAt: now},
+	}
+	filtered := Apply(allTicks, Filter{Parent: "e1"})
+
+	got := AgentReady(filtered, allTicks)
+	if !got["ready"] {
+		t.Error("expected unblocked open tick to be agent-ready")
+	}
+	// Ready for a human to act on, but not for an agent
+	if got["review"] {
+		t.Error("expected awaiting tick not to be agent-ready")
+	}
+	if len(ReadyIncludeAwaiting([]tick.Tick{allTicks[1]})) != 1 {
+		t.Error("expected awaiting tick to be ready when awaiting ticks are included")
+	}
+	if got["blocked"] {
+		t.Error("expected tick blocked from outside the filtered set not to be agent-ready")
+	}
+	if got["done"] {
+		t.Error("expected closed tick not to be agent-ready")
+	}
+	if len(got) != 1 {
+		t.Errorf("expected only one agent-ready tick, got %v", got)
+	}
+}
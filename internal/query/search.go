@@ -0,0 +1,75 @@
+package query
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+// Searchable tick fields, in ranking order.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldNotes       = "notes"
+)
+
+// SearchMatch is a tick whose content matched a search.
+type SearchMatch struct {
+	Tick tick.Tick
+	// Fields lists the matched fields in ranking order; Fields[0] is the best.
+	Fields []string
+}
+
+// CompileSearch builds a case-insensitive matcher for a search query.
+// The query is matched literally unless regex is true.
+func CompileSearch(q string, regex bool) (*regexp.Regexp, error) {
+	if !regex {
+		q = regexp.QuoteMeta(q)
+	}
+	return regexp.Compile("(?i)" + q)
+}
+
+// Search returns items whose title, description, or notes match re.
+// Title hits rank above description hits, which rank above notes hits;
+// ties are ordered like SortByPriorityCreatedAt.
+func Search(items []tick.Tick, re *regexp.Regexp) []SearchMatch {
+	var out []SearchMatch
+	for _, t := range items {
+		var fields []string
+		for _, f := range []struct {
+			name, text string
+		}{
+			{FieldTitle, t.Title},
+			{FieldDescription, t.Description},
+			{FieldNotes, t.Notes},
+		} {
+			if re.MatchString(f.text) {
+				fields = append(fields, f.name)
+			}
+		}
+		if len(fields) > 0 {
+			out = append(out, SearchMatch{Tick: t, Fields: fields})
+		}
+	}
+
+	ticks := make([]tick.Tick, len(out))
+	for i, m := range out {
+		ticks[i] = m.Tick
+	}
+	SortByPriorityCreatedAt(ticks)
+	order := make(map[string]int, len(ticks))
+	for i, t := range ticks {
+		order[t.ID] = i
+	}
+
+	rank := map[string]int{FieldTitle: 0, FieldDescription: 1, FieldNotes: 2}
+	sort.Slice(out, func(i, j int) bool {
+		ri, rj := rank[out[i].Fields[0]], rank[out[j].Fields[0]]
+		if ri != rj {
+			return ri < rj
+		}
+		return order[out[i].Tick.ID] < order[out[j].Tick.ID]
+	})
+	return out
+}
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+func TestSearchRanking(t *testing.T) {
+	items := []tick.Tick{
+		{ID: "a", Title: "Refactor store", Notes: "Watch for the STRIPE webhook", Priority: 1},
+		{ID: "b", Title: "Add docs", Description: "Document the Stripe flow", Priority: 0},
+		{ID: "c", Title: "Stripe retries", Priority: 2},
+		{ID: "d", Title: "Fix stripe payouts", Description: "stripe again", Priority: 1},
+		{ID: "e", Title: "Unrelated"},
+	}
+
+	re, err := CompileSearch("stripe", false)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	matches := Search(items, re)
+
+	var ids []string
+	for _, m := range matches {
+		ids = append(ids, m.Tick.ID)
+	}
+	want := []string{"d", "c", "b", "a"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, ids)
+		}
+	}
+
+	if f := matches[0].Fields; len(f) != 2 || f[0] != FieldTitle || f[1] != FieldDescription {
+		t.Errorf("expected title and description for d, got %v", f)
+	}
+	if f := matches[3].Fields; len(f) != 1 || f[0] != FieldNotes {
+		t.Errorf("expected notes for a, got %v", f)
+	}
+}
+
+func TestSearchRegex(t *testing.T) {
+	items := []tick.Tick{
+		{ID: "a", Title: "Bump v1.2"},
+		{ID: "b", Title: "Bump v1x2"},
+		{ID: "c", Title: "Release notes", Description: "see ISSUE-42"},
+	}
+
+	literal, err := CompileSearch("v1.2", false)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	if got := Search(items, literal); len(got) != 1 || got[0].Tick.ID != "a" {
+		t.Errorf("literal search should not treat . as a wildcard, got %+v", got)
+	}
+
+	pattern, err := CompileSearch(`issue-\d+`, true)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	if got := Search(items, pattern); len(got) != 1 || got[0].Tick.ID != "c" {
+		t.Errorf("expected case-insensitive regex match on c, got %+v", got)
+	}
+
+	if _, err := CompileSearch("(unclosed", true); err == nil {
+		t.Errorf("expected error for invalid regex")
+	}
+}
@@ -0,0 +1,68 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+// DefaultSimilarityThreshold is the title similarity score at or above which
+// two ticks are considered likely duplicates.
+const DefaultSimilarityThreshold = 0.8
+
+// TitleMatch is a tick whose title is similar to a searched title.
+type TitleMatch struct {
+	Tick  tick.Tick
+	Score float64
+}
+
+// SimilarTitles returns items whose titles score at or above threshold against title,
+// most similar first. Titles are compared case-insensitively on their word tokens
+// (punctuation ignored) using the Dice coefficient, so word order does not matter.
+func SimilarTitles(title string, items []tick.Tick, threshold float64) []TitleMatch {
+	want := titleTokens(title)
+	if len(want) == 0 {
+		return nil
+	}
+
+	var out []TitleMatch
+	for _, t := range items {
+		score := diceCoefficient(want, titleTokens(t.Title))
+		if score >= threshold {
+			out = append(out, TitleMatch{Tick: t, Score: score})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Score > out[j].Score
+	})
+	return out
+}
+
+// titleTokens returns the set of lowercase word tokens in a title.
+func titleTokens(title string) map[string]bool {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		tokens[f] = true
+	}
+	return tokens
+}
+
+// diceCoefficient returns 2|A∩B| / (|A|+|B|) for two token sets.
+func diceCoefficient(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for tok := range a {
+		if b[tok] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
@@ -0,0 +1,49 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+func TestSimilarTitles(t *testing.T) {
+	items := []tick.Tick{
+		{ID: "a", Title: "Fix login bug"},
+		{ID: "b", Title: "Fix the login bug"},
+		{ID: "c", Title: "Login bug: fix"},
+		{ID: "d", Title: "Add OAuth support"},
+		{ID: "e", Title: "Update API docs for login"},
+	}
+
+	matches := SimilarTitles("fix login bug!", items, DefaultSimilarityThreshold)
+	got := make(map[string]bool)
+	for _, m := range matches {
+		got[m.Tick.ID] = true
+	}
+	if len(got) != 3 || !got["a"] || !got["b"] || !got["c"] {
+		t.Fatalf("expected a, b, c as near-duplicates, got %+v", matches)
+	}
+	if matches[0].Score != 1 {
+		t.Errorf("expected exact token match first with score 1, got %+v", matches[0])
+	}
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Score > matches[i-1].Score {
+			t.Errorf("matches not sorted by score: %+v", matches)
+		}
+	}
+}
+
+func TestSimilarTitlesDissimilar(t *testing.T) {
+	items := []tick.Tick{
+		{ID: "a", Title: "Add OAuth support"},
+		{ID: "b", Title: "Update API docs"},
+		{ID: "c", Title: "Fix payment rounding"},
+	}
+
+	if matches := SimilarTitles("Fix login bug", items, DefaultSimilarityThreshold); len(matches) != 0 {
+		t.Errorf("expected no matches, got %+v", matches)
+	}
+	if matches := SimilarTitles("   ", items, 0); matches != nil {
+		t.Errorf("expected nil for empty title, got %+v", matches)
+	}
+}
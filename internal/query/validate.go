@@ -0,0 +1,213 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+// Problem kinds reported by CheckGraph.
+const (
+	ProblemInvalid           = "invalid"
+	ProblemDanglingBlocker   = "dangling_blocker"
+	ProblemDanglingParent    = "dangling_parent"
+	ProblemParentCycle       = "parent_cycle"
+	ProblemBlockerCycle      = "blocker_cycle"
+	ProblemClosedOpenBlocker = "closed_open_blocker"
+	ProblemClosedAwaiting    = "closed_awaiting"
+)
+
+// Problem is a defect found in the tick graph.
+type Problem struct {
+	Kind string `json:"kind"`
+	// Tick is the tick the problem is reported against. For cycles it is the
+	// lowest ID in the cycle.
+	Tick string `json:"tick"`
+	// Refs lists the other ticks involved: the missing or open blocker, the
+	// missing parent, or every member of a cycle.
+	Refs    []string `json:"refs,omitempty"`
+	Message string   `json:"message"`
+}
+
+// CheckGraph reports invalid ticks, references to missing blockers and
+// parents, parent cycles, blocked-by cycles among open ticks, and closed
+// ticks still blocked by open ones or still awaiting a human.
+//
+// present holds the IDs of every tick file, including ones that failed to
+// load, so references to them are not reported as missing. A nil present
+// treats only ticks as existing.
+func CheckGraph(ticks []tick.Tick, present map[string]bool) []Problem {
+	index := indexByID(ticks)
+	exists := func(id string) bool {
+		_, ok := index[id]
+		return ok || present[id]
+	}
+
+	var problems []Problem
+	for _, t := range ticks {
+		if err := t.Validate(); err != nil {
+			problems = append(problems, Problem{Kind: ProblemInvalid, Tick: t.ID, Message: err.Error()})
+		}
+		if t.Parent != "" && !exists(t.Parent) {
+			problems = append(problems, Problem{
+				Kind:    ProblemDanglingParent,
+				Tick:    t.ID,
+				Refs:    []string{t.Parent},
+				Message: fmt.Sprintf("parent %s does not exist", t.Parent),
+			})
+		}
+		if t.Status == tick.StatusClosed && t.IsAwaitingHuman() {
+			problems = append(problems, Problem{
+				Kind:    ProblemClosedAwaiting,
+				Tick:    t.ID,
+				Message: fmt.Sprintf("closed but still awaiting %s", t.GetAwaitingType()),
+			})
+		}
+		for _, blockerID := range t.BlockedBy {
+			if !exists(blockerID) {
+				problems = append(problems, Problem{
+					Kind:    ProblemDanglingBlocker,
+					Tick:    t.ID,
+					Refs:    []string{blockerID},
+					Message: fmt.Sprintf("blocked by missing tick %s", blockerID),
+				})
+				continue
+			}
+			blocker, ok := index[blockerID]
+			if t.Status == tick.StatusClosed && ok && blocker.Status != tick.StatusClosed {
+				problems = append(problems, Problem{
+					Kind:    ProblemClosedOpenBlocker,
+					Tick:    t.ID,
+					Refs:    []string{blockerID},
+					Message: fmt.Sprintf("closed but still blocked by open tick %s", blockerID),
+				})
+			}
+		}
+	}
+
+	problems = append(problems, parentCycles(ticks, index)...)
+	problems = append(problems, blockerCycles(ticks, index)...)
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Tick != problems[j].Tick {
+			return problems[i].Tick < problems[j].Tick
+		}
+		return problems[i].Kind < problems[j].Kind
+	})
+	return problems
+}
+
+// parentCycles follows each tick's parent chain and reports each loop once.
+func parentCycles(ticks []tick.Tick, index map[string]tick.Tick) []Problem {
+	var problems []Problem
+	done := make(map[string]bool, len(ticks))
+	for _, start := range ticks {
+		pos := make(map[string]int)
+		var path []string
+		for id := start.ID; id != "" && !done[id]; id = index[id].Parent {
+			if i, seen := pos[id]; seen {
+				cycle := append([]string(nil), path[i:]...)
+				chain := strings.Join(append(cycle, id), " -> ")
+				sort.Strings(cycle)
+				problems = append(problems, Problem{
+					Kind:    ProblemParentCycle,
+					Tick:    cycle[0],
+					Refs:    cycle,
+					Message: "parent cycle: " + chain,
+				})
+				break
+			}
+			if _, ok := index[id]; !ok {
+				break
+			}
+			pos[id] = len(path)
+			path = append(path, id)
+		}
+		for _, id := range path {
+			done[id] = true
+		}
+	}
+	return problems
+}
+
+// blockerCycles finds strongly connected components in the blocked-by graph
+// of open ticks. Closed blockers never block, so they cannot deadlock.
+func blockerCycles(ticks []tick.Tick, index map[string]tick.Tick) []Problem {
+	edges := make(map[string][]string)
+	var nodes []string
+	for _, t := range ticks {
+		if t.Status == tick.StatusClosed {
+			continue
+		}
+		nodes = append(nodes, t.ID)
+		for _, blockerID := range t.BlockedBy {
+			if blocker, ok := index[blockerID]; ok && blocker.Status != tick.StatusClosed {
+				edges[t.ID] = append(edges[t.ID], blockerID)
+			}
+		}
+	}
+	sort.Strings(nodes)
+
+	// Tarjan's algorithm
+	var (
+		problems []Problem
+		stack    []string
+		next     int
+		order    = make(map[string]int)
+		low      = make(map[string]int)
+		onStack  = make(map[string]bool)
+	)
+	var visit func(id string)
+	visit = func(id string) {
+		order[id] = next
+		low[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, to := range edges[id] {
+			if to == id {
+				selfLoop = true
+			}
+			if _, seen := order[to]; !seen {
+				visit(to)
+				low[id] = min(low[id], low[to])
+			} else if onStack[to] {
+				low[id] = min(low[id], order[to])
+			}
+		}
+		if low[id] != order[id] {
+			return
+		}
+
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) == 1 && !selfLoop {
+			return
+		}
+		sort.Strings(component)
+		problems = append(problems, Problem{
+			Kind:    ProblemBlockerCycle,
+			Tick:    component[0],
+			Refs:    component,
+			Message: "blocked-by cycle among " + strings.Join(component, ", "),
+		})
+	}
+	for _, id := range nodes {
+		if _, seen := order[id]; !seen {
+			visit(id)
+		}
+	}
+	return problems
+}
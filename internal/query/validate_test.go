@@ -0,0 +1,137 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+func validTick(id, status string) tick.Tick {
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	return tick.Tick{
+		ID:        id,
+		Title:     "Tick " + id,
+		Status:    status,
+		Priority:  2,
+		Type:      tick.TypeTask,
+		Owner:     "alice",
+		CreatedBy: "alice",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func problemSummary(problems []Problem) string {
+	var parts []string
+	for _, p := range problems {
+		parts = append(parts, p.Kind+":"+p.Tick+"["+strings.Join(p.Refs, ",")+"]")
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestCheckGraph(t *testing.T) {
+	withBlockers := func(tk tick.Tick, blockers ...string) tick.Tick {
+		tk.BlockedBy = blockers
+		return tk
+	}
+	withParent := func(tk tick.Tick, parent string) tick.Tick {
+		tk.Parent = parent
+		return tk
+	}
+
+	tests := []struct {
+		name    string
+		ticks   []tick.Tick
+		present map[string]bool
+		want    string
+	}{
+		{
+			name:  "clean",
+			ticks: []tick.Tick{validTick("a", tick.StatusOpen), withBlockers(validTick("b", tick.StatusOpen), "a")},
+			want:  "",
+		},
+		{
+			name:  "invalid tick",
+			ticks: []tick.Tick{func() tick.Tick { tk := validTick("a", tick.StatusOpen); tk.Priority = 9; return tk }()},
+			want:  "invalid:a[]",
+		},
+		{
+			name:  "dangling blocker",
+			ticks: []tick.Tick{withBlockers(validTick("a", tick.StatusOpen), "gone")},
+			want:  "dangling_blocker:a[gone]",
+		},
+		{
+			name:    "blocker present but unreadable",
+			ticks:   []tick.Tick{withBlockers(validTick("a", tick.StatusOpen), "broken")},
+			present: map[string]bool{"a": true, "broken": true},
+			want:    "",
+		},
+		{
+			name:  "dangling parent",
+			ticks: []tick.Tick{withParent(validTick("a", tick.StatusOpen), "gone")},
+			want:  "dangling_parent:a[gone]",
+		},
+		{
+			name: "parent cycle",
+			ticks: []tick.Tick{
+				withParent(validTick("a", tick.StatusOpen), "b"),
+				withParent(validTick("b", tick.StatusOpen), "c"),
+				withParent(validTick("c", tick.StatusOpen), "a"),
+				withParent(validTick("d", tick.StatusOpen), "a"),
+			},
+			want: "parent_cycle:a[a,b,c]",
+		},
+		{
+			name: "blocker cycle across epics",
+			ticks: []tick.Tick{
+				validTick("e1", tick.StatusOpen),
+				validTick("e2", tick.StatusOpen),
+				withBlockers(withParent(validTick("x", tick.StatusOpen), "e1"), "y"),
+				withBlockers(withParent(validTick("y", tick.StatusOpen), "e2"), "x"),
+				withBlockers(validTick("z", tick.StatusOpen), "x"),
+			},
+			want: "blocker_cycle:x[x,y]",
+		},
+		{
+			name:  "self block",
+			ticks: []tick.Tick{withBlockers(validTick("a", tick.StatusOpen), "a")},
+			want:  "blocker_cycle:a[a]",
+		},
+		{
+			name: "cycle through closed tick is not a deadlock",
+			ticks: []tick.Tick{
+				withBlockers(validTick("a", tick.StatusOpen), "b"),
+				withBlockers(validTick("b", tick.StatusClosed), "c"),
+				withBlockers(validTick("c", tick.StatusOpen), "a"),
+			},
+			want: "closed_open_blocker:b[c]",
+		},
+		{
+			name: "closed with open blocker",
+			ticks: []tick.Tick{
+				validTick("a", tick.StatusOpen),
+				withBlockers(validTick("b", tick.StatusClosed), "a"),
+			},
+			want: "closed_open_blocker:b[a]",
+		},
+		{
+			name: "closed but awaiting",
+			ticks: []tick.Tick{func() tick.Tick {
+				tk := validTick("a", tick.StatusClosed)
+				tk.Manual = true
+				return tk
+			}()},
+			want: "closed_awaiting:a[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := problemSummary(CheckGraph(tt.ticks, tt.present))
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
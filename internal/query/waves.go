@@ -0,0 +1,76 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+// Waves groups tasks into dependency levels that can be worked in parallel.
+// Wave 1 holds tasks with no open blockers among tasks; each later wave holds
+// tasks whose blockers are all in earlier waves. Blockers outside tasks, and
+// closed blockers, are ignored. Tasks within a wave are sorted by priority, then ID.
+// Tasks caught in a dependency cycle are left out of the waves and returned as
+// sorted IDs in cycle.
+func Waves(tasks []tick.Tick) (waves [][]tick.Tick, cycle []string) {
+	taskSet := make(map[string]tick.Tick, len(tasks))
+	for _, t := range tasks {
+		taskSet[t.ID] = t
+	}
+
+	inDegree := make(map[string]int, len(tasks))
+	blocks := make(map[string][]string)
+	for _, t := range tasks {
+		inDegree[t.ID] = 0
+	}
+	for _, t := range tasks {
+		for _, blockerID := range t.BlockedBy {
+			blocker, ok := taskSet[blockerID]
+			if ok && blocker.Status != tick.StatusClosed {
+				inDegree[t.ID]++
+				blocks[blockerID] = append(blocks[blockerID], t.ID)
+			}
+		}
+	}
+
+	remaining := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		remaining[t.ID] = true
+	}
+
+	for len(remaining) > 0 {
+		var ready []tick.Tick
+		for _, t := range tasks {
+			if remaining[t.ID] && inDegree[t.ID] == 0 {
+				ready = append(ready, t)
+			}
+		}
+
+		if len(ready) == 0 {
+			for id := range remaining {
+				cycle = append(cycle, id)
+			}
+			sort.Strings(cycle)
+			break
+		}
+
+		sort.Slice(ready, func(i, j int) bool {
+			if ready[i].Priority != ready[j].Priority {
+				return ready[i].Priority < ready[j].Priority
+			}
+			return ready[i].ID < ready[j].ID
+		})
+		waves = append(waves, ready)
+
+		for _, t := range ready {
+			delete(remaining, t.ID)
+			for _, dependentID := range blocks[t.ID] {
+				if remaining[dependentID] {
+					inDegree[dependentID]--
+				}
+			}
+		}
+	}
+
+	return waves, cycle
+}
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+func TestWaves(t *testing.T) {
+	tasks := []tick.Tick{
+		{ID: "d", Priority: 2, Status: tick.StatusOpen, BlockedBy: []string{"b", "c"}},
+		{ID: "c", Priority: 2, Status: tick.StatusOpen, BlockedBy: []string{"a"}},
+		{ID: "b", Priority: 1, Status: tick.StatusOpen, BlockedBy: []string{"a"}},
+		{ID: "a", Priority: 2, Status: tick.StatusOpen},
+		{ID: "e", Priority: 0, Status: tick.StatusOpen, BlockedBy: []string{"outside"}},
+		{ID: "f", Priority: 3, Status: tick.StatusOpen, BlockedBy: []string{"done"}},
+		{ID: "done", Priority: 1, Status: tick.StatusClosed},
+	}
+
+	waves, cycle := Waves(tasks)
+	if len(cycle) != 0 {
+		t.Fatalf("unexpected cycle: %v", cycle)
+	}
+
+	want := [][]string{{"e", "done", "a", "f"}, {"b", "c"}, {"d"}}
+	if len(waves) != len(want) {
+		t.Fatalf("expected %d waves, got %d: %+v", len(want), len(waves), waves)
+	}
+	for i, w := range waves {
+		if len(w) != len(want[i]) {
+			t.Fatalf("wave %d: expected %v, got %+v", i+1, want[i], w)
+		}
+		for j, tk := range w {
+			if tk.ID != want[i][j] {
+				t.Errorf("wave %d position %d: expected %s, got %s", i+1, j, want[i][j], tk.ID)
+			}
+		}
+	}
+}
+
+func TestWavesCycle(t *testing.T) {
+	tasks := []tick.Tick{
+		{ID: "a", Status: tick.StatusOpen},
+		{ID: "y", Status: tick.StatusOpen, BlockedBy: []string{"x"}},
+		{ID: "x", Status: tick.StatusOpen, BlockedBy: []string{"y"}},
+	}
+
+	waves, cycle := Waves(tasks)
+	if len(waves) != 1 || len(waves[0]) != 1 || waves[0][0].ID != "a" {
+		t.Fatalf("expected only a to be scheduled, got %+v", waves)
+	}
+	if len(cycle) != 2 || cycle[0] != "x" || cycle[1] != "y" {
+		t.Fatalf("expected cycle [x y], got %v", cycle)
+	}
+}
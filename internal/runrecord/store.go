@@ -90,6 +90,24 @@ func (s *Store) Delete(tickID string) error {
 	return nil
 }
 
+// Rename moves the run records of oldID to newID: the run record, any live
+// record, and for epics the status and live files.
+// Files that don't exist are skipped.
+func (s *Store) Rename(oldID, newID string) error {
+	moves := [][2]string{
+		{s.path(oldID), s.path(newID)},
+		{s.livePath(oldID), s.livePath(newID)},
+		{s.epicStatusPath(oldID), s.epicStatusPath(newID)},
+		{s.epicLivePath(oldID), s.epicLivePath(newID)},
+	}
+	for _, m := range moves {
+		if err := os.Rename(m[0], m[1]); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("rename run record: %w", err)
+		}
+	}
+	return nil
+}
+
 // List returns all tick IDs that have run records.
 func (s *Store) List() ([]string, error) {
 	entries, err := os.ReadDir(s.dir)
@@ -106,8 +124,9 @@ func (s *Store) List() ([]string, error) {
 			continue
 		}
 		name := entry.Name()
-		// Only include .json files, skip .live.json (future: in-progress runs)
-		if filepath.Ext(name) == ".json" && !isLiveFile(name) {
+		// Only include .json files, skip .live.json (in-progress runs)
+		// and epic status files
+		if filepath.Ext(name) == ".json" && !isLiveFile(name) && !IsEpicStatusFile(name) {
 			id := name[:len(name)-5] // strip .json
 			ids = append(ids, id)
 		}
@@ -136,7 +155,11 @@ func (s *Store) WriteLive(tickID string, snap agent.AgentStateSnapshot) error {
 
 	// Convert snapshot to a live record structure
 	liveRecord := snapshotToLiveRecord(snap)
+	return s.writeLive(tickID, &liveRecord)
+}
 
+// writeLive writes a live record atomically using a temp file + rename.
+func (s *Store) writeLive(tickID string, liveRecord *LiveRecord) error {
 	data, err := json.MarshalIndent(liveRecord, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal live record: %w", err)
@@ -158,6 +181,25 @@ func (s *Store) WriteLive(tickID string, snap agent.AgentStateSnapshot) error {
 	return nil
 }
 
+// MarkLiveInterrupted rewrites the live record with an interrupted status and
+// the given reason, so a run cut short ends in a terminal state when finalized.
+// If the live file doesn't exist, this is a no-op (returns nil).
+func (s *Store) MarkLiveInterrupted(tickID, reason string) error {
+	liveRecord, err := s.ReadLive(tickID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	liveRecord.Status = string(agent.StatusInterrupted)
+	liveRecord.ActiveTool = nil
+	liveRecord.ErrorMsg = reason
+	liveRecord.LastUpdated = time.Now()
+	return s.writeLive(tickID, liveRecord)
+}
+
 // FinalizeLive renames a .live.json file to .json, marking the run as complete.
 // If the live file doesn't exist, this is a no-op (returns nil).
 func (s *Store) FinalizeLive(tickID string) error {
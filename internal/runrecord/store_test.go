@@ -121,6 +121,45 @@ func TestStore_Delete(t *testing.T) {
 	}
 }
 
+func TestStore_Rename(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	if err := store.Write("old", &agent.RunRecord{SessionID: "done"}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := store.WriteLive("old", agent.AgentStateSnapshot{SessionID: "live"}); err != nil {
+		t.Fatalf("WriteLive failed: %v", err)
+	}
+
+	if err := store.Rename("old", "new"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+
+	if store.Exists("old") || store.LiveExists("old") {
+		t.Error("Records for old id still exist after rename")
+	}
+	record, err := store.Read("new")
+	if err != nil {
+		t.Fatalf("Read renamed record failed: %v", err)
+	}
+	if record.SessionID != "done" {
+		t.Errorf("SessionID = %q, want %q", record.SessionID, "done")
+	}
+	live, err := store.ReadLive("new")
+	if err != nil {
+		t.Fatalf("ReadLive renamed record failed: %v", err)
+	}
+	if live.SessionID != "live" {
+		t.Errorf("live SessionID = %q, want %q", live.SessionID, "live")
+	}
+
+	// Renaming an id without records should not error
+	if err := store.Rename("nonexistent", "other"); err != nil {
+		t.Errorf("Rename nonexistent returned error: %v", err)
+	}
+}
+
 func TestStore_List(t *testing.T) {
 	dir := t.TempDir()
 	store := NewStore(dir)
@@ -171,13 +210,18 @@ func TestStore_ListSkipsLiveFiles(t *testing.T) {
 		t.Fatalf("Failed to create file: %v", err)
 	}
 
+	// Create an epic status file (should be skipped)
+	if err := os.WriteFile(filepath.Join(runrecordsDir, "_epic-e1.status.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
 	ids, err := store.List()
 	if err != nil {
 		t.Fatalf("List failed: %v", err)
 	}
 
 	if len(ids) != 1 {
-		t.Errorf("Expected 1 item (excluding .live.json), got %d", len(ids))
+		t.Errorf("Expected 1 item (excluding .live.json and epic status), got %d", len(ids))
 	}
 	if len(ids) > 0 && ids[0] != "abc" {
 		t.Errorf("Expected 'abc', got %q", ids[0])
@@ -304,6 +348,52 @@ func TestStore_FinalizeLiveNoOp(t *testing.T) {
 	}
 }
 
+func TestStore_MarkLiveInterrupted(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	snap := agent.AgentStateSnapshot{
+		SessionID:  "interrupt-session",
+		StartedAt:  time.Now(),
+		Output:     "Working...",
+		Status:     agent.StatusToolUse,
+		ActiveTool: &agent.ToolActivity{Name: "Edit", StartedAt: time.Now()},
+		NumTurns:   3,
+	}
+	if err := store.WriteLive("int", snap); err != nil {
+		t.Fatalf("WriteLive failed: %v", err)
+	}
+
+	if err := store.MarkLiveInterrupted("int", "run interrupted: context canceled"); err != nil {
+		t.Fatalf("MarkLiveInterrupted failed: %v", err)
+	}
+
+	record, err := store.ReadLive("int")
+	if err != nil {
+		t.Fatalf("ReadLive failed: %v", err)
+	}
+	if record.Status != string(agent.StatusInterrupted) {
+		t.Errorf("Status = %q, want %q", record.Status, agent.StatusInterrupted)
+	}
+	if record.ErrorMsg != "run interrupted: context canceled" {
+		t.Errorf("ErrorMsg = %q", record.ErrorMsg)
+	}
+	if record.ActiveTool != nil {
+		t.Errorf("ActiveTool = %+v, want nil", record.ActiveTool)
+	}
+	if record.Output != "Working..." || record.NumTurns != 3 {
+		t.Errorf("expected the rest of the snapshot to be kept, got %+v", record)
+	}
+
+	// No live record is a no-op
+	if err := store.MarkLiveInterrupted("missing", "interrupted"); err != nil {
+		t.Errorf("MarkLiveInterrupted on missing record: %v", err)
+	}
+	if store.LiveExists("missing") {
+		t.Error("MarkLiveInterrupted should not create a live record")
+	}
+}
+
 func TestStore_DeleteLive(t *testing.T) {
 	dir := t.TempDir()
 	store := NewStore(dir)
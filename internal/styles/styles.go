@@ -9,7 +9,12 @@
 package styles
 
 import (
+	"os"
+	"regexp"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
@@ -65,6 +70,7 @@ var (
 	BoldStyle   = lipgloss.NewStyle().Bold(true)
 	Yellow      = lipgloss.NewStyle().Foreground(ColorYellow)
 	Dim         = lipgloss.NewStyle().Foreground(ColorDim)
+	MatchStyle  = lipgloss.NewStyle().Bold(true).Foreground(ColorYellow)
 )
 
 // Priority styles (aligned with web UI)
@@ -113,20 +119,58 @@ var (
 			Padding(0, 1)
 )
 
+// plain disables styling; see SetPlain.
+var plain bool
+
+// NoColorEnv reports whether the NO_COLOR environment variable requests
+// uncolored output (set and not empty, per https://no-color.org).
+func NoColorEnv() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// SetPlain switches between styled and plain output. In plain mode the Render
+// helpers return their input unchanged and styles rendered directly emit no
+// ANSI escape sequences.
+func SetPlain(p bool) {
+	if p == plain {
+		return
+	}
+	plain = p
+	if p {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return
+	}
+	// Fall back to detecting the terminal's capabilities again
+	lipgloss.SetDefaultRenderer(lipgloss.NewRenderer(os.Stdout))
+}
+
+// IsPlain reports whether plain output is enabled.
+func IsPlain() bool {
+	return plain
+}
+
+// render applies style to text unless plain output is enabled.
+func render(style lipgloss.Style, text string) string {
+	if plain {
+		return text
+	}
+	return style.Render(text)
+}
+
 // RenderPriority returns a color-coded priority string.
 func RenderPriority(priority int) string {
 	label := "P" + string(rune('0'+priority))
 	switch priority {
 	case 0:
-		return PriorityP0Style.Render(label)
+		return render(PriorityP0Style, label)
 	case 1:
-		return PriorityP1Style.Render(label)
+		return render(PriorityP1Style, label)
 	case 2:
-		return PriorityP2Style.Render(label)
+		return render(PriorityP2Style, label)
 	case 3:
-		return PriorityP3Style.Render(label)
+		return render(PriorityP3Style, label)
 	default:
-		return PriorityP4Style.Render(label)
+		return render(PriorityP4Style, label)
 	}
 }
 
@@ -134,11 +178,11 @@ func RenderPriority(priority int) string {
 func RenderStatus(status string) string {
 	switch status {
 	case tick.StatusOpen:
-		return StatusOpenStyle.Render(IconOpen)
+		return render(StatusOpenStyle, IconOpen)
 	case tick.StatusInProgress:
-		return StatusInProgressStyle.Render(IconInProgress)
+		return render(StatusInProgressStyle, IconInProgress)
 	case tick.StatusClosed:
-		return StatusClosedStyle.Render(IconClosed)
+		return render(StatusClosedStyle, IconClosed)
 	default:
 		return status
 	}
@@ -148,11 +192,11 @@ func RenderStatus(status string) string {
 func RenderStatusWithLabel(status string) string {
 	switch status {
 	case tick.StatusOpen:
-		return StatusOpenStyle.Render(IconOpen + " " + status)
+		return render(StatusOpenStyle, IconOpen+" "+status)
 	case tick.StatusInProgress:
-		return StatusInProgressStyle.Render(IconInProgress + " " + status)
+		return render(StatusInProgressStyle, IconInProgress+" "+status)
 	case tick.StatusClosed:
-		return StatusClosedStyle.Render(IconClosed + " " + status)
+		return render(StatusClosedStyle, IconClosed+" "+status)
 	default:
 		return status
 	}
@@ -162,7 +206,7 @@ func RenderStatusWithLabel(status string) string {
 // accounting for awaiting state. Awaiting ticks show yellow half-circle.
 func RenderTickStatus(t tick.Tick) string {
 	if t.IsAwaitingHuman() {
-		return StatusAwaitingStyle.Render(IconAwaiting)
+		return render(StatusAwaitingStyle, IconAwaiting)
 	}
 	return RenderStatus(t.Status)
 }
@@ -174,10 +218,10 @@ func RenderTickStatus(t tick.Tick) string {
 // 3. Status (open/in_progress/closed)
 func RenderTickStatusWithBlocked(t tick.Tick, isBlocked bool) string {
 	if t.IsAwaitingHuman() {
-		return StatusAwaitingStyle.Render(IconAwaiting)
+		return render(StatusAwaitingStyle, IconAwaiting)
 	}
 	if t.Status == tick.StatusOpen && isBlocked {
-		return StatusBlockedStyle.Render(IconBlocked)
+		return render(StatusBlockedStyle, IconBlocked)
 	}
 	return RenderStatus(t.Status)
 }
@@ -186,27 +230,55 @@ func RenderTickStatusWithBlocked(t tick.Tick, isBlocked bool) string {
 func RenderType(tickType string) string {
 	switch tickType {
 	case tick.TypeEpic:
-		return TypeEpicStyle.Render(tickType)
+		return render(TypeEpicStyle, tickType)
 	case tick.TypeBug:
-		return TypeBugStyle.Render(tickType)
+		return render(TypeBugStyle, tickType)
 	case tick.TypeFeature:
-		return TypeFeatureStyle.Render(tickType)
+		return render(TypeFeatureStyle, tickType)
 	case tick.TypeTask:
-		return TypeTaskStyle.Render(tickType)
+		return render(TypeTaskStyle, tickType)
 	case tick.TypeChore:
-		return TypeChoreStyle.Render(tickType)
+		return render(TypeChoreStyle, tickType)
 	default:
 		return tickType
 	}
 }
 
+// Summary styles tick.Tick.SummaryStyled lines for the terminal, coloring
+// the status by workflow state the way RenderTickStatusWithBlocked does.
+var Summary tick.SummaryStyle = summaryStyle{}
+
+type summaryStyle struct{}
+
+func (summaryStyle) ID(id string) string          { return RenderID(id) }
+func (summaryStyle) Priority(priority int) string { return RenderPriority(priority) }
+func (summaryStyle) Type(tickType string) string  { return RenderType(tickType) }
+
+func (summaryStyle) Status(status, decoration string) string {
+	style := StatusOpenStyle
+	switch {
+	case strings.HasPrefix(decoration, "awaiting"):
+		style = StatusAwaitingStyle
+	case decoration == "blocked":
+		style = StatusBlockedStyle
+	case status == tick.StatusInProgress:
+		style = StatusInProgressStyle
+	case status == tick.StatusClosed:
+		style = StatusClosedStyle
+	}
+	if decoration != "" {
+		status += " (" + decoration + ")"
+	}
+	return render(style, status)
+}
+
 // RenderVerdict returns a color-coded verdict string.
 func RenderVerdict(verdict string) string {
 	switch verdict {
 	case tick.VerdictApproved:
-		return VerdictApprovedStyle.Render(verdict)
+		return render(VerdictApprovedStyle, verdict)
 	case tick.VerdictRejected:
-		return VerdictRejectedStyle.Render(verdict)
+		return render(VerdictRejectedStyle, verdict)
 	default:
 		return verdict
 	}
@@ -214,25 +286,42 @@ func RenderVerdict(verdict string) string {
 
 // RenderID returns a styled tick ID.
 func RenderID(id string) string {
-	return BoldStyle.Render(id)
+	return render(BoldStyle, id)
 }
 
 // RenderOwner returns a styled owner string with @ prefix.
 func RenderOwner(owner string) string {
-	return DimStyle.Render("@" + owner)
+	return render(DimStyle, "@"+owner)
 }
 
 // RenderLabel renders a label with fixed width.
+// The width is kept in plain mode so columns still line up.
 func RenderLabel(label string) string {
 	return LabelStyle.Render(label)
 }
 
 // RenderHeader renders a section header.
 func RenderHeader(text string) string {
-	return HeaderStyle.Render(text)
+	return render(HeaderStyle, text)
 }
 
 // RenderDim renders text in dim style.
 func RenderDim(text string) string {
-	return DimStyle.Render(text)
+	return render(DimStyle, text)
+}
+
+// PadRight pads rendered text with spaces to width visible columns, ignoring
+// ANSI escape sequences so styled cells line up in tables.
+func PadRight(text string, width int) string {
+	if w := lipgloss.Width(text); w < width {
+		return text + strings.Repeat(" ", width-w)
+	}
+	return text
+}
+
+// RenderMatches highlights every match of re in text.
+func RenderMatches(text string, re *regexp.Regexp) string {
+	return re.ReplaceAllStringFunc(text, func(m string) string {
+		return render(MatchStyle, m)
+	})
 }
@@ -0,0 +1,126 @@
+package styles
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
+)
+
+// forceColor enables true color output for the test, as if on a terminal.
+func forceColor(t *testing.T) {
+	t.Helper()
+	SetPlain(false)
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() {
+		SetPlain(false)
+		lipgloss.SetDefaultRenderer(lipgloss.NewRenderer(os.Stdout))
+	})
+}
+
+func rendered() map[string]string {
+	return map[string]string{
+		"RenderStatus":                RenderStatus(tick.StatusOpen),
+		"RenderStatusWithLabel":       RenderStatusWithLabel(tick.StatusInProgress),
+		"RenderTickStatusWithBlocked": RenderTickStatusWithBlocked(tick.Tick{Status: tick.StatusOpen}, true),
+		"RenderPriority":              RenderPriority(0),
+		"RenderType":                  RenderType(tick.TypeBug),
+		"RenderVerdict":               RenderVerdict(tick.VerdictApproved),
+		"RenderID":                    RenderID("a1b"),
+		"RenderOwner":                 RenderOwner("petere"),
+		"RenderLabel":                 RenderLabel("Parent:"),
+		"RenderHeader":                RenderHeader("Notes:"),
+		"RenderDim":                   RenderDim("dim"),
+		"RenderMatches":               RenderMatches("fix the bug", regexp.MustCompile("bug")),
+		"BoxStyle":                    BoxStyle.Render("box"),
+		"HeaderStyle":                 HeaderStyle.Render("header"),
+		"Summary":                     tick.Tick{ID: "a1b", Priority: 1, Type: tick.TypeBug, Status: tick.StatusOpen, Title: "Fix auth"}.SummaryStyled(Summary, true),
+	}
+}
+
+func TestNoColor(t *testing.T) {
+	forceColor(t)
+
+	// Sanity check: with a color profile, styles emit escape sequences
+	if got := RenderStatus(tick.StatusOpen); !strings.Contains(got, "\x1b[") {
+		t.Fatalf("expected ANSI escapes with a color profile, got %q", got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if !NoColorEnv() {
+		t.Fatal("expected NO_COLOR to request plain output")
+	}
+	SetPlain(NoColorEnv())
+	if !IsPlain() {
+		t.Fatal("expected plain mode")
+	}
+
+	for name, got := range rendered() {
+		if strings.Contains(got, "\x1b") {
+			t.Errorf("%s emitted ANSI escapes with NO_COLOR: %q", name, got)
+		}
+	}
+	if got := RenderStatus(tick.StatusOpen); got != IconOpen {
+		t.Errorf("RenderStatus = %q, want %q", got, IconOpen)
+	}
+	if got := RenderPriority(1); got != "P1" {
+		t.Errorf("RenderPriority = %q, want P1", got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	awaiting := tick.AwaitingReview
+	tk := tick.Tick{ID: "a1b", Priority: 1, Type: tick.TypeBug, Status: tick.StatusOpen, Title: "Fix auth"}
+	review := tk
+	review.Status = tick.StatusInProgress
+	review.Awaiting = &awaiting
+
+	forceColor(t)
+	colored := tk.SummaryStyled(Summary, true)
+	if !strings.Contains(colored, render(StatusBlockedStyle, "open (blocked)")) {
+		t.Errorf("expected blocked status in blocked style, got %q", colored)
+	}
+	if got := review.SummaryStyled(Summary, false); !strings.Contains(got, render(StatusAwaitingStyle, "in_progress (awaiting review)")) {
+		t.Errorf("expected awaiting status in awaiting style, got %q", got)
+	}
+
+	// Plain output matches Tick.Summary.
+	SetPlain(true)
+	if got, want := tk.SummaryStyled(Summary, false), tk.Summary(); got != want {
+		t.Errorf("plain SummaryStyled = %q, want %q", got, want)
+	}
+	if got, want := review.SummaryStyled(Summary, false), review.Summary(); got != want {
+		t.Errorf("plain awaiting SummaryStyled = %q, want %q", got, want)
+	}
+	if got, want := tk.SummaryStyled(Summary, true), "a1b P1 bug open (blocked) — Fix auth"; got != want {
+		t.Errorf("plain blocked SummaryStyled = %q, want %q", got, want)
+	}
+}
+
+func TestNoColorEnvEmpty(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if NoColorEnv() {
+		t.Error("expected empty NO_COLOR to keep colors")
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	forceColor(t)
+
+	styled := RenderType(tick.TypeBug)
+	padded := PadRight(styled, 7)
+	if lipgloss.Width(padded) != 7 {
+		t.Errorf("PadRight width = %d, want 7 (%q)", lipgloss.Width(padded), padded)
+	}
+	if !strings.HasPrefix(padded, styled) {
+		t.Errorf("PadRight changed the styled text: %q", padded)
+	}
+	if got := PadRight("feature", 4); got != "feature" {
+		t.Errorf("PadRight truncated wide text: %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package tick
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -80,3 +81,92 @@ func TestWriteLogsActivity(t *testing.T) {
 		t.Errorf("Expected action 'create', got '%s'", activities[0].Action)
 	}
 }
+
+func TestWriteAsHistory(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), ".tick"))
+
+	now := time.Now()
+	tk := Tick{
+		ID:        "abc",
+		Title:     "Audit me",
+		Status:    StatusOpen,
+		Priority:  2,
+		Type:      TypeTask,
+		Owner:     "owner@example.com",
+		CreatedBy: "owner@example.com",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := store.WriteAs(tk, "alice"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	tk.Priority = 1
+	tk.Labels = []string{"urgent"}
+	tk.UpdatedAt = now.Add(time.Minute)
+	if err := store.WriteAs(tk, "bob"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	// A description change isn't an action detectChange knows about, but it
+	// still belongs in the audit trail.
+	tk.Description = "more detail"
+	tk.UpdatedAt = now.Add(2 * time.Minute)
+	if err := store.WriteAs(tk, "carol"); err != nil {
+		t.Fatalf("describe: %v", err)
+	}
+
+	// Touching only updated_at records nothing.
+	tk.UpdatedAt = now.Add(3 * time.Minute)
+	if err := store.WriteAs(tk, "dave"); err != nil {
+		t.Fatalf("touch: %v", err)
+	}
+
+	closedAt := now.Add(4 * time.Minute)
+	tk.Status = StatusClosed
+	tk.ClosedAt = &closedAt
+	tk.UpdatedAt = closedAt
+	if err := store.Write(tk); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	other := tk
+	other.ID = "xyz"
+	if err := store.WriteAs(other, "eve"); err != nil {
+		t.Fatalf("other: %v", err)
+	}
+
+	history, err := store.History("abc")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+
+	want := []struct {
+		action string
+		actor  string
+		fields []string
+	}{
+		{ActivityCreate, "alice", nil},
+		{ActivityUpdate, "bob", []string{"labels", "priority"}},
+		{ActivityUpdate, "carol", []string{"description"}},
+		{ActivityClose, "owner@example.com", []string{"closed_at", "status"}},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("expected %d history entries, got %d: %+v", len(want), len(history), history)
+	}
+	for i, w := range want {
+		a := history[i]
+		if a.TickID != "abc" || a.Action != w.action || a.Actor != w.actor {
+			t.Errorf("entry %d: got %s %s by %q, want %s by %q", i, a.TickID, a.Action, a.Actor, w.action, w.actor)
+		}
+		var fields []string
+		if raw, ok := a.Data["fields"].([]interface{}); ok {
+			for _, f := range raw {
+				fields = append(fields, f.(string))
+			}
+		}
+		if strings.Join(fields, ",") != strings.Join(w.fields, ",") {
+			t.Errorf("entry %d: fields = %v, want %v", i, fields, w.fields)
+		}
+	}
+}
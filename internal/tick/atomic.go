@@ -0,0 +1,41 @@
+package tick
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeTemp writes data to a temp file (for testing interrupted writes).
+var writeTemp = func(f *os.File, data []byte) (int, error) {
+	return f.Write(data)
+}
+
+// WriteFileAtomic writes data to a temp file in the same directory as path
+// and renames it into place, so readers see either the old or the new
+// contents, never a partial file. Temp files end in .tmp, which file
+// watchers filtering on .json ignore; the rename shows up as a single event.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := writeTemp(tmp, data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+	return nil
+}
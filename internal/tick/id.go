@@ -3,6 +3,7 @@ package tick
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 )
 
@@ -13,30 +14,77 @@ const (
 	maxAttempts  = 3
 )
 
+// UnambiguousIDChars is base36 without the easily confused 0/o and 1/l.
+const UnambiguousIDChars = "abcdefghijkmnpqrstuvwxyz23456789"
+
+// IDPrefixSeparator joins a type prefix to the random part of an ID.
+const IDPrefixSeparator = "-"
+
 // IDGenerator produces random base36 tick IDs.
 type IDGenerator struct {
-	rng *rand.Rand
+	rng         *rand.Rand
+	alphabet    string
+	maxAttempts int
+}
+
+// IDGeneratorOption configures an IDGenerator.
+type IDGeneratorOption func(*IDGenerator)
+
+// WithIDAlphabet draws ID characters from chars instead of base36,
+// e.g. UnambiguousIDChars. Generation fails if chars has fewer than two
+// distinct characters.
+func WithIDAlphabet(chars string) IDGeneratorOption {
+	return func(g *IDGenerator) {
+		g.alphabet = chars
+	}
+}
+
+// WithMaxAttempts sets how many candidates are tried at each length before
+// growing it. Values of zero or less keep the default of 3.
+func WithMaxAttempts(n int) IDGeneratorOption {
+	return func(g *IDGenerator) {
+		if n > 0 {
+			g.maxAttempts = n
+		}
+	}
 }
 
 // NewIDGenerator returns a generator. If rng is nil, a time-based source is used.
-func NewIDGenerator(rng *rand.Rand) *IDGenerator {
+func NewIDGenerator(rng *rand.Rand, opts ...IDGeneratorOption) *IDGenerator {
 	if rng == nil {
 		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 	}
-	return &IDGenerator{rng: rng}
+	g := &IDGenerator{rng: rng, alphabet: base36Chars, maxAttempts: maxAttempts}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
 }
 
 // Generate returns a new ID, possibly bumping the length to 4 on collisions.
 // The returned length indicates the ID length used.
 func (g *IDGenerator) Generate(exists func(string) bool, length int) (string, int, error) {
+	return g.GenerateWithPrefix(exists, length, "")
+}
+
+// GenerateWithPrefix is like Generate but prepends prefix and a dash to the
+// random part (e.g. "bug-a1b"). An empty prefix yields a plain ID.
+// The returned length is the length of the random part.
+func (g *IDGenerator) GenerateWithPrefix(exists func(string) bool, length int, prefix string) (string, int, error) {
 	if length < minIDLength || length > maxIDLength {
 		return "", length, fmt.Errorf("id_length must be %d-%d", minIDLength, maxIDLength)
 	}
+	if distinctChars(g.alphabet) < 2 {
+		return "", length, fmt.Errorf("id alphabet %q must have at least 2 distinct characters", g.alphabet)
+	}
 
 	currentLength := length
 	for {
-		for attempt := 0; attempt < maxAttempts; attempt++ {
+		for attempt := 0; attempt < g.maxAttempts; attempt++ {
 			candidate := g.randomID(currentLength)
+			if prefix != "" {
+				candidate = prefix + IDPrefixSeparator + candidate
+			}
 			if !exists(candidate) {
 				return candidate, currentLength, nil
 			}
@@ -50,13 +98,42 @@ func (g *IDGenerator) Generate(exists func(string) bool, length int) (string, in
 		break
 	}
 
-	return "", currentLength, fmt.Errorf("unable to generate unique id after %d attempts", maxAttempts)
+	return "", currentLength, fmt.Errorf("unable to generate unique id after %d attempts at length %d-%d", g.maxAttempts, length, maxIDLength)
 }
 
 func (g *IDGenerator) randomID(length int) string {
 	buf := make([]byte, length)
 	for i := range buf {
-		buf[i] = base36Chars[g.rng.Intn(len(base36Chars))]
+		buf[i] = g.alphabet[g.rng.Intn(len(g.alphabet))]
 	}
 	return string(buf)
 }
+
+// ValidID reports whether id has the shape of a tick ID: lowercase letters
+// and digits, optionally after a prefix and IDPrefixSeparator (e.g. "a1b" or
+// "bug-a1b").
+func ValidID(id string) bool {
+	parts := strings.Split(id, IDPrefixSeparator)
+	if len(parts) > 2 {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return false
+		}
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func distinctChars(s string) int {
+	seen := map[rune]bool{}
+	for _, r := range s {
+		seen[r] = true
+	}
+	return len(seen)
+}
@@ -65,3 +65,129 @@ func TestIDGeneratorRejectsInvalidLength(t *testing.T) {
 		t.Fatalf("expected error for invalid length")
 	}
 }
+
+func TestIDGeneratorWithPrefix(t *testing.T) {
+	gen := NewIDGenerator(rand.New(rand.NewSource(4)))
+	id, length, err := gen.GenerateWithPrefix(func(string) bool { return false }, 3, "bug")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if length != 3 {
+		t.Fatalf("expected length 3, got %d", length)
+	}
+	if !strings.HasPrefix(id, "bug-") || len(id) != len("bug-")+3 {
+		t.Fatalf("expected bug-xxx id, got %q", id)
+	}
+
+	var seen []string
+	exists := func(candidate string) bool {
+		seen = append(seen, candidate)
+		return len(seen) <= maxAttempts
+	}
+	id, length, err = gen.GenerateWithPrefix(exists, 3, "epic")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if length != 4 || len(id) != len("epic-")+4 {
+		t.Fatalf("expected bumped epic-xxxx id, got %q (length %d)", id, length)
+	}
+	for _, candidate := range seen {
+		if !strings.HasPrefix(candidate, "epic-") {
+			t.Fatalf("collision check saw unprefixed candidate %q", candidate)
+		}
+	}
+}
+
+func TestIDGeneratorDefaultsUnchanged(t *testing.T) {
+	plain := NewIDGenerator(rand.New(rand.NewSource(5)))
+	withZero := NewIDGenerator(rand.New(rand.NewSource(5)), WithMaxAttempts(0))
+	for i := 0; i < 20; i++ {
+		a, _, errA := plain.Generate(func(string) bool { return false }, 3)
+		b, _, errB := withZero.Generate(func(string) bool { return false }, 3)
+		if errA != nil || errB != nil {
+			t.Fatalf("unexpected errors: %v, %v", errA, errB)
+		}
+		if a != b {
+			t.Fatalf("expected identical ids from the same seed, got %q and %q", a, b)
+		}
+	}
+}
+
+func TestIDGeneratorAlphabet(t *testing.T) {
+	gen := NewIDGenerator(rand.New(rand.NewSource(6)), WithIDAlphabet(UnambiguousIDChars))
+	for i := 0; i < 200; i++ {
+		id, _, err := gen.Generate(func(string) bool { return false }, 4)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.ContainsAny(id, "0o1l") {
+			t.Fatalf("ambiguous character in id %q", id)
+		}
+	}
+
+	bad := NewIDGenerator(rand.New(rand.NewSource(6)), WithIDAlphabet("aaa"))
+	if _, _, err := bad.Generate(func(string) bool { return false }, 3); err == nil {
+		t.Fatal("expected error for an alphabet with one distinct character")
+	}
+}
+
+func TestIDGeneratorManyCollisionsGrowLength(t *testing.T) {
+	// With alphabet "ab" every 3-character id is taken, so the generator must
+	// grow to 4 characters to find a free one
+	taken := map[string]bool{}
+	for _, a := range "ab" {
+		for _, b := range "ab" {
+			for _, c := range "ab" {
+				taken[string([]rune{a, b, c})] = true
+			}
+		}
+	}
+	// Block most 4-character ids too so several attempts are needed there
+	for id := range taken {
+		taken[id+"a"] = true
+	}
+
+	gen := NewIDGenerator(rand.New(rand.NewSource(7)), WithIDAlphabet("ab"), WithMaxAttempts(50))
+	calls := 0
+	id, length, err := gen.Generate(func(candidate string) bool {
+		calls++
+		return taken[candidate]
+	}, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if length != 4 || len(id) != 4 {
+		t.Fatalf("expected grown 4-character id, got %q (length %d)", id, length)
+	}
+	if taken[id] {
+		t.Fatalf("returned taken id %q", id)
+	}
+	if calls <= 50 {
+		t.Fatalf("expected all 50 attempts at length 3 before growing, got %d checks", calls)
+	}
+
+	// Once every id of every allowed length is taken, generation gives up
+	for id := range taken {
+		taken[id[:3]+"b"] = true
+	}
+	_, _, err = gen.Generate(func(candidate string) bool { return taken[candidate] }, 3)
+	if err == nil {
+		t.Fatal("expected error when no free id exists within bounds")
+	}
+	if !strings.Contains(err.Error(), "50 attempts") {
+		t.Fatalf("expected error to mention the attempt limit, got %v", err)
+	}
+}
+
+func TestValidID(t *testing.T) {
+	for _, id := range []string{"a1b", "abcd", "bug-a1b", "x"} {
+		if !ValidID(id) {
+			t.Errorf("expected %q to be valid", id)
+		}
+	}
+	for _, id := range []string{"", "A1B", "a b", "bug-", "-a1b", "a-b-c", "a_b", "../x", "a1b.json"} {
+		if ValidID(id) {
+			t.Errorf("expected %q to be invalid", id)
+		}
+	}
+}
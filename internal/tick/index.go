@@ -0,0 +1,178 @@
+package tick
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// IndexFileName is the gitignored index cache inside the .tick directory.
+const IndexFileName = ".index.json"
+
+// Index is a cached snapshot of all ticks, with the modification time, size
+// and identity of each tick file so stale entries can be detected per file.
+// It holds full ticks so List can serve unchanged ticks without reading their
+// files.
+//
+// List only reads the index. It is written by Reindex and kept current by
+// the store's own writes and deletes; files changed behind the store's back
+// (a git pull, an editor) are re-read by List until the next Reindex.
+type Index struct {
+	BuiltAt time.Time            `json:"built_at"`
+	Ticks   []Tick               `json:"ticks"`
+	Files   map[string]IndexFile `json:"files,omitempty"`
+}
+
+// IndexFile records the state of a tick file when it was indexed.
+type IndexFile struct {
+	ModTime time.Time `json:"mtime"`
+	Size    int64     `json:"size"`
+	// Inode identifies the file on systems that have inodes (0 elsewhere).
+	// Tick files are replaced by rename on every write, so a rewrite that
+	// keeps the size within the mtime resolution still gets a new inode.
+	Inode uint64 `json:"inode,omitempty"`
+}
+
+// newIndexFile returns the indexed state of the file described by info.
+func newIndexFile(info os.FileInfo) IndexFile {
+	return IndexFile{ModTime: info.ModTime(), Size: info.Size(), Inode: fileInode(info)}
+}
+
+// matches reports whether info describes the same file state.
+func (f IndexFile) matches(info os.FileInfo) bool {
+	return f.Size == info.Size() && f.ModTime.Equal(info.ModTime()) && f.Inode == fileInode(info)
+}
+
+// IndexPath returns the path of the index cache.
+func (s *Store) IndexPath() string {
+	return filepath.Join(s.Root, IndexFileName)
+}
+
+// Reindex rebuilds the index cache from every tick file and returns the
+// number of ticks indexed. Once an index exists, the store's writes and
+// deletes keep it up to date.
+func (s *Store) Reindex() (int, error) {
+	ticks, files, err := s.scanWithIndex(nil)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.saveIndex(ticks, files); err != nil {
+		return 0, err
+	}
+	return len(ticks), nil
+}
+
+// listIndexed lists ticks using the index cache, re-reading only files that
+// changed since they were indexed. The index itself is left untouched.
+func (s *Store) listIndexed(idx Index) ([]Tick, error) {
+	ticks, _, err := s.scanWithIndex(&idx)
+	return ticks, err
+}
+
+// updateIndex records a write (t non-nil) or delete (t nil) of tick id in
+// the index cache, if there is one. Best effort: an entry that is lost, for
+// example to a concurrent update, no longer matches its file and is re-read.
+func (s *Store) updateIndex(id string, t *Tick) {
+	idx, ok := s.loadIndex()
+	if !ok {
+		return
+	}
+	if idx.Files == nil {
+		idx.Files = make(map[string]IndexFile)
+	}
+
+	ticks := slices.DeleteFunc(idx.Ticks, func(cached Tick) bool { return cached.ID == id })
+	delete(idx.Files, id)
+	if t != nil {
+		info, err := os.Stat(s.tickPath(id))
+		if err != nil {
+			return
+		}
+		ticks = append(ticks, *t)
+		idx.Files[id] = newIndexFile(info)
+	}
+	_ = s.saveIndex(ticks, idx.Files)
+}
+
+// scanWithIndex lists all tick files, taking unchanged ticks from idx (if
+// non-nil) and reading the rest. Returns the ticks and their file states.
+func (s *Store) scanWithIndex(idx *Index) ([]Tick, map[string]IndexFile, error) {
+	entries, err := os.ReadDir(s.issuesDir())
+	if err != nil {
+		return nil, nil, fmt.Errorf("read issues dir: %w", err)
+	}
+
+	cached := make(map[string]Tick)
+	if idx != nil {
+		for _, t := range idx.Ticks {
+			cached[t.ID] = t
+		}
+	}
+
+	var ticks []Tick
+	files := make(map[string]IndexFile)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		id := entry.Name()[:len(entry.Name())-len(".json")]
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, nil, fmt.Errorf("stat tick %s: %w", id, err)
+		}
+
+		t, hit := cached[id]
+		if f, ok := idx.fileState(id); !hit || !ok || !f.matches(info) {
+			t, err = s.Read(id)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		ticks = append(ticks, t)
+		files[id] = newIndexFile(info)
+	}
+
+	return ticks, files, nil
+}
+
+// fileState returns the indexed state of a tick file, if any.
+func (idx *Index) fileState(id string) (IndexFile, bool) {
+	if idx == nil {
+		return IndexFile{}, false
+	}
+	f, ok := idx.Files[id]
+	return f, ok
+}
+
+// loadIndex reads the index cache. ok is false if there is no usable index.
+func (s *Store) loadIndex() (Index, bool) {
+	data, err := os.ReadFile(s.IndexPath())
+	if err != nil {
+		return Index{}, false
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return Index{}, false
+	}
+	return idx, true
+}
+
+// saveIndex writes the index cache atomically so concurrent readers never
+// see a partial file.
+func (s *Store) saveIndex(ticks []Tick, files map[string]IndexFile) error {
+	idx := Index{BuiltAt: time.Now().UTC(), Ticks: ticks, Files: files}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("encode index: %w", err)
+	}
+
+	if err := WriteFileAtomic(s.IndexPath(), data, 0o644); err != nil {
+		return fmt.Errorf("write index: %w", err)
+	}
+	return nil
+}
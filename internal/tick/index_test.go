@@ -0,0 +1,181 @@
+package tick
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func indexTestTick(id, title string) Tick {
+	now := time.Date(2025, 1, 8, 10, 30, 0, 0, time.UTC)
+	return Tick{
+		ID:        id,
+		Title:     title,
+		Status:    StatusOpen,
+		Priority:  2,
+		Type:      TypeTask,
+		Owner:     "petere",
+		CreatedBy: "petere",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func listTitles(t *testing.T, store *Store) map[string]string {
+	t.Helper()
+	ticks, err := store.List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	titles := make(map[string]string, len(ticks))
+	for _, tk := range ticks {
+		titles[tk.ID] = tk.Title
+	}
+	return titles
+}
+
+func TestStoreIndex(t *testing.T) {
+	root := filepath.Join(t.TempDir(), ".tick")
+	store := NewStore(root)
+
+	for _, tk := range []Tick{indexTestTick("a1b", "Fix auth"), indexTestTick("c2d", "Add docs")} {
+		if err := store.Write(tk); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+	}
+
+	count, err := store.Reindex()
+	if err != nil {
+		t.Fatalf("reindex: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 ticks indexed, got %d", count)
+	}
+
+	// Same size and mtime: served from the index without re-reading
+	path := filepath.Join(root, "issues", "a1b.json")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	edited := strings.Replace(string(data), "Fix auth", "Fix AUTH", 1)
+	if err := os.WriteFile(path, []byte(edited), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	if got := listTitles(t, store)["a1b"]; got != "Fix auth" {
+		t.Fatalf("expected cached title, got %q", got)
+	}
+
+	// Newer mtime: re-read, without List rewriting the index
+	before, err := os.ReadFile(store.IndexPath())
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	later := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	if got := listTitles(t, store)["a1b"]; got != "Fix AUTH" {
+		t.Fatalf("expected re-read title, got %q", got)
+	}
+	if after, _ := os.ReadFile(store.IndexPath()); string(after) != string(before) {
+		t.Fatalf("expected List to leave the index alone")
+	}
+
+	// Same size and mtime but a replaced file: re-read
+	if fileInode(info) != 0 {
+		replaced := strings.Replace(edited, "Fix AUTH", "Fix Auth", 1)
+		if err := WriteFileAtomic(path, []byte(replaced), 0o644); err != nil {
+			t.Fatalf("replace file: %v", err)
+		}
+		if err := os.Chtimes(path, later, later); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+		if got := listTitles(t, store)["a1b"]; got != "Fix Auth" {
+			t.Fatalf("expected re-read title after replace, got %q", got)
+		}
+	}
+
+	// New and deleted files
+	if err := store.Write(indexTestTick("e3f", "New tick")); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+	if err := store.Delete("c2d"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	titles := listTitles(t, store)
+	if len(titles) != 2 || titles["e3f"] != "New tick" {
+		t.Fatalf("expected a1b and e3f, got %v", titles)
+	}
+
+	idx, ok := store.loadIndex()
+	if !ok {
+		t.Fatalf("expected index to be readable")
+	}
+	if _, ok := idx.Files["c2d"]; ok || len(idx.Files) != 2 || len(idx.Ticks) != 2 {
+		t.Fatalf("expected index updated by write and delete, got files %v", idx.Files)
+	}
+	for _, tk := range idx.Ticks {
+		if tk.ID == "e3f" && tk.Title != "New tick" {
+			t.Fatalf("expected written tick in index, got %+v", tk)
+		}
+	}
+}
+
+func TestStoreIndexConcurrentWrites(t *testing.T) {
+	root := filepath.Join(t.TempDir(), ".tick")
+	store := NewStore(root)
+
+	if err := store.Write(indexTestTick("a1b", "Fix auth")); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+	if _, err := store.Reindex(); err != nil {
+		t.Fatalf("reindex: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Each write rewrites the index while others list
+			tk := indexTestTick("a1b", fmt.Sprintf("Fix auth %d", i))
+			if err := store.Write(tk); err != nil {
+				t.Errorf("write: %v", err)
+			}
+			if _, err := store.List(); err != nil {
+				t.Errorf("list: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(store.IndexPath())
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		t.Fatalf("index corrupted: %v", err)
+	}
+	if len(idx.Ticks) != 1 {
+		t.Fatalf("expected 1 indexed tick, got %d", len(idx.Ticks))
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(root, IndexFileName+".*.tmp"))
+	if len(leftovers) != 0 {
+		t.Fatalf("expected no temp files, got %v", leftovers)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package tick
+
+import "os"
+
+// fileInode returns 0: os.FileInfo carries no file identity on Windows, so
+// the index compares mtime and size only.
+func fileInode(info os.FileInfo) uint64 {
+	return 0
+}
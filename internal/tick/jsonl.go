@@ -0,0 +1,95 @@
+package tick
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Import conflict modes: what to do when an imported tick's ID already exists.
+const (
+	ConflictSkip      = "skip"      // keep the existing tick
+	ConflictOverwrite = "overwrite" // replace it
+	ConflictNewer     = "newer"     // replace it if the imported tick has a later UpdatedAt
+)
+
+// ImportResult summarizes a JSON-lines import.
+type ImportResult struct {
+	Imported    int `json:"imported"`
+	Overwritten int `json:"overwritten"`
+	Skipped     int `json:"skipped"`
+}
+
+// WriteJSONL writes ticks as JSON lines, one tick per line.
+func WriteJSONL(w io.Writer, ticks []Tick) error {
+	enc := json.NewEncoder(w)
+	for _, t := range ticks {
+		if err := enc.Encode(t); err != nil {
+			return fmt.Errorf("encode tick %s: %w", t.ID, err)
+		}
+	}
+	return nil
+}
+
+// ReadJSONL reads JSON-lines ticks, validating each. Blank lines are skipped.
+// Errors name the offending line.
+func ReadJSONL(r io.Reader) ([]Tick, error) {
+	var ticks []Tick
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var t Tick
+		if err := json.Unmarshal(scanner.Bytes(), &t); err != nil {
+			return nil, fmt.Errorf("line %d: parse tick: %w", line, err)
+		}
+		if err := t.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: invalid tick %s: %w", line, t.ID, err)
+		}
+		ticks = append(ticks, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ticks: %w", err)
+	}
+	return ticks, nil
+}
+
+// Import writes ticks through Write, resolving existing IDs per onConflict
+// (ConflictSkip, ConflictOverwrite, or ConflictNewer).
+func (s *Store) Import(ticks []Tick, onConflict string) (ImportResult, error) {
+	var result ImportResult
+	switch onConflict {
+	case ConflictSkip, ConflictOverwrite, ConflictNewer:
+	default:
+		return result, fmt.Errorf("invalid conflict mode: %s (must be skip, overwrite, or newer)", onConflict)
+	}
+
+	for _, t := range ticks {
+		existing, err := s.Read(t.ID)
+		exists := err == nil
+		if exists {
+			switch {
+			case onConflict == ConflictSkip,
+				onConflict == ConflictNewer && !t.UpdatedAt.After(existing.UpdatedAt):
+				result.Skipped++
+				continue
+			}
+		}
+
+		if err := s.Write(t); err != nil {
+			return result, err
+		}
+		if exists {
+			result.Overwritten++
+		} else {
+			result.Imported++
+		}
+	}
+	return result, nil
+}
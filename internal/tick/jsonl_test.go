@@ -0,0 +1,129 @@
+package tick
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONLRoundTrip(t *testing.T) {
+	src := NewStore(filepath.Join(t.TempDir(), ".tick"))
+
+	closedAt := time.Date(2025, 1, 9, 8, 0, 0, 0, time.UTC)
+	awaiting := AwaitingReview
+	full := indexTestTick("a1b", "Fix auth")
+	full.Description = "Line one\nLine two"
+	full.Labels = []string{"security"}
+	full.BlockedBy = []string{"c2d"}
+	full.Awaiting = &awaiting
+	closed := indexTestTick("c2d", "Add docs")
+	closed.Status = StatusClosed
+	closed.ClosedAt = &closedAt
+	closed.ClosedReason = "done"
+	for _, tk := range []Tick{full, closed, indexTestTick("e3f", "Plain")} {
+		if err := src.Write(tk); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+	}
+
+	exported, err := src.List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, exported); err != nil {
+		t.Fatalf("write jsonl: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Fatalf("expected 3 lines, got %d", lines)
+	}
+
+	parsed, err := ReadJSONL(&buf)
+	if err != nil {
+		t.Fatalf("read jsonl: %v", err)
+	}
+	dst := NewStore(filepath.Join(t.TempDir(), ".tick"))
+	result, err := dst.Import(parsed, ConflictSkip)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.Imported != 3 {
+		t.Fatalf("expected 3 imported, got %+v", result)
+	}
+
+	imported, err := dst.List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	byID := func(ticks []Tick) {
+		sort.Slice(ticks, func(i, j int) bool { return ticks[i].ID < ticks[j].ID })
+	}
+	byID(exported)
+	byID(imported)
+	if !reflect.DeepEqual(exported, imported) {
+		t.Fatalf("round trip mismatch:\nexported %+v\nimported %+v", exported, imported)
+	}
+}
+
+func TestReadJSONLInvalid(t *testing.T) {
+	input := `{"id":"a1b","title":"ok","status":"open","priority":2,"type":"task","owner":"x","created_by":"x","created_at":"2025-01-08T10:30:00Z","updated_at":"2025-01-08T10:30:00Z"}
+
+{"id":"c2d","title":"","status":"open","type":"task"}
+`
+	_, err := ReadJSONL(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("expected validation error on line 3, got %v", err)
+	}
+}
+
+func TestStoreImportConflicts(t *testing.T) {
+	base := time.Date(2025, 1, 8, 10, 30, 0, 0, time.UTC)
+	existing := indexTestTick("a1b", "Local")
+
+	older := existing
+	older.Title = "Older"
+	older.UpdatedAt = base.Add(-time.Hour)
+	newer := existing
+	newer.Title = "Newer"
+	newer.UpdatedAt = base.Add(time.Hour)
+
+	tests := []struct {
+		mode      string
+		incoming  Tick
+		wantTitle string
+		want      ImportResult
+	}{
+		{ConflictSkip, newer, "Local", ImportResult{Skipped: 1}},
+		{ConflictOverwrite, older, "Older", ImportResult{Overwritten: 1}},
+		{ConflictNewer, older, "Local", ImportResult{Skipped: 1}},
+		{ConflictNewer, newer, "Newer", ImportResult{Overwritten: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode+"_"+tt.incoming.Title, func(t *testing.T) {
+			store := NewStore(filepath.Join(t.TempDir(), ".tick"))
+			if err := store.Write(existing); err != nil {
+				t.Fatalf("write tick: %v", err)
+			}
+			result, err := store.Import([]Tick{tt.incoming}, tt.mode)
+			if err != nil {
+				t.Fatalf("import: %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("result = %+v, want %+v", result, tt.want)
+			}
+			got, _ := store.Read("a1b")
+			if got.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", got.Title, tt.wantTitle)
+			}
+		})
+	}
+
+	store := NewStore(filepath.Join(t.TempDir(), ".tick"))
+	if _, err := store.Import(nil, "merge"); err == nil {
+		t.Errorf("expected error for invalid conflict mode")
+	}
+}
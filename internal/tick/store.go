@@ -1,10 +1,16 @@
 package tick
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -23,21 +29,26 @@ func (s *Store) Ensure() error {
 	return os.MkdirAll(s.issuesDir(), 0o755)
 }
 
-// Read loads a tick by ID.
+// Read loads a tick by ID. The tick is validated, so a hand-edited file
+// with a bad field fails here, with an error naming the file.
 func (s *Store) Read(id string) (Tick, error) {
 	path := s.tickPath(id)
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return Tick{}, fmt.Errorf("read tick %s: %w", id, err)
 	}
+	return parseTick(id, path, data)
+}
 
+// parseTick decodes and validates the contents of the tick file at path.
+func parseTick(id, path string, data []byte) (Tick, error) {
 	var t Tick
 	if err := json.Unmarshal(data, &t); err != nil {
-		return Tick{}, fmt.Errorf("parse tick %s: %w", id, err)
+		return Tick{}, fmt.Errorf("parse tick %s (%s): %w", id, path, err)
 	}
 
 	if err := t.Validate(); err != nil {
-		return Tick{}, fmt.Errorf("invalid tick %s: %w", id, err)
+		return Tick{}, fmt.Errorf("invalid tick %s (%s): %w", id, path, err)
 	}
 
 	return t, nil
@@ -50,7 +61,8 @@ func (s *Store) Write(t Tick) error {
 }
 
 // WriteAs saves a tick and logs activity with the specified actor.
-// If actor is empty, uses t.Owner. Auto-detects the action type.
+// If actor is empty, uses t.Owner. Auto-detects the action type and records
+// the names of the changed fields, which History reads back.
 func (s *Store) WriteAs(t Tick, actor string) error {
 	if err := s.Ensure(); err != nil {
 		return fmt.Errorf("ensure issues dir: %w", err)
@@ -68,23 +80,10 @@ func (s *Store) WriteAs(t Tick, actor string) error {
 		return fmt.Errorf("encode tick %s: %w", t.ID, err)
 	}
 
-	tmp, err := os.CreateTemp(s.issuesDir(), t.ID+".*.tmp")
-	if err != nil {
-		return fmt.Errorf("create temp file: %w", err)
-	}
-	defer os.Remove(tmp.Name())
-
-	if _, err := tmp.Write(data); err != nil {
-		_ = tmp.Close()
-		return fmt.Errorf("write temp file: %w", err)
-	}
-	if err := tmp.Close(); err != nil {
-		return fmt.Errorf("close temp file: %w", err)
-	}
-
-	if err := os.Rename(tmp.Name(), s.tickPath(t.ID)); err != nil {
-		return fmt.Errorf("rename temp file: %w", err)
+	if err := WriteFileAtomic(s.tickPath(t.ID), data, 0o644); err != nil {
+		return fmt.Errorf("write tick %s: %w", t.ID, err)
 	}
+	s.updateIndex(t.ID, &t)
 
 	// Log activity (synchronous but ignore errors - non-critical)
 	if actor == "" {
@@ -105,15 +104,22 @@ func (s *Store) logTickChange(t Tick, old Tick, isNew bool, actor string) {
 		data = map[string]interface{}{"title": t.Title, "type": t.Type, "priority": t.Priority}
 	} else {
 		// Detect what changed
+		fields := changedFields(old, t)
 		action, data = detectChange(old, t)
 		if action == "" {
-			return // No significant change detected
+			if len(fields) == 0 {
+				return // No change besides updated_at
+			}
+			action = ActivityUpdate
 		}
 		// Always include title for context
 		if data == nil {
 			data = make(map[string]interface{})
 		}
 		data["title"] = t.Title
+		if len(fields) > 0 {
+			data["fields"] = fields
+		}
 	}
 
 	_ = s.LogActivity(t.ID, action, actor, t.Parent, data)
@@ -206,27 +212,67 @@ func detectChange(old, new Tick) (string, map[string]interface{}) {
 	return "", nil // No change detected
 }
 
+// changedFields returns the sorted JSON names of the fields that differ
+// between old and new, ignoring updated_at.
+func changedFields(old, new Tick) []string {
+	oldFields, err1 := jsonFields(old)
+	newFields, err2 := jsonFields(new)
+	if err1 != nil || err2 != nil {
+		return nil
+	}
+	var changed []string
+	for name, v := range newFields {
+		if o, ok := oldFields[name]; !ok || !bytes.Equal(o, v) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range oldFields {
+		if _, ok := newFields[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	changed = slices.DeleteFunc(changed, func(name string) bool { return name == "updated_at" })
+	sort.Strings(changed)
+	return changed
+}
+
+// jsonFields splits a tick into its encoded JSON fields.
+func jsonFields(t Tick) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Delete removes a tick file by ID.
 func (s *Store) Delete(id string) error {
 	if err := os.Remove(s.tickPath(id)); err != nil {
 		return fmt.Errorf("delete tick %s: %w", id, err)
 	}
+	s.updateIndex(id, nil)
 	return nil
 }
 
 // List loads all ticks under .tick/issues.
+// If an index cache exists (see Reindex), unchanged ticks are served from it
+// and only modified files are read. List never writes the index.
 func (s *Store) List() ([]Tick, error) {
-	entries, err := os.ReadDir(s.issuesDir())
+	if idx, ok := s.loadIndex(); ok {
+		return s.listIndexed(idx)
+	}
+
+	ids, err := s.scanIDs()
 	if err != nil {
-		return nil, fmt.Errorf("read issues dir: %w", err)
+		return nil, err
 	}
 
 	var ticks []Tick
-	for _, entry := range entries {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
-			continue
-		}
-		id := entry.Name()[:len(entry.Name())-len(".json")]
+	for _, id := range ids {
 		t, err := s.Read(id)
 		if err != nil {
 			return nil, err
@@ -237,6 +283,151 @@ func (s *Store) List() ([]Tick, error) {
 	return ticks, nil
 }
 
+// ReadMany loads the given ticks in a single pass over the issues
+// directory, stopping once every ID has been found. Ticks that are missing
+// or fail to load are reported in errs, in the order of ids; the rest are
+// returned keyed by ID, so one bad ID does not fail the whole batch.
+func (s *Store) ReadMany(ids []string) (map[string]Tick, []error) {
+	out := make(map[string]Tick, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	pending := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+	failed := make(map[string]error)
+
+	dir, err := os.Open(s.issuesDir())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return out, []error{fmt.Errorf("read issues dir: %w", err)}
+	}
+	if dir != nil {
+		defer dir.Close()
+		for len(pending) > 0 {
+			entries, err := dir.ReadDir(256)
+			for _, entry := range entries {
+				name := entry.Name()
+				id := strings.TrimSuffix(name, ".json")
+				if entry.IsDir() || id == name || !pending[id] {
+					continue
+				}
+				delete(pending, id)
+				path := filepath.Join(dir.Name(), name)
+				data, err := os.ReadFile(path)
+				if err != nil {
+					failed[id] = fmt.Errorf("read tick %s: %w", id, err)
+					continue
+				}
+				t, err := parseTick(id, path, data)
+				if err != nil {
+					failed[id] = err
+					continue
+				}
+				out[id] = t
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return out, []error{fmt.Errorf("read issues dir: %w", err)}
+			}
+		}
+	}
+
+	var errs []error
+	for _, id := range ids {
+		if err, ok := failed[id]; ok {
+			errs = append(errs, err)
+			delete(failed, id)
+		} else if pending[id] {
+			errs = append(errs, fmt.Errorf("read tick %s: %w", id, os.ErrNotExist))
+			delete(pending, id)
+		}
+	}
+	return out, errs
+}
+
+// ReadAll loads every tick under .tick/issues keyed by ID. Unlike List, a
+// tick that fails to load is reported in errs instead of failing the read.
+func (s *Store) ReadAll() (map[string]Tick, []error) {
+	ids, err := s.scanIDs()
+	if err != nil {
+		return map[string]Tick{}, []error{err}
+	}
+
+	out := make(map[string]Tick, len(ids))
+	var errs []error
+	for _, id := range ids {
+		t, err := s.Read(id)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out[id] = t
+	}
+	return out, errs
+}
+
+// IDs returns the IDs of all tick files, including ones that fail to load.
+func (s *Store) IDs() ([]string, error) {
+	return s.scanIDs()
+}
+
+// ErrAmbiguousPrefix is returned by ResolvePrefix when a prefix matches
+// more than one tick.
+var ErrAmbiguousPrefix = errors.New("ambiguous id prefix")
+
+// ResolvePrefix returns the ID of the tick whose ID starts with prefix. An
+// exact ID is returned as-is. If several ticks match, the error wraps
+// ErrAmbiguousPrefix and lists the candidates; if none do, it wraps
+// os.ErrNotExist.
+func (s *Store) ResolvePrefix(prefix string) (string, error) {
+	if prefix == "" {
+		return "", fmt.Errorf("id is required")
+	}
+	if _, err := os.Stat(s.tickPath(prefix)); err == nil {
+		return prefix, nil
+	}
+
+	ids, err := s.scanIDs()
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, id := range ids {
+		if strings.HasPrefix(id, prefix) {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no tick matches %q: %w", prefix, os.ErrNotExist)
+	case 1:
+		return matches[0], nil
+	}
+	sort.Strings(matches)
+	return "", fmt.Errorf("%w %q matches %s", ErrAmbiguousPrefix, prefix, strings.Join(matches, ", "))
+}
+
+// scanIDs returns the IDs of all tick files in the issues directory.
+func (s *Store) scanIDs() ([]string, error) {
+	entries, err := os.ReadDir(s.issuesDir())
+	if err != nil {
+		return nil, fmt.Errorf("read issues dir: %w", err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		ids = append(ids, entry.Name()[:len(entry.Name())-len(".json")])
+	}
+	return ids, nil
+}
+
 func (s *Store) issuesDir() string {
 	return filepath.Join(s.Root, "issues")
 }
@@ -342,6 +533,21 @@ func (s *Store) ReadActivity(limit int) ([]Activity, error) {
 	return activities, nil
 }
 
+// History returns the activity entries for a single tick, oldest first.
+func (s *Store) History(id string) ([]Activity, error) {
+	activities, err := s.ReadActivity(0)
+	if err != nil {
+		return nil, err
+	}
+	var history []Activity
+	for _, a := range activities {
+		if a.TickID == id {
+			history = append(history, a)
+		}
+	}
+	return history, nil
+}
+
 // splitLines splits data by newlines without allocating empty strings.
 func splitLines(data []byte) [][]byte {
 	var lines [][]byte
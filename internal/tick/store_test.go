@@ -1,7 +1,11 @@
 package tick
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -47,3 +51,188 @@ func TestStoreCRUD(t *testing.T) {
 		t.Fatalf("delete tick: %v", err)
 	}
 }
+
+func TestStoreReadInvalid(t *testing.T) {
+	root := filepath.Join(t.TempDir(), ".tick")
+	store := NewStore(root)
+	if err := store.Ensure(); err != nil {
+		t.Fatalf("ensure: %v", err)
+	}
+
+	path := filepath.Join(root, "issues", "a1b.json")
+	data := `{"id":"a1b","title":"Fix auth","status":"finished","priority":2,"type":"bug","owner":"petere","created_by":"petere","created_at":"2025-01-08T10:30:00Z","updated_at":"2025-01-08T10:30:00Z"}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write tick file: %v", err)
+	}
+
+	_, err := store.Read("a1b")
+	if err == nil {
+		t.Fatal("expected error reading a tick with an invalid status")
+	}
+	for _, want := range []string{"invalid tick a1b", path, "invalid status: finished"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	if _, err := store.List(); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("expected List to fail naming %s, got %v", path, err)
+	}
+}
+
+func TestStoreResolvePrefix(t *testing.T) {
+	root := filepath.Join(t.TempDir(), ".tick")
+	store := NewStore(root)
+
+	now := time.Date(2025, 1, 8, 10, 30, 0, 0, time.UTC)
+	for _, id := range []string{"a1b", "a2c", "a2d", "bug-x9z"} {
+		tick := Tick{
+			ID:        id,
+			Title:     "Tick " + id,
+			Status:    StatusOpen,
+			Priority:  2,
+			Type:      TypeTask,
+			Owner:     "petere",
+			CreatedBy: "petere",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := store.Write(tick); err != nil {
+			t.Fatalf("write tick %s: %v", id, err)
+		}
+	}
+
+	for prefix, want := range map[string]string{"a1": "a1b", "a2c": "a2c", "bug-": "bug-x9z"} {
+		got, err := store.ResolvePrefix(prefix)
+		if err != nil {
+			t.Errorf("ResolvePrefix(%q): %v", prefix, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ResolvePrefix(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+
+	_, err := store.ResolvePrefix("a2")
+	if !errors.Is(err, ErrAmbiguousPrefix) {
+		t.Fatalf("expected ErrAmbiguousPrefix for a2, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "a2c, a2d") {
+		t.Errorf("expected candidates in error, got %q", err)
+	}
+
+	if _, err := store.ResolvePrefix("zz"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not-exist error for zz, got %v", err)
+	}
+}
+
+func TestStoreReadMany(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), ".tick"))
+	now := time.Date(2025, 1, 8, 10, 30, 0, 0, time.UTC)
+	for _, id := range []string{"a1b", "c2d", "e3f"} {
+		tk := Tick{ID: id, Title: "Tick " + id, Status: StatusOpen, Type: TypeTask, Owner: "petere", CreatedBy: "petere", CreatedAt: now, UpdatedAt: now}
+		if err := store.Write(tk); err != nil {
+			t.Fatalf("write tick %s: %v", id, err)
+		}
+	}
+
+	got, errs := store.ReadMany([]string{"a1b", "zzz", "e3f", "a1b"})
+	if len(got) != 2 || got["a1b"].Title != "Tick a1b" || got["e3f"].Title != "Tick e3f" {
+		t.Fatalf("expected a1b and e3f populated, got %+v", got)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], os.ErrNotExist) || !strings.Contains(errs[0].Error(), "zzz") {
+		t.Fatalf("expected one not-exist error for zzz, got %v", errs)
+	}
+
+	all, errs := store.ReadAll()
+	if len(errs) != 0 || len(all) != 3 {
+		t.Fatalf("expected 3 ticks and no errors, got %d ticks, errs %v", len(all), errs)
+	}
+
+	// A corrupt file is reported without hiding the others
+	if err := os.WriteFile(filepath.Join(store.Root, "issues", "bad.json"), []byte("{"), 0o644); err != nil {
+		t.Fatalf("write corrupt tick: %v", err)
+	}
+	all, errs = store.ReadAll()
+	if len(all) != 3 || len(errs) != 1 {
+		t.Fatalf("expected 3 ticks and 1 error, got %d ticks, errs %v", len(all), errs)
+	}
+}
+
+func benchmarkStore(b *testing.B, n int) (*Store, []string) {
+	b.Helper()
+	store := NewStore(filepath.Join(b.TempDir(), ".tick"))
+	now := time.Date(2025, 1, 8, 10, 30, 0, 0, time.UTC)
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("t%03d", i)
+		tk := Tick{ID: id, Title: "Tick " + id, Status: StatusOpen, Type: TypeTask, Owner: "petere", CreatedBy: "petere", CreatedAt: now, UpdatedAt: now}
+		if err := store.Write(tk); err != nil {
+			b.Fatalf("write tick %s: %v", id, err)
+		}
+		ids = append(ids, id)
+	}
+	return store, ids
+}
+
+func BenchmarkStoreReadLoop100(b *testing.B) {
+	store, ids := benchmarkStore(b, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if _, err := store.Read(id); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkStoreReadMany100(b *testing.B) {
+	store, ids := benchmarkStore(b, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, errs := store.ReadMany(ids); len(errs) > 0 {
+			b.Fatal(errs)
+		}
+	}
+}
+
+func TestStoreWriteInterrupted(t *testing.T) {
+	root := filepath.Join(t.TempDir(), ".tick")
+	store := NewStore(root)
+
+	original := indexTestTick("a1b", "Fix auth")
+	if err := store.Write(original); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+
+	// Simulate a crash partway through each write: only a prefix of the
+	// new contents reaches disk before the write fails
+	orig := writeTemp
+	defer func() { writeTemp = orig }()
+	for _, frac := range []int{0, 1, 2, 3} {
+		writeTemp = func(f *os.File, data []byte) (int, error) {
+			n, _ := f.Write(data[:len(data)*frac/4])
+			return n, errors.New("interrupted")
+		}
+
+		updated := original
+		updated.Title = strings.Repeat("Rewritten title ", 10)
+		if err := store.Write(updated); err == nil {
+			t.Fatalf("expected interrupted write to fail")
+		}
+
+		got, err := store.Read("a1b")
+		if err != nil {
+			t.Fatalf("tick left unreadable after interrupted write (%d/4): %v", frac, err)
+		}
+		if got.Title != "Fix auth" {
+			t.Fatalf("expected original title after interrupted write, got %q", got.Title)
+		}
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(root, "issues", "*.tmp"))
+	if len(leftovers) != 0 {
+		t.Fatalf("expected no temp files, got %v", leftovers)
+	}
+}
@@ -3,6 +3,7 @@ package tick
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -23,6 +24,21 @@ const (
 	TypeChore   = "chore"
 )
 
+// Priority bounds. Lower numbers are more urgent: 0 is critical, 4 is backlog.
+const (
+	PriorityMin = 0
+	PriorityMax = 4
+)
+
+// priorityNames maps human-friendly priority names to their numeric value.
+var priorityNames = map[string]int{
+	"critical": 0,
+	"high":     1,
+	"medium":   2,
+	"low":      3,
+	"backlog":  4,
+}
+
 // Requires values (pre-declared gates).
 const (
 	RequiresApproval = "approval"
@@ -131,6 +147,21 @@ func (t Tick) Validate() error {
 	return errors.Join(errs...)
 }
 
+// ParsePriority converts a priority given as a number ("1"), a P-label ("P1")
+// or a name ("high") into its numeric value. Matching is case-insensitive.
+func ParsePriority(value string) (int, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if p, ok := priorityNames[v]; ok {
+		return p, nil
+	}
+	v = strings.TrimPrefix(v, "p")
+	p, err := strconv.Atoi(v)
+	if err != nil || p < PriorityMin || p > PriorityMax {
+		return 0, fmt.Errorf("invalid priority: %q (use 0-4, P0-P4, or critical|high|medium|low|backlog)", value)
+	}
+	return p, nil
+}
+
 func isStatusValid(value string) bool {
 	switch value {
 	case StatusOpen, StatusInProgress, StatusClosed:
@@ -248,3 +279,93 @@ func (t *Tick) Release() {
 	t.StartedAt = nil
 	t.UpdatedAt = now
 }
+
+// Age returns how long ago the tick was created, as of now.
+func (t Tick) Age(now time.Time) time.Duration {
+	return now.Sub(t.CreatedAt)
+}
+
+// TimeToClose returns how long the tick took from creation to close, or nil
+// if it has not been closed.
+func (t Tick) TimeToClose() *time.Duration {
+	if t.ClosedAt == nil {
+		return nil
+	}
+	d := t.ClosedAt.Sub(t.CreatedAt)
+	return &d
+}
+
+// StaleSince returns how long ago the tick was last updated, as of now.
+func (t Tick) StaleSince(now time.Time) time.Duration {
+	return now.Sub(t.UpdatedAt)
+}
+
+// SummaryStyle styles the parts of a Summary line. The styles package
+// provides one for terminal output; a nil SummaryStyle leaves them plain.
+type SummaryStyle interface {
+	ID(id string) string
+	Priority(priority int) string
+	Type(tickType string) string
+	// Status styles status together with its decoration, which is
+	// "awaiting <type>", "blocked", or empty.
+	Status(status, decoration string) string
+}
+
+// Summary returns the tick as one line,
+// "<id> P<priority> <type> <status> — <title>". A tick awaiting a human
+// shows "<status> (awaiting <type>)".
+func (t Tick) Summary() string {
+	return t.SummaryStyled(nil, false)
+}
+
+// SummaryStyled returns Summary with each part styled by style. Whether the
+// tick is blocked depends on its blockers, so the caller decides: an open,
+// blocked tick that is not awaiting a human shows "open (blocked)".
+func (t Tick) SummaryStyled(style SummaryStyle, blocked bool) string {
+	decoration := ""
+	if awaiting := t.GetAwaitingType(); awaiting != "" {
+		decoration = "awaiting " + awaiting
+	} else if blocked && t.Status == StatusOpen {
+		decoration = "blocked"
+	}
+
+	if style == nil {
+		status := t.Status
+		if decoration != "" {
+			status += " (" + decoration + ")"
+		}
+		return fmt.Sprintf("%s P%d %s %s — %s", t.ID, t.Priority, t.Type, status, t.Title)
+	}
+	return fmt.Sprintf("%s %s %s %s — %s",
+		style.ID(t.ID),
+		style.Priority(t.Priority),
+		style.Type(t.Type),
+		style.Status(t.Status, decoration),
+		t.Title)
+}
+
+// Clone returns an open copy of the tick under a new ID, for use as a
+// template. Descriptive fields (title, description, type, priority, labels,
+// parent, acceptance criteria) are carried over; blockers, workflow state,
+// notes, and timestamps are reset, and actor becomes owner and creator.
+func (t Tick) Clone(id, actor string, now time.Time) Tick {
+	var labels []string
+	if len(t.Labels) > 0 {
+		labels = append([]string(nil), t.Labels...)
+	}
+	return Tick{
+		ID:                 id,
+		Title:              t.Title,
+		Description:        t.Description,
+		Status:             StatusOpen,
+		Priority:           t.Priority,
+		Type:               t.Type,
+		Owner:              actor,
+		Labels:             labels,
+		Parent:             t.Parent,
+		AcceptanceCriteria: t.AcceptanceCriteria,
+		CreatedBy:          actor,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
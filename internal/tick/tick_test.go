@@ -990,3 +990,167 @@ func TestRelease(t *testing.T) {
 		}
 	})
 }
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"4", 4, false},
+		{"P1", 1, false},
+		{"p3", 3, false},
+		{"critical", 0, false},
+		{"High", 1, false},
+		{"medium", 2, false},
+		{" low ", 3, false},
+		{"backlog", 4, false},
+		{"5", 0, true},
+		{"-1", 0, true},
+		{"P9", 0, true},
+		{"urgent", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePriority(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePriority(%q) expected error, got %d", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePriority(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePriority(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	created := time.Date(2025, 1, 8, 10, 30, 0, 0, time.UTC)
+	closed := created.Add(time.Hour)
+	awaiting := AwaitingReview
+	verdict := VerdictApproved
+	src := Tick{
+		ID:                 "a1b",
+		Title:              "Rotate API keys",
+		Description:        "Rotate keys for all services",
+		Notes:              "2025-01-08 10:30 - done for prod",
+		Status:             StatusClosed,
+		Priority:           1,
+		Type:               TypeChore,
+		Owner:              "alice",
+		Labels:             []string{"security", "ops"},
+		BlockedBy:          []string{"c2d"},
+		Parent:             "e3f",
+		AcceptanceCriteria: "All keys rotated",
+		Awaiting:           &awaiting,
+		Verdict:            &verdict,
+		CreatedBy:          "alice",
+		CreatedAt:          created,
+		UpdatedAt:          closed,
+		StartedAt:          &created,
+		ClosedAt:           &closed,
+		ClosedReason:       "done",
+	}
+
+	now := time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC)
+	c := src.Clone("g4h", "bob", now)
+
+	if c.ID != "g4h" || c.Title != src.Title || c.Description != src.Description ||
+		c.Type != src.Type || c.Priority != src.Priority || c.Parent != src.Parent ||
+		c.AcceptanceCriteria != src.AcceptanceCriteria {
+		t.Errorf("descriptive fields not carried over: %+v", c)
+	}
+	if len(c.Labels) != 2 || c.Labels[0] != "security" || c.Labels[1] != "ops" {
+		t.Errorf("expected labels copied, got %v", c.Labels)
+	}
+	c.Labels[0] = "changed"
+	if src.Labels[0] != "security" {
+		t.Errorf("clone labels share storage with source")
+	}
+
+	if c.Status != StatusOpen || c.BlockedBy != nil || c.Awaiting != nil || c.Verdict != nil ||
+		c.Notes != "" || c.StartedAt != nil || c.ClosedAt != nil || c.ClosedReason != "" {
+		t.Errorf("runtime fields not reset: %+v", c)
+	}
+	if c.Owner != "bob" || c.CreatedBy != "bob" || !c.CreatedAt.Equal(now) || !c.UpdatedAt.Equal(now) {
+		t.Errorf("expected owner/creator bob at %v, got %+v", now, c)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("clone should be valid: %v", err)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	created := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	updated := created.Add(6 * time.Hour)
+	now := created.Add(48 * time.Hour)
+
+	open := Tick{Status: StatusOpen, CreatedAt: created, UpdatedAt: updated}
+	if got := open.Age(now); got != 48*time.Hour {
+		t.Errorf("Age = %v, want 48h", got)
+	}
+	if got := open.StaleSince(now); got != 42*time.Hour {
+		t.Errorf("StaleSince = %v, want 42h", got)
+	}
+	if got := open.TimeToClose(); got != nil {
+		t.Errorf("expected nil TimeToClose for open tick, got %v", *got)
+	}
+
+	closedAt := created.Add(26 * time.Hour)
+	closed := open
+	closed.Status = StatusClosed
+	closed.ClosedAt = &closedAt
+	got := closed.TimeToClose()
+	if got == nil {
+		t.Fatal("expected TimeToClose for closed tick")
+	}
+	if *got != 26*time.Hour {
+		t.Errorf("TimeToClose = %v, want 26h", *got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	approval := AwaitingApproval
+	base := Tick{ID: "a1b", Title: "Fix auth", Status: StatusOpen, Priority: 1, Type: TypeBug}
+
+	awaiting := base
+	awaiting.Status = StatusInProgress
+	awaiting.Awaiting = &approval
+
+	manual := base
+	manual.Manual = true
+
+	closed := base
+	closed.Status = StatusClosed
+
+	tests := []struct {
+		name    string
+		tick    Tick
+		blocked bool
+		want    string
+	}{
+		{"plain", base, false, "a1b P1 bug open — Fix auth"},
+		{"blocked", base, true, "a1b P1 bug open (blocked) — Fix auth"},
+		{"awaiting", awaiting, false, "a1b P1 bug in_progress (awaiting approval) — Fix auth"},
+		{"awaiting wins over blocked", awaiting, true, "a1b P1 bug in_progress (awaiting approval) — Fix auth"},
+		{"legacy manual", manual, false, "a1b P1 bug open (awaiting work) — Fix auth"},
+		{"closed is never blocked", closed, true, "a1b P1 bug closed — Fix auth"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tick.SummaryStyled(nil, tt.blocked); got != tt.want {
+				t.Errorf("SummaryStyled(nil, %v) = %q, want %q", tt.blocked, got, tt.want)
+			}
+		})
+	}
+
+	if got, want := awaiting.Summary(), "a1b P1 bug in_progress (awaiting approval) — Fix auth"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
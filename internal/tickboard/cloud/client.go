@@ -1,15 +1,19 @@
 package cloud
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +22,7 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/pengelbrecht/ticks/internal/github"
+	"github.com/pengelbrecht/ticks/internal/runrecord"
 	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
@@ -25,12 +30,22 @@ const (
 	// DefaultCloudURL is the default ticks.sh WebSocket endpoint.
 	DefaultCloudURL = "wss://ticks.sh/api/projects"
 
+	// DefaultSyncClosedWindow is how long closed ticks keep being synced.
+	DefaultSyncClosedWindow = 24 * time.Hour
+
+	// reconnectJitter is the fraction by which each reconnect backoff is
+	// randomized (±25%) so clients don't reconnect in lockstep.
+	reconnectJitter = 0.25
+
 	// EnvToken is the environment variable for the cloud token.
 	EnvToken = "TICKS_TOKEN"
 
 	// EnvCloudURL is the environment variable to override the cloud URL.
 	EnvCloudURL = "TICKS_URL"
 
+	// EnvForceIPv4 is the environment variable that turns on ForceIPv4.
+	EnvForceIPv4 = "TICKS_FORCE_IPV4"
+
 	// ConfigFileName is the name of the config file in user's home directory.
 	ConfigFileName = ".ticksrc"
 )
@@ -72,6 +87,15 @@ type Client struct {
 	boardName string
 	tickDir   string // path to .tick directory
 
+	closedWindow  time.Duration                         // see Config.SyncClosedWindow
+	maxReconnects int                                   // see Config.MaxReconnectAttempts
+	forceIPv4     bool                                  // see Config.ForceIPv4
+	proxy         func(*http.Request) (*url.URL, error) // see Config.Proxy
+
+	// connect and wait default to Connect and time.After (for testing)
+	connect func(ctx context.Context) error
+	wait    func(d time.Duration) <-chan time.Time
+
 	conn   *websocket.Conn
 	connMu sync.Mutex
 
@@ -94,12 +118,19 @@ type Client struct {
 	// Callback for remote changes (optional)
 	OnRemoteChange func(t tick.Tick)
 
+	// Callback when a remote update is discarded because the local tick is
+	// as new or newer (optional). Conflicts are also logged to stderr.
+	OnConflict func(local, remote tick.Tick)
+
 	// State change callback (optional)
 	OnStateChange func(state SyncState)
 
 	// Track pending files to avoid echo
 	pendingWrites   map[string]time.Time
 	pendingWritesMu sync.Mutex
+
+	// Responses to recent tick operations, for answering redelivered requests
+	processedOps *opCache
 }
 
 // Config holds the cloud client configuration.
@@ -108,6 +139,23 @@ type Config struct {
 	CloudURL  string
 	BoardName string
 	TickDir   string // path to .tick directory (required)
+
+	// SyncClosedWindow limits which closed ticks are synced: those closed
+	// within the window. Zero syncs all ticks; negative syncs only open ticks.
+	SyncClosedWindow time.Duration
+
+	// MaxReconnectAttempts is the number of consecutive failed connection
+	// attempts after which Run gives up. Zero retries forever.
+	MaxReconnectAttempts int
+
+	// ForceIPv4 resolves the cloud host to an IPv4 address before dialing,
+	// for networks with broken IPv6. Off by default; LoadConfig turns it on
+	// from TICKS_FORCE_IPV4 or force_ipv4 in ~/.ticksrc.
+	ForceIPv4 bool
+
+	// Proxy picks the proxy for the WebSocket connection. Nil uses the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+	Proxy func(*http.Request) (*url.URL, error)
 }
 
 // SyncFullMessage sends all ticks to the DO for initial sync.
@@ -227,8 +275,13 @@ func NewClient(cfg Config) (*Client, error) {
 		cloudURL:      cloudURL,
 		boardName:     cfg.BoardName,
 		tickDir:       cfg.TickDir,
+		closedWindow:  cfg.SyncClosedWindow,
+		maxReconnects: cfg.MaxReconnectAttempts,
+		forceIPv4:     cfg.ForceIPv4,
+		proxy:         cfg.Proxy,
 		stopChan:      make(chan struct{}),
 		pendingWrites: make(map[string]time.Time),
+		processedOps:  newOpCache(DefaultProcessedOpsSize),
 	}, nil
 }
 
@@ -256,23 +309,54 @@ func LoadConfig(tickDir string) *Config {
 	}
 
 	// Derive board name from .tick directory or parent directory name
-	boardName := deriveBoardName(tickDir)
+	boardName := DeriveBoardName(tickDir)
+
+	// Force IPv4: env var > config file
+	forceIPv4 := fileCfg.ForceIPv4
+	if v, err := strconv.ParseBool(os.Getenv(EnvForceIPv4)); err == nil {
+		forceIPv4 = v
+	}
 
 	return &Config{
-		Token:     token,
-		CloudURL:  cloudURL,
-		BoardName: boardName,
-		TickDir:   tickDir,
+		Token:            token,
+		CloudURL:         cloudURL,
+		BoardName:        boardName,
+		TickDir:          tickDir,
+		SyncClosedWindow: closedSyncWindow(fileCfg),
+		ForceIPv4:        forceIPv4,
 	}
 }
 
+// ClosedSyncWindow returns the configured window for syncing closed ticks:
+// closed_sync_window from ~/.ticksrc, or DefaultSyncClosedWindow.
+func ClosedSyncWindow() time.Duration {
+	return closedSyncWindow(readConfigFile())
+}
+
+func closedSyncWindow(fileCfg configFile) time.Duration {
+	if fileCfg.ClosedSyncWindow != nil {
+		return *fileCfg.ClosedSyncWindow
+	}
+	return DefaultSyncClosedWindow
+}
+
+// InSyncWindow reports whether t is synced under a closed-tick window:
+// open ticks always are, closed ticks only if closed within window.
+// A zero window includes all ticks; a negative one excludes closed ticks.
+func InSyncWindow(t tick.Tick, window time.Duration, now time.Time) bool {
+	return t.ClosedAt == nil || window == 0 || (window > 0 && t.ClosedAt.After(now.Add(-window)))
+}
+
 // configFile holds values read from ~/.ticksrc.
 type configFile struct {
-	Token string
-	URL   string
+	Token            string
+	URL              string
+	ClosedSyncWindow *time.Duration // nil if not set
+	ForceIPv4        bool
 }
 
-// readConfigFile reads token and URL from ~/.ticksrc.
+// readConfigFile reads token, URL, closed sync window and force_ipv4 from
+// ~/.ticksrc.
 func readConfigFile() configFile {
 	var cfg configFile
 
@@ -299,6 +383,13 @@ func readConfigFile() configFile {
 			cfg.Token = strings.TrimPrefix(line, "token=")
 		} else if strings.HasPrefix(line, "url=") {
 			cfg.URL = strings.TrimPrefix(line, "url=")
+		} else if strings.HasPrefix(line, "closed_sync_window=") {
+			// Invalid durations are ignored so the default applies
+			if d, err := time.ParseDuration(strings.TrimPrefix(line, "closed_sync_window=")); err == nil {
+				cfg.ClosedSyncWindow = &d
+			}
+		} else if strings.HasPrefix(line, "force_ipv4=") {
+			cfg.ForceIPv4, _ = strconv.ParseBool(strings.TrimPrefix(line, "force_ipv4="))
 		} else if cfg.Token == "" {
 			// Legacy: first non-empty line without key= is token
 			cfg.Token = line
@@ -307,9 +398,25 @@ func readConfigFile() configFile {
 	return cfg
 }
 
-// deriveBoardName returns the full repo name in format "owner/repo[:worktree]".
-// Falls back to directory name if git info is unavailable.
-func deriveBoardName(tickDir string) string {
+// RepoIdentity is the repo identity cloud sync derives a board name from.
+type RepoIdentity struct {
+	// Repo is "owner/repo" from the origin remote, or the directory name if
+	// git info is unavailable.
+	Repo string
+	// Worktree is the directory name of a checkout below the repo root, if any.
+	Worktree string
+}
+
+// BoardName returns the board name in format "owner/repo[:worktree]".
+func (r RepoIdentity) BoardName() string {
+	if r.Worktree == "" {
+		return r.Repo
+	}
+	return r.Repo + ":" + r.Worktree
+}
+
+// DetectRepoIdentity returns the repo identity for the repo containing tickDir.
+func DetectRepoIdentity(tickDir string) RepoIdentity {
 	repoDir := filepath.Dir(tickDir)
 
 	// Try to get the remote URL
@@ -317,68 +424,53 @@ func deriveBoardName(tickDir string) string {
 	out, err := cmd.Output()
 	if err != nil {
 		// Fallback to directory name
-		return filepath.Base(repoDir)
+		return RepoIdentity{Repo: filepath.Base(repoDir)}
 	}
 
 	// Parse the remote URL to extract owner/repo
 	remoteURL := strings.TrimSpace(string(out))
-	repoName := parseGitRemote(remoteURL)
+	repoName := ParseGitRemote(remoteURL)
 	if repoName == "" {
-		return filepath.Base(repoDir)
+		return RepoIdentity{Repo: filepath.Base(repoDir)}
 	}
 
 	// Check if this is a worktree
+	identity := RepoIdentity{Repo: repoName}
 	cmd = exec.Command("git", "-C", repoDir, "rev-parse", "--show-toplevel")
 	topLevel, err := cmd.Output()
 	if err == nil {
-		topLevelDir := strings.TrimSpace(string(topLevel))
-		currentBase := filepath.Base(repoDir)
-		topBase := filepath.Base(topLevelDir)
-		// If the current directory name differs from the repo root, it's a worktree
-		if currentBase != topBase && topLevelDir != repoDir {
-			repoName = repoName + ":" + currentBase
-		}
+		identity.Worktree = worktreeSuffix(repoDir, strings.TrimSpace(string(topLevel)))
 	}
-
-	return repoName
+	return identity
 }
 
-// parseGitRemote extracts "owner/repo" from a git remote URL.
-// Supports HTTPS (https://github.com/owner/repo.git) and SSH (git@github.com:owner/repo.git).
-func parseGitRemote(remoteURL string) string {
-	// Remove trailing .git
-	remoteURL = strings.TrimSuffix(remoteURL, ".git")
-
-	var ownerRepo string
-
-	// SSH format: git@github.com:owner/repo
-	if strings.HasPrefix(remoteURL, "git@") {
-		// Extract the path after the ":"
-		parts := strings.SplitN(remoteURL, ":", 2)
-		if len(parts) == 2 {
-			ownerRepo = parts[1]
-		}
+// worktreeSuffix returns the board name suffix for repoDir: its directory
+// name when it differs from the repo root's, otherwise "".
+func worktreeSuffix(repoDir, topLevelDir string) string {
+	currentBase := filepath.Base(repoDir)
+	topBase := filepath.Base(topLevelDir)
+	// If the current directory name differs from the repo root, it's a worktree
+	if currentBase != topBase && topLevelDir != repoDir {
+		return currentBase
 	}
+	return ""
+}
 
-	// HTTPS format: https://github.com/owner/repo
-	if ownerRepo == "" && strings.Contains(remoteURL, "://") {
-		// Parse URL and extract path
-		parts := strings.SplitN(remoteURL, "://", 2)
-		if len(parts) == 2 {
-			hostPath := parts[1]
-			// Remove host
-			slashIdx := strings.Index(hostPath, "/")
-			if slashIdx != -1 {
-				ownerRepo = hostPath[slashIdx+1:]
-			}
-		}
-	}
+// DeriveBoardName returns the full repo name in format "owner/repo[:worktree]".
+// Falls back to directory name if git info is unavailable.
+func DeriveBoardName(tickDir string) string {
+	return DetectRepoIdentity(tickDir).BoardName()
+}
 
-	if ownerRepo == "" {
+// ParseGitRemote extracts the project from a git remote URL: "owner/repo" for
+// GitHub, "host/path" for other hosts (e.g. "gitlab.com/group/sub/repo").
+// Returns "" if the URL cannot be parsed.
+func ParseGitRemote(remoteURL string) string {
+	r, err := github.ParseRemote(remoteURL)
+	if err != nil {
 		return ""
 	}
-
-	return ownerRepo
+	return r.Project()
 }
 
 // Connect establishes the WebSocket connection to the cloud.
@@ -390,42 +482,9 @@ func (c *Client) Connect(ctx context.Context) error {
 	encodedBoardName := url.PathEscape(c.boardName)
 	wsURL := fmt.Sprintf("%s/%s/sync?token=%s&type=local", c.cloudURL, encodedBoardName, c.token)
 
-	// Extract hostname for TLS ServerName (needed if connecting via IP)
-	cloudHost := "ticks.sh"
-	if strings.Contains(c.cloudURL, "://") {
-		parts := strings.SplitN(c.cloudURL, "://", 2)
-		if len(parts) == 2 {
-			hostPort := strings.SplitN(parts[1], "/", 2)[0]
-			cloudHost = strings.SplitN(hostPort, ":", 2)[0]
-		}
-	}
-
-	dialer := websocket.Dialer{
-		HandshakeTimeout: 30 * time.Second, // Extended for D1 cold starts
-		TLSClientConfig: &tls.Config{
-			ServerName: cloudHost,
-		},
-		NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-			// Force IPv4 by resolving and picking IPv4 address
-			hostPart, port, err := net.SplitHostPort(addr)
-			if err != nil {
-				return (&net.Dialer{}).DialContext(ctx, network, addr)
-			}
-
-			ips, err := net.LookupIP(hostPart)
-			if err != nil {
-				return (&net.Dialer{}).DialContext(ctx, network, addr)
-			}
-
-			// Find first IPv4 address
-			for _, ip := range ips {
-				if ip4 := ip.To4(); ip4 != nil {
-					return (&net.Dialer{}).DialContext(ctx, "tcp4", net.JoinHostPort(ip4.String(), port))
-				}
-			}
-
-			return (&net.Dialer{}).DialContext(ctx, network, addr)
-		},
+	dialer, err := c.dialer()
+	if err != nil {
+		return err
 	}
 
 	conn, resp, err := dialer.DialContext(ctx, wsURL, nil)
@@ -447,11 +506,76 @@ func (c *Client) Connect(ctx context.Context) error {
 	return nil
 }
 
+// dialer builds the WebSocket dialer for the configured cloud URL. The TLS
+// ServerName is the URL's host, proxies come from Config.Proxy or the
+// environment (HTTPS_PROXY, HTTP_PROXY, NO_PROXY), and IPv4 is forced only
+// with Config.ForceIPv4.
+func (c *Client) dialer() (*websocket.Dialer, error) {
+	u, err := url.Parse(c.cloudURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cloud URL %q: %w", c.cloudURL, err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid cloud URL %q: missing host", c.cloudURL)
+	}
+
+	proxy := c.proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	dialer := &websocket.Dialer{
+		HandshakeTimeout: 30 * time.Second, // Extended for D1 cold starts
+		Proxy:            proxy,
+		TLSClientConfig: &tls.Config{
+			ServerName: u.Hostname(),
+		},
+	}
+	if c.forceIPv4 {
+		dialer.NetDialContext = dialIPv4
+	}
+	return dialer, nil
+}
+
+// dialIPv4 dials the first IPv4 address of the host in addr, falling back
+// to a normal dial if the host has none or can't be resolved.
+func dialIPv4(ctx context.Context, network, addr string) (net.Conn, error) {
+	hostPart, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, hostPart)
+	if err != nil {
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	}
+
+	for _, ip := range ips {
+		if ip4 := ip.IP.To4(); ip4 != nil {
+			return (&net.Dialer{}).DialContext(ctx, "tcp4", net.JoinHostPort(ip4.String(), port))
+		}
+	}
+
+	return (&net.Dialer{}).DialContext(ctx, network, addr)
+}
+
 // Run connects to the cloud and handles messages until context is cancelled.
-// It automatically reconnects with exponential backoff on disconnection.
+// It automatically reconnects with jittered exponential backoff on
+// disconnection, and returns an error once Config.MaxReconnectAttempts
+// consecutive connection attempts have failed.
 func (c *Client) Run(ctx context.Context) error {
 	backoff := time.Second
 	maxBackoff := 30 * time.Second
+	failures := 0
+
+	connect := c.connect
+	if connect == nil {
+		connect = c.Connect
+	}
+	wait := c.wait
+	if wait == nil {
+		wait = time.After
+	}
 
 	for {
 		select {
@@ -469,18 +593,25 @@ func (c *Client) Run(ctx context.Context) error {
 		c.setSyncState(SyncConnecting)
 
 		// Try to connect
-		if err := c.Connect(ctx); err != nil {
+		if err := connect(ctx); err != nil {
 			c.setSyncState(SyncError)
+			failures++
+			if c.maxReconnects > 0 && failures >= c.maxReconnects {
+				fmt.Fprintf(os.Stderr, "cloud: connection failed: %v (giving up after %d attempts)\n", err, failures)
+				return fmt.Errorf("cloud connection failed after %d attempts: %w", failures, err)
+			}
+
+			delay := jitterBackoff(backoff)
 			pending := c.PendingCount()
 			if pending > 0 {
-				fmt.Fprintf(os.Stderr, "cloud: connection failed: %v (retrying in %v, %d pending)\n", err, backoff, pending)
+				fmt.Fprintf(os.Stderr, "cloud: connection failed: %v (retrying in %v, %d pending)\n", err, delay, pending)
 			} else {
-				fmt.Fprintf(os.Stderr, "cloud: connection failed: %v (retrying in %v)\n", err, backoff)
+				fmt.Fprintf(os.Stderr, "cloud: connection failed: %v (retrying in %v)\n", err, delay)
 			}
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(backoff):
+			case <-wait(delay):
 			}
 			// Exponential backoff
 			backoff *= 2
@@ -493,6 +624,7 @@ func (c *Client) Run(ctx context.Context) error {
 		c.setSyncState(SyncConnected)
 		fmt.Fprintf(os.Stderr, "cloud: connected to %s as %s\n", c.cloudURL, c.boardName)
 		backoff = time.Second // Reset backoff on successful connection
+		failures = 0
 
 		// Start file watcher and send initial state
 		if err := c.startSyncMode(ctx); err != nil {
@@ -520,6 +652,12 @@ func (c *Client) Run(ctx context.Context) error {
 	}
 }
 
+// jitterBackoff randomizes d by up to ±reconnectJitter.
+func jitterBackoff(d time.Duration) time.Duration {
+	spread := (rand.Float64()*2 - 1) * reconnectJitter
+	return d + time.Duration(spread*float64(d)).Round(time.Millisecond)
+}
+
 // handleMessages reads and processes messages from the cloud.
 func (c *Client) handleMessages(ctx context.Context) error {
 	c.connMu.Lock()
@@ -717,6 +855,26 @@ func (c *Client) PendingCount() int {
 	return len(c.pendingMessages)
 }
 
+// Status is a point-in-time snapshot of the client's sync state.
+type Status struct {
+	State    string    `json:"state"`
+	Board    string    `json:"board"`
+	CloudURL string    `json:"cloud_url"`
+	LastSync time.Time `json:"last_sync"`
+	Pending  int       `json:"pending"`
+}
+
+// Status returns a snapshot of the current sync state.
+func (c *Client) Status() Status {
+	return Status{
+		State:    c.GetSyncState().String(),
+		Board:    c.boardName,
+		CloudURL: c.cloudURL,
+		LastSync: c.GetLastSync(),
+		Pending:  c.PendingCount(),
+	}
+}
+
 // queueMessage adds a message to the offline queue.
 func (c *Client) queueMessage(data json.RawMessage) {
 	c.pendingMessagesMu.Lock()
@@ -809,7 +967,8 @@ func (c *Client) stopFileWatcher() {
 }
 
 // loadAllTicks loads ticks from .tick/issues/ for syncing.
-// Only syncs open ticks and recently closed ticks (within 24h) to reduce payload size.
+// Only syncs open ticks and ticks closed within the configured window to reduce
+// payload size (see Config.SyncClosedWindow).
 func (c *Client) loadAllTicks() (map[string]tick.Tick, error) {
 	store := tick.NewStore(c.tickDir)
 	allTicks, err := store.List()
@@ -817,12 +976,10 @@ func (c *Client) loadAllTicks() (map[string]tick.Tick, error) {
 		return nil, err
 	}
 
-	// Include open ticks + ticks closed within the last 24 hours
-	closedCutoff := time.Now().Add(-24 * time.Hour)
+	now := time.Now()
 	result := make(map[string]tick.Tick)
 	for _, t := range allTicks {
-		// Include if: not closed (ClosedAt is nil) OR closed recently
-		if t.ClosedAt == nil || t.ClosedAt.After(closedCutoff) {
+		if InSyncWindow(t, c.closedWindow, now) {
 			result[t.ID] = t
 		}
 	}
@@ -1009,9 +1166,15 @@ func (c *Client) SyncDelete(id string) error {
 func (c *Client) applyRemoteState(ticks map[string]tick.Tick) {
 	store := tick.NewStore(c.tickDir)
 
+	ids := make([]string, 0, len(ticks))
+	for id := range ticks {
+		ids = append(ids, id)
+	}
+	localTicks, _ := store.ReadMany(ids)
+
 	for id, remoteTick := range ticks {
-		localTick, err := store.Read(id)
-		if err != nil {
+		localTick, ok := localTicks[id]
+		if !ok {
 			// Tick doesn't exist locally - create it
 			c.writeTickLocally(remoteTick)
 			continue
@@ -1020,6 +1183,8 @@ func (c *Client) applyRemoteState(ticks map[string]tick.Tick) {
 		// Only apply if remote is newer
 		if remoteTick.UpdatedAt.After(localTick.UpdatedAt) {
 			c.writeTickLocally(remoteTick)
+		} else if ticksDiffer(localTick, remoteTick) {
+			c.reportConflict(localTick, remoteTick)
 		}
 	}
 }
@@ -1052,6 +1217,8 @@ func (c *Client) applyRemoteTick(remoteTick tick.Tick) {
 	// Only apply if remote is newer
 	if remoteTick.UpdatedAt.After(localTick.UpdatedAt) {
 		c.writeTickLocally(remoteTick)
+	} else if ticksDiffer(localTick, remoteTick) {
+		c.reportConflict(localTick, remoteTick)
 	}
 
 	// Call the callback if set
@@ -1060,6 +1227,31 @@ func (c *Client) applyRemoteTick(remoteTick tick.Tick) {
 	}
 }
 
+// reportConflict records that a remote update lost to the local tick under
+// last-write-wins, so concurrent edits are not discarded silently.
+func (c *Client) reportConflict(local, remote tick.Tick) {
+	reason := "local is newer"
+	if remote.UpdatedAt.Equal(local.UpdatedAt) {
+		reason = "same updated_at, local kept"
+	}
+	fmt.Fprintf(os.Stderr, "cloud: conflict on %s: remote change discarded (%s; local %s, remote %s)\n",
+		local.ID, reason, local.UpdatedAt.Format(time.RFC3339), remote.UpdatedAt.Format(time.RFC3339))
+
+	if c.OnConflict != nil {
+		c.OnConflict(local, remote)
+	}
+}
+
+// ticksDiffer reports whether two versions of a tick have different contents.
+func ticksDiffer(a, b tick.Tick) bool {
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return true
+	}
+	return !bytes.Equal(aj, bj)
+}
+
 // applyRemoteDelete deletes a tick file locally.
 func (c *Client) applyRemoteDelete(id string) {
 	path := filepath.Join(c.tickDir, "issues", id+".json")
@@ -1072,6 +1264,15 @@ func (c *Client) applyRemoteDelete(id string) {
 	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
 		fmt.Fprintf(os.Stderr, "cloud: failed to delete local tick %s: %v\n", id, err)
 	}
+
+	// Drop the tick's run records too, or the board keeps showing a ghost run
+	records := runrecord.NewStore(filepath.Dir(c.tickDir))
+	if err := records.DeleteLive(id); err != nil {
+		fmt.Fprintf(os.Stderr, "cloud: failed to delete live record for %s: %v\n", id, err)
+	}
+	if err := records.Delete(id); err != nil {
+		fmt.Fprintf(os.Stderr, "cloud: failed to delete run record for %s: %v\n", id, err)
+	}
 }
 
 // writeTickLocally writes a tick to .tick/issues/, tracking as pending to avoid echo.
@@ -1089,13 +1290,25 @@ func (c *Client) writeTickLocally(t tick.Tick) {
 		return
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	// Atomic so a crash never leaves a truncated tick; the watcher sees
+	// only the rename, which pendingWrites suppresses as an echo
+	if err := tick.WriteFileAtomic(path, data, 0644); err != nil {
 		fmt.Fprintf(os.Stderr, "cloud: failed to write tick %s: %v\n", t.ID, err)
 	}
 }
 
 // handleTickOperation handles operation requests from cloud UI via DO.
 func (c *Client) handleTickOperation(req TickOperationRequest) {
+	// A redelivered request (e.g. after a reconnect) gets the original
+	// response again instead of being applied twice
+	if req.RequestID != "" {
+		if resp, ok := c.processedOps.get(req.RequestID); ok {
+			fmt.Printf("cloud: operation %s already handled, resending response\n", req.RequestID)
+			c.sendSyncMessage(resp)
+			return
+		}
+	}
+
 	fmt.Printf("cloud: handling operation %s for tick %s (requestId: %s)\n",
 		req.Operation, req.TickID, req.RequestID)
 
@@ -1248,6 +1461,9 @@ func (c *Client) sendOperationResponse(requestID string, t *tick.Tick, errMsg st
 		fmt.Fprintf(os.Stderr, "cloud: operation %s failed: %s\n", requestID, errMsg)
 	}
 
+	if requestID != "" {
+		c.processedOps.put(requestID, response)
+	}
 	c.sendSyncMessage(response)
 }
 
@@ -1,9 +1,25 @@
 package cloud
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/pengelbrecht/ticks/internal/agent"
+	"github.com/pengelbrecht/ticks/internal/runrecord"
+	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
 func TestLoadConfig_NoToken(t *testing.T) {
@@ -62,6 +78,27 @@ func TestLoadConfig_CustomCloudURL(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_ForceIPv4(t *testing.T) {
+	os.Setenv(EnvToken, "test-token")
+	defer os.Unsetenv(EnvToken)
+
+	os.Unsetenv(EnvForceIPv4)
+	if cfg := LoadConfig("/tmp/test/.tick"); cfg == nil || cfg.ForceIPv4 != readConfigFile().ForceIPv4 {
+		t.Error("expected ForceIPv4 to follow ~/.ticksrc without TICKS_FORCE_IPV4")
+	}
+
+	os.Setenv(EnvForceIPv4, "1")
+	defer os.Unsetenv(EnvForceIPv4)
+	if cfg := LoadConfig("/tmp/test/.tick"); cfg == nil || !cfg.ForceIPv4 {
+		t.Error("expected ForceIPv4 on with TICKS_FORCE_IPV4=1")
+	}
+
+	os.Setenv(EnvForceIPv4, "false")
+	if cfg := LoadConfig("/tmp/test/.tick"); cfg == nil || cfg.ForceIPv4 {
+		t.Error("expected TICKS_FORCE_IPV4=false to turn ForceIPv4 off")
+	}
+}
+
 func TestLoadConfig_FromFile(t *testing.T) {
 	// Create a temporary config file
 	home, err := os.UserHomeDir()
@@ -108,9 +145,87 @@ func TestDeriveBoardName(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		got := deriveBoardName(tt.tickDir)
+		got := DeriveBoardName(tt.tickDir)
+		if got != tt.expected {
+			t.Errorf("DeriveBoardName(%q) = %q, want %q", tt.tickDir, got, tt.expected)
+		}
+	}
+}
+
+func TestParseGitRemote(t *testing.T) {
+	tests := []struct {
+		remote   string
+		expected string
+	}{
+		{"git@github.com:petere/chefswiz.git", "petere/chefswiz"},
+		{"git@github.com:petere/chefswiz", "petere/chefswiz"},
+		{"https://github.com/petere/chefswiz.git", "petere/chefswiz"},
+		{"https://gitlab.example.com/team/api", "gitlab.example.com/team/api"},
+		{"ssh://git@github.com/petere/chefswiz.git", "petere/chefswiz"},
+		{"git@gitlab.com:group/sub/repo.git", "gitlab.com/group/sub/repo"},
+		{"https://bitbucket.org/team/repo.git", "bitbucket.org/team/repo"},
+		{"not-a-remote", ""},
+	}
+
+	for _, tt := range tests {
+		got := ParseGitRemote(tt.remote)
+		if got != tt.expected {
+			t.Errorf("ParseGitRemote(%q) = %q, want %q", tt.remote, got, tt.expected)
+		}
+	}
+}
+
+func TestDetectRepoIdentity(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"remote", "add", "origin", "git@github.com:petere/chefswiz.git"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	sub := filepath.Join(repo, "feature-x")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	got := DetectRepoIdentity(filepath.Join(repo, ".tick"))
+	if got != (RepoIdentity{Repo: "petere/chefswiz"}) {
+		t.Errorf("repo root identity = %+v", got)
+	}
+	if got.BoardName() != "petere/chefswiz" {
+		t.Errorf("repo root BoardName() = %q", got.BoardName())
+	}
+
+	got = DetectRepoIdentity(filepath.Join(sub, ".tick"))
+	if got != (RepoIdentity{Repo: "petere/chefswiz", Worktree: "feature-x"}) {
+		t.Errorf("worktree identity = %+v", got)
+	}
+	if got.BoardName() != "petere/chefswiz:feature-x" {
+		t.Errorf("worktree BoardName() = %q", got.BoardName())
+	}
+}
+
+func TestWorktreeSuffix(t *testing.T) {
+	tests := []struct {
+		repoDir  string
+		topLevel string
+		expected string
+	}{
+		{"/src/chefswiz", "/src/chefswiz", ""},
+		{"/src/chefswiz/wt-auth", "/src/chefswiz", "wt-auth"},
+		{"/tmp/chefswiz", "/src/chefswiz", ""},
+	}
+
+	for _, tt := range tests {
+		got := worktreeSuffix(tt.repoDir, tt.topLevel)
 		if got != tt.expected {
-			t.Errorf("deriveBoardName(%q) = %q, want %q", tt.tickDir, got, tt.expected)
+			t.Errorf("worktreeSuffix(%q, %q) = %q, want %q", tt.repoDir, tt.topLevel, got, tt.expected)
 		}
 	}
 }
@@ -154,6 +269,96 @@ func TestNewClient_Success(t *testing.T) {
 	}
 }
 
+func newDialerTestClient(t *testing.T, cfg Config) *Client {
+	t.Helper()
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	if err := os.MkdirAll(filepath.Join(tickDir, "issues"), 0755); err != nil {
+		t.Fatalf("failed to create issues dir: %v", err)
+	}
+	cfg.Token = "test-token"
+	cfg.BoardName = "myboard"
+	cfg.TickDir = tickDir
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestClient_DialerServerNameFromURL(t *testing.T) {
+	client := newDialerTestClient(t, Config{CloudURL: "wss://sync.example.com:8443/api/projects"})
+
+	dialer, err := client.dialer()
+	if err != nil {
+		t.Fatalf("dialer: %v", err)
+	}
+	if got := dialer.TLSClientConfig.ServerName; got != "sync.example.com" {
+		t.Errorf("ServerName = %q, want %q", got, "sync.example.com")
+	}
+	if dialer.Proxy == nil {
+		t.Error("expected proxy to default to the environment")
+	}
+	if dialer.NetDialContext != nil {
+		t.Error("expected IPv4 not to be forced by default")
+	}
+
+	client = newDialerTestClient(t, Config{CloudURL: "/api/projects"})
+	if _, err := client.dialer(); err == nil {
+		t.Error("expected error for cloud URL without a host")
+	}
+}
+
+func TestClient_DialerForceIPv4(t *testing.T) {
+	client := newDialerTestClient(t, Config{ForceIPv4: true})
+
+	dialer, err := client.dialer()
+	if err != nil {
+		t.Fatalf("dialer: %v", err)
+	}
+	if dialer.NetDialContext == nil {
+		t.Error("expected IPv4 dialing with ForceIPv4")
+	}
+}
+
+func TestClient_ConnectUsesProxy(t *testing.T) {
+	// The fake proxy records the CONNECT target and refuses the tunnel
+	connects := make(chan string, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodConnect {
+			select {
+			case connects <- r.Host:
+			default:
+			}
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := newDialerTestClient(t, Config{
+		CloudURL: "wss://cloud.example.test/api/projects",
+		Proxy:    http.ProxyURL(proxyURL),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err == nil {
+		t.Fatal("expected connect to fail through the refusing proxy")
+	}
+
+	select {
+	case host := <-connects:
+		if host != "cloud.example.test:443" {
+			t.Errorf("proxy CONNECT host = %q, want %q", host, "cloud.example.test:443")
+		}
+	default:
+		t.Fatal("expected the connection to go through the proxy")
+	}
+}
+
 func TestSyncState_String(t *testing.T) {
 	tests := []struct {
 		state    SyncState
@@ -277,3 +482,395 @@ func TestClient_IsConnected(t *testing.T) {
 		t.Error("expected IsConnected() to be false initially")
 	}
 }
+
+func TestReadConfigFile_ClosedSyncWindow(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if cfg := readConfigFile(); cfg.ClosedSyncWindow != nil {
+		t.Fatalf("expected no window without config file, got %v", *cfg.ClosedSyncWindow)
+	}
+
+	content := "token=abc\nclosed_sync_window=72h\n"
+	if err := os.WriteFile(filepath.Join(home, ConfigFileName), []byte(content), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg := readConfigFile()
+	if cfg.ClosedSyncWindow == nil || *cfg.ClosedSyncWindow != 72*time.Hour {
+		t.Fatalf("expected 72h window, got %v", cfg.ClosedSyncWindow)
+	}
+}
+
+func TestClient_LoadAllTicksClosedWindow(t *testing.T) {
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	store := tick.NewStore(tickDir)
+
+	now := time.Now().UTC()
+	closedAt := func(age time.Duration) *time.Time {
+		ts := now.Add(-age)
+		return &ts
+	}
+	ticks := []tick.Tick{
+		{ID: "open", Status: tick.StatusOpen},
+		{ID: "hour", Status: tick.StatusClosed, ClosedAt: closedAt(time.Hour)},
+		{ID: "day2", Status: tick.StatusClosed, ClosedAt: closedAt(48 * time.Hour)},
+		{ID: "week", Status: tick.StatusClosed, ClosedAt: closedAt(7 * 24 * time.Hour)},
+	}
+	for _, tk := range ticks {
+		tk.Title = tk.ID
+		tk.Type = tick.TypeTask
+		tk.Priority = 2
+		tk.Owner = "tester"
+		tk.CreatedBy = "tester"
+		tk.CreatedAt = now.Add(-30 * 24 * time.Hour)
+		tk.UpdatedAt = now
+		if err := store.Write(tk); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		want   []string
+	}{
+		{"default", DefaultSyncClosedWindow, []string{"hour", "open"}},
+		{"wider", 72 * time.Hour, []string{"day2", "hour", "open"}},
+		{"zero syncs all", 0, []string{"day2", "hour", "open", "week"}},
+		{"negative syncs open only", -time.Hour, []string{"open"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(Config{Token: "tok", TickDir: tickDir, SyncClosedWindow: tt.window})
+			if err != nil {
+				t.Fatalf("new client: %v", err)
+			}
+			loaded, err := client.loadAllTicks()
+			if err != nil {
+				t.Fatalf("load ticks: %v", err)
+			}
+			var got []string
+			for id := range loaded {
+				got = append(got, id)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClient_ApplyRemoteTickConflict(t *testing.T) {
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	store := tick.NewStore(tickDir)
+
+	updated := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	local := tick.Tick{
+		ID:        "abc",
+		Title:     "Local title",
+		Status:    tick.StatusOpen,
+		Priority:  2,
+		Type:      tick.TypeTask,
+		Owner:     "tester",
+		CreatedBy: "tester",
+		CreatedAt: updated,
+		UpdatedAt: updated,
+	}
+	if err := store.Write(local); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+
+	client, err := NewClient(Config{Token: "tok", TickDir: tickDir})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	var conflicts []tick.Tick
+	client.OnConflict = func(local, remote tick.Tick) {
+		conflicts = append(conflicts, remote)
+	}
+
+	older := local
+	older.Title = "Older remote"
+	older.UpdatedAt = updated.Add(-time.Hour)
+	client.applyRemoteTick(older)
+
+	tied := local
+	tied.Title = "Tied remote"
+	client.applyRemoteTick(tied)
+
+	// Identical content is not a conflict
+	client.applyRemoteTick(local)
+
+	if len(conflicts) != 2 || conflicts[0].Title != "Older remote" || conflicts[1].Title != "Tied remote" {
+		t.Fatalf("expected conflicts for older and tied remotes, got %+v", conflicts)
+	}
+	if got, _ := store.Read("abc"); got.Title != "Local title" {
+		t.Fatalf("expected local to win, got title %q", got.Title)
+	}
+
+	newer := local
+	newer.Title = "Newer remote"
+	newer.UpdatedAt = updated.Add(time.Hour)
+	client.applyRemoteTick(newer)
+
+	if len(conflicts) != 2 {
+		t.Fatalf("expected no conflict for newer remote, got %d", len(conflicts))
+	}
+	if got, _ := store.Read("abc"); got.Title != "Newer remote" {
+		t.Fatalf("expected remote to win, got title %q", got.Title)
+	}
+}
+
+func TestClient_RunReconnectCap(t *testing.T) {
+	client, err := NewClient(Config{Token: "tok", TickDir: "/tmp/.tick", MaxReconnectAttempts: 4})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	attempts := 0
+	client.connect = func(ctx context.Context) error {
+		attempts++
+		return errors.New("connection refused")
+	}
+	var waits []time.Duration
+	client.wait = func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+
+	err = client.Run(context.Background())
+	if err == nil {
+		t.Fatal("expected Run to give up")
+	}
+	if attempts != 4 {
+		t.Fatalf("expected 4 connect attempts, got %d", attempts)
+	}
+	if len(waits) != 3 {
+		t.Fatalf("expected 3 backoff waits, got %d", len(waits))
+	}
+
+	base := time.Second
+	for i, d := range waits {
+		lo := time.Duration(float64(base) * (1 - reconnectJitter))
+		hi := time.Duration(float64(base) * (1 + reconnectJitter))
+		if d < lo || d > hi {
+			t.Errorf("wait %d = %v, want within [%v, %v]", i, d, lo, hi)
+		}
+		base *= 2
+	}
+}
+
+func TestClient_WriteTickLocallySingleEvent(t *testing.T) {
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	issuesDir := filepath.Join(tickDir, "issues")
+	if err := os.MkdirAll(issuesDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	client, err := NewClient(Config{Token: "tok", TickDir: tickDir})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Add(issuesDir); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	tk := tick.Tick{
+		ID:        "abc",
+		Title:     "Remote title",
+		Status:    tick.StatusOpen,
+		Priority:  2,
+		Type:      tick.TypeTask,
+		Owner:     "tester",
+		CreatedBy: "tester",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Both creating and overwriting a tick should surface as one .json event
+	for _, title := range []string{"Created", "Overwritten"} {
+		tk.Title = title
+		client.writeTickLocally(tk)
+
+		events := 0
+		timeout := time.After(200 * time.Millisecond)
+	collect:
+		for {
+			select {
+			case event := <-watcher.Events:
+				if strings.HasSuffix(event.Name, ".json") {
+					events++
+				}
+			case err := <-watcher.Errors:
+				t.Fatalf("watcher error: %v", err)
+			case <-timeout:
+				break collect
+			}
+		}
+		if events != 1 {
+			t.Fatalf("%s: expected 1 .json event, got %d", title, events)
+		}
+	}
+
+	got, err := tick.NewStore(tickDir).Read("abc")
+	if err != nil {
+		t.Fatalf("read tick: %v", err)
+	}
+	if got.Title != "Overwritten" {
+		t.Fatalf("expected overwritten title, got %q", got.Title)
+	}
+}
+
+func TestClient_TickOperationIdempotent(t *testing.T) {
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	if err := os.MkdirAll(filepath.Join(tickDir, "issues"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	if err := tick.NewStore(tickDir).Write(tick.Tick{
+		ID:        "abc",
+		Title:     "Task",
+		Status:    tick.StatusOpen,
+		Priority:  2,
+		Type:      tick.TypeTask,
+		Owner:     "tester",
+		CreatedBy: "tester",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+
+	client, err := NewClient(Config{Token: "tok", TickDir: tickDir})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	// Offline, so every message lands in the pending queue
+	var req TickOperationRequest
+	req.RequestID = "req-1"
+	req.Operation = "add_note"
+	req.TickID = "abc"
+	req.Payload.Message = "looks good"
+	client.handleTickOperation(req)
+	client.handleTickOperation(req)
+
+	got, err := tick.NewStore(tickDir).Read("abc")
+	if err != nil {
+		t.Fatalf("read tick: %v", err)
+	}
+	if n := strings.Count(got.Notes, "looks good"); n != 1 {
+		t.Fatalf("expected note appended once, got %d:\n%s", n, got.Notes)
+	}
+
+	var responses []json.RawMessage
+	updates := 0
+	for _, msg := range client.pendingMessages {
+		var env struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(msg, &env); err != nil {
+			t.Fatalf("parse queued message: %v", err)
+		}
+		switch env.Type {
+		case "tick_operation_response":
+			responses = append(responses, msg)
+		case "tick_update":
+			updates++
+		}
+	}
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if string(responses[0]) != string(responses[1]) {
+		t.Errorf("second response differs from first:\n%s\n%s", responses[0], responses[1])
+	}
+	if updates != 1 {
+		t.Errorf("expected one tick_update broadcast, got %d", updates)
+	}
+
+	// A redelivered close doesn't fail with "already closed"
+	req = TickOperationRequest{RequestID: "req-2", Operation: "close", TickID: "abc"}
+	client.handleTickOperation(req)
+	client.handleTickOperation(req)
+	var last TickOperationResponse
+	if err := json.Unmarshal(client.pendingMessages[len(client.pendingMessages)-1], &last); err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+	if !last.Success || last.RequestID != "req-2" || last.Tick == nil || last.Tick.Status != tick.StatusClosed {
+		t.Errorf("expected redelivered close to succeed with closed tick, got %+v", last)
+	}
+}
+
+func TestOpCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newOpCache(2)
+	c.put("a", TickOperationResponse{RequestID: "a"})
+	c.put("b", TickOperationResponse{RequestID: "b"})
+	c.get("a") // a is now more recent than b
+	c.put("c", TickOperationResponse{RequestID: "c"})
+
+	if _, ok := c.get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, id := range []string{"a", "c"} {
+		if resp, ok := c.get(id); !ok || resp.RequestID != id {
+			t.Errorf("expected %s to be cached, got %+v %v", id, resp, ok)
+		}
+	}
+}
+
+func TestClient_ApplyRemoteDeleteRemovesRunRecords(t *testing.T) {
+	root := t.TempDir()
+	tickDir := filepath.Join(root, ".tick")
+	if err := os.MkdirAll(filepath.Join(tickDir, "issues"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	if err := tick.NewStore(tickDir).Write(tick.Tick{
+		ID:        "abc",
+		Title:     "Running task",
+		Status:    tick.StatusInProgress,
+		Priority:  2,
+		Type:      tick.TypeTask,
+		Owner:     "tester",
+		CreatedBy: "tester",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("write tick: %v", err)
+	}
+
+	records := runrecord.NewStore(root)
+	if err := records.WriteLive("abc", agent.AgentStateSnapshot{SessionID: "s1"}); err != nil {
+		t.Fatalf("write live record: %v", err)
+	}
+	if err := records.Write("abc", &agent.RunRecord{SessionID: "s0"}); err != nil {
+		t.Fatalf("write run record: %v", err)
+	}
+
+	client, err := NewClient(Config{Token: "tok", TickDir: tickDir})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	client.applyRemoteDelete("abc")
+
+	if _, err := os.Stat(filepath.Join(tickDir, "issues", "abc.json")); !os.IsNotExist(err) {
+		t.Errorf("tick file should be removed, stat err: %v", err)
+	}
+	if records.LiveExists("abc") {
+		t.Error("live record should be removed")
+	}
+	if records.Exists("abc") {
+		t.Error("run record should be removed")
+	}
+}
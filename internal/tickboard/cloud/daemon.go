@@ -0,0 +1,146 @@
+package cloud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrDaemonNotRunning is returned when no live sync daemon is recorded.
+var ErrDaemonNotRunning = errors.New("sync daemon not running")
+
+// Daemon tracks a background sync process through a PID file and a status
+// snapshot, both kept under .tick/logs/ (which is gitignored).
+type Daemon struct {
+	dir string
+}
+
+// DaemonStatus is the snapshot a running daemon writes for `tk sync --status`.
+type DaemonStatus struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Status
+}
+
+// NewDaemon returns a Daemon for the given .tick directory.
+func NewDaemon(tickDir string) *Daemon {
+	return &Daemon{dir: filepath.Join(tickDir, "logs")}
+}
+
+// PIDPath returns the path of the PID file.
+func (d *Daemon) PIDPath() string {
+	return filepath.Join(d.dir, "sync.pid")
+}
+
+// StatusPath returns the path of the status snapshot file.
+func (d *Daemon) StatusPath() string {
+	return filepath.Join(d.dir, "sync-status.json")
+}
+
+// LogPath returns the path the daemon's output is redirected to.
+func (d *Daemon) LogPath() string {
+	return filepath.Join(d.dir, "sync.log")
+}
+
+// WritePID records pid as the running daemon.
+func (d *Daemon) WritePID(pid int) error {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return fmt.Errorf("create logs dir: %w", err)
+	}
+	if err := os.WriteFile(d.PIDPath(), []byte(strconv.Itoa(pid)+"\n"), 0o644); err != nil {
+		return fmt.Errorf("write pid file: %w", err)
+	}
+	return nil
+}
+
+// ReadPID returns the pid recorded in the PID file.
+// Returns ErrDaemonNotRunning if there is no PID file.
+func (d *Daemon) ReadPID() (int, error) {
+	data, err := os.ReadFile(d.PIDPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, ErrDaemonNotRunning
+		}
+		return 0, fmt.Errorf("read pid file: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid pid file %s", d.PIDPath())
+	}
+	return pid, nil
+}
+
+// Running reports the pid of a live daemon. A PID file whose process has
+// exited is stale: it is removed (with the status file) and stale is true.
+func (d *Daemon) Running() (pid int, stale bool, err error) {
+	pid, err = d.ReadPID()
+	if err != nil {
+		if errors.Is(err, ErrDaemonNotRunning) {
+			return 0, false, ErrDaemonNotRunning
+		}
+		// Unparseable PID file is as good as stale
+		_ = d.Remove()
+		return 0, true, ErrDaemonNotRunning
+	}
+	if !processAlive(pid) {
+		_ = d.Remove()
+		return pid, true, ErrDaemonNotRunning
+	}
+	return pid, false, nil
+}
+
+// Stop terminates the running daemon and removes its PID file.
+func (d *Daemon) Stop() (int, error) {
+	pid, _, err := d.Running()
+	if err != nil {
+		return 0, err
+	}
+	if err := terminateProcess(pid); err != nil {
+		return pid, fmt.Errorf("stop pid %d: %w", pid, err)
+	}
+	return pid, d.Remove()
+}
+
+// Remove deletes the PID and status files. Missing files are not an error.
+func (d *Daemon) Remove() error {
+	for _, path := range []string{d.PIDPath(), d.StatusPath()} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+// WriteStatus saves a status snapshot for other processes to read.
+func (d *Daemon) WriteStatus(status DaemonStatus) error {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return fmt.Errorf("create logs dir: %w", err)
+	}
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode status: %w", err)
+	}
+	if err := os.WriteFile(d.StatusPath(), data, 0o644); err != nil {
+		return fmt.Errorf("write status: %w", err)
+	}
+	return nil
+}
+
+// ReadStatus loads the last status snapshot written by the daemon.
+func (d *Daemon) ReadStatus() (DaemonStatus, error) {
+	data, err := os.ReadFile(d.StatusPath())
+	if err != nil {
+		return DaemonStatus{}, fmt.Errorf("read status: %w", err)
+	}
+	var status DaemonStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return DaemonStatus{}, fmt.Errorf("parse status: %w", err)
+	}
+	return status, nil
+}
@@ -0,0 +1,151 @@
+package cloud
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// exitedPID returns the pid of a process that has already exited.
+func exitedPID(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("run helper process: %v", err)
+	}
+	return cmd.Process.Pid
+}
+
+func TestDaemon_PIDLifecycle(t *testing.T) {
+	d := NewDaemon(filepath.Join(t.TempDir(), ".tick"))
+
+	if _, _, err := d.Running(); !errors.Is(err, ErrDaemonNotRunning) {
+		t.Fatalf("expected ErrDaemonNotRunning with no pid file, got %v", err)
+	}
+
+	pid := os.Getpid()
+	if err := d.WritePID(pid); err != nil {
+		t.Fatalf("WritePID: %v", err)
+	}
+	got, err := d.ReadPID()
+	if err != nil || got != pid {
+		t.Fatalf("ReadPID = %d, %v; want %d", got, err, pid)
+	}
+
+	running, stale, err := d.Running()
+	if err != nil || stale || running != pid {
+		t.Fatalf("Running = %d, %v, %v; want %d, false, nil", running, stale, err, pid)
+	}
+
+	status := DaemonStatus{
+		PID:       pid,
+		StartedAt: time.Now().UTC().Truncate(time.Second),
+		Status:    Status{State: "connected", Board: "owner/repo", Pending: 2},
+	}
+	if err := d.WriteStatus(status); err != nil {
+		t.Fatalf("WriteStatus: %v", err)
+	}
+	read, err := d.ReadStatus()
+	if err != nil {
+		t.Fatalf("ReadStatus: %v", err)
+	}
+	if read.State != "connected" || read.Board != "owner/repo" || read.Pending != 2 || read.PID != pid {
+		t.Errorf("unexpected status round-trip: %+v", read)
+	}
+
+	if err := d.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	for _, path := range []string{d.PIDPath(), d.StatusPath()} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s removed, stat err = %v", filepath.Base(path), err)
+		}
+	}
+	// Removing again is a no-op
+	if err := d.Remove(); err != nil {
+		t.Errorf("second Remove: %v", err)
+	}
+}
+
+func TestDaemon_StalePIDIsCleanedUp(t *testing.T) {
+	d := NewDaemon(filepath.Join(t.TempDir(), ".tick"))
+
+	dead := exitedPID(t)
+	if err := d.WritePID(dead); err != nil {
+		t.Fatalf("WritePID: %v", err)
+	}
+	if err := d.WriteStatus(DaemonStatus{PID: dead}); err != nil {
+		t.Fatalf("WriteStatus: %v", err)
+	}
+
+	pid, stale, err := d.Running()
+	if !errors.Is(err, ErrDaemonNotRunning) {
+		t.Fatalf("expected ErrDaemonNotRunning for exited process, got %v", err)
+	}
+	if !stale || pid != dead {
+		t.Errorf("Running = %d, stale=%v; want %d, stale=true", pid, stale, dead)
+	}
+	if _, err := os.Stat(d.PIDPath()); !os.IsNotExist(err) {
+		t.Errorf("expected stale pid file removed, stat err = %v", err)
+	}
+	if _, err := os.Stat(d.StatusPath()); !os.IsNotExist(err) {
+		t.Errorf("expected stale status file removed, stat err = %v", err)
+	}
+
+	if _, err := d.Stop(); !errors.Is(err, ErrDaemonNotRunning) {
+		t.Errorf("Stop with no daemon: expected ErrDaemonNotRunning, got %v", err)
+	}
+}
+
+func TestDaemon_CorruptPIDFileIsStale(t *testing.T) {
+	d := NewDaemon(filepath.Join(t.TempDir(), ".tick"))
+	if err := os.MkdirAll(filepath.Dir(d.PIDPath()), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(d.PIDPath(), []byte("not-a-pid"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stale, err := d.Running()
+	if !errors.Is(err, ErrDaemonNotRunning) || !stale {
+		t.Fatalf("expected stale ErrDaemonNotRunning, got stale=%v err=%v", stale, err)
+	}
+	if _, err := os.Stat(d.PIDPath()); !os.IsNotExist(err) {
+		t.Errorf("expected corrupt pid file removed")
+	}
+}
+
+func TestDaemon_StopTerminatesProcess(t *testing.T) {
+	d := NewDaemon(filepath.Join(t.TempDir(), ".tick"))
+
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("sleep not available: %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+
+	if err := d.WritePID(cmd.Process.Pid); err != nil {
+		t.Fatalf("WritePID: %v", err)
+	}
+	pid, err := d.Stop()
+	if err != nil || pid != cmd.Process.Pid {
+		t.Fatalf("Stop = %d, %v; want %d, nil", pid, err, cmd.Process.Pid)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		_ = cmd.Process.Kill()
+		t.Fatal("process did not exit after Stop")
+	}
+	if _, err := os.Stat(d.PIDPath()); !os.IsNotExist(err) {
+		t.Errorf("expected pid file removed after Stop")
+	}
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package cloud
+
+import "os"
+
+// processAlive reports whether a process with the given pid exists.
+// On Windows FindProcess opens a handle and fails if the process is gone.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
+
+// terminateProcess stops the process. Windows has no SIGTERM, so this kills it.
+func terminateProcess(pid int) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Kill()
+}
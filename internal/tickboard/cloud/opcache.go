@@ -0,0 +1,66 @@
+package cloud
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultProcessedOpsSize is how many tick_operation responses are kept for
+// deduplicating redelivered requests.
+const DefaultProcessedOpsSize = 256
+
+// opCache is a bounded LRU of tick_operation responses keyed by request ID.
+// A request that is delivered again (e.g. after a reconnect) is answered
+// from the cache instead of being applied a second time.
+type opCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is most recently used; values are request IDs
+	entries map[string]*list.Element
+	resps   map[string]TickOperationResponse
+}
+
+func newOpCache(size int) *opCache {
+	if size <= 0 {
+		size = DefaultProcessedOpsSize
+	}
+	return &opCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		resps:   make(map[string]TickOperationResponse),
+	}
+}
+
+// get returns the response recorded for requestID, if any.
+func (c *opCache) get(requestID string) (TickOperationResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[requestID]
+	if !ok {
+		return TickOperationResponse{}, false
+	}
+	c.order.MoveToFront(el)
+	return c.resps[requestID], true
+}
+
+// put records the response for requestID, evicting the least recently used
+// entry when the cache is full.
+func (c *opCache) put(requestID string, resp TickOperationResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[requestID]; ok {
+		c.order.MoveToFront(el)
+		c.resps[requestID] = resp
+		return
+	}
+	c.entries[requestID] = c.order.PushFront(requestID)
+	c.resps[requestID] = resp
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		id := oldest.Value.(string)
+		c.order.Remove(oldest)
+		delete(c.entries, id)
+		delete(c.resps, id)
+	}
+}
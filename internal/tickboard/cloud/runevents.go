@@ -0,0 +1,136 @@
+package cloud
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// RunEventSender sends run events to the cloud. *Client satisfies it.
+type RunEventSender interface {
+	SendRunEvent(event RunEventMessage) error
+}
+
+// DefaultOutputInterval is the minimum time between task-update events
+// carrying streamed output, so a chatty agent doesn't flood the socket.
+const DefaultOutputInterval = 250 * time.Millisecond
+
+// RunEventForwarder turns the progress of a local run on one epic into run
+// events, so the cloud board can show the run live. Events go straight to
+// the sender; like all run events they are dropped while offline.
+type RunEventForwarder struct {
+	sender         RunEventSender
+	epicID         string
+	source         string
+	outputInterval time.Duration
+	now            func() time.Time
+
+	mu        sync.Mutex
+	taskID    string
+	iteration int
+	pending   strings.Builder // output not yet sent
+	streamed  bool            // whether Output was called this iteration
+	lastSent  time.Time
+}
+
+// NewRunEventForwarder creates a forwarder for runs of epicID. Source names
+// the runner, e.g. "ralph".
+func NewRunEventForwarder(sender RunEventSender, epicID, source string) *RunEventForwarder {
+	return &RunEventForwarder{
+		sender:         sender,
+		epicID:         epicID,
+		source:         source,
+		outputInterval: DefaultOutputInterval,
+		now:            time.Now,
+	}
+}
+
+// TaskStarted reports that an iteration started working on taskID.
+func (f *RunEventForwarder) TaskStarted(taskID, title string, iteration int) {
+	f.mu.Lock()
+	f.taskID = taskID
+	f.iteration = iteration
+	f.pending.Reset()
+	f.streamed = false
+	f.lastSent = time.Time{}
+	f.mu.Unlock()
+
+	f.send(taskID, RunEventData{
+		Type:      "task-started",
+		Status:    "running",
+		Iteration: iteration,
+		Message:   title,
+	})
+}
+
+// Output buffers streamed agent output and sends what arrived since the
+// last task-update as a new one, at most once per output interval.
+func (f *RunEventForwarder) Output(chunk string) {
+	f.mu.Lock()
+	if f.taskID == "" {
+		f.mu.Unlock()
+		return
+	}
+	f.pending.WriteString(chunk)
+	f.streamed = true
+	now := f.now()
+	if now.Sub(f.lastSent) < f.outputInterval {
+		f.mu.Unlock()
+		return
+	}
+	f.lastSent = now
+	taskID, iteration, output := f.taskID, f.iteration, f.pending.String()
+	f.pending.Reset()
+	f.mu.Unlock()
+
+	f.send(taskID, RunEventData{
+		Type:      "task-update",
+		Status:    "running",
+		Iteration: iteration,
+		Output:    output,
+	})
+}
+
+// IterationEnded reports the end of an iteration on taskID with its usage:
+// a final task-update carrying the output not sent yet, followed by
+// task-completed if the iteration closed the task. Output is the full
+// output, sent whole only if none was streamed.
+func (f *RunEventForwarder) IterationEnded(taskID string, iteration int, output string, metrics RunEventMetrics, success, closed bool) {
+	f.mu.Lock()
+	if f.streamed {
+		output = f.pending.String()
+	}
+	f.taskID = ""
+	f.pending.Reset()
+	f.streamed = false
+	f.mu.Unlock()
+
+	f.send(taskID, RunEventData{
+		Type:      "task-update",
+		Status:    "done",
+		Iteration: iteration,
+		Output:    output,
+		Metrics:   &metrics,
+	})
+	if !closed {
+		return
+	}
+	f.send(taskID, RunEventData{
+		Type:      "task-completed",
+		Iteration: iteration,
+		Success:   success,
+		Metrics:   &metrics,
+	})
+}
+
+func (f *RunEventForwarder) send(taskID string, data RunEventData) {
+	data.Timestamp = f.now().Format(time.RFC3339)
+	// Best effort: live output is not worth failing a run over
+	_ = f.sender.SendRunEvent(RunEventMessage{
+		Type:   "run_event",
+		EpicID: f.epicID,
+		TaskID: taskID,
+		Source: f.source,
+		Event:  data,
+	})
+}
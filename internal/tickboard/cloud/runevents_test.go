@@ -0,0 +1,117 @@
+package cloud
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeRunEventSender records the run events it is asked to send.
+type fakeRunEventSender struct {
+	events []RunEventMessage
+}
+
+func (f *fakeRunEventSender) SendRunEvent(event RunEventMessage) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestRunEventForwarder(t *testing.T) {
+	sender := &fakeRunEventSender{}
+	fwd := NewRunEventForwarder(sender, "epic1", "ralph")
+	clock := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	fwd.now = func() time.Time { return clock }
+
+	fwd.TaskStarted("task1", "Add refunds", 3)
+	fwd.Output("Working")
+	fwd.Output(" still") // within the output interval, not sent
+	clock = clock.Add(DefaultOutputInterval)
+	fwd.Output(" on it")
+	fwd.IterationEnded("task1", 3, "Working still on it. Done.", RunEventMetrics{
+		InputTokens:  1200,
+		OutputTokens: 300,
+		CostUSD:      0.05,
+		DurationMS:   1500,
+	}, true, true)
+
+	// Each task-update carries only the output since the previous one
+	want := []struct {
+		typ    string
+		output string
+	}{
+		{"task-started", ""},
+		{"task-update", "Working"},
+		{"task-update", " still on it"},
+		{"task-update", ""},
+		{"task-completed", ""},
+	}
+	if len(sender.events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(sender.events), len(want), sender.events)
+	}
+	for i, w := range want {
+		ev := sender.events[i]
+		if ev.Type != "run_event" || ev.EpicID != "epic1" || ev.TaskID != "task1" || ev.Source != "ralph" {
+			t.Errorf("event %d: envelope = %+v", i, ev)
+		}
+		if ev.Event.Type != w.typ || ev.Event.Output != w.output {
+			t.Errorf("event %d: got %s %q, want %s %q", i, ev.Event.Type, ev.Event.Output, w.typ, w.output)
+		}
+		if ev.Event.Iteration != 3 {
+			t.Errorf("event %d: iteration = %d, want 3", i, ev.Event.Iteration)
+		}
+		if ev.Event.Timestamp == "" {
+			t.Errorf("event %d: missing timestamp", i)
+		}
+	}
+
+	if sender.events[0].Event.Message != "Add refunds" {
+		t.Errorf("task-started message = %q, want task title", sender.events[0].Event.Message)
+	}
+	for _, ev := range sender.events[3:] {
+		m := ev.Event.Metrics
+		if m == nil || m.InputTokens != 1200 || m.OutputTokens != 300 || m.CostUSD != 0.05 || m.DurationMS != 1500 {
+			t.Errorf("%s metrics = %+v", ev.Event.Type, m)
+		}
+	}
+	if !sender.events[4].Event.Success {
+		t.Error("task-completed should report success")
+	}
+
+	// Output between iterations belongs to no task and is not sent
+	fwd.Output("stray")
+	if len(sender.events) != len(want) {
+		t.Errorf("output outside an iteration was sent: %+v", sender.events[len(want):])
+	}
+}
+
+func TestRunEventForwarder_TaskLeftOpen(t *testing.T) {
+	sender := &fakeRunEventSender{}
+	fwd := NewRunEventForwarder(sender, "epic1", "ralph")
+	clock := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	fwd.now = func() time.Time { return clock }
+
+	// Without streamed output, the final task-update carries the full output
+	fwd.TaskStarted("task1", "Add refunds", 1)
+	fwd.IterationEnded("task1", 1, "Ran out of ideas", RunEventMetrics{}, true, false)
+
+	if len(sender.events) != 2 {
+		t.Fatalf("got %d events, want task-started and task-update: %+v", len(sender.events), sender.events)
+	}
+	if ev := sender.events[1].Event; ev.Type != "task-update" || ev.Output != "Ran out of ideas" {
+		t.Errorf("final event = %s %q, want task-update with the full output", ev.Type, ev.Output)
+	}
+}
+
+func TestRunEventForwarder_OfflineClient(t *testing.T) {
+	// A client that never connected drops run events instead of queueing them
+	client, err := NewClient(Config{Token: "test-token", TickDir: t.TempDir(), BoardName: "test"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	fwd := NewRunEventForwarder(client, "epic1", "ralph")
+	fwd.TaskStarted("task1", "Add refunds", 1)
+	fwd.IterationEnded("task1", 1, "done", RunEventMetrics{}, true, true)
+
+	if n := client.PendingCount(); n != 0 {
+		t.Errorf("run events were queued offline: %d pending", n)
+	}
+}
@@ -17,6 +17,8 @@ import (
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/pengelbrecht/ticks/internal/agent"
+	"github.com/pengelbrecht/ticks/internal/config"
+	"github.com/pengelbrecht/ticks/internal/github"
 	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/runrecord"
 	"github.com/pengelbrecht/ticks/internal/tick"
@@ -585,42 +587,15 @@ func (s *Server) getRepoName() string {
 	return repoName
 }
 
-// parseGitRemote extracts "owner/repo" from a git remote URL.
-// Supports HTTPS (https://github.com/owner/repo.git) and SSH (git@github.com:owner/repo.git).
+// parseGitRemote extracts the project from a git remote URL: "owner/repo" for
+// GitHub, "host/path" for other hosts (e.g. "gitlab.com/group/sub/repo").
+// Returns "" if the URL cannot be parsed.
 func parseGitRemote(remoteURL string) string {
-	// Remove trailing .git
-	remoteURL = strings.TrimSuffix(remoteURL, ".git")
-
-	var ownerRepo string
-
-	// SSH format: git@github.com:owner/repo
-	if strings.HasPrefix(remoteURL, "git@") {
-		// Extract the path after the ":"
-		parts := strings.SplitN(remoteURL, ":", 2)
-		if len(parts) == 2 {
-			ownerRepo = parts[1]
-		}
-	}
-
-	// HTTPS format: https://github.com/owner/repo
-	if ownerRepo == "" && strings.Contains(remoteURL, "://") {
-		// Parse URL and extract path
-		parts := strings.SplitN(remoteURL, "://", 2)
-		if len(parts) == 2 {
-			hostPath := parts[1]
-			// Remove host
-			slashIdx := strings.Index(hostPath, "/")
-			if slashIdx != -1 {
-				ownerRepo = hostPath[slashIdx+1:]
-			}
-		}
-	}
-
-	if ownerRepo == "" {
+	r, err := github.ParseRemote(remoteURL)
+	if err != nil {
 		return ""
 	}
-
-	return ownerRepo
+	return r.Project()
 }
 
 // handleInfo handles GET /api/info.
@@ -1956,11 +1931,16 @@ func (s *Server) handleCreateTick(w http.ResponseWriter, r *http.Request) {
 		existingIDs[t.ID] = true
 	}
 
-	// Generate unique ID
+	// Generate unique ID, honoring configured length and type prefixes
+	cfg, err := config.LoadOrDefault(filepath.Join(s.tickDir, "config.json"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to load config: %v", err), http.StatusInternalServerError)
+		return
+	}
 	idGen := tick.NewIDGenerator(nil)
-	newID, _, err := idGen.Generate(func(id string) bool {
+	newID, _, err := idGen.GenerateWithPrefix(func(id string) bool {
 		return existingIDs[id]
-	}, 3)
+	}, cfg.IDLength, cfg.IDPrefix(tickType))
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to generate tick ID: %v", err), http.StatusInternalServerError)
 		return
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/pengelbrecht/ticks/internal/agent"
 	"github.com/pengelbrecht/ticks/internal/runrecord"
 )
 
@@ -42,6 +43,9 @@ type LiveFileEvent struct {
 	Type   EventType
 	TickID string
 	Record *runrecord.LiveRecord // nil for Finalized events
+	// Final is the finalized run record for Finalized events when the watcher
+	// was created WithFinalRecords; nil otherwise.
+	Final *agent.RunRecord
 }
 
 // LiveFileWatcher monitors the records directory for .live.json changes.
@@ -50,6 +54,18 @@ type LiveFileWatcher struct {
 	watcher    *fsnotify.Watcher
 	events     chan LiveFileEvent
 
+	// Read finalized run records into Finalized events
+	includeFinal bool
+
+	// Coalescing delivery: instead of dropping events when the channel is
+	// full, keep the latest undelivered event per tick and flush in order
+	coalesce     bool
+	pending      map[string]LiveFileEvent
+	pendingOrder []string
+	pendingMu    sync.Mutex
+	flushCh      chan struct{}
+	flushedCh    chan struct{}
+
 	// Debouncing
 	debounceDelay time.Duration
 	debounceTimers map[string]*time.Timer
@@ -66,17 +82,70 @@ type LiveFileWatcher struct {
 	runningMu sync.Mutex
 }
 
+// DefaultLiveDebounceDelay is how long the watcher waits for a burst of
+// changes to a live file to settle before emitting an event.
+const DefaultLiveDebounceDelay = 100 * time.Millisecond
+
+// LiveFileWatcherOption configures a LiveFileWatcher.
+type LiveFileWatcherOption func(*LiveFileWatcher)
+
+// WithDebounceDelay sets how long changes to a live file are coalesced before
+// an event is emitted. Longer delays suit slow network filesystems; shorter
+// ones make local UIs more responsive. Zero or negative values keep
+// DefaultLiveDebounceDelay.
+func WithDebounceDelay(d time.Duration) LiveFileWatcherOption {
+	return func(w *LiveFileWatcher) {
+		if d > 0 {
+			w.debounceDelay = d
+		}
+	}
+}
+
+// WithFinalRecords populates LiveFileEvent.Final on Finalized events with the
+// finalized run record, so consumers can render the final state without a
+// separate store read.
+func WithFinalRecords() LiveFileWatcherOption {
+	return func(w *LiveFileWatcher) {
+		w.includeFinal = true
+	}
+}
+
+// WithCoalescing makes the watcher never lose the latest state of a tick.
+// By default events are dropped when the channel is full; with coalescing,
+// undelivered events are kept in one slot per tick, each new event replacing
+// the previous one, and flushed as the consumer catches up. Intermediate
+// updates may be skipped, but the last event for every tick is delivered.
+func WithCoalescing() LiveFileWatcherOption {
+	return func(w *LiveFileWatcher) {
+		w.coalesce = true
+	}
+}
+
 // NewLiveFileWatcher creates a new watcher for the given records directory.
-func NewLiveFileWatcher(recordsDir string) *LiveFileWatcher {
-	return &LiveFileWatcher{
+func NewLiveFileWatcher(recordsDir string, opts ...LiveFileWatcherOption) *LiveFileWatcher {
+	w := &LiveFileWatcher{
 		recordsDir:     recordsDir,
 		events:         make(chan LiveFileEvent, 100),
-		debounceDelay:  100 * time.Millisecond,
+		debounceDelay:  DefaultLiveDebounceDelay,
 		debounceTimers: make(map[string]*time.Timer),
 		knownFiles:     make(map[string]struct{}),
 		stopCh:         make(chan struct{}),
 		stoppedCh:      make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.coalesce {
+		w.pending = make(map[string]LiveFileEvent)
+		w.flushCh = make(chan struct{}, 1)
+		w.flushedCh = make(chan struct{})
+	}
+	return w
+}
+
+// DebounceDelay returns the delay used to coalesce live file changes.
+func (w *LiveFileWatcher) DebounceDelay() time.Duration {
+	return w.debounceDelay
 }
 
 // Start begins watching the records directory.
@@ -114,6 +183,9 @@ func (w *LiveFileWatcher) Start() error {
 	// Start the watch loop
 	w.running = true
 	go w.watchLoop()
+	if w.coalesce {
+		go w.flushLoop()
+	}
 
 	return nil
 }
@@ -131,6 +203,9 @@ func (w *LiveFileWatcher) Stop() {
 	// Signal stop and wait for clean shutdown
 	close(w.stopCh)
 	<-w.stoppedCh
+	if w.coalesce {
+		<-w.flushedCh
+	}
 
 	// Clean up
 	if w.watcher != nil {
@@ -308,15 +383,11 @@ func (w *LiveFileWatcher) processLiveFileChange(tickID string, op fsnotify.Op) {
 	}
 
 	// Emit the event
-	select {
-	case w.events <- LiveFileEvent{
+	w.emit(LiveFileEvent{
 		Type:   eventType,
 		TickID: tickID,
 		Record: record,
-	}:
-	default:
-		// Channel full, drop event
-	}
+	})
 }
 
 // processFinalization handles a finalized run record.
@@ -334,14 +405,89 @@ func (w *LiveFileWatcher) processFinalization(tickID string) {
 		return
 	}
 
-	// Emit finalization event (no record - caller can read if needed)
-	select {
-	case w.events <- LiveFileEvent{
+	// Emit finalization event, with the final record if requested
+	// (a record that can't be read is left nil for the caller to handle)
+	event := LiveFileEvent{
 		Type:   Finalized,
 		TickID: tickID,
-		Record: nil,
-	}:
+	}
+	if w.includeFinal {
+		if final, err := store.Read(tickID); err == nil {
+			event.Final = final
+		}
+	}
+
+	w.emit(event)
+}
+
+// emit delivers an event to the events channel. Without coalescing the event
+// is dropped if the channel is full; with it, the event is parked in its
+// tick's slot for flushLoop to deliver.
+func (w *LiveFileWatcher) emit(event LiveFileEvent) {
+	if !w.coalesce {
+		select {
+		case w.events <- event:
+		default:
+			// Channel full, drop event
+		}
+		return
+	}
+
+	w.pendingMu.Lock()
+	if prev, ok := w.pending[event.TickID]; ok {
+		// A run that started and then changed before delivery still reads as started
+		if prev.Type == Created && event.Type == Updated {
+			event.Type = Created
+		}
+	} else {
+		w.pendingOrder = append(w.pendingOrder, event.TickID)
+	}
+	w.pending[event.TickID] = event
+	w.pendingMu.Unlock()
+
+	// Wake the flusher without blocking if it is already signalled
+	select {
+	case w.flushCh <- struct{}{}:
 	default:
-		// Channel full, drop event
+	}
+}
+
+// nextPending removes and returns the oldest parked event.
+func (w *LiveFileWatcher) nextPending() (LiveFileEvent, bool) {
+	w.pendingMu.Lock()
+	defer w.pendingMu.Unlock()
+	if len(w.pendingOrder) == 0 {
+		return LiveFileEvent{}, false
+	}
+	tickID := w.pendingOrder[0]
+	w.pendingOrder = w.pendingOrder[1:]
+	event := w.pending[tickID]
+	delete(w.pending, tickID)
+	return event, true
+}
+
+// flushLoop delivers parked events in the order their ticks were first
+// parked, blocking until the consumer receives each one.
+func (w *LiveFileWatcher) flushLoop() {
+	defer close(w.flushedCh)
+
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-w.flushCh:
+		}
+
+		for {
+			event, ok := w.nextPending()
+			if !ok {
+				break
+			}
+			select {
+			case w.events <- event:
+			case <-w.stopCh:
+				return
+			}
+		}
 	}
 }
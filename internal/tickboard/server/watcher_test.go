@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -196,7 +197,7 @@ func TestLiveFileWatcher_DetectsFinalized(t *testing.T) {
 		t.Fatalf("failed to write live file: %v", err)
 	}
 
-	w := NewLiveFileWatcher(recordsDir)
+	w := NewLiveFileWatcher(recordsDir, WithFinalRecords())
 	if err := w.Start(); err != nil {
 		t.Fatalf("Start() error = %v", err)
 	}
@@ -219,6 +220,46 @@ func TestLiveFileWatcher_DetectsFinalized(t *testing.T) {
 		if event.Record != nil {
 			t.Error("event.Record should be nil for Finalized events")
 		}
+		if event.Final == nil {
+			t.Fatal("event.Final should hold the finalized run record")
+		}
+		if event.Final.SessionID != "test-session" || event.Final.NumTurns != 3 {
+			t.Errorf("event.Final = %+v, want session test-session with 3 turns", event.Final)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("timeout waiting for Finalized event")
+	}
+}
+
+func TestLiveFileWatcher_FinalizedWithoutFinalRecords(t *testing.T) {
+	recordsDir := filepath.Join(t.TempDir(), "repo", ".tick", "logs", "records")
+	if err := os.MkdirAll(recordsDir, 0755); err != nil {
+		t.Fatalf("failed to create records dir: %v", err)
+	}
+	data, _ := json.Marshal(runrecord.LiveRecord{SessionID: "test-session", Status: "complete"})
+	livePath := filepath.Join(recordsDir, "tick5.live.json")
+	if err := os.WriteFile(livePath, data, 0644); err != nil {
+		t.Fatalf("failed to write live file: %v", err)
+	}
+
+	w := NewLiveFileWatcher(recordsDir)
+	if err := w.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer w.Stop()
+
+	if err := os.Rename(livePath, filepath.Join(recordsDir, "tick5.json")); err != nil {
+		t.Fatalf("failed to rename live to final: %v", err)
+	}
+
+	select {
+	case event := <-w.Events():
+		if event.Type != Finalized {
+			t.Errorf("event.Type = %v, want Finalized", event.Type)
+		}
+		if event.Final != nil {
+			t.Errorf("event.Final should be nil without WithFinalRecords, got %+v", event.Final)
+		}
 	case <-time.After(500 * time.Millisecond):
 		t.Fatal("timeout waiting for Finalized event")
 	}
@@ -251,57 +292,101 @@ func TestLiveFileWatcher_IgnoresNonLiveFiles(t *testing.T) {
 }
 
 func TestLiveFileWatcher_Debouncing(t *testing.T) {
-	tmpDir := t.TempDir()
-	tickRoot := filepath.Join(tmpDir, "repo")
-	recordsDir := filepath.Join(tickRoot, ".tick", "logs", "records")
+	// writeBurst writes the same live file n times, gap apart, and returns
+	// the events received until quiet passes without a new one.
+	writeBurst := func(t *testing.T, w *LiveFileWatcher, recordsDir string, n int, gap, quiet time.Duration) []LiveFileEvent {
+		t.Helper()
+		livePath := filepath.Join(recordsDir, "tick4.live.json")
+		for i := 0; i < n; i++ {
+			liveRecord := runrecord.LiveRecord{
+				SessionID: "test-session",
+				Status:    "writing",
+				NumTurns:  i + 1,
+			}
+			data, _ := json.MarshalIndent(liveRecord, "", "  ")
+			if err := os.WriteFile(livePath, data, 0644); err != nil {
+				t.Fatalf("failed to write live file (iteration %d): %v", i, err)
+			}
+			time.Sleep(gap)
+		}
 
-	w := NewLiveFileWatcher(recordsDir)
-	if err := w.Start(); err != nil {
-		t.Fatalf("Start() error = %v", err)
+		var events []LiveFileEvent
+		for {
+			select {
+			case event := <-w.Events():
+				if event.TickID != "tick4" {
+					t.Errorf("event.TickID = %q, want %q", event.TickID, "tick4")
+				}
+				events = append(events, event)
+			case <-time.After(quiet):
+				return events
+			}
+		}
 	}
-	defer w.Stop()
 
-	// Rapidly write to the same file multiple times
-	livePath := filepath.Join(recordsDir, "tick4.live.json")
-	for i := 0; i < 5; i++ {
-		liveRecord := runrecord.LiveRecord{
-			SessionID: "test-session",
-			Status:    "writing",
-			NumTurns:  i + 1,
+	newWatcher := func(t *testing.T, opts ...LiveFileWatcherOption) (*LiveFileWatcher, string) {
+		t.Helper()
+		recordsDir := filepath.Join(t.TempDir(), "repo", ".tick", "logs", "records")
+		w := NewLiveFileWatcher(recordsDir, opts...)
+		if err := w.Start(); err != nil {
+			t.Fatalf("Start() error = %v", err)
 		}
-		data, _ := json.MarshalIndent(liveRecord, "", "  ")
-		if err := os.WriteFile(livePath, data, 0644); err != nil {
-			t.Fatalf("failed to write live file (iteration %d): %v", i, err)
-		}
-		time.Sleep(10 * time.Millisecond) // Rapid writes
+		t.Cleanup(w.Stop)
+		return w, recordsDir
 	}
 
-	// Should receive at most 2 events (one for initial create, possibly one debounced update)
-	eventCount := 0
-	timeout := time.After(500 * time.Millisecond)
+	t.Run("default_delay_coalesces_rapid_writes", func(t *testing.T) {
+		w, recordsDir := newWatcher(t)
+		if w.DebounceDelay() != DefaultLiveDebounceDelay {
+			t.Errorf("DebounceDelay() = %v, want %v", w.DebounceDelay(), DefaultLiveDebounceDelay)
+		}
 
-	for {
-		select {
-		case event := <-w.Events():
-			eventCount++
-			if event.TickID != "tick4" {
-				t.Errorf("event.TickID = %q, want %q", event.TickID, "tick4")
-			}
-			// Check that debouncing worked - the last update should have the highest NumTurns
-			if eventCount == 1 && event.Record != nil && event.Record.NumTurns < 1 {
-				t.Errorf("first event NumTurns = %d, want >= 1", event.Record.NumTurns)
-			}
-		case <-timeout:
-			// Done waiting
-			if eventCount == 0 {
-				t.Error("expected at least one event")
-			}
-			if eventCount > 3 {
-				t.Errorf("expected at most 3 events due to debouncing, got %d", eventCount)
+		// Writes 10ms apart fall inside the 100ms window
+		events := writeBurst(t, w, recordsDir, 5, 10*time.Millisecond, 500*time.Millisecond)
+		if len(events) == 0 {
+			t.Fatal("expected at least one event")
+		}
+		if len(events) > 3 {
+			t.Errorf("expected at most 3 events due to debouncing, got %d", len(events))
+		}
+		if last := events[len(events)-1]; last.Record != nil && last.Record.NumTurns != 5 {
+			t.Errorf("last event NumTurns = %d, want 5", last.Record.NumTurns)
+		}
+	})
+
+	t.Run("coalescing_scales_with_delay", func(t *testing.T) {
+		const writes, gap = 4, 150 * time.Millisecond
+
+		// A window shorter than the gap between writes emits an event per write
+		short, shortDir := newWatcher(t, WithDebounceDelay(20*time.Millisecond))
+		shortEvents := writeBurst(t, short, shortDir, writes, gap, 300*time.Millisecond)
+
+		// A window longer than the gap keeps restarting, folding the burst into one event
+		long, longDir := newWatcher(t, WithDebounceDelay(3*gap))
+		if long.DebounceDelay() != 3*gap {
+			t.Errorf("DebounceDelay() = %v, want %v", long.DebounceDelay(), 3*gap)
+		}
+		longEvents := writeBurst(t, long, longDir, writes, gap, 5*gap)
+
+		if len(shortEvents) < writes-1 {
+			t.Errorf("short delay: expected about %d events, got %d", writes, len(shortEvents))
+		}
+		if len(longEvents) != 1 {
+			t.Fatalf("long delay: expected 1 coalesced event, got %d", len(longEvents))
+		}
+		if rec := longEvents[0].Record; rec == nil || rec.NumTurns != writes {
+			t.Errorf("coalesced event should carry the last write, got %+v", rec)
+		}
+	})
+
+	t.Run("non_positive_delay_uses_default", func(t *testing.T) {
+		for _, d := range []time.Duration{0, -time.Second} {
+			w := NewLiveFileWatcher(t.TempDir(), WithDebounceDelay(d))
+			if w.DebounceDelay() != DefaultLiveDebounceDelay {
+				t.Errorf("WithDebounceDelay(%v): DebounceDelay() = %v, want %v", d, w.DebounceDelay(), DefaultLiveDebounceDelay)
 			}
-			return
 		}
-	}
+	})
 }
 
 func TestEventType_String(t *testing.T) {
@@ -367,3 +452,90 @@ func TestLiveFileWatcher_ExistingFilesDetectedOnStart(t *testing.T) {
 		t.Fatal("timeout waiting for Updated event")
 	}
 }
+
+func TestLiveFileWatcher_CoalescingKeepsLatestPerTick(t *testing.T) {
+	const ticks, updates = 20, 50
+
+	// flood emits more events than the channel holds, ending each tick with
+	// a Finalized event, without anyone consuming.
+	flood := func(w *LiveFileWatcher) {
+		for i := 1; i <= updates; i++ {
+			for n := 0; n < ticks; n++ {
+				eventType := Updated
+				if i == 1 {
+					eventType = Created
+				}
+				w.emit(LiveFileEvent{
+					Type:   eventType,
+					TickID: fmt.Sprintf("tick%d", n),
+					Record: &runrecord.LiveRecord{NumTurns: i},
+				})
+			}
+		}
+		for n := 0; n < ticks; n++ {
+			w.emit(LiveFileEvent{Type: Finalized, TickID: fmt.Sprintf("tick%d", n)})
+		}
+	}
+
+	// drain collects every event delivered until the channel goes quiet.
+	drain := func(w *LiveFileWatcher) []LiveFileEvent {
+		var events []LiveFileEvent
+		for {
+			select {
+			case event := <-w.Events():
+				events = append(events, event)
+			case <-time.After(200 * time.Millisecond):
+				return events
+			}
+		}
+	}
+
+	t.Run("coalescing", func(t *testing.T) {
+		w := NewLiveFileWatcher(t.TempDir(), WithCoalescing())
+		if err := w.Start(); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+		defer w.Stop()
+
+		flood(w)
+		events := drain(w)
+
+		last := make(map[string]LiveFileEvent)
+		turns := make(map[string]int)
+		for _, event := range events {
+			if event.Record != nil {
+				if event.Record.NumTurns < turns[event.TickID] {
+					t.Errorf("%s: update %d delivered after %d", event.TickID, event.Record.NumTurns, turns[event.TickID])
+				}
+				turns[event.TickID] = event.Record.NumTurns
+			}
+			last[event.TickID] = event
+		}
+		if len(last) != ticks {
+			t.Fatalf("expected events for %d ticks, got %d", ticks, len(last))
+		}
+		for tickID, event := range last {
+			if event.Type != Finalized {
+				t.Errorf("%s: last event = %v, want Finalized", tickID, event.Type)
+			}
+		}
+		if len(events) >= ticks*(updates+1) {
+			t.Errorf("expected intermediate events to be coalesced, got all %d", len(events))
+		}
+	})
+
+	t.Run("default_drops_when_full", func(t *testing.T) {
+		w := NewLiveFileWatcher(t.TempDir())
+		if err := w.Start(); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+		defer w.Stop()
+
+		flood(w)
+		for _, event := range drain(w) {
+			if event.Type == Finalized {
+				t.Fatalf("expected Finalized events to be dropped once the channel filled, got %s", event.TickID)
+			}
+		}
+	})
+}
@@ -11,7 +11,7 @@
  * This interface was referenced by `RunRecords`'s JSON-Schema
  * via the `definition` "RunStatus".
  */
-export type RunStatus = 'starting' | 'thinking' | 'writing' | 'tool_use' | 'complete' | 'error';
+export type RunStatus = 'starting' | 'thinking' | 'writing' | 'tool_use' | 'complete' | 'error' | 'interrupted';
 
 /**
  * Types for agent run execution records
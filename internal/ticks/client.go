@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/pengelbrecht/ticks/internal/agent"
+	"github.com/pengelbrecht/ticks/internal/config"
 	"github.com/pengelbrecht/ticks/internal/query"
 	"github.com/pengelbrecht/ticks/internal/runrecord"
 	"github.com/pengelbrecht/ticks/internal/tick"
@@ -478,6 +479,79 @@ func (c *Client) ReopenTask(taskID string) error {
 	return nil
 }
 
+// FileVerificationBug records a verification failure as an open bug tick
+// discovered from the task, and blocks the task on it. An existing open bug
+// for the same task with the same title is reused instead of filing a
+// duplicate. Returns the bug ID and whether a new bug was created.
+func (c *Client) FileVerificationBug(taskID, title, description string) (string, bool, error) {
+	task, err := c.store.Read(taskID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read task: %w", err)
+	}
+
+	allTicks, err := c.store.List()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list ticks: %w", err)
+	}
+
+	var bugID string
+	for _, t := range allTicks {
+		if t.Type == tick.TypeBug && t.Status != tick.StatusClosed &&
+			t.DiscoveredFrom == taskID && t.Title == title {
+			bugID = t.ID
+			break
+		}
+	}
+
+	created := false
+	if bugID == "" {
+		cfg, err := config.LoadOrDefault(filepath.Join(c.store.Root, "config.json"))
+		if err != nil {
+			return "", false, fmt.Errorf("failed to load config: %w", err)
+		}
+		id, _, err := tick.NewIDGenerator(nil).GenerateWithPrefix(func(candidate string) bool {
+			_, err := c.store.Read(candidate)
+			return err == nil
+		}, cfg.IDLength, cfg.IDPrefix(tick.TypeBug))
+		if err != nil {
+			return "", false, fmt.Errorf("failed to generate id: %w", err)
+		}
+
+		now := time.Now().UTC()
+		bug := tick.Tick{
+			ID:             id,
+			Title:          title,
+			Description:    description,
+			Status:         tick.StatusOpen,
+			Priority:       task.Priority,
+			Type:           tick.TypeBug,
+			Owner:          task.Owner,
+			Parent:         task.Parent,
+			DiscoveredFrom: taskID,
+			CreatedBy:      task.Owner,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := c.store.Write(bug); err != nil {
+			return "", false, fmt.Errorf("failed to create bug: %w", err)
+		}
+		bugID = id
+		created = true
+	}
+
+	for _, blocker := range task.BlockedBy {
+		if blocker == bugID {
+			return bugID, created, nil
+		}
+	}
+	task.BlockedBy = append(task.BlockedBy, bugID)
+	task.UpdatedAt = time.Now().UTC()
+	if err := c.store.Write(task); err != nil {
+		return "", false, fmt.Errorf("failed to block task: %w", err)
+	}
+	return bugID, created, nil
+}
+
 // CloseEpic closes an epic with the given reason.
 func (c *Client) CloseEpic(epicID, reason string) error {
 	return c.CloseTask(epicID, reason)
@@ -2256,3 +2256,82 @@ func TestAutoModePriorityOrdering(t *testing.T) {
 		}
 	}
 }
+
+func TestFileVerificationBug(t *testing.T) {
+	tmpDir := t.TempDir()
+	issuesDir := filepath.Join(tmpDir, ".tick", "issues")
+	if err := os.MkdirAll(issuesDir, 0755); err != nil {
+		t.Fatalf("creating tick dir: %v", err)
+	}
+
+	taskData := map[string]interface{}{
+		"id":         "vb1",
+		"title":      "Implement feature",
+		"status":     "open",
+		"priority":   1,
+		"type":       "task",
+		"owner":      "test",
+		"parent":     "ep1",
+		"created_by": "test",
+		"created_at": "2025-01-01T00:00:00Z",
+		"updated_at": "2025-01-01T00:00:00Z",
+	}
+	taskJSON, _ := json.MarshalIndent(taskData, "", "  ")
+	if err := os.WriteFile(filepath.Join(issuesDir, "vb1.json"), taskJSON, 0600); err != nil {
+		t.Fatalf("writing task file: %v", err)
+	}
+
+	client := NewClient(filepath.Join(tmpDir, ".tick"))
+	title := "Verification failed: git check for vb1"
+
+	bugID, created, err := client.FileVerificationBug("vb1", title, "uncommitted changes")
+	if err != nil {
+		t.Fatalf("FileVerificationBug failed: %v", err)
+	}
+	if !created {
+		t.Fatal("expected bug to be created on first failure")
+	}
+
+	bug, err := client.store.Read(bugID)
+	if err != nil {
+		t.Fatalf("reading bug: %v", err)
+	}
+	if bug.Type != "bug" || bug.Status != "open" {
+		t.Errorf("expected open bug, got type=%s status=%s", bug.Type, bug.Status)
+	}
+	if bug.DiscoveredFrom != "vb1" {
+		t.Errorf("expected discovered_from vb1, got %q", bug.DiscoveredFrom)
+	}
+	if bug.Parent != "ep1" {
+		t.Errorf("expected parent ep1, got %q", bug.Parent)
+	}
+
+	task, err := client.GetTask("vb1")
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if len(task.BlockedBy) != 1 || task.BlockedBy[0] != bugID {
+		t.Errorf("expected task blocked by %s, got %v", bugID, task.BlockedBy)
+	}
+
+	// Second failure reuses the open bug
+	againID, created, err := client.FileVerificationBug("vb1", title, "uncommitted changes")
+	if err != nil {
+		t.Fatalf("FileVerificationBug failed: %v", err)
+	}
+	if created || againID != bugID {
+		t.Errorf("expected existing bug %s to be reused, got %s (created=%v)", bugID, againID, created)
+	}
+
+	all, err := client.store.List()
+	if err != nil {
+		t.Fatalf("listing ticks: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected 2 ticks (task + bug), got %d", len(all))
+	}
+	task, _ = client.GetTask("vb1")
+	if len(task.BlockedBy) != 1 {
+		t.Errorf("expected single blocker, got %v", task.BlockedBy)
+	}
+}
@@ -940,6 +940,7 @@ type RunStatus string
 
 const RunStatusComplete RunStatus = "complete"
 const RunStatusError RunStatus = "error"
+const RunStatusInterrupted RunStatus = "interrupted"
 
 // Response from GET /api/run-status/:epicId
 type RunStatusResponse struct {
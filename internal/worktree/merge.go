@@ -3,10 +3,18 @@ package worktree
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
+
+	"github.com/pengelbrecht/ticks/internal/merge"
+	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
 // ErrMergeConflict is returned when a merge cannot be completed due to conflicts.
@@ -24,6 +32,10 @@ var ErrNoTargetBranch = errors.New("no target branch specified and no parent bra
 // MergeOptions contains options for the Merge operation.
 type MergeOptions struct {
 	TargetBranch string // Target branch to merge into (overrides worktree's ParentBranch)
+
+	// Rebase retries a conflicting merge after rebasing the worktree branch
+	// onto the target branch. Rebase conflicts are reported in RebaseConflicts.
+	Rebase bool
 }
 
 // MergeResult represents the outcome of a merge attempt.
@@ -34,6 +46,15 @@ type MergeResult struct {
 	MergeCommit  string   // Commit hash of merge commit (if success)
 	ErrorMessage string   // Error details if failed
 	TargetBranch string   // The branch that was merged into
+	AutoResolved []string // Tick files whose conflicts were resolved field by field
+
+	// Set when MergeOptions.Rebase is used
+	Rebased         bool     // True if the worktree branch was rebased before merging
+	RebaseConflicts []string // Files that conflicted while rebasing (branch left as it was)
+
+	// Set by DryRunMerge only
+	MergeBase        string // Merge base of the target and worktree branches
+	WouldFastForward bool   // True if the target is an ancestor of the worktree branch
 }
 
 // MergeManager handles merging worktree branches to their target branch.
@@ -64,30 +85,17 @@ func (m *MergeManager) MainBranch() string {
 // Merge merges the worktree branch into the target branch.
 // Must be called from main repo (not worktree).
 // Returns MergeResult with conflict details if merge fails.
+// With opts.Rebase, a conflicting merge is aborted and retried once after
+// rebasing the worktree branch onto the target branch.
 //
 // Target branch resolution:
 // 1. If opts.TargetBranch is set, use it
 // 2. Else if wt.ParentBranch is set, use it
 // 3. Else return ErrNoTargetBranch
 func (m *MergeManager) Merge(wt *Worktree, opts MergeOptions) (*MergeResult, error) {
-	// Resolve target branch
-	var targetBranch string
-	var targetFromOpts bool
-	if opts.TargetBranch != "" {
-		targetBranch = opts.TargetBranch
-		targetFromOpts = true
-	} else if wt.ParentBranch != "" {
-		targetBranch = wt.ParentBranch
-	} else {
-		return nil, ErrNoTargetBranch
-	}
-
-	// Check if target branch exists
-	if !branchExists(m.repoRoot, targetBranch) {
-		if targetFromOpts {
-			return nil, fmt.Errorf("target branch %q does not exist", targetBranch)
-		}
-		return nil, ErrParentBranchNotFound
+	targetBranch, err := m.resolveTargetBranch(wt, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	// First, checkout target branch
@@ -99,6 +107,31 @@ func (m *MergeManager) Merge(wt *Worktree, opts MergeOptions) (*MergeResult, err
 		}, nil
 	}
 
+	result := m.mergeBranch(wt, targetBranch)
+	if len(result.Conflicts) == 0 || !opts.Rebase || m.isAncestor(targetBranch, wt.Branch) {
+		return result, nil
+	}
+
+	// Retry on top of the latest target so changes already upstream drop out
+	if err := m.AbortMerge(); err != nil {
+		return nil, err
+	}
+	if conflicts, err := m.rebaseBranch(wt, targetBranch); err != nil {
+		return &MergeResult{
+			Success:         false,
+			TargetBranch:    targetBranch,
+			RebaseConflicts: conflicts,
+			ErrorMessage:    fmt.Sprintf("rebase onto %s failed: %v", targetBranch, err),
+		}, nil
+	}
+
+	result = m.mergeBranch(wt, targetBranch)
+	result.Rebased = true
+	return result, nil
+}
+
+// mergeBranch merges the worktree branch into the checked-out target branch.
+func (m *MergeManager) mergeBranch(wt *Worktree, targetBranch string) *MergeResult {
 	// Attempt merge with --no-ff to always create merge commit
 	mergeMsg := fmt.Sprintf("Merge %s", wt.Branch)
 	cmd := exec.Command("git", "merge", wt.Branch, "--no-ff", "-m", mergeMsg)
@@ -108,6 +141,13 @@ func (m *MergeManager) Merge(wt *Worktree, opts MergeOptions) (*MergeResult, err
 	if err != nil {
 		// Check if it's a conflict
 		conflicts := m.getConflictingFiles()
+		if len(conflicts) > 0 && allTickFiles(conflicts) {
+			// Tick files merge field by field, so these only need a human
+			// if a version can't be read
+			if err := m.resolveTickConflicts(conflicts); err == nil {
+				return m.mergeResult(targetBranch, conflicts)
+			}
+		}
 		if len(conflicts) > 0 {
 			return &MergeResult{
 				Success:      false,
@@ -115,7 +155,7 @@ func (m *MergeManager) Merge(wt *Worktree, opts MergeOptions) (*MergeResult, err
 				Conflicts:    conflicts,
 				TargetBranch: targetBranch,
 				ErrorMessage: "merge conflict",
-			}, nil
+			}
 		}
 
 		// Some other error
@@ -123,9 +163,179 @@ func (m *MergeManager) Merge(wt *Worktree, opts MergeOptions) (*MergeResult, err
 			Success:      false,
 			TargetBranch: targetBranch,
 			ErrorMessage: fmt.Sprintf("merge failed: %s", strings.TrimSpace(string(output))),
-		}, nil
+		}
 	}
 
+	return m.mergeResult(targetBranch, nil)
+}
+
+// rebaseBranch rebases the worktree branch onto targetBranch inside the
+// worktree. On failure the rebase is aborted, leaving the branch unchanged,
+// and the conflicting files (if any) are returned.
+func (m *MergeManager) rebaseBranch(wt *Worktree, targetBranch string) ([]string, error) {
+	cmd := exec.Command("git", "rebase", targetBranch)
+	cmd.Dir = wt.Path
+
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil, nil
+	}
+
+	var conflicts []string
+	diff := exec.Command("git", "diff", "--name-only", "--diff-filter=U")
+	diff.Dir = wt.Path
+	if out, derr := diff.Output(); derr == nil {
+		conflicts = strings.Fields(string(out))
+	}
+
+	abort := exec.Command("git", "rebase", "--abort")
+	abort.Dir = wt.Path
+	_ = abort.Run()
+
+	if len(conflicts) > 0 {
+		return conflicts, errors.New("rebase conflict")
+	}
+	return nil, fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+}
+
+// isAncestor reports whether commit a is an ancestor of (or equal to) b.
+func (m *MergeManager) isAncestor(a, b string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", a, b)
+	cmd.Dir = m.repoRoot
+	return cmd.Run() == nil
+}
+
+// IsMerged reports whether wt's branch is fully merged into its parent
+// branch, or into the main branch when the parent is unknown. It also returns
+// the branch it checked against. A branch with no commits of its own is an
+// ancestor of its parent too, so it counts as merged; use HasOwnCommits to
+// tell the two apart.
+func (m *MergeManager) IsMerged(wt *Worktree) (bool, string) {
+	target := wt.ParentBranch
+	if target == "" {
+		target = m.mainBranch
+	}
+	return m.isAncestor(wt.Branch, target), target
+}
+
+// HasOwnCommits reports whether wt's branch has moved since it was created,
+// judged from the branch's reflog. A branch without a reflog is assumed to
+// have commits.
+func (m *MergeManager) HasOwnCommits(wt *Worktree) bool {
+	cmd := exec.Command("git", "reflog", "show", "--format=%H", "refs/heads/"+wt.Branch)
+	cmd.Dir = m.repoRoot
+	output, err := cmd.Output()
+	entries := strings.Fields(string(output))
+	if err != nil || len(entries) == 0 {
+		return true
+	}
+	// Entries are newest first; the oldest records where the branch started
+	return entries[0] != entries[len(entries)-1]
+}
+
+// DryRunMerge previews merging the worktree branch into its parent branch
+// without touching the working tree, index or HEAD. It computes the merge
+// in memory with git merge-tree and reports the conflicts a real Merge would
+// leave, the merge base, and whether the target could be fast-forwarded.
+// Tick files that Merge would resolve automatically with merge.ThreeWay are
+// listed in AutoResolved.
+// Success is true if Merge would complete without manual resolution; Merged
+// and MergeCommit are never set.
+func (m *MergeManager) DryRunMerge(wt *Worktree) (*MergeResult, error) {
+	targetBranch, err := m.resolveTargetBranch(wt, MergeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{TargetBranch: targetBranch}
+
+	cmd := exec.Command("git", "merge-base", targetBranch, wt.Branch)
+	cmd.Dir = m.repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		result.ErrorMessage = fmt.Sprintf("no merge base between %s and %s", targetBranch, wt.Branch)
+		return result, nil
+	}
+	result.MergeBase = strings.TrimSpace(string(output))
+
+	// Fast-forward possible if the target has nothing the branch lacks
+	result.WouldFastForward = m.isAncestor(targetBranch, wt.Branch)
+
+	// merge-tree exits 1 when there are conflicts; the first line of output
+	// is the resulting tree, followed by "<mode> <object> <stage>\t<path>"
+	// for each stage of each conflicted path
+	cmd = exec.Command("git", "merge-tree", "--write-tree", "--no-messages", targetBranch, wt.Branch)
+	cmd.Dir = m.repoRoot
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err = cmd.Output()
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		result.ErrorMessage = fmt.Sprintf("merge-tree failed: %s", strings.TrimSpace(stderr.String()))
+		return result, nil
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	var conflicts []string
+	stages := make(map[string]map[int]string) // path -> stage -> object
+	for _, line := range lines[1:] {
+		info, file, ok := strings.Cut(line, "\t")
+		fields := strings.Fields(info)
+		if !ok || len(fields) != 3 {
+			continue
+		}
+		stage, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+		if stages[file] == nil {
+			stages[file] = make(map[int]string)
+			conflicts = append(conflicts, file)
+		}
+		stages[file][stage] = fields[1]
+	}
+
+	if len(conflicts) > 0 && allTickFiles(conflicts) && m.tickStagesMerge(conflicts, stages) {
+		result.AutoResolved = conflicts
+		conflicts = nil
+	}
+	if len(conflicts) > 0 {
+		result.Conflicts = conflicts
+		result.ErrorMessage = "merge conflict"
+		return result, nil
+	}
+
+	result.Success = true
+	return result, nil
+}
+
+// resolveTargetBranch returns the branch to merge wt into:
+// opts.TargetBranch if set, else wt.ParentBranch.
+func (m *MergeManager) resolveTargetBranch(wt *Worktree, opts MergeOptions) (string, error) {
+	var targetBranch string
+	var targetFromOpts bool
+	if opts.TargetBranch != "" {
+		targetBranch = opts.TargetBranch
+		targetFromOpts = true
+	} else if wt.ParentBranch != "" {
+		targetBranch = wt.ParentBranch
+	} else {
+		return "", ErrNoTargetBranch
+	}
+
+	// Check if target branch exists
+	if !branchExists(m.repoRoot, targetBranch) {
+		if targetFromOpts {
+			return "", fmt.Errorf("target branch %q does not exist", targetBranch)
+		}
+		return "", ErrParentBranchNotFound
+	}
+
+	return targetBranch, nil
+}
+
+// mergeResult builds the result of a completed merge.
+func (m *MergeManager) mergeResult(targetBranch string, autoResolved []string) *MergeResult {
 	// Get the merge commit hash
 	commitHash, err := m.getHeadCommit()
 	if err != nil {
@@ -133,8 +343,9 @@ func (m *MergeManager) Merge(wt *Worktree, opts MergeOptions) (*MergeResult, err
 			Success:      true,
 			Merged:       true,
 			TargetBranch: targetBranch,
+			AutoResolved: autoResolved,
 			ErrorMessage: fmt.Sprintf("merge succeeded but failed to get commit hash: %v", err),
-		}, nil
+		}
 	}
 
 	return &MergeResult{
@@ -142,7 +353,110 @@ func (m *MergeManager) Merge(wt *Worktree, opts MergeOptions) (*MergeResult, err
 		Merged:       true,
 		MergeCommit:  commitHash,
 		TargetBranch: targetBranch,
-	}, nil
+		AutoResolved: autoResolved,
+	}
+}
+
+// tickStagesMerge reports whether resolveTickConflicts would resolve every
+// file, given the stage objects of each from git merge-tree.
+func (m *MergeManager) tickStagesMerge(files []string, stages map[string]map[int]string) bool {
+	for _, file := range files {
+		_, err := mergeTickStages(func(stage int) (tick.Tick, error) {
+			object, ok := stages[file][stage]
+			if !ok {
+				return tick.Tick{}, fmt.Errorf("%s has no stage %d", file, stage)
+			}
+			return m.readTick(object)
+		})
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveTickConflicts resolves conflicted tick files in an in-progress merge
+// with merge.ThreeWay, the rules the tk merge-driver uses, and commits the
+// merge.
+func (m *MergeManager) resolveTickConflicts(paths []string) error {
+	for _, file := range paths {
+		merged, err := mergeTickStages(func(stage int) (tick.Tick, error) {
+			return m.readTick(fmt.Sprintf(":%d:%s", stage, file))
+		})
+		if err != nil {
+			return fmt.Errorf("merge %s: %w", file, err)
+		}
+
+		data, err := json.MarshalIndent(merged, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", file, err)
+		}
+		if err := os.WriteFile(filepath.Join(m.repoRoot, file), data, 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", file, err)
+		}
+		if err := m.git("add", "--", file); err != nil {
+			return err
+		}
+	}
+
+	return m.git("commit", "--no-edit")
+}
+
+// mergeTickStages merges the versions of a conflicted tick file that read
+// returns for each stage (1 = base, 2 = ours, 3 = theirs) with
+// merge.ThreeWay. The base is missing when both sides added the tick.
+func mergeTickStages(read func(stage int) (tick.Tick, error)) (tick.Tick, error) {
+	base, _ := read(1)
+	ours, err := read(2)
+	if err != nil {
+		return tick.Tick{}, err
+	}
+	theirs, err := read(3)
+	if err != nil {
+		return tick.Tick{}, err
+	}
+	return merge.ThreeWay(base, ours, theirs)
+}
+
+// readTick reads a tick from a git object, e.g. ":2:<path>" for a stage of
+// a conflicted file or a blob id.
+func (m *MergeManager) readTick(object string) (tick.Tick, error) {
+	cmd := exec.Command("git", "show", object)
+	cmd.Dir = m.repoRoot
+
+	output, err := cmd.Output()
+	if err != nil {
+		return tick.Tick{}, fmt.Errorf("read %s: %w", object, err)
+	}
+
+	var t tick.Tick
+	if err := json.Unmarshal(output, &t); err != nil {
+		return tick.Tick{}, fmt.Errorf("parse %s: %w", object, err)
+	}
+	return t, nil
+}
+
+// git runs a git command in the repository root.
+func (m *MergeManager) git(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = m.repoRoot
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// allTickFiles reports whether every path is a tick file in .tick/issues/.
+func allTickFiles(paths []string) bool {
+	for _, p := range paths {
+		dir, name := path.Split(filepath.ToSlash(p))
+		if dir != ".tick/issues/" || path.Ext(name) != ".json" {
+			return false
+		}
+	}
+	return true
 }
 
 // AbortMerge aborts an in-progress merge.
@@ -1,11 +1,15 @@
 package worktree
 
 import (
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/pengelbrecht/ticks/internal/tick"
 )
 
 func TestNewMergeManager(t *testing.T) {
@@ -745,3 +749,483 @@ func TestMerge_TargetBranchPopulated(t *testing.T) {
 		}
 	})
 }
+
+func TestMerge_AutoResolvesTickConflicts(t *testing.T) {
+	dir := createTempGitRepo(t)
+
+	base := time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)
+	writeTick := func(root string, title string, priority int, updated time.Time) {
+		t.Helper()
+		tk := tick.Tick{
+			ID:        "abc",
+			Title:     title,
+			Status:    tick.StatusOpen,
+			Priority:  priority,
+			Type:      tick.TypeTask,
+			Owner:     "tester",
+			CreatedBy: "tester",
+			CreatedAt: base,
+			UpdatedAt: updated,
+		}
+		data, err := json.MarshalIndent(tk, "", "  ")
+		if err != nil {
+			t.Fatalf("marshal tick: %v", err)
+		}
+		// Stage via the object database: in worktrees .tick is a symlink
+		// to the main repo, so the branch's copy can't be edited in place
+		blob := filepath.Join(t.TempDir(), "abc.json")
+		if err := os.WriteFile(blob, data, 0644); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+		cmd := exec.Command("git", "hash-object", "-w", blob)
+		cmd.Dir = root
+		sha, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("hash-object: %v", err)
+		}
+		runGit(t, root, "update-index", "--add", "--cacheinfo", "100644,"+strings.TrimSpace(string(sha))+",.tick/issues/abc.json")
+		runGit(t, root, "commit", "-m", "Update tick: "+title)
+		if root == dir {
+			runGit(t, root, "checkout", "--", ".tick")
+		}
+	}
+
+	writeTick(dir, "Original", 2, base)
+
+	wm, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	wt, err := wm.Create("tick-conflict")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Divergent edits: both retitle, only main reprioritizes; the worktree
+	// edit is newer
+	writeTick(wt.Path, "Worktree edit", 2, base.Add(2*time.Hour))
+	writeTick(dir, "Main edit", 1, base.Add(time.Hour))
+
+	mm, err := NewMergeManager(dir)
+	if err != nil {
+		t.Fatalf("NewMergeManager() error = %v", err)
+	}
+
+	preview, err := mm.DryRunMerge(wt)
+	if err != nil {
+		t.Fatalf("DryRunMerge() error = %v", err)
+	}
+	if !preview.Success || len(preview.AutoResolved) != 1 || len(preview.Conflicts) != 0 {
+		t.Errorf("DryRunMerge() = success %v, auto-resolved %v, conflicts %v; want the tick auto-resolved",
+			preview.Success, preview.AutoResolved, preview.Conflicts)
+	}
+
+	result, err := mm.Merge(wt, MergeOptions{})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if !result.Success {
+		t.Fatalf("Merge() Success = false, want true. Error: %s, Conflicts: %v", result.ErrorMessage, result.Conflicts)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("Merge() Conflicts = %v, want empty", result.Conflicts)
+	}
+	if len(result.AutoResolved) != 1 || result.AutoResolved[0] != ".tick/issues/abc.json" {
+		t.Errorf("Merge() AutoResolved = %v, want [.tick/issues/abc.json]", result.AutoResolved)
+	}
+	if result.MergeCommit == "" {
+		t.Error("Merge() MergeCommit should not be empty")
+	}
+	if mm.HasConflict() {
+		t.Error("merge should not be left in progress")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ".tick", "issues", "abc.json"))
+	if err != nil {
+		t.Fatalf("read merged tick: %v", err)
+	}
+	if strings.Contains(string(data), "<<<<<<<") {
+		t.Fatalf("merged tick contains conflict markers:\n%s", data)
+	}
+	var merged tick.Tick
+	if err := json.Unmarshal(data, &merged); err != nil {
+		t.Fatalf("parse merged tick: %v", err)
+	}
+	if merged.Title != "Worktree edit" {
+		t.Errorf("merged title = %q, want newer %q", merged.Title, "Worktree edit")
+	}
+	if merged.Priority != 1 {
+		t.Errorf("merged priority = %d, want main's 1 (changed on one side only)", merged.Priority)
+	}
+}
+
+func TestMerge_UnreadableTickConflict(t *testing.T) {
+	dir := createTempGitRepo(t)
+	commitTick := func(root, content string) {
+		t.Helper()
+		blob := filepath.Join(t.TempDir(), "abc.json")
+		if err := os.WriteFile(blob, []byte(content), 0644); err != nil {
+			t.Fatalf("write tick: %v", err)
+		}
+		cmd := exec.Command("git", "hash-object", "-w", blob)
+		cmd.Dir = root
+		sha, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("hash-object: %v", err)
+		}
+		runGit(t, root, "update-index", "--add", "--cacheinfo", "100644,"+strings.TrimSpace(string(sha))+",.tick/issues/abc.json")
+		runGit(t, root, "commit", "-m", "Update tick")
+		if root == dir {
+			runGit(t, root, "checkout", "--", ".tick")
+		}
+	}
+
+	commitTick(dir, `{"id": "abc", "title": "Original"}`)
+	wm, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	wt, err := wm.Create("tick-unreadable")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	commitTick(wt.Path, `{"id": "abc", "title": "Worktree edit"}`)
+	commitTick(dir, `not json`)
+
+	mm, err := NewMergeManager(dir)
+	if err != nil {
+		t.Fatalf("NewMergeManager() error = %v", err)
+	}
+
+	// The dry run predicts the same outcome as the merge: a real conflict
+	preview, err := mm.DryRunMerge(wt)
+	if err != nil {
+		t.Fatalf("DryRunMerge() error = %v", err)
+	}
+	if preview.Success || len(preview.Conflicts) != 1 || len(preview.AutoResolved) != 0 {
+		t.Errorf("DryRunMerge() = success %v, conflicts %v, auto-resolved %v; want one conflict",
+			preview.Success, preview.Conflicts, preview.AutoResolved)
+	}
+
+	result, err := mm.Merge(wt, MergeOptions{})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if result.Success || len(result.Conflicts) != 1 || result.Conflicts[0] != ".tick/issues/abc.json" {
+		t.Errorf("Merge() = success %v, conflicts %v; want a conflict on the tick", result.Success, result.Conflicts)
+	}
+	if mm.HasConflict() {
+		mm.AbortMerge()
+	}
+}
+
+func TestMergeManager_DryRunMerge(t *testing.T) {
+	// repoState captures HEAD, the current branch and working tree status
+	repoState := func(t *testing.T, dir string) string {
+		t.Helper()
+		var parts []string
+		for _, args := range [][]string{
+			{"rev-parse", "HEAD"},
+			{"branch", "--show-current"},
+			{"status", "--porcelain"},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("git %v failed: %v", args, err)
+			}
+			parts = append(parts, string(out))
+		}
+		return strings.Join(parts, "|")
+	}
+
+	commitFile := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		runGit(t, dir, "add", name)
+		runGit(t, dir, "commit", "-m", "Change "+name)
+	}
+
+	t.Run("fast-forward", func(t *testing.T) {
+		dir := createTempGitRepo(t)
+		wm, err := NewManager(dir)
+		if err != nil {
+			t.Fatalf("NewManager() error = %v", err)
+		}
+		wt, err := wm.Create("dry-ff")
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		commitFile(t, wt.Path, "new-file.txt", "new content")
+
+		mm, err := NewMergeManager(dir)
+		if err != nil {
+			t.Fatalf("NewMergeManager() error = %v", err)
+		}
+		before := repoState(t, dir)
+
+		result, err := mm.DryRunMerge(wt)
+		if err != nil {
+			t.Fatalf("DryRunMerge() error = %v", err)
+		}
+		if !result.Success {
+			t.Errorf("DryRunMerge() Success = false, want true. Error: %s", result.ErrorMessage)
+		}
+		if !result.WouldFastForward {
+			t.Error("DryRunMerge() WouldFastForward = false, want true")
+		}
+		if result.Merged || result.MergeCommit != "" {
+			t.Error("DryRunMerge() should not report a merge commit")
+		}
+		if result.MergeBase == "" {
+			t.Error("DryRunMerge() MergeBase should not be empty")
+		}
+		if after := repoState(t, dir); after != before {
+			t.Errorf("repo changed by dry run:\nbefore %q\nafter  %q", before, after)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "new-file.txt")); !os.IsNotExist(err) {
+			t.Error("new-file.txt should not exist on main after dry run")
+		}
+	})
+
+	t.Run("clean merge with commits on both sides", func(t *testing.T) {
+		dir := createTempGitRepo(t)
+		wm, err := NewManager(dir)
+		if err != nil {
+			t.Fatalf("NewManager() error = %v", err)
+		}
+		wt, err := wm.Create("dry-both")
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		commitFile(t, wt.Path, "worktree-file.txt", "worktree content")
+		commitFile(t, dir, "main-file.txt", "main content")
+
+		mm, err := NewMergeManager(dir)
+		if err != nil {
+			t.Fatalf("NewMergeManager() error = %v", err)
+		}
+		before := repoState(t, dir)
+
+		result, err := mm.DryRunMerge(wt)
+		if err != nil {
+			t.Fatalf("DryRunMerge() error = %v", err)
+		}
+		if !result.Success {
+			t.Errorf("DryRunMerge() Success = false, want true. Error: %s", result.ErrorMessage)
+		}
+		if result.WouldFastForward {
+			t.Error("DryRunMerge() WouldFastForward = true, want false")
+		}
+		if len(result.Conflicts) > 0 {
+			t.Errorf("DryRunMerge() Conflicts = %v, want empty", result.Conflicts)
+		}
+		if after := repoState(t, dir); after != before {
+			t.Errorf("repo changed by dry run:\nbefore %q\nafter  %q", before, after)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		dir := createTempGitRepo(t)
+		wm, err := NewManager(dir)
+		if err != nil {
+			t.Fatalf("NewManager() error = %v", err)
+		}
+		wt, err := wm.Create("dry-conflict")
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		commitFile(t, wt.Path, "initial.txt", "worktree version")
+		commitFile(t, dir, "initial.txt", "main version")
+
+		mm, err := NewMergeManager(dir)
+		if err != nil {
+			t.Fatalf("NewMergeManager() error = %v", err)
+		}
+		before := repoState(t, dir)
+
+		result, err := mm.DryRunMerge(wt)
+		if err != nil {
+			t.Fatalf("DryRunMerge() error = %v", err)
+		}
+		if result.Success {
+			t.Error("DryRunMerge() Success = true, want false (conflict)")
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0] != "initial.txt" {
+			t.Errorf("DryRunMerge() Conflicts = %v, want [initial.txt]", result.Conflicts)
+		}
+		if mm.HasConflict() {
+			t.Error("dry run should not leave a merge in progress")
+		}
+		if after := repoState(t, dir); after != before {
+			t.Errorf("repo changed by dry run:\nbefore %q\nafter  %q", before, after)
+		}
+	})
+}
+
+func TestMerge_RebaseRetry(t *testing.T) {
+	commitFile := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		runGit(t, dir, "add", name)
+		runGit(t, dir, "commit", "-m", "Change "+name+" to "+content)
+	}
+
+	t.Run("clean rebase turns conflict into fast-forward", func(t *testing.T) {
+		dir := createTempGitRepo(t)
+		wm, err := NewManager(dir)
+		if err != nil {
+			t.Fatalf("NewManager() error = %v", err)
+		}
+		wt, err := wm.Create("rebase-clean")
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		commitFile(t, wt.Path, "initial.txt", "v2")
+
+		// Main already picked up the worktree change and moved on, so a
+		// plain merge conflicts but the rebase drops the duplicate commit
+		commitFile(t, dir, "main-file.txt", "main content")
+		runGit(t, dir, "cherry-pick", wt.Branch)
+		commitFile(t, dir, "initial.txt", "v3")
+
+		mm, err := NewMergeManager(dir)
+		if err != nil {
+			t.Fatalf("NewMergeManager() error = %v", err)
+		}
+		if dry, err := mm.DryRunMerge(wt); err != nil || len(dry.Conflicts) == 0 {
+			t.Fatalf("expected plain merge to conflict, got %+v (err %v)", dry, err)
+		}
+
+		result, err := mm.Merge(wt, MergeOptions{Rebase: true})
+		if err != nil {
+			t.Fatalf("Merge() error = %v", err)
+		}
+		if !result.Success {
+			t.Fatalf("Merge() Success = false, want true. Error: %s, Conflicts: %v", result.ErrorMessage, result.Conflicts)
+		}
+		if !result.Rebased {
+			t.Error("Merge() Rebased = false, want true")
+		}
+		if !mm.isAncestor(wt.Branch, result.TargetBranch) || !mm.isAncestor(result.TargetBranch, wt.Branch) {
+			t.Error("rebased branch should match the target (fast-forward)")
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "initial.txt"))
+		if err != nil {
+			t.Fatalf("read initial.txt: %v", err)
+		}
+		if string(data) != "v3" {
+			t.Errorf("initial.txt = %q, want %q", data, "v3")
+		}
+	})
+
+	t.Run("rebase conflict reported separately", func(t *testing.T) {
+		dir := createTempGitRepo(t)
+		wm, err := NewManager(dir)
+		if err != nil {
+			t.Fatalf("NewManager() error = %v", err)
+		}
+		wt, err := wm.Create("rebase-conflict")
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		commitFile(t, wt.Path, "initial.txt", "worktree version")
+		commitFile(t, dir, "initial.txt", "main version")
+
+		mm, err := NewMergeManager(dir)
+		if err != nil {
+			t.Fatalf("NewMergeManager() error = %v", err)
+		}
+
+		cmd := exec.Command("git", "rev-parse", wt.Branch)
+		cmd.Dir = dir
+		before, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("rev-parse: %v", err)
+		}
+
+		result, err := mm.Merge(wt, MergeOptions{Rebase: true})
+		if err != nil {
+			t.Fatalf("Merge() error = %v", err)
+		}
+		if result.Success {
+			t.Error("Merge() Success = true, want false")
+		}
+		if len(result.Conflicts) != 0 {
+			t.Errorf("Merge() Conflicts = %v, want empty (rebase conflicts are separate)", result.Conflicts)
+		}
+		if len(result.RebaseConflicts) != 1 || result.RebaseConflicts[0] != "initial.txt" {
+			t.Errorf("Merge() RebaseConflicts = %v, want [initial.txt]", result.RebaseConflicts)
+		}
+		if mm.HasConflict() {
+			t.Error("no merge should be left in progress")
+		}
+
+		cmd = exec.Command("git", "rev-parse", wt.Branch)
+		cmd.Dir = dir
+		after, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("rev-parse: %v", err)
+		}
+		if string(before) != string(after) {
+			t.Error("worktree branch should be unchanged after failed rebase")
+		}
+	})
+}
+
+func TestMergeManager_IsMerged(t *testing.T) {
+	dir := createTempGitRepo(t)
+	wm, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	mm, err := NewMergeManager(dir)
+	if err != nil {
+		t.Fatalf("NewMergeManager() error = %v", err)
+	}
+
+	wt, err := wm.Create("merged-check")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// A fresh branch has nothing of its own yet
+	if merged, target := mm.IsMerged(wt); !merged || target != wt.ParentBranch {
+		t.Errorf("IsMerged() = %v, %q; want true, %q", merged, target, wt.ParentBranch)
+	}
+	if mm.HasOwnCommits(wt) {
+		t.Error("HasOwnCommits() = true for a fresh branch")
+	}
+
+	if err := os.WriteFile(filepath.Join(wt.Path, "work.txt"), []byte("work"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGit(t, wt.Path, "add", "work.txt")
+	runGit(t, wt.Path, "commit", "-m", "Add work")
+
+	if merged, _ := mm.IsMerged(wt); merged {
+		t.Error("IsMerged() = true for a branch with unmerged commits")
+	}
+	if !mm.HasOwnCommits(wt) {
+		t.Error("HasOwnCommits() = false after committing on the branch")
+	}
+
+	runGit(t, dir, "merge", "--no-ff", "-m", "Merge work", wt.Branch)
+	if merged, _ := mm.IsMerged(wt); !merged {
+		t.Error("IsMerged() = false after merging the branch")
+	}
+
+	t.Run("falls back to main branch", func(t *testing.T) {
+		orphan := &Worktree{Branch: wt.Branch}
+		if merged, target := mm.IsMerged(orphan); !merged || target != mm.MainBranch() {
+			t.Errorf("IsMerged() = %v, %q; want true, %q", merged, target, mm.MainBranch())
+		}
+	})
+}
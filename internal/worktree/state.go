@@ -0,0 +1,119 @@
+package worktree
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RunState associates an in-flight epic run with its worktree, so an
+// interrupted run can be resumed in the same tree.
+type RunState struct {
+	EpicID       string    `json:"epic_id"`
+	Path         string    `json:"path"`
+	Branch       string    `json:"branch"`
+	ParentBranch string    `json:"parent_branch,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+}
+
+// runStatePath returns the state file for an epic's worktree run.
+// States are stored in <tickDir>/logs/worktrees/<epic-id>.json.
+func runStatePath(tickDir, epicID string) string {
+	return filepath.Join(tickDir, "logs", "worktrees", epicID+".json")
+}
+
+// SaveRunState records that wt is the worktree of a run in progress.
+func SaveRunState(tickDir string, wt *Worktree) error {
+	if wt == nil || wt.EpicID == "" {
+		return fmt.Errorf("epic ID is required")
+	}
+
+	path := runStatePath(tickDir, wt.EpicID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating run state directory: %w", err)
+	}
+
+	state := RunState{
+		EpicID:       wt.EpicID,
+		Path:         wt.Path,
+		Branch:       wt.Branch,
+		ParentBranch: wt.ParentBranch,
+		StartedAt:    time.Now().UTC(),
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal run state: %w", err)
+	}
+
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		return fmt.Errorf("writing temp run state file: %w", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("renaming run state file: %w", err)
+	}
+	return nil
+}
+
+// LoadRunState reads the run state for an epic.
+// Returns nil (not error) if no run is in progress.
+func LoadRunState(tickDir, epicID string) (*RunState, error) {
+	if epicID == "" {
+		return nil, fmt.Errorf("epic ID is required")
+	}
+
+	data, err := os.ReadFile(runStatePath(tickDir, epicID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading run state file: %w", err)
+	}
+
+	var state RunState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse run state file: %w", err)
+	}
+	return &state, nil
+}
+
+// RemoveRunState deletes the run state for an epic. A missing state is not an error.
+func RemoveRunState(tickDir, epicID string) error {
+	if err := os.Remove(runStatePath(tickDir, epicID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing run state file: %w", err)
+	}
+	return nil
+}
+
+// Attach returns the worktree recorded in state, checking that it still
+// exists on the recorded branch. Returns ErrWorktreeNotFound if the worktree
+// was removed since the state was saved.
+func (m *Manager) Attach(state *RunState) (*Worktree, error) {
+	wt, err := m.Get(state.EpicID)
+	if err != nil {
+		return nil, err
+	}
+	if wt == nil {
+		return nil, ErrWorktreeNotFound
+	}
+	if wt.Branch != state.Branch || !samePath(wt.Path, state.Path) {
+		return nil, fmt.Errorf("worktree for %s changed: recorded %s on %s, found %s on %s",
+			state.EpicID, state.Path, state.Branch, wt.Path, wt.Branch)
+	}
+	return wt, nil
+}
+
+// samePath reports whether a and b name the same directory, resolving
+// symlinks such as /var -> /private/var on macOS.
+func samePath(a, b string) bool {
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
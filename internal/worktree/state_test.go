@@ -0,0 +1,89 @@
+package worktree
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunState_SaveLoadRemove(t *testing.T) {
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+
+	state, err := LoadRunState(tickDir, "epic1")
+	if err != nil {
+		t.Fatalf("LoadRunState() error = %v", err)
+	}
+	if state != nil {
+		t.Fatalf("LoadRunState() = %+v, want nil before save", state)
+	}
+
+	wt := &Worktree{Path: "/repo/.worktrees/epic1", Branch: "tick/epic1", EpicID: "epic1", ParentBranch: "main"}
+	if err := SaveRunState(tickDir, wt); err != nil {
+		t.Fatalf("SaveRunState() error = %v", err)
+	}
+
+	state, err = LoadRunState(tickDir, "epic1")
+	if err != nil {
+		t.Fatalf("LoadRunState() error = %v", err)
+	}
+	if state == nil {
+		t.Fatal("LoadRunState() = nil after save")
+	}
+	if state.Path != wt.Path || state.Branch != wt.Branch || state.ParentBranch != "main" || state.StartedAt.IsZero() {
+		t.Errorf("LoadRunState() = %+v, want values from %+v", state, wt)
+	}
+
+	if err := RemoveRunState(tickDir, "epic1"); err != nil {
+		t.Fatalf("RemoveRunState() error = %v", err)
+	}
+	if state, _ := LoadRunState(tickDir, "epic1"); state != nil {
+		t.Error("run state should be gone after RemoveRunState")
+	}
+	if err := RemoveRunState(tickDir, "epic1"); err != nil {
+		t.Errorf("RemoveRunState() on missing state error = %v, want nil", err)
+	}
+}
+
+func TestManager_Attach(t *testing.T) {
+	repoRoot := createTempGitRepo(t)
+	m, err := NewManager(repoRoot)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	wt, err := m.Create("epic1")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	tickDir := filepath.Join(repoRoot, ".tick")
+	if err := SaveRunState(tickDir, wt); err != nil {
+		t.Fatalf("SaveRunState() error = %v", err)
+	}
+	state, err := LoadRunState(tickDir, "epic1")
+	if err != nil {
+		t.Fatalf("LoadRunState() error = %v", err)
+	}
+
+	got, err := m.Attach(state)
+	if err != nil {
+		t.Fatalf("Attach() error = %v", err)
+	}
+	if !samePath(got.Path, wt.Path) || got.Branch != wt.Branch {
+		t.Errorf("Attach() = %s on %s, want %s on %s", got.Path, got.Branch, wt.Path, wt.Branch)
+	}
+
+	// A state pointing at a different branch is rejected
+	moved := *state
+	moved.Branch = "tick/other"
+	if _, err := m.Attach(&moved); err == nil {
+		t.Error("Attach() with mismatched branch should fail")
+	}
+
+	// Once the worktree is gone there is nothing to attach to
+	if err := m.Remove("epic1"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := m.Attach(state); !errors.Is(err, ErrWorktreeNotFound) {
+		t.Errorf("Attach() after Remove error = %v, want ErrWorktreeNotFound", err)
+	}
+}
@@ -363,6 +363,37 @@ func (m *Manager) IsDirty() (bool, []string, error) {
 	return len(dirtyFiles) > 0, dirtyFiles, nil
 }
 
+// UncommittedChanges returns the files with uncommitted changes inside a
+// worktree, including untracked files. The .tick/ symlink to the main
+// repository and the tk metadata file are not counted.
+func (m *Manager) UncommittedChanges(wt *Worktree) ([]string, error) {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = wt.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check git status in %s: %w", wt.Path, err)
+	}
+
+	var files []string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) < 3 {
+			continue
+		}
+		filename := strings.TrimSpace(line[2:])
+		if filename == ".tick" || strings.HasPrefix(filename, ".tick/") || filename == metadataFileName {
+			continue
+		}
+		files = append(files, filename)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse git status: %w", err)
+	}
+	return files, nil
+}
+
 // IsOnlyTickFilesDirty checks if only .tick/ files are dirty.
 // Returns true if all dirty files are in .tick/ directory, false if there are other dirty files.
 // Also returns the list of dirty tick files.
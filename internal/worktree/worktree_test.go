@@ -1094,3 +1094,55 @@ func TestManager_List_MissingMetadata(t *testing.T) {
 		}
 	})
 }
+
+func TestManager_UncommittedChanges(t *testing.T) {
+	dir := createTempGitRepo(t)
+	// A tracked .tick/ directory gets replaced by a symlink in the worktree
+	if err := os.MkdirAll(filepath.Join(dir, ".tick"), 0755); err != nil {
+		t.Fatalf("failed to create .tick: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".tick", "config.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cmd := exec.Command("git", "add", ".tick")
+	cmd.Dir = dir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to stage .tick: %v", err)
+	}
+	cmd = exec.Command("git", "commit", "-m", "Add tick dir")
+	cmd.Dir = dir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to commit .tick: %v", err)
+	}
+
+	m, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	wt, err := m.Create("dirty-check")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	files, err := m.UncommittedChanges(wt)
+	if err != nil {
+		t.Fatalf("UncommittedChanges() error = %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("UncommittedChanges() = %v for a fresh worktree, want none", files)
+	}
+
+	if err := os.WriteFile(filepath.Join(wt.Path, "initial.txt"), []byte("edited"), 0644); err != nil {
+		t.Fatalf("failed to edit file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(wt.Path, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	files, err = m.UncommittedChanges(wt)
+	if err != nil {
+		t.Fatalf("UncommittedChanges() error = %v", err)
+	}
+	if strings.Join(files, ",") != "initial.txt,new.txt" {
+		t.Errorf("UncommittedChanges() = %v, want [initial.txt new.txt]", files)
+	}
+}